@@ -35,7 +35,8 @@ var _ = Describe("Multiple ATCs", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 
 		atcOneCommand = NewATCCommand(atcBin, 1, postgresRunner.DataSourceName(), []string{}, NO_AUTH)
 		err := atcOneCommand.Start()
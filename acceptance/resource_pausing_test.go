@@ -62,15 +62,16 @@ var _ = Describe("Resource Pausing", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		teamDB := teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 
 		savedPipeline, found, err := teamDB.GetPipelineByName("some-pipeline")
 		Expect(err).NotTo(HaveOccurred())
 		Expect(found).To(BeTrue())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
 	})
 
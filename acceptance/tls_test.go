@@ -36,7 +36,8 @@ var _ = Describe("TLS", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 	})
 
 	AfterEach(func() {
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"golang.org/x/crypto/bcrypt"
+
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -20,7 +22,7 @@ var _ = Describe("Auth API", func() {
 
 		BeforeEach(func() {
 			savedTeam = db.SavedTeam{
-				ID: 0,
+				ID: 1,
 				Team: db.Team{
 					Name:  "some-team",
 					Admin: true,
@@ -69,10 +71,46 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, isAdmin, teams, username, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(isAdmin).To(Equal(savedTeam.Admin))
+						Expect(teams).To(Equal([]string{savedTeam.Name}))
+						Expect(username).To(BeEmpty())
+					})
+
+					It("starts and records a new session", func() {
+						expiration, _, _, _, _, sessionID, issuedAt := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(sessionID).NotTo(BeEmpty())
+						Expect(issuedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+						Expect(teamDB.SaveSessionCallCount()).To(Equal(1))
+						savedSessionID, savedUsername, savedIssuedAt, savedExpiresAt := teamDB.SaveSessionArgsForCall(0)
+						Expect(savedSessionID).To(Equal(sessionID))
+						Expect(savedUsername).To(BeEmpty())
+						Expect(savedIssuedAt).To(Equal(issuedAt))
+						Expect(savedExpiresAt).To(Equal(expiration))
+					})
+
+					Context("when recording the session fails", func() {
+						BeforeEach(func() {
+							teamDB.SaveSessionReturns(errors.New("nope"))
+						})
+
+						It("returns Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when getting the teams fails", func() {
+					BeforeEach(func() {
+						teamServerDB.GetTeamsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(BeZero())
 					})
 				})
 
@@ -97,6 +135,235 @@ var _ = Describe("Auth API", func() {
 					})
 				})
 			})
+
+			Context("when the request has basic auth credentials", func() {
+				BeforeEach(func() {
+					encryptedPassword, err := bcrypt.GenerateFromPassword([]byte("some-password"), 4)
+					Expect(err).NotTo(HaveOccurred())
+
+					otherPassword, err := bcrypt.GenerateFromPassword([]byte("other-password"), 4)
+					Expect(err).NotTo(HaveOccurred())
+
+					savedTeam.Team.Admin = false
+					teamDB.GetTeamReturns(savedTeam, true, nil)
+
+					teamServerDB.GetTeamsReturns([]db.SavedTeam{
+						savedTeam,
+						{
+							ID: 2,
+							Team: db.Team{
+								Name:  "main",
+								Admin: true,
+								BasicAuth: &db.BasicAuth{
+									BasicAuthUsername: "some-user",
+									BasicAuthPassword: string(encryptedPassword),
+								},
+							},
+						},
+						{
+							ID: 3,
+							Team: db.Team{
+								Name: "other-team",
+								BasicAuth: &db.BasicAuth{
+									BasicAuthUsername: "some-user",
+									BasicAuthPassword: string(otherPassword),
+								},
+							},
+						},
+					}, nil)
+
+					request.SetBasicAuth("some-user", "some-password")
+				})
+
+				It("includes every team whose basic auth accepts the credentials", func() {
+					_, teamName, isAdmin, teams, _, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(teams).To(Equal([]string{"some-team", "main"}))
+					Expect(isAdmin).To(BeTrue())
+				})
+			})
+
+			Context("when the request has a local user's credentials", func() {
+				BeforeEach(func() {
+					passwordHash, err := bcrypt.GenerateFromPassword([]byte("local-password"), 4)
+					Expect(err).NotTo(HaveOccurred())
+
+					teamDB.GetLocalUserReturns(db.LocalUser{
+						Username:     "local-user",
+						PasswordHash: string(passwordHash),
+						Enabled:      true,
+					}, true, nil)
+
+					request.SetBasicAuth("local-user", "local-password")
+				})
+
+				It("records the username in the token", func() {
+					_, _, _, _, username, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+					Expect(username).To(Equal("local-user"))
+
+					Expect(teamDB.GetLocalUserArgsForCall(0)).To(Equal("local-user"))
+				})
+
+				Context("when the password is wrong", func() {
+					BeforeEach(func() {
+						request.SetBasicAuth("local-user", "bogus")
+					})
+
+					It("does not record the username", func() {
+						_, _, _, _, username, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(username).To(BeEmpty())
+					})
+				})
+			})
+
+			Context("when the request has a token for several teams", func() {
+				BeforeEach(func() {
+					request.Header.Add("Authorization", "Bearer some-token")
+
+					userContextReader.GetTeamReturns("other-team", false, true)
+					userContextReader.GetTeamsReturns([]string{"other-team", "some-team"}, true)
+
+					teamServerDB.GetTeamsReturns([]db.SavedTeam{
+						savedTeam,
+						{ID: 3, Team: db.Team{Name: "other-team"}},
+						{ID: 4, Team: db.Team{Name: "unrelated-team"}},
+					}, nil)
+				})
+
+				It("keeps the teams of the token", func() {
+					_, teamName, _, teams, _, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(teams).To(Equal([]string{"some-team", "other-team"}))
+				})
+
+				Context("when the token belongs to a session", func() {
+					var issuedAt time.Time
+
+					BeforeEach(func() {
+						issuedAt = time.Now().Add(-time.Hour).Truncate(time.Second)
+						userContextReader.GetSessionReturns("some-session", issuedAt, true)
+					})
+
+					It("keeps the session of the token", func() {
+						_, _, _, _, _, sessionID, sessionIssuedAt := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(sessionID).To(Equal("some-session"))
+						Expect(sessionIssuedAt).To(Equal(issuedAt))
+
+						savedSessionID, _, savedIssuedAt, _ := teamDB.SaveSessionArgsForCall(0)
+						Expect(savedSessionID).To(Equal("some-session"))
+						Expect(savedIssuedAt).To(Equal(issuedAt))
+					})
+
+					It("does not extend the other teams beyond the session's original expiry", func() {
+						expiration, _, _, _, _, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(expiration).To(Equal(issuedAt.Add(24 * time.Hour)))
+
+						_, _, _, savedExpiresAt := teamDB.SaveSessionArgsForCall(0)
+						Expect(savedExpiresAt).To(Equal(expiration))
+					})
+
+					Context("when the token is only for the requested team", func() {
+						BeforeEach(func() {
+							userContextReader.GetTeamReturns("some-team", false, true)
+							userContextReader.GetTeamsReturns([]string{"some-team"}, true)
+						})
+
+						It("renews the token for the full duration", func() {
+							expiration, _, _, teams, _, sessionID, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(teams).To(Equal([]string{"some-team"}))
+							Expect(sessionID).To(Equal("some-session"))
+							Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
+						})
+					})
+				})
+
+				Context("when the token records a username", func() {
+					BeforeEach(func() {
+						userContextReader.GetUsernameReturns("local-user", true)
+					})
+
+					Context("when the name is an enabled local user of the team that issued the token", func() {
+						BeforeEach(func() {
+							teamDB.GetLocalUserReturns(db.LocalUser{Username: "local-user", Enabled: true}, true, nil)
+						})
+
+						It("keeps the username", func() {
+							_, _, _, _, username, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(username).To(Equal("local-user"))
+
+							Expect(teamDBFactory.GetTeamDBArgsForCall(teamDBFactory.GetTeamDBCallCount() - 1)).To(Equal("other-team"))
+							Expect(teamDB.GetLocalUserArgsForCall(0)).To(Equal("local-user"))
+						})
+					})
+
+					Context("when the local user has been disabled", func() {
+						BeforeEach(func() {
+							teamDB.GetLocalUserReturns(db.LocalUser{Username: "local-user", Enabled: false}, true, nil)
+						})
+
+						It("returns Unauthorized", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+							Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the local user no longer exists", func() {
+						BeforeEach(func() {
+							teamDB.GetLocalUserReturns(db.LocalUser{}, false, nil)
+						})
+
+						It("returns Unauthorized", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+							Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(BeZero())
+						})
+
+						Context("when the name is the shared basic auth username of the team", func() {
+							BeforeEach(func() {
+								savedTeam.BasicAuth = &db.BasicAuth{BasicAuthUsername: "local-user"}
+								teamDB.GetTeamReturns(savedTeam, true, nil)
+							})
+
+							It("keeps the username", func() {
+								_, _, _, _, username, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+								Expect(username).To(Equal("local-user"))
+							})
+						})
+					})
+
+					Context("when looking up the local user fails", func() {
+						BeforeEach(func() {
+							teamDB.GetLocalUserReturns(db.LocalUser{}, false, errors.New("nope"))
+						})
+
+						It("returns Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the token is not for the requested team", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamsReturns([]string{"other-team"}, true)
+					})
+
+					It("only includes the requested team", func() {
+						_, _, _, teams, _, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(teams).To(Equal([]string{"some-team"}))
+					})
+
+					Context("when the token belongs to a session", func() {
+						BeforeEach(func() {
+							userContextReader.GetSessionReturns("some-session", time.Now(), true)
+						})
+
+						It("starts a new session", func() {
+							_, _, _, _, _, sessionID, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(sessionID).NotTo(BeEmpty())
+							Expect(sessionID).NotTo(Equal("some-session"))
+						})
+					})
+				})
+			})
 		})
 
 		Context("when not authenticated", func() {
@@ -143,6 +410,18 @@ var _ = Describe("Auth API", func() {
 							ClientSecret: "client-secret",
 							DisplayName:  "custom secure auth",
 						},
+						OIDCAuth: &db.OIDCAuth{
+							ClientID:     "client-id",
+							ClientSecret: "client-secret",
+							DisplayName:  "Single Sign-On",
+						},
+						GitLabAuth: &db.GitLabAuth{
+							ClientID:     "client-id",
+							ClientSecret: "client-secret",
+						},
+						LDAPAuth: &db.LDAPAuth{
+							Host: "ldap.example.com:389",
+						},
 					},
 				}
 
@@ -182,6 +461,16 @@ var _ = Describe("Auth API", func() {
 						"display_name": "GitHub",
 						"auth_url": "https://oauth.example.com/auth/github?team_name=some-team"
 					},
+					{
+						"type": "oauth",
+						"display_name": "GitLab",
+						"auth_url": "https://oauth.example.com/auth/gitlab?team_name=some-team"
+					},
+					{
+						"type": "oauth",
+						"display_name": "Single Sign-On",
+						"auth_url": "https://oauth.example.com/auth/oidc?team_name=some-team"
+					},
 					{
 						"type": "oauth",
 						"display_name": "UAA",
@@ -196,6 +485,11 @@ var _ = Describe("Auth API", func() {
 						"type": "basic",
 						"display_name": "Basic Auth",
 						"auth_url": "https://example.com/teams/some-team/login"
+					},
+					{
+						"type": "basic",
+						"display_name": "LDAP",
+						"auth_url": "https://example.com/teams/some-team/login"
 					}
 				]`))
 			})
@@ -377,6 +671,19 @@ var _ = Describe("Auth API", func() {
 
 								Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"}}`))
 							})
+
+							Context("when the token records a username", func() {
+								BeforeEach(func() {
+									userContextReader.GetUsernameReturns("local-user", true)
+								})
+
+								It("returns the username", func() {
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"},"username":"local-user"}`))
+								})
+							})
 						})
 					})
 				})
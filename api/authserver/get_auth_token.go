@@ -8,6 +8,8 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
 )
 
 const CookieName = "ATC-Authorization"
@@ -33,13 +35,59 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.Admin)
+	username, valid, err := s.loginUsername(r, team, teamDB)
+	if err != nil {
+		logger.Error("get-login-user", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if !valid {
+		logger.Info("login-user-no-longer-valid", lager.Data{
+			"username": username,
+		})
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	allTeams, err := s.teamsDB.GetTeams()
+	if err != nil {
+		logger.Error("get-teams", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	members, renewed := memberTeams(r, team, allTeams)
+	teams, isAdmin := auth.Membership(team, members)
+
+	sessionID, issuedAt, err := session(r, team)
+	if err != nil {
+		logger.Error("generate-session-id", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// memberships carried over from the token being renewed cannot be checked
+	// again without the user's credentials, so they only last as long as the
+	// session they were granted in
+	expiresAt := time.Now().Add(s.expire)
+	if sessionExpiresAt := issuedAt.Add(s.expire); renewed && sessionExpiresAt.Before(expiresAt) {
+		expiresAt = sessionExpiresAt
+	}
+
+	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(expiresAt, team.Name, isAdmin, teams, username, sessionID, issuedAt)
 	if err != nil {
 		logger.Error("generate-token", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	err = teamDB.SaveSession(sessionID, username, issuedAt, expiresAt)
+	if err != nil {
+		logger.Error("save-session", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
 	token.Type = string(tokenType)
 	token.Value = string(tokenValue)
 
@@ -47,9 +95,106 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 		Name:    CookieName,
 		Value:   fmt.Sprintf("%s %s", token.Type, token.Value),
 		Path:    "/",
-		Expires: time.Now().Add(s.expire),
+		Expires: expiresAt,
 	})
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(token)
 }
+
+// session returns the ID and issue time of the session the token is for.
+// Renewed tokens stay in the session of the token being renewed, so that
+// revoking the session revokes its renewals too. Logging in with credentials
+// starts a new session.
+func session(r *http.Request, team db.SavedTeam) (string, time.Time, error) {
+	if _, _, ok := r.BasicAuth(); !ok {
+		authTeam, found := auth.GetTeam(r)
+		sessionID, issuedAt, sessionFound := auth.GetSession(r)
+		if found && sessionFound && authTeam.IsAuthorized(team.Name) {
+			return sessionID, issuedAt, nil
+		}
+	}
+
+	sessionID, err := auth.NewSessionID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return sessionID, time.Now(), nil
+}
+
+// memberTeams returns the other teams whose basic auth accepts the request's
+// credentials. When a token is being renewed instead, the teams it was issued
+// for are kept as long as it was issued for the requested team, and whether
+// any were kept is returned too.
+func memberTeams(r *http.Request, team db.SavedTeam, allTeams []db.SavedTeam) ([]db.SavedTeam, bool) {
+	members := map[string]bool{}
+	for _, basicAuthTeam := range auth.BasicAuthTeams(allTeams, r) {
+		members[basicAuthTeam.Name] = true
+	}
+
+	renewed := false
+
+	authTeam, found := auth.GetTeam(r)
+	if found && authTeam.IsAuthorized(team.Name) {
+		for _, teamName := range authTeam.Teams() {
+			if teamName != team.Name && !members[teamName] {
+				members[teamName] = true
+				renewed = true
+			}
+		}
+	}
+
+	memberTeams := []db.SavedTeam{}
+	for _, t := range allTeams {
+		if members[t.Name] {
+			memberTeams = append(memberTeams, t)
+		}
+	}
+
+	return memberTeams, renewed
+}
+
+// loginUsername returns the name the user authenticated as: the basic auth
+// username when the credentials are the team's own, or otherwise the name
+// recorded in the token being renewed. A token is not valid for renewal if
+// its name no longer belongs to an enabled local user or to the shared basic
+// auth of the team it was issued by.
+func (s *Server) loginUsername(r *http.Request, team db.SavedTeam, teamDB db.TeamDB) (string, bool, error) {
+	username, _, ok := r.BasicAuth()
+	if ok && auth.NewBasicAuthValidator(team, teamDB).IsAuthenticated(r) {
+		return username, true, nil
+	}
+
+	username, found := auth.GetUsername(r)
+	if !found || username == "" {
+		return "", true, nil
+	}
+
+	authTeam, found := auth.GetTeam(r)
+	if !found {
+		return username, false, nil
+	}
+
+	loginTeamDB := s.teamDBFactory.GetTeamDB(authTeam.Name())
+
+	localUser, found, err := loginTeamDB.GetLocalUser(username)
+	if err != nil {
+		return "", false, err
+	}
+
+	if found {
+		return username, localUser.Enabled, nil
+	}
+
+	loginTeam, found, err := loginTeamDB.GetTeam()
+	if err != nil {
+		return "", false, err
+	}
+
+	if found && loginTeam.BasicAuth != nil && loginTeam.BasicAuth.BasicAuthUsername == username {
+		return username, true, nil
+	}
+
+	return username, false, nil
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/gitlab"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/oidc"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
@@ -82,6 +85,23 @@ func (s *Server) authMethods(team db.SavedTeam) ([]atc.AuthMethod, error) {
 		})
 	}
 
+	if team.GitLabAuth != nil {
+		path, err := auth.OAuthRoutes.CreatePathForRoute(
+			auth.OAuthBegin,
+			rata.Params{"provider": gitlab.ProviderName},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		path = path + fmt.Sprintf("?team_name=%s", team.Name)
+		methods = append(methods, atc.AuthMethod{
+			Type:        atc.AuthTypeOAuth,
+			DisplayName: gitlab.DisplayName,
+			AuthURL:     s.oAuthBaseURL + path,
+		})
+	}
+
 	if team.UAAAuth != nil {
 		path, err := auth.OAuthRoutes.CreatePathForRoute(
 			auth.OAuthBegin,
@@ -116,6 +136,23 @@ func (s *Server) authMethods(team db.SavedTeam) ([]atc.AuthMethod, error) {
 		})
 	}
 
+	if team.OIDCAuth != nil {
+		path, err := auth.OAuthRoutes.CreatePathForRoute(
+			auth.OAuthBegin,
+			rata.Params{"provider": oidc.ProviderName},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		path = path + fmt.Sprintf("?team_name=%s", team.Name)
+		methods = append(methods, atc.AuthMethod{
+			Type:        atc.AuthTypeOAuth,
+			DisplayName: team.OIDCAuth.DisplayName,
+			AuthURL:     s.oAuthBaseURL + path,
+		})
+	}
+
 	if team.BasicAuth != nil {
 		path, err := web.Routes.CreatePathForRoute(
 			web.TeamLogIn,
@@ -132,5 +169,28 @@ func (s *Server) authMethods(team db.SavedTeam) ([]atc.AuthMethod, error) {
 		})
 	}
 
+	// LDAP credentials are checked the same way as basic auth, by
+	// GetAuthToken, so it shares the basic auth log in page
+	if team.LDAPAuth != nil {
+		path, err := web.Routes.CreatePathForRoute(
+			web.TeamLogIn,
+			rata.Params{"team_name": team.Name},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		displayName := team.LDAPAuth.DisplayName
+		if displayName == "" {
+			displayName = ldap.DisplayName
+		}
+
+		methods = append(methods, atc.AuthMethod{
+			Type:        atc.AuthTypeBasic,
+			DisplayName: displayName,
+			AuthURL:     s.externalURL + path,
+		})
+	}
+
 	return methods, nil
 }
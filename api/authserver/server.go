@@ -15,6 +15,7 @@ type Server struct {
 	tokenGenerator  auth.TokenGenerator
 	providerFactory auth.ProviderFactory
 	teamDBFactory   db.TeamDBFactory
+	teamsDB         auth.TeamsDB
 	expire          time.Duration
 }
 
@@ -25,6 +26,7 @@ func NewServer(
 	tokenGenerator auth.TokenGenerator,
 	providerFactory auth.ProviderFactory,
 	teamDBFactory db.TeamDBFactory,
+	teamsDB auth.TeamsDB,
 	expire time.Duration,
 ) *Server {
 	return &Server{
@@ -34,6 +36,7 @@ func NewServer(
 		tokenGenerator:  tokenGenerator,
 		providerFactory: providerFactory,
 		teamDBFactory:   teamDBFactory,
+		teamsDB:         teamsDB,
 		expire:          expire,
 	}
 }
@@ -39,6 +39,8 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
 			user = User{
 				Team: &presentedTeam,
 			}
+
+			user.Username, _ = auth.GetUsername(r)
 		}
 	}
 
@@ -47,6 +49,7 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
 }
 
 type User struct {
-	Team   *atc.Team `json:"team,omitempty"`
-	System *bool     `json:"system,omitempty"`
+	Team     *atc.Team `json:"team,omitempty"`
+	Username string    `json:"username,omitempty"`
+	System   *bool     `json:"system,omitempty"`
 }
@@ -1172,6 +1172,9 @@ var _ = Describe("Builds API", func() {
 					},
 					InputsSatisfied:     db.BuildPreparationStatusBlocking,
 					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+					Locks: map[string]db.BuildPreparationStatus{
+						"deploy": db.BuildPreparationStatusBlocking,
+					},
 				}
 				buildsDB.GetBuildByIDReturns(build, true, nil)
 				build.JobNameReturns("job1")
@@ -1280,6 +1283,9 @@ var _ = Describe("Builds API", func() {
 					"inputs_satisfied": "blocking",
 					"missing_input_reasons": {
 						"some-input": "some-reason"
+					},
+					"locks": {
+						"deploy": "blocking"
 					}
 				}`))
 				})
@@ -10,6 +10,7 @@ import (
 	"net/textproto"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	"github.com/onsi/gomega/gbytes"
@@ -374,6 +375,64 @@ var _ = Describe("Config API", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
 							})
 						})
+
+						Context("when the config has passed constraints on jobs in other pipelines", func() {
+							BeforeEach(func() {
+								pipelineConfig.Jobs[0].Plan[0].Passed = []string{"other-pipeline/unit"}
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							Context("when the other pipeline's job uses the same resource", func() {
+								BeforeEach(func() {
+									teamDB.GetPipelinesReturns([]db.SavedPipeline{
+										{
+											Pipeline: db.Pipeline{
+												Name: "other-pipeline",
+												Config: atc.Config{
+													Resources: atc.ResourceConfigs{pipelineConfig.Resources[0]},
+													Jobs: atc.JobConfigs{
+														{
+															Name: "unit",
+															Plan: atc.PlanSequence{{Get: "some-resource"}},
+														},
+													},
+												},
+											},
+										},
+									}, nil)
+								})
+
+								It("validates them against the team's pipelines", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+								})
+							})
+
+							Context("when the other pipeline does not exist", func() {
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("does not save it", func() {
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+						})
+
+						Context("when getting the team's pipelines fails", func() {
+							BeforeEach(func() {
+								teamDB.GetPipelinesReturns(nil, errors.New("nope"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+
+							It("does not save it", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							})
+						})
 					})
 
 					Context("YAML", func() {
@@ -102,7 +102,32 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	warnings, errorMessages := config.Validate()
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	err = atc.ValidatePipelineName(pipelineName)
+	if err != nil {
+		s.handleBadRequest(w, []string{err.Error()}, session)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	savedPipelines, err := teamDB.GetPipelines()
+	if err != nil {
+		session.Error("failed-to-get-pipelines", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	pipelines := map[string]atc.Config{}
+	for _, savedPipeline := range savedPipelines {
+		pipelines[savedPipeline.Name] = savedPipeline.Config
+	}
+
+	pipelines[pipelineName] = config
+
+	warnings, errorMessages := config.ValidateInTeam(pipelines)
 	if len(errorMessages) > 0 {
 		session.Error("ignoring-invalid-config", err)
 		s.handleBadRequest(w, errorMessages, session)
@@ -111,9 +136,6 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 	session.Info("saving")
 
-	pipelineName := rata.Param(r, "pipeline_name")
-	teamName := rata.Param(r, "team_name")
-
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		session.Error("failed-to-find-team", err)
@@ -89,6 +89,7 @@ func NewHandler(
 		tokenGenerator,
 		providerFactory,
 		teamDBFactory,
+		teamsDB,
 		expire,
 	)
 
@@ -104,7 +105,7 @@ func NewHandler(
 	)
 
 	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
-	resourceServer := resourceserver.NewServer(logger, scannerFactory)
+	resourceServer := resourceserver.NewServer(logger, scannerFactory, externalURL)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
 
@@ -171,6 +172,8 @@ func NewHandler(
 		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
 		atc.CheckResource:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 
+		atc.ListResourceChecks: pipelineHandlerFactory.HandlerFor(resourceServer.ListResourceChecks),
+
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
@@ -205,6 +208,25 @@ func NewHandler(
 		atc.ListTeams:   http.HandlerFunc(teamServer.ListTeams),
 		atc.SetTeam:     http.HandlerFunc(teamServer.SetTeam),
 		atc.DestroyTeam: http.HandlerFunc(teamServer.DestroyTeam),
+
+		atc.ListTeamLocks:   http.HandlerFunc(teamServer.ListTeamLocks),
+		atc.SetTeamLock:     http.HandlerFunc(teamServer.SetTeamLock),
+		atc.ReleaseTeamLock: http.HandlerFunc(teamServer.ReleaseTeamLock),
+
+		atc.ListAPITokens:  http.HandlerFunc(teamServer.ListAPITokens),
+		atc.CreateAPIToken: http.HandlerFunc(teamServer.CreateAPIToken),
+		atc.RevokeAPIToken: http.HandlerFunc(teamServer.RevokeAPIToken),
+
+		atc.ListSessions:      http.HandlerFunc(teamServer.ListSessions),
+		atc.RevokeSession:     http.HandlerFunc(teamServer.RevokeSession),
+		atc.RevokeAllSessions: http.HandlerFunc(teamServer.RevokeAllSessions),
+
+		atc.ListLocalUsers:       http.HandlerFunc(teamServer.ListLocalUsers),
+		atc.CreateLocalUser:      http.HandlerFunc(teamServer.CreateLocalUser),
+		atc.SetLocalUserPassword: http.HandlerFunc(teamServer.SetLocalUserPassword),
+		atc.EnableLocalUser:      http.HandlerFunc(teamServer.EnableLocalUser),
+		atc.DisableLocalUser:     http.HandlerFunc(teamServer.DisableLocalUser),
+		atc.DeleteLocalUser:      http.HandlerFunc(teamServer.DeleteLocalUser),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
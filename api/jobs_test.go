@@ -13,6 +13,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 )
@@ -1280,11 +1281,16 @@ var _ = Describe("Jobs API", func() {
 
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", func() {
 		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/inputs")
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/inputs" + query)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -1429,6 +1435,63 @@ var _ = Describe("Jobs API", func() {
 						It("returns 404", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 						})
+
+						Context("when asked to explain", func() {
+							BeforeEach(func() {
+								query = "?explain=true"
+							})
+
+							Context("when the explanation can be loaded", func() {
+								BeforeEach(func() {
+									pipelineDB.GetInputsExplanationReturns(algorithm.Explanation{
+										"some-input": algorithm.InputExplanation{
+											Constraints: []algorithm.ConstraintExplanation{
+												{Constraint: algorithm.ConstraintPassed, Job: "job-a", Candidates: 2},
+												{Constraint: algorithm.ConstraintCommonBuild, Job: "job-a", Candidates: 0},
+											},
+											JobsWithoutCommonBuild: []string{"job-a"},
+										},
+									}, true, nil)
+								})
+
+								It("returns 200 OK", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+								})
+
+								It("loaded the explanation with the correct job name", func() {
+									Expect(pipelineDB.GetInputsExplanationArgsForCall(0)).To(Equal("some-job"))
+								})
+
+								It("returns the explanation", func() {
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(body).To(MatchJSON(`{
+										"inputs": [],
+										"explanation": {
+											"some-input": {
+												"constraints": [
+													{"constraint": "passed", "job": "job-a", "candidates": 2},
+													{"constraint": "common-build", "job": "job-a", "candidates": 0}
+												],
+												"jobs_without_common_build": ["job-a"],
+												"satisfied": false
+											}
+										}
+									}`))
+								})
+							})
+
+							Context("when loading the explanation fails", func() {
+								BeforeEach(func() {
+									pipelineDB.GetInputsExplanationReturns(nil, false, errors.New("oh no!"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+							})
+						})
 					})
 
 					Context("when the input versions for the job can not be determined", func() {
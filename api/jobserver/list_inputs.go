@@ -38,7 +38,9 @@ func (s *Server) ListJobInputs(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
-		if !found {
+		explain := r.FormValue("explain") == "true"
+
+		if !found && !explain {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
@@ -59,6 +61,21 @@ func (s *Server) ListJobInputs(pipelineDB db.PipelineDB) http.Handler {
 			presentedBuildInputs[i] = present.BuildInput(input, config, resource.Source)
 		}
 
+		if explain {
+			explanation, _, err := pipelineDB.GetInputsExplanation(jobName)
+			if err != nil {
+				logger.Error("failed-to-get-inputs-explanation", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			json.NewEncoder(w).Encode(atc.JobInputsExplanation{
+				Inputs:      presentedBuildInputs,
+				Explanation: present.InputsExplanation(explanation),
+			})
+			return
+		}
+
 		json.NewEncoder(w).Encode(presentedBuildInputs)
 	})
 }
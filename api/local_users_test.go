@@ -0,0 +1,524 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Local Users API", func() {
+	Describe("GET /api/v1/teams/:team_name/users", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/users")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, true, nil)
+				})
+
+				Context("when getting the users succeeds", func() {
+					BeforeEach(func() {
+						teamDB.GetLocalUsersReturns([]db.LocalUser{
+							{
+								Username:     "alice",
+								PasswordHash: "some-hash",
+								Enabled:      true,
+								CreatedAt:    time.Unix(100, 0),
+							},
+							{
+								Username:     "bob",
+								PasswordHash: "some-other-hash",
+								Enabled:      false,
+								CreatedAt:    time.Unix(150, 0),
+							},
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the users without their password hashes", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{"username": "alice", "enabled": true, "created_at": 100},
+							{"username": "bob", "enabled": false, "created_at": 150}
+						]`))
+					})
+				})
+
+				Context("when getting the users fails", func() {
+					BeforeEach(func() {
+						teamDB.GetLocalUsersReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/users", func() {
+		var (
+			localUser atc.LocalUser
+			response  *http.Response
+		)
+
+		BeforeEach(func() {
+			localUser = atc.LocalUser{
+				Username: "alice",
+				Password: "some-password",
+			}
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(localUser)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Post(
+				server.URL+"/api/v1/teams/some-team/users",
+				"application/json",
+				bytes.NewBuffer(payload),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+				teamDB.GetTeamReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, true, nil)
+			})
+
+			Context("when creating the user succeeds", func() {
+				BeforeEach(func() {
+					teamDB.CreateLocalUserReturns(db.LocalUser{
+						Username:     "alice",
+						PasswordHash: "some-hash",
+						Enabled:      true,
+						CreatedAt:    time.Unix(100, 0),
+					}, nil)
+				})
+
+				It("returns 201 Created", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+
+				It("creates the user for the team", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+
+					username, password := teamDB.CreateLocalUserArgsForCall(0)
+					Expect(username).To(Equal("alice"))
+					Expect(password).To(Equal("some-password"))
+				})
+
+				It("does not return the password", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{"username": "alice", "enabled": true, "created_at": 100}`))
+				})
+			})
+
+			Context("when the user already exists", func() {
+				BeforeEach(func() {
+					teamDB.CreateLocalUserReturns(db.LocalUser{}, db.ErrLocalUserExists)
+				})
+
+				It("returns 409 Conflict", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when the username is the team's basic auth username", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{
+						ID: 2,
+						Team: db.Team{
+							Name:      "some-team",
+							BasicAuth: &db.BasicAuth{BasicAuthUsername: "alice", BasicAuthPassword: "some-hash"},
+						},
+					}, true, nil)
+				})
+
+				It("returns 409 Conflict", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(teamDB.CreateLocalUserCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when creating the user fails", func() {
+				BeforeEach(func() {
+					teamDB.CreateLocalUserReturns(db.LocalUser{}, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the password is missing", func() {
+				BeforeEach(func() {
+					localUser.Password = ""
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.CreateLocalUserCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the username cannot be used with basic auth", func() {
+				BeforeEach(func() {
+					localUser.Username = "ali:ce"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.CreateLocalUserCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(teamDB.CreateLocalUserCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(teamDB.CreateLocalUserCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/users/:username/password", func() {
+		var (
+			payload  string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			payload = `{"password": "new-password"}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/users/alice/password", bytes.NewBufferString(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the user exists", func() {
+				BeforeEach(func() {
+					teamDB.SetLocalUserPasswordReturns(true, nil)
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				It("changes the password", func() {
+					username, password := teamDB.SetLocalUserPasswordArgsForCall(0)
+					Expect(username).To(Equal("alice"))
+					Expect(password).To(Equal("new-password"))
+				})
+
+				It("revokes the user's sessions", func() {
+					Expect(teamDB.RevokeUserSessionsCallCount()).To(Equal(1))
+					Expect(teamDB.RevokeUserSessionsArgsForCall(0)).To(Equal("alice"))
+				})
+
+				Context("when revoking the user's sessions fails", func() {
+					BeforeEach(func() {
+						teamDB.RevokeUserSessionsReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the user does not exist", func() {
+				BeforeEach(func() {
+					teamDB.SetLocalUserPasswordReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(teamDB.RevokeUserSessionsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the password is missing", func() {
+				BeforeEach(func() {
+					payload = `{}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.SetLocalUserPasswordCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.SetLocalUserPasswordCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/users/:username/disable", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/users/alice/disable", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the user exists", func() {
+				BeforeEach(func() {
+					teamDB.SetLocalUserEnabledReturns(true, nil)
+				})
+
+				It("disables the user", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					username, enabled := teamDB.SetLocalUserEnabledArgsForCall(0)
+					Expect(username).To(Equal("alice"))
+					Expect(enabled).To(BeFalse())
+				})
+
+				It("revokes the user's sessions", func() {
+					Expect(teamDB.RevokeUserSessionsCallCount()).To(Equal(1))
+					Expect(teamDB.RevokeUserSessionsArgsForCall(0)).To(Equal("alice"))
+				})
+
+				Context("when revoking the user's sessions fails", func() {
+					BeforeEach(func() {
+						teamDB.RevokeUserSessionsReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the user does not exist", func() {
+				BeforeEach(func() {
+					teamDB.SetLocalUserEnabledReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when disabling the user fails", func() {
+				BeforeEach(func() {
+					teamDB.SetLocalUserEnabledReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/users/:username/enable", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			authValidator.IsAuthenticatedReturns(true)
+			userContextReader.GetTeamReturns("some-team", false, true)
+			teamDB.SetLocalUserEnabledReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/users/alice/enable", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("enables the user", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			username, enabled := teamDB.SetLocalUserEnabledArgsForCall(0)
+			Expect(username).To(Equal("alice"))
+			Expect(enabled).To(BeTrue())
+		})
+
+		It("does not revoke the user's sessions", func() {
+			Expect(teamDB.RevokeUserSessionsCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/users/:username", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/users/alice", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the user exists", func() {
+				BeforeEach(func() {
+					teamDB.DeleteLocalUserReturns(true, nil)
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(teamDB.DeleteLocalUserArgsForCall(0)).To(Equal("alice"))
+				})
+
+				It("revokes the user's sessions", func() {
+					Expect(teamDB.RevokeUserSessionsCallCount()).To(Equal(1))
+					Expect(teamDB.RevokeUserSessionsArgsForCall(0)).To(Equal("alice"))
+				})
+
+				Context("when revoking the user's sessions fails", func() {
+					BeforeEach(func() {
+						teamDB.RevokeUserSessionsReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the user does not exist", func() {
+				BeforeEach(func() {
+					teamDB.DeleteLocalUserReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when deleting the user fails", func() {
+				BeforeEach(func() {
+					teamDB.DeleteLocalUserReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(teamDB.DeleteLocalUserCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -213,6 +213,101 @@ var _ = Describe("Pipelines API", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 				})
 			})
+
+			Context("when the user belongs to several teams", func() {
+				var anotherTeamDB *dbfakes.FakeTeamDB
+
+				BeforeEach(func() {
+					userContextReader.GetTeamsReturns([]string{"main", "another"}, true)
+
+					anotherTeamDB = new(dbfakes.FakeTeamDB)
+					anotherTeamDB.GetPipelinesReturns([]db.SavedPipeline{
+						{
+							ID:       4,
+							TeamName: "another",
+							Pipeline: db.Pipeline{
+								Name: "another-private-pipeline",
+							},
+						},
+						{
+							ID:       3,
+							Paused:   true,
+							Public:   true,
+							TeamName: "another",
+							Pipeline: db.Pipeline{
+								Name: "another-pipeline",
+							},
+						},
+					}, nil)
+
+					teamDBFactory.GetTeamDBStub = func(teamName string) db.TeamDB {
+						if teamName == "another" {
+							return anotherTeamDB
+						}
+
+						return teamDB
+					}
+				})
+
+				It("returns all pipelines of each team + all other public pipelines", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+					{
+						"name": "private-pipeline",
+						"url": "/teams/main/pipelines/private-pipeline",
+						"paused": false,
+						"public": false,
+						"team_name": "main",
+						"groups": [
+							{
+								"name": "group1",
+								"jobs": ["job1", "job2"],
+								"resources": ["resource1", "resource2"]
+							}
+						]
+					},
+					{
+						"name": "public-pipeline",
+						"url": "/teams/main/pipelines/public-pipeline",
+						"paused": true,
+						"public": true,
+						"team_name": "main",
+						"groups": [
+							{
+								"name": "group2",
+								"jobs": ["job3", "job4"],
+								"resources": ["resource3", "resource4"]
+							}
+						]
+					},
+					{
+						"name": "another-private-pipeline",
+						"url": "/teams/another/pipelines/another-private-pipeline",
+						"paused": false,
+						"public": false,
+						"team_name": "another"
+					},
+					{
+						"name": "another-pipeline",
+						"url": "/teams/another/pipelines/another-pipeline",
+						"paused": true,
+						"public": true,
+						"team_name": "another"
+					}]`))
+				})
+
+				Context("when getting another team's pipelines fails", func() {
+					BeforeEach(func() {
+						anotherTeamDB.GetPipelinesReturns(nil, errors.New("disaster"))
+					})
+
+					It("returns 500 internal server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
 		})
 	})
 
@@ -322,6 +417,20 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 
+		Context("when authenticated as a member of the requested team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("another-team", false, true)
+				userContextReader.GetTeamsReturns([]string{"another-team", "main"}, true)
+			})
+
+			It("returns all team's pipelines", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(teamDB.GetPipelinesCallCount()).To(Equal(1))
+				Expect(teamDB.GetPublicPipelinesCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when not authenticated", func() {
 			BeforeEach(func() {
 				authValidator.IsAuthenticatedReturns(false)
@@ -551,6 +660,25 @@ var _ = Describe("Pipelines API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("when other pipelines refer to the pipeline", func() {
+					BeforeEach(func() {
+						pipelineDB.DestroyReturns(db.PipelineReferencedError{
+							Pipeline:     "a-pipeline-name",
+							ReferencedBy: []string{"some-downstream-pipeline"},
+						})
+					})
+
+					It("returns 409 Conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("names the pipelines referring to it", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring("some-downstream-pipeline"))
+					})
+				})
 			})
 
 			Context("when requester does not belong to the team", func() {
@@ -1123,11 +1251,16 @@ var _ = Describe("Pipelines API", func() {
 
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename", func() {
 		var response *http.Response
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = `{"name":"some-new-name"}`
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/rename", bytes.NewBufferString(`{"name":"some-new-name"}`))
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/rename", bytes.NewBufferString(requestBody))
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -1173,6 +1306,33 @@ var _ = Describe("Pipelines API", func() {
 						Expect(logger.LogMessages()).To(ContainElement("callbacks.call-to-update-pipeline-name-failed"))
 					})
 				})
+
+				Context("when other pipelines refer to the pipeline", func() {
+					BeforeEach(func() {
+						pipelineDB.UpdateNameReturns(db.PipelineReferencedError{
+							Pipeline:     "a-pipeline",
+							ReferencedBy: []string{"some-downstream-pipeline"},
+						})
+					})
+
+					It("returns 409 Conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when the new name contains a slash", func() {
+					BeforeEach(func() {
+						requestBody = `{"name":"some/new-name"}`
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not rename the pipeline", func() {
+						Expect(pipelineDB.UpdateNameCallCount()).To(BeZero())
+					})
+				})
 			})
 
 			Context("when requester does not belong to the team", func() {
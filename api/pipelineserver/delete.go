@@ -1,6 +1,7 @@
 package pipelineserver
 
 import (
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -17,6 +18,13 @@ func (s *Server) DeletePipeline(pipelineDB db.PipelineDB) http.Handler {
 
 		err := pipelineDB.Destroy()
 		if err != nil {
+			if _, ok := err.(db.PipelineReferencedError); ok {
+				logger.Info("referenced-by-other-pipelines", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "%s", err)
+				return
+			}
+
 			s.logger.Error("failed", err)
 
 			w.WriteHeader(http.StatusInternalServerError)
@@ -17,7 +17,7 @@ func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 	var pipelines []db.SavedPipeline
 	var err error
 	if authTeamFound {
-		pipelines, err = s.teamDBFactory.GetTeamDB(authTeam.Name()).GetPrivateAndAllPublicPipelines()
+		pipelines, err = s.memberPipelines(authTeam)
 	} else {
 		pipelines, err = s.pipelinesDB.GetAllPublicPipelines()
 	}
@@ -32,3 +32,38 @@ func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 
 	json.NewEncoder(w).Encode(present.Pipelines(pipelines))
 }
+
+// memberPipelines lists the pipelines of the team the user logged in to, then
+// those of the other teams they belong to, then everyone else's public ones.
+func (s *Server) memberPipelines(authTeam auth.Team) ([]db.SavedPipeline, error) {
+	pipelines, err := s.teamDBFactory.GetTeamDB(authTeam.Name()).GetPrivateAndAllPublicPipelines()
+	if err != nil {
+		return nil, err
+	}
+
+	otherTeams := authTeam.Teams()[1:]
+	if len(otherTeams) == 0 {
+		return pipelines, nil
+	}
+
+	ownPipelines := []db.SavedPipeline{}
+	publicPipelines := []db.SavedPipeline{}
+	for _, pipeline := range pipelines {
+		if pipeline.TeamName == authTeam.Name() {
+			ownPipelines = append(ownPipelines, pipeline)
+		} else if !authTeam.IsAuthorized(pipeline.TeamName) {
+			publicPipelines = append(publicPipelines, pipeline)
+		}
+	}
+
+	for _, teamName := range otherTeams {
+		teamPipelines, err := s.teamDBFactory.GetTeamDB(teamName).GetPipelines()
+		if err != nil {
+			return nil, err
+		}
+
+		ownPipelines = append(ownPipelines, teamPipelines...)
+	}
+
+	return append(ownPipelines, publicPipelines...), nil
+}
@@ -2,9 +2,11 @@ package pipelineserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -25,8 +27,21 @@ func (s *Server) RenamePipeline(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
+		err = atc.ValidatePipelineName(value.Name)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s", err)
+			return
+		}
+
 		err = pipelineDB.UpdateName(value.Name)
 		if err != nil {
+			if _, ok := err.(db.PipelineReferencedError); ok {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "%s", err)
+				return
+			}
+
 			s.logger.Error("call-to-update-pipeline-name-failed", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
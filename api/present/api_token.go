@@ -0,0 +1,26 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func APIToken(apiToken db.APIToken) atc.APIToken {
+	presented := atc.APIToken{
+		Name:      apiToken.Name,
+		Kind:      apiToken.Kind,
+		Owner:     apiToken.Owner,
+		Scopes:    apiToken.Scopes,
+		CreatedAt: apiToken.CreatedAt.Unix(),
+	}
+
+	if !apiToken.ExpiresAt.IsZero() {
+		presented.ExpiresAt = apiToken.ExpiresAt.Unix()
+	}
+
+	if !apiToken.LastUsedAt.IsZero() {
+		presented.LastUsedAt = apiToken.LastUsedAt.Unix()
+	}
+
+	return presented
+}
@@ -3,6 +3,7 @@ package present
 import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 )
 
 func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
@@ -12,6 +13,15 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		inputs[k] = atc.BuildPreparationStatus(v)
 	}
 
+	var locks map[string]atc.BuildPreparationStatus
+	if len(preparation.Locks) > 0 {
+		locks = make(map[string]atc.BuildPreparationStatus)
+
+		for k, v := range preparation.Locks {
+			locks[k] = atc.BuildPreparationStatus(v)
+		}
+	}
+
 	return atc.BuildPreparation{
 		BuildID:             preparation.BuildID,
 		PausedPipeline:      atc.BuildPreparationStatus(preparation.PausedPipeline),
@@ -20,5 +30,36 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		Inputs:              inputs,
 		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
 		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
+		InputsExplanation:   InputsExplanation(preparation.InputsExplanation),
+		Locks:               locks,
+	}
+}
+
+func InputsExplanation(explanation algorithm.Explanation) map[string]atc.InputExplanation {
+	if explanation == nil {
+		return nil
 	}
+
+	presented := make(map[string]atc.InputExplanation)
+
+	for inputName, inputExplanation := range explanation {
+		constraints := make([]atc.InputConstraintExplanation, len(inputExplanation.Constraints))
+		for i, constraint := range inputExplanation.Constraints {
+			constraints[i] = atc.InputConstraintExplanation{
+				Constraint: constraint.Constraint,
+				Job:        constraint.Job,
+				Candidates: constraint.Candidates,
+			}
+		}
+
+		presented[inputName] = atc.InputExplanation{
+			Constraints:            constraints,
+			JobsWithoutCommonBuild: inputExplanation.JobsWithoutCommonBuild,
+			PinnedVersionID:        inputExplanation.PinnedVersionID,
+			DisabledVersions:       inputExplanation.DisabledVersions,
+			Satisfied:              inputExplanation.Satisfied,
+		}
+	}
+
+	return presented
 }
@@ -0,0 +1,14 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func LocalUser(localUser db.LocalUser) atc.LocalUser {
+	return atc.LocalUser{
+		Username:  localUser.Username,
+		Enabled:   localUser.Enabled,
+		CreatedAt: localUser.CreatedAt.Unix(),
+	}
+}
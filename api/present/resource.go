@@ -37,6 +37,11 @@ func Resource(resource db.SavedResource, groups atc.GroupConfigs, showCheckError
 		checkErrString = resource.CheckError.Error()
 	}
 
+	var nextCheckAt int64
+	if !resource.NextCheckAt.IsZero() {
+		nextCheckAt = resource.NextCheckAt.Unix()
+	}
+
 	return atc.Resource{
 		Name:   resource.Name,
 		Type:   resource.Config.Type,
@@ -47,5 +52,8 @@ func Resource(resource db.SavedResource, groups atc.GroupConfigs, showCheckError
 
 		FailingToCheck: resource.FailingToCheck(),
 		CheckError:     checkErrString,
+
+		ConsecutiveCheckFailures: resource.ConsecutiveCheckFailures,
+		NextCheckAt:              nextCheckAt,
 	}
 }
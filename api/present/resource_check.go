@@ -0,0 +1,23 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func ResourceCheck(check db.SavedResourceCheck, showCheckError bool) atc.ResourceCheck {
+	var checkErrString string
+	if showCheckError && check.CheckError != nil {
+		checkErrString = check.CheckError.Error()
+	}
+
+	return atc.ResourceCheck{
+		ID:          check.ID,
+		StartTime:   check.StartTime.Unix(),
+		EndTime:     check.EndTime.Unix(),
+		Succeeded:   check.Succeeded(),
+		CheckError:  checkErrString,
+		NewVersions: check.NewVersions,
+		WorkerName:  check.WorkerName,
+	}
+}
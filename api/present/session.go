@@ -0,0 +1,15 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func Session(session db.Session) atc.Session {
+	return atc.Session{
+		ID:        session.ID,
+		Username:  session.Username,
+		IssuedAt:  session.IssuedAt.Unix(),
+		ExpiresAt: session.ExpiresAt.Unix(),
+	}
+}
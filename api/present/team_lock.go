@@ -0,0 +1,55 @@
+package present
+
+import (
+	"sort"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func TeamLocks(capacities map[string]int, holders []db.TeamLockHolder) []atc.TeamLock {
+	names := []string{}
+	for name := range capacities {
+		names = append(names, name)
+	}
+
+	byName := map[string][]atc.TeamLockHolder{}
+	for _, holder := range holders {
+		if _, found := byName[holder.LockName]; !found {
+			if _, configured := capacities[holder.LockName]; !configured {
+				names = append(names, holder.LockName)
+			}
+		}
+
+		byName[holder.LockName] = append(byName[holder.LockName], atc.TeamLockHolder{
+			BuildID:      holder.BuildID,
+			BuildName:    holder.BuildName,
+			JobName:      holder.JobName,
+			PipelineName: holder.PipelineName,
+			AcquiredAt:   holder.AcquiredAt.Unix(),
+		})
+	}
+
+	sort.Strings(names)
+
+	locks := []atc.TeamLock{}
+	for _, name := range names {
+		capacity, found := capacities[name]
+		if !found {
+			capacity = db.DefaultTeamLockCapacity
+		}
+
+		lockHolders := byName[name]
+		if lockHolders == nil {
+			lockHolders = []atc.TeamLockHolder{}
+		}
+
+		locks = append(locks, atc.TeamLock{
+			Name:     name,
+			Capacity: capacity,
+			Holders:  lockHolders,
+		})
+	}
+
+	return locks
+}
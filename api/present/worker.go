@@ -28,5 +28,8 @@ func Worker(workerInfo dbng.Worker) atc.Worker {
 		Name:             workerInfo.Name,
 		Team:             workerInfo.TeamName,
 		State:            string(workerInfo.State),
+
+		DiskCapacityInBytes: workerInfo.DiskCapacityInBytes,
+		DiskUsageInBytes:    workerInfo.DiskUsageInBytes,
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -365,6 +366,231 @@ var _ = Describe("Resources API", func() {
 							}`))
 				})
 			})
+
+			Context("when checking the resource is backing off", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceReturns(db.SavedResource{
+						ID:           1,
+						CheckError:   errors.New("sup"),
+						PipelineName: "a-pipeline",
+						Resource: db.Resource{
+							Name: "resource-1",
+						},
+						Config: atc.ResourceConfig{
+							Type: "type-1",
+						},
+						ConsecutiveCheckFailures: 3,
+						NextCheckAt:              time.Unix(1234, 0),
+					}, true, nil)
+				})
+
+				It("returns the backoff state and when the next check will be", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"type": "type-1",
+								"groups": [],
+								"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-1",
+								"failing_to_check": true,
+								"check_error": "sup",
+								"consecutive_check_failures": 3,
+								"next_check_at": 1234
+							}`))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/checks"+queryParams, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("", false, false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipelineDB.IsPublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipelineDB.IsPublicReturns(true)
+					fakePipelineDB.GetResourceChecksReturns([]db.SavedResourceCheck{
+						{
+							ID: 2,
+							ResourceCheck: db.ResourceCheck{
+								StartTime:  time.Unix(100, 0),
+								EndTime:    time.Unix(105, 0),
+								CheckError: errors.New("sup"),
+								WorkerName: "some-worker",
+							},
+						},
+					}, db.Pagination{}, true, nil)
+				})
+
+				It("returns the checks without their errors", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"start_time": 100,
+							"end_time": 105,
+							"succeeded": false,
+							"new_versions": 0,
+							"worker_name": "some-worker"
+						}
+					]`))
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+			})
+
+			Context("when the checks can be found", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceChecksReturns([]db.SavedResourceCheck{
+						{
+							ID: 2,
+							ResourceCheck: db.ResourceCheck{
+								StartTime:  time.Unix(100, 0),
+								EndTime:    time.Unix(105, 0),
+								CheckError: errors.New("sup"),
+								WorkerName: "some-worker",
+							},
+						},
+						{
+							ID: 1,
+							ResourceCheck: db.ResourceCheck{
+								StartTime:   time.Unix(40, 0),
+								EndTime:     time.Unix(42, 0),
+								NewVersions: 3,
+								WorkerName:  "other-worker",
+							},
+						},
+					}, db.Pagination{}, true, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns content type application/json", func() {
+					Expect(response.Header.Get("Content-type")).To(Equal("application/json"))
+				})
+
+				It("looks them up with the default page size", func() {
+					Expect(fakePipelineDB.GetResourceChecksCallCount()).To(Equal(1))
+
+					resourceName, page := fakePipelineDB.GetResourceChecksArgsForCall(0)
+					Expect(resourceName).To(Equal("some-resource"))
+					Expect(page).To(Equal(db.Page{Limit: atc.PaginationAPIDefaultLimit}))
+				})
+
+				It("returns the checks, newest first", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"start_time": 100,
+							"end_time": 105,
+							"succeeded": false,
+							"check_error": "sup",
+							"new_versions": 0,
+							"worker_name": "some-worker"
+						},
+						{
+							"id": 1,
+							"start_time": 40,
+							"end_time": 42,
+							"succeeded": true,
+							"new_versions": 3,
+							"worker_name": "other-worker"
+						}
+					]`))
+				})
+
+				Context("when paging", func() {
+					BeforeEach(func() {
+						queryParams = "?since=5&limit=2"
+					})
+
+					It("passes the page along", func() {
+						_, page := fakePipelineDB.GetResourceChecksArgsForCall(0)
+						Expect(page).To(Equal(db.Page{Since: 5, Limit: 2}))
+					})
+				})
+
+				Context("when next/previous pages are available", func() {
+					BeforeEach(func() {
+						fakePipelineDB.GetPipelineNameReturns("a-pipeline")
+						fakePipelineDB.GetResourceChecksReturns([]db.SavedResourceCheck{}, db.Pagination{
+							Previous: &db.Page{Until: 4, Limit: 2},
+							Next:     &db.Page{Since: 2, Limit: 2},
+						}, true, nil)
+					})
+
+					It("returns Link headers per rfc5988", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/checks?until=4&limit=2>; rel="previous"`, externalURL),
+							fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/checks?since=2&limit=2>; rel="next"`, externalURL),
+						}))
+					})
+				})
+			})
+
+			Context("when the resource cannot be found", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceChecksReturns(nil, db.Pagination{}, false, nil)
+				})
+
+				It("returns 404 not found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the checks fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceChecksReturns(nil, db.Pagination{}, false, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
 		})
 	})
 
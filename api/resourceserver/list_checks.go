@@ -0,0 +1,83 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListResourceChecks(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("list-resource-checks")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+		teamName := r.FormValue(":team_name")
+
+		until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+		since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit == 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		checks, pagination, found, err := pipelineDB.GetResourceChecks(resourceName, db.Page{
+			Until: until,
+			Since: since,
+			Limit: limit,
+		})
+		if err != nil {
+			logger.Error("failed-to-get-resource-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if pagination.Next != nil {
+			s.addChecksLink(w, teamName, pipelineDB.GetPipelineName(), resourceName, atc.PaginationQuerySince, pagination.Next.Since, pagination.Next.Limit, atc.LinkRelNext)
+		}
+
+		if pagination.Previous != nil {
+			s.addChecksLink(w, teamName, pipelineDB.GetPipelineName(), resourceName, atc.PaginationQueryUntil, pagination.Previous.Until, pagination.Previous.Limit, atc.LinkRelPrevious)
+		}
+
+		showCheckError := auth.IsAuthenticated(r)
+
+		presentedChecks := make([]atc.ResourceCheck, len(checks))
+		for i, check := range checks {
+			presentedChecks[i] = present.ResourceCheck(check, showCheckError)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(presentedChecks)
+	})
+}
+
+func (s *Server) addChecksLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, query string, id int, limit int, rel string) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/checks?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		teamName,
+		pipelineName,
+		resourceName,
+		query,
+		id,
+		atc.PaginationQueryLimit,
+		limit,
+		rel,
+	))
+}
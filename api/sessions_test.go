@@ -0,0 +1,267 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sessions API", func() {
+	Describe("GET /api/v1/teams/:team_name/sessions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/sessions")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", true, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, true, nil)
+				})
+
+				Context("when getting the sessions succeeds", func() {
+					BeforeEach(func() {
+						teamDB.GetSessionsReturns([]db.Session{
+							{
+								ID:        "some-session",
+								TeamName:  "some-team",
+								Username:  "some-user",
+								IssuedAt:  time.Unix(100, 0),
+								ExpiresAt: time.Unix(200, 0),
+							},
+							{
+								ID:        "other-session",
+								TeamName:  "some-team",
+								IssuedAt:  time.Unix(150, 0),
+								ExpiresAt: time.Unix(250, 0),
+							},
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the team's sessions", func() {
+						Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"id": "some-session",
+								"username": "some-user",
+								"issued_at": 100,
+								"expires_at": 200
+							},
+							{
+								"id": "other-session",
+								"issued_at": 150,
+								"expires_at": 250
+							}
+						]`))
+					})
+				})
+
+				Context("when getting the sessions fails", func() {
+					BeforeEach(func() {
+						teamDB.GetSessionsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(teamDB.GetSessionsCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.GetSessionsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/sessions/:session_id", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/sessions/some-session", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, true, nil)
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				It("revokes the session", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+					Expect(teamDB.RevokeSessionCallCount()).To(Equal(1))
+					Expect(teamDB.RevokeSessionArgsForCall(0)).To(Equal("some-session"))
+				})
+
+				Context("when revoking the session fails", func() {
+					BeforeEach(func() {
+						teamDB.RevokeSessionReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(teamDB.RevokeSessionCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.RevokeSessionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/sessions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/sessions", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", true, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, true, nil)
+				})
+
+				It("revokes all of the team's sessions", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+					Expect(teamDB.RevokeAllSessionsCallCount()).To(Equal(1))
+				})
+
+				Context("when revoking the sessions fails", func() {
+					BeforeEach(func() {
+						teamDB.RevokeAllSessionsReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when getting the team fails", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{}, false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					Expect(teamDB.RevokeAllSessionsCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.RevokeAllSessionsCallCount()).To(BeZero())
+			})
+		})
+	})
+})
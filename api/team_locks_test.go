@@ -0,0 +1,381 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team Locks API", func() {
+	Describe("GET /api/v1/teams/:team_name/locks", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/locks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, true, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, true, nil)
+				})
+
+				Context("when getting the lock holders succeeds", func() {
+					BeforeEach(func() {
+						teamDB.GetLockCapacitiesReturns(map[string]int{
+							"deploy":  2,
+							"staging": 3,
+						}, nil)
+
+						teamDB.GetLockHoldersReturns([]db.TeamLockHolder{
+							{
+								LockName:     "deploy",
+								BuildID:      1,
+								BuildName:    "3",
+								JobName:      "some-job",
+								PipelineName: "some-pipeline",
+								AcquiredAt:   time.Unix(100, 0),
+							},
+							{
+								LockName:     "deploy",
+								BuildID:      2,
+								BuildName:    "1",
+								JobName:      "some-other-job",
+								PipelineName: "some-other-pipeline",
+								AcquiredAt:   time.Unix(200, 0),
+							},
+							{
+								LockName:     "smoke-env",
+								BuildID:      1,
+								BuildName:    "3",
+								JobName:      "some-job",
+								PipelineName: "some-pipeline",
+								AcquiredAt:   time.Unix(100, 0),
+							},
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("looks up the requested team", func() {
+						Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+					})
+
+					It("returns the holders grouped by lock, along with every configured lock", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"name": "deploy",
+								"capacity": 2,
+								"holders": [
+									{
+										"build_id": 1,
+										"build_name": "3",
+										"job_name": "some-job",
+										"pipeline_name": "some-pipeline",
+										"acquired_at": 100
+									},
+									{
+										"build_id": 2,
+										"build_name": "1",
+										"job_name": "some-other-job",
+										"pipeline_name": "some-other-pipeline",
+										"acquired_at": 200
+									}
+								]
+							},
+							{
+								"name": "smoke-env",
+								"capacity": 1,
+								"holders": [
+									{
+										"build_id": 1,
+										"build_name": "3",
+										"job_name": "some-job",
+										"pipeline_name": "some-pipeline",
+										"acquired_at": 100
+									}
+								]
+							},
+							{
+								"name": "staging",
+								"capacity": 3,
+								"holders": []
+							}
+						]`))
+					})
+				})
+
+				Context("when getting the lock capacities fails", func() {
+					BeforeEach(func() {
+						teamDB.GetLockCapacitiesReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when getting the lock holders fails", func() {
+					BeforeEach(func() {
+						teamDB.GetLockHoldersReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/locks/:lock_name", func() {
+		var body string
+		var response *http.Response
+
+		BeforeEach(func() {
+			body = `{"capacity":3}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/locks/deploy", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, true, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, true, nil)
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				It("sets the capacity of the lock in the requested team", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+
+					Expect(teamDB.SetLockCapacityCallCount()).To(Equal(1))
+					lockName, capacity := teamDB.SetLockCapacityArgsForCall(0)
+					Expect(lockName).To(Equal("deploy"))
+					Expect(capacity).To(Equal(3))
+				})
+
+				Context("when setting the capacity fails", func() {
+					BeforeEach(func() {
+						teamDB.SetLockCapacityReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the capacity is below 1", func() {
+					BeforeEach(func() {
+						body = `{"capacity":0}`
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not set it", func() {
+						Expect(teamDB.SetLockCapacityCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the request is malformed", func() {
+					BeforeEach(func() {
+						body = `nope`
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not set anything", func() {
+					Expect(teamDB.SetLockCapacityCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not set anything", func() {
+				Expect(teamDB.SetLockCapacityCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/locks/:lock_name/holders/:build_id", func() {
+		var buildID string
+		var response *http.Response
+
+		BeforeEach(func() {
+			buildID = "42"
+		})
+
+		JustBeforeEach(func() {
+			path := fmt.Sprintf("%s/api/v1/teams/some-team/locks/deploy/holders/%s", server.URL, buildID)
+
+			request, err := http.NewRequest("DELETE", path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, true, true)
+			})
+
+			Context("when the build holds the lock", func() {
+				BeforeEach(func() {
+					teamDB.ReleaseLockReturns(true, nil)
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				It("releases the lock for the build in the requested team", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+
+					Expect(teamDB.ReleaseLockCallCount()).To(Equal(1))
+					lockName, actualBuildID := teamDB.ReleaseLockArgsForCall(0)
+					Expect(lockName).To(Equal("deploy"))
+					Expect(actualBuildID).To(Equal(42))
+				})
+			})
+
+			Context("when the build does not hold the lock", func() {
+				BeforeEach(func() {
+					teamDB.ReleaseLockReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when releasing the lock fails", func() {
+				BeforeEach(func() {
+					teamDB.ReleaseLockReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the build id is malformed", func() {
+				BeforeEach(func() {
+					buildID = "nope"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not release anything", func() {
+					Expect(teamDB.ReleaseLockCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not release anything", func() {
+				Expect(teamDB.ReleaseLockCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -168,6 +168,44 @@ var _ = Describe("Teams API", func() {
 				  }
 				]`))
 			})
+
+			Context("when authenticated as a member of some of the teams", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("aliens", false, true)
+					userContextReader.GetTeamsReturns([]string{"aliens", "cyborgs"}, true)
+				})
+
+				It("returns only those teams", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 9,
+							"name": "aliens"
+						},
+						{
+							"id": 23,
+							"name": "cyborgs"
+						}
+					]`))
+				})
+			})
+
+			Context("when authenticated as an admin", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("main", true, true)
+				})
+
+				It("returns all teams", func() {
+					var teams []atc.Team
+					err := json.NewDecoder(response.Body).Decode(&teams)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(teams).To(HaveLen(4))
+				})
+			})
 		})
 	})
 
@@ -432,6 +470,54 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Describe("GitLab Authentication", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						GitLabAuth: &atc.GitLabAuth{
+							ClientID:     "Dean Venture",
+							ClientSecret: "Hank Venture",
+							Groups:       []string{"Venture Industries"},
+						},
+					}
+				})
+
+				Context("when passed a valid team with GitLab Auth", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("ClientID not filled in", func() {
+					BeforeEach(func() {
+						team.GitLabAuth.ClientID = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("ClientSecret not filled in", func() {
+					BeforeEach(func() {
+						team.GitLabAuth.ClientSecret = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("no groups or users", func() {
+					BeforeEach(func() {
+						team.GitLabAuth.Groups = nil
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
 			Describe("Generic OAuth Authentication", func() {
 				BeforeEach(func() {
 					team = atc.Team{
@@ -502,6 +588,173 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Describe("OIDC Authentication", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						OIDCAuth: &atc.OIDCAuth{
+							DisplayName:  "Venture Industries",
+							Issuer:       "https://issuer.example.com",
+							ClientID:     "Brock Samson",
+							ClientSecret: "09262-8765-001",
+							EmailDomains: []string{"venture.com"},
+						},
+					}
+				})
+
+				Context("when passed a valid team with OIDC Auth", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("ClientID not filled in", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.ClientID = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("ClientSecret not filled in", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.ClientSecret = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("Issuer not filled in", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.Issuer = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("DisplayName not filled in", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.DisplayName = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("no users, email domains or groups", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.EmailDomains = nil
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Describe("LDAP Authentication", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						LDAPAuth: &atc.LDAPAuth{
+							Host:              "ldap.example.com:389",
+							UserSearchBaseDN:  "ou=people,dc=example,dc=com",
+							GroupSearchBaseDN: "ou=groups,dc=example,dc=com",
+							Groups:            []string{"developers"},
+						},
+					}
+				})
+
+				Context("when passed a valid team with LDAP Auth", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("Host not filled in", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.Host = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("UserSearchBaseDN not filled in", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.UserSearchBaseDN = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("GroupSearchBaseDN not filled in with Groups", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.GroupSearchBaseDN = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("CACert is invalid", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.CACert = "not a certificate"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Describe("Cert Authentication", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						CertAuth: &atc.CertAuth{
+							CommonNames: []string{"ci-bot"},
+						},
+					}
+				})
+
+				Context("when passed a valid team with Cert Auth", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("when only SANs are given", func() {
+					BeforeEach(func() {
+						team.CertAuth = &atc.CertAuth{
+							SANs: []string{"ci.example.com"},
+						}
+					})
+
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("CommonNames and SANs not filled in", func() {
+					BeforeEach(func() {
+						team.CertAuth = &atc.CertAuth{}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
 			Context("when there's a problem finding teams", func() {
 				BeforeEach(func() {
 					teamDB.GetTeamReturns(db.SavedTeam{}, false, errors.New("a dingo ate my baby!"))
@@ -669,6 +922,108 @@ var _ = Describe("Teams API", func() {
 						})
 					})
 
+					Context("when passed OIDC auth credentials", func() {
+						BeforeEach(func() {
+							team.OIDCAuth = &atc.OIDCAuth{
+								DisplayName:  "Venture Industries",
+								Issuer:       "https://issuer.example.com",
+								ClientID:     "Brock Samson",
+								ClientSecret: "09262-8765-001",
+								Groups:       []string{"guild"},
+							}
+
+							teamDB.UpdateOIDCAuthStub = func(oidcAuth *db.OIDCAuth) (db.SavedTeam, error) {
+								Expect(oidcAuth.Issuer).To(Equal(team.OIDCAuth.Issuer))
+								Expect(oidcAuth.ClientID).To(Equal(team.OIDCAuth.ClientID))
+								Expect(oidcAuth.ClientSecret).To(Equal(team.OIDCAuth.ClientSecret))
+								Expect(oidcAuth.Groups).To(Equal(team.OIDCAuth.Groups))
+								Expect(oidcAuth.DisplayName).To(Equal(team.OIDCAuth.DisplayName))
+
+								savedTeam.OIDCAuth = oidcAuth
+								return savedTeam, nil
+							}
+						})
+
+						It("updates the OIDC auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateOIDCAuthCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when passed GitLab auth credentials", func() {
+						BeforeEach(func() {
+							team.GitLabAuth = &atc.GitLabAuth{
+								ClientID:     "Dean Venture",
+								ClientSecret: "Hank Venture",
+								Users:        []string{"brock"},
+								APIURL:       "https://gitlab.example.com/api/v4/",
+							}
+
+							teamDB.UpdateGitLabAuthStub = func(gitLabAuth *db.GitLabAuth) (db.SavedTeam, error) {
+								Expect(gitLabAuth.ClientID).To(Equal(team.GitLabAuth.ClientID))
+								Expect(gitLabAuth.ClientSecret).To(Equal(team.GitLabAuth.ClientSecret))
+								Expect(gitLabAuth.Users).To(Equal(team.GitLabAuth.Users))
+								Expect(gitLabAuth.APIURL).To(Equal(team.GitLabAuth.APIURL))
+
+								savedTeam.GitLabAuth = gitLabAuth
+								return savedTeam, nil
+							}
+						})
+
+						It("updates the GitLab auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateGitLabAuthCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when passed LDAP auth configuration", func() {
+						BeforeEach(func() {
+							team.LDAPAuth = &atc.LDAPAuth{
+								Host:             "ldap.example.com:389",
+								BindDN:           "cn=concourse,dc=example,dc=com",
+								BindPassword:     "some-password",
+								UserSearchBaseDN: "ou=people,dc=example,dc=com",
+							}
+
+							teamDB.UpdateLDAPAuthStub = func(ldapAuth *db.LDAPAuth) (db.SavedTeam, error) {
+								Expect(ldapAuth.Host).To(Equal(team.LDAPAuth.Host))
+								Expect(ldapAuth.BindDN).To(Equal(team.LDAPAuth.BindDN))
+								Expect(ldapAuth.BindPassword).To(Equal(team.LDAPAuth.BindPassword))
+								Expect(ldapAuth.UserSearchBaseDN).To(Equal(team.LDAPAuth.UserSearchBaseDN))
+
+								savedTeam.LDAPAuth = ldapAuth
+								return savedTeam, nil
+							}
+						})
+
+						It("updates the LDAP auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateLDAPAuthCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when passed cert auth configuration", func() {
+						BeforeEach(func() {
+							team.CertAuth = &atc.CertAuth{
+								CommonNames: []string{"ci-bot"},
+								SANs:        []string{"ci.example.com"},
+							}
+
+							teamDB.UpdateCertAuthStub = func(certAuth *db.CertAuth) (db.SavedTeam, error) {
+								Expect(certAuth.CommonNames).To(Equal(team.CertAuth.CommonNames))
+								Expect(certAuth.SANs).To(Equal(team.CertAuth.SANs))
+
+								savedTeam.CertAuth = certAuth
+								return savedTeam, nil
+							}
+						})
+
+						It("updates the cert auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateCertAuthCallCount()).To(Equal(1))
+						})
+					})
+
 				})
 			})
 
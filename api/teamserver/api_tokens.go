@@ -0,0 +1,193 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	hLog := s.logger.Session("list-api-tokens", lager.Data{
+		"team": teamName,
+	})
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	apiTokens, err := teamDB.GetAPITokens()
+	if err != nil {
+		hLog.Error("failed-to-get-api-tokens", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedTokens := []atc.APIToken{}
+	for _, apiToken := range apiTokens {
+		presentedTokens = append(presentedTokens, present.APIToken(apiToken))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presentedTokens)
+}
+
+func (s *Server) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	hLog := s.logger.Session("create-api-token", lager.Data{
+		"team": teamName,
+	})
+
+	if auth.IsAPITokenRequest(r) {
+		hLog.Info("created-with-api-token")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var request atc.APIToken
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.Kind == "" {
+		request.Kind = atc.APITokenKindService
+	}
+
+	var expiresAt time.Time
+	if request.ExpiresAt != 0 {
+		expiresAt = time.Unix(request.ExpiresAt, 0)
+	}
+
+	if !validAPIToken(request, expiresAt) {
+		hLog.Info("invalid-api-token", lager.Data{
+			"name":   request.Name,
+			"kind":   request.Kind,
+			"scopes": request.Scopes,
+		})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// personal tokens are revoked along with their owner's sessions, so they
+	// can only be created by a known user
+	var owner string
+	if request.Kind == atc.APITokenKindPersonal {
+		owner, _ = auth.GetUsername(r)
+		if owner == "" {
+			hLog.Info("personal-api-token-without-user")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	token, err := auth.GenerateAPIToken()
+	if err != nil {
+		hLog.Error("failed-to-generate-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	apiToken, err := teamDB.CreateAPIToken(request.Name, token, request.Kind, owner, request.Scopes, expiresAt)
+	if err != nil {
+		if err == db.ErrAPITokenExists {
+			hLog.Info("api-token-exists", lager.Data{"name": request.Name})
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		hLog.Error("failed-to-create-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedToken := present.APIToken(apiToken)
+	presentedToken.Token = token
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	json.NewEncoder(w).Encode(presentedToken)
+}
+
+func (s *Server) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	tokenName := r.FormValue(":token_name")
+	hLog := s.logger.Session("revoke-api-token", lager.Data{
+		"team": teamName,
+		"name": tokenName,
+	})
+
+	revoked, err := s.teamDBFactory.GetTeamDB(teamName).RevokeAPIToken(tokenName)
+	if err != nil {
+		hLog.Error("failed-to-revoke-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !revoked {
+		hLog.Info("api-token-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func validAPIToken(apiToken atc.APIToken, expiresAt time.Time) bool {
+	if apiToken.Name == "" || len(apiToken.Scopes) == 0 {
+		return false
+	}
+
+	if apiToken.Kind != atc.APITokenKindPersonal && apiToken.Kind != atc.APITokenKindService {
+		return false
+	}
+
+	for _, scope := range apiToken.Scopes {
+		if scope != atc.APITokenScopeRead && scope != atc.APITokenScopeWrite {
+			return false
+		}
+	}
+
+	if !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return false
+	}
+
+	return true
+}
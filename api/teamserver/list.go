@@ -7,8 +7,12 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 )
 
+// ListTeams lists every team to anonymous users so that they can pick one to
+// log in to, and admins, but only lists the teams a user belongs to once
+// they are logged in.
 func (s *Server) ListTeams(w http.ResponseWriter, r *http.Request) {
 	hLog := s.logger.Session("list-teams")
 
@@ -16,11 +20,19 @@ func (s *Server) ListTeams(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		hLog.Error("failed-to-get-teams", errors.New("sorry"))
 		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
 
-	presentedTeams := make([]atc.Team, len(savedTeams))
-	for i, savedTeam := range savedTeams {
-		presentedTeams[i] = present.Team(savedTeam)
+	authTeam, authTeamFound := auth.GetTeam(r)
+	filter := authTeamFound && auth.IsAuthenticated(r) && !authTeam.IsAdmin()
+
+	presentedTeams := []atc.Team{}
+	for _, savedTeam := range savedTeams {
+		if filter && !authTeam.IsAuthorized(savedTeam.Name) {
+			continue
+		}
+
+		presentedTeams = append(presentedTeams, present.Team(savedTeam))
 	}
 
 	json.NewEncoder(w).Encode(presentedTeams)
@@ -0,0 +1,244 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListLocalUsers(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	hLog := s.logger.Session("list-local-users", lager.Data{
+		"team": teamName,
+	})
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	localUsers, err := teamDB.GetLocalUsers()
+	if err != nil {
+		hLog.Error("failed-to-get-local-users", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedUsers := []atc.LocalUser{}
+	for _, localUser := range localUsers {
+		presentedUsers = append(presentedUsers, present.LocalUser(localUser))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presentedUsers)
+}
+
+func (s *Server) CreateLocalUser(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	hLog := s.logger.Session("create-local-user", lager.Data{
+		"team": teamName,
+	})
+
+	var request atc.LocalUser
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !validUsername(request.Username) || request.Password == "" {
+		hLog.Info("invalid-local-user", lager.Data{"username": request.Username})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	team, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// tokens only record the name a user logged in as, so a local user named
+	// after the team's basic auth user would be mistaken for it on renewal
+	if team.BasicAuth != nil && team.BasicAuth.BasicAuthUsername == request.Username {
+		hLog.Info("username-is-basic-auth-username", lager.Data{"username": request.Username})
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	localUser, err := teamDB.CreateLocalUser(request.Username, request.Password)
+	if err != nil {
+		if err == db.ErrLocalUserExists {
+			hLog.Info("local-user-exists", lager.Data{"username": request.Username})
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		hLog.Error("failed-to-create-local-user", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	json.NewEncoder(w).Encode(present.LocalUser(localUser))
+}
+
+func (s *Server) SetLocalUserPassword(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	username := r.FormValue(":username")
+	hLog := s.logger.Session("set-local-user-password", lager.Data{
+		"team":     teamName,
+		"username": username,
+	})
+
+	var request atc.LocalUser
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.Password == "" {
+		hLog.Info("missing-password")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	updated, err := teamDB.SetLocalUserPassword(username, request.Password)
+	if err != nil {
+		hLog.Error("failed-to-set-password", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !updated {
+		hLog.Info("local-user-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// sessions started with the old password must not outlive it
+	err = teamDB.RevokeUserSessions(username)
+	if err != nil {
+		hLog.Error("failed-to-revoke-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) EnableLocalUser(w http.ResponseWriter, r *http.Request) {
+	s.setLocalUserEnabled(w, r, true)
+}
+
+func (s *Server) DisableLocalUser(w http.ResponseWriter, r *http.Request) {
+	s.setLocalUserEnabled(w, r, false)
+}
+
+func (s *Server) setLocalUserEnabled(w http.ResponseWriter, r *http.Request, enabled bool) {
+	teamName := r.FormValue(":team_name")
+	username := r.FormValue(":username")
+	hLog := s.logger.Session("set-local-user-enabled", lager.Data{
+		"team":     teamName,
+		"username": username,
+		"enabled":  enabled,
+	})
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	updated, err := teamDB.SetLocalUserEnabled(username, enabled)
+	if err != nil {
+		hLog.Error("failed-to-set-enabled", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !updated {
+		hLog.Info("local-user-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if !enabled {
+		err = teamDB.RevokeUserSessions(username)
+		if err != nil {
+			hLog.Error("failed-to-revoke-sessions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) DeleteLocalUser(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	username := r.FormValue(":username")
+	hLog := s.logger.Session("delete-local-user", lager.Data{
+		"team":     teamName,
+		"username": username,
+	})
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	deleted, err := teamDB.DeleteLocalUser(username)
+	if err != nil {
+		hLog.Error("failed-to-delete-local-user", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		hLog.Info("local-user-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// otherwise a user created later with the same name would inherit them
+	err = teamDB.RevokeUserSessions(username)
+	if err != nil {
+		hLog.Error("failed-to-revoke-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validUsername rejects names that could not be sent as basic auth.
+func validUsername(username string) bool {
+	return username != "" && !strings.Contains(username, ":")
+}
@@ -0,0 +1,136 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) ListTeamLocks(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	hLog := s.logger.Session("list-team-locks", lager.Data{
+		"team": teamName,
+	})
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	capacities, err := teamDB.GetLockCapacities()
+	if err != nil {
+		hLog.Error("failed-to-get-lock-capacities", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	holders, err := teamDB.GetLockHolders()
+	if err != nil {
+		hLog.Error("failed-to-get-lock-holders", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(present.TeamLocks(capacities, holders))
+}
+
+func (s *Server) SetTeamLock(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	lockName := r.FormValue(":lock_name")
+	hLog := s.logger.Session("set-team-lock", lager.Data{
+		"team": teamName,
+		"lock": lockName,
+	})
+
+	var config atc.TeamLockConfig
+	err := json.NewDecoder(r.Body).Decode(&config)
+	if err != nil {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if config.Capacity < 1 {
+		hLog.Info("invalid-capacity", lager.Data{"capacity": config.Capacity})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = teamDB.SetLockCapacity(lockName, config.Capacity)
+	if err != nil {
+		hLog.Error("failed-to-set-lock-capacity", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Info("set", lager.Data{"capacity": config.Capacity})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) ReleaseTeamLock(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	lockName := r.FormValue(":lock_name")
+	hLog := s.logger.Session("release-team-lock", lager.Data{
+		"team": teamName,
+		"lock": lockName,
+	})
+
+	buildID, err := strconv.Atoi(r.FormValue(":build_id"))
+	if err != nil {
+		hLog.Info("malformed-build-id", lager.Data{"build-id": r.FormValue(":build_id")})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	released, err := teamDB.ReleaseLock(lockName, buildID)
+	if err != nil {
+		hLog.Error("failed-to-release-lock", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !released {
+		hLog.Info("lock-holder-not-found", lager.Data{"build-id": buildID})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	hLog.Info("released", lager.Data{"build-id": buildID})
+
+	w.WriteHeader(http.StatusNoContent)
+}
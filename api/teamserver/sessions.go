@@ -0,0 +1,143 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+)
+
+func (s *Server) ListSessions(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	hLog := s.logger.Session("list-sessions", lager.Data{
+		"team": teamName,
+	})
+
+	if !canManageSessions(r, teamName) {
+		hLog.Info("not-permitted")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	sessions, err := teamDB.GetSessions()
+	if err != nil {
+		hLog.Error("failed-to-get-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedSessions := []atc.Session{}
+	for _, session := range sessions {
+		presentedSessions = append(presentedSessions, present.Session(session))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presentedSessions)
+}
+
+func (s *Server) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	sessionID := r.FormValue(":session_id")
+	hLog := s.logger.Session("revoke-session", lager.Data{
+		"team":    teamName,
+		"session": sessionID,
+	})
+
+	if !canManageSessions(r, teamName) {
+		hLog.Info("not-permitted")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = teamDB.RevokeSession(sessionID)
+	if err != nil {
+		hLog.Error("failed-to-revoke-session", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) RevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	hLog := s.logger.Session("revoke-all-sessions", lager.Data{
+		"team": teamName,
+	})
+
+	if !canManageSessions(r, teamName) {
+		hLog.Info("not-permitted")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = teamDB.RevokeAllSessions()
+	if err != nil {
+		hLog.Error("failed-to-revoke-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// canManageSessions lets a team list and revoke its own sessions and admins
+// list and revoke anyone's.
+func canManageSessions(r *http.Request, teamName string) bool {
+	authTeam, found := auth.GetTeam(r)
+	if !found {
+		return false
+	}
+
+	return authTeam.IsAdmin() || authTeam.IsAuthorized(teamName)
+}
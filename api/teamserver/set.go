@@ -92,6 +92,11 @@ func (s *Server) updateCredentials(team db.Team, teamDB db.TeamDB) error {
 		return err
 	}
 
+	_, err = teamDB.UpdateGitLabAuth(team.GitLabAuth)
+	if err != nil {
+		return err
+	}
+
 	_, err = teamDB.UpdateUAAAuth(team.UAAAuth)
 	if err != nil {
 		return err
@@ -102,6 +107,21 @@ func (s *Server) updateCredentials(team db.Team, teamDB db.TeamDB) error {
 		return err
 	}
 
+	_, err = teamDB.UpdateOIDCAuth(team.OIDCAuth)
+	if err != nil {
+		return err
+	}
+
+	_, err = teamDB.UpdateLDAPAuth(team.LDAPAuth)
+	if err != nil {
+		return err
+	}
+
+	_, err = teamDB.UpdateCertAuth(team.CertAuth)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -124,6 +144,16 @@ func (s *Server) validate(team db.Team) error {
 		}
 	}
 
+	if team.GitLabAuth != nil {
+		if team.GitLabAuth.ClientID == "" || team.GitLabAuth.ClientSecret == "" {
+			return errors.New("GitLab auth missing ClientID or ClientSecret")
+		}
+
+		if len(team.GitLabAuth.Groups) == 0 && len(team.GitLabAuth.Users) == 0 {
+			return errors.New("GitLab auth requires at least one Group or User")
+		}
+	}
+
 	if team.UAAAuth != nil {
 		if team.UAAAuth.ClientID == "" || team.UAAAuth.ClientSecret == "" {
 			return errors.New("CF auth missing ClientID or ClientSecret")
@@ -166,5 +196,59 @@ func (s *Server) validate(team db.Team) error {
 		}
 	}
 
+	if team.OIDCAuth != nil {
+		if team.OIDCAuth.ClientID == "" || team.OIDCAuth.ClientSecret == "" {
+			return errors.New("OIDC auth requires ClientID and ClientSecret")
+		}
+
+		if team.OIDCAuth.Issuer == "" {
+			return errors.New("OIDC auth requires an Issuer")
+		}
+
+		if team.OIDCAuth.DisplayName == "" {
+			return errors.New("OIDC auth requires a Display Name")
+		}
+
+		if len(team.OIDCAuth.Users) == 0 &&
+			len(team.OIDCAuth.EmailDomains) == 0 &&
+			len(team.OIDCAuth.Groups) == 0 {
+			return errors.New("OIDC auth requires at least one User, Email Domain, or Group")
+		}
+	}
+
+	if team.LDAPAuth != nil {
+		if team.LDAPAuth.Host == "" {
+			return errors.New("LDAP auth requires a Host")
+		}
+
+		if team.LDAPAuth.UserSearchBaseDN == "" {
+			return errors.New("LDAP auth requires a UserSearchBaseDN")
+		}
+
+		if len(team.LDAPAuth.Groups) > 0 && team.LDAPAuth.GroupSearchBaseDN == "" {
+			return errors.New("LDAP auth requires a GroupSearchBaseDN to authorize Groups")
+		}
+
+		if team.LDAPAuth.CACert != "" {
+			block, _ := pem.Decode([]byte(team.LDAPAuth.CACert))
+			invalidCertErr := errors.New("LDAP certificate is invalid")
+
+			if block == nil {
+				return invalidCertErr
+			}
+
+			_, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return invalidCertErr
+			}
+		}
+	}
+
+	if team.CertAuth != nil {
+		if len(team.CertAuth.CommonNames) == 0 && len(team.CertAuth.SANs) == 0 {
+			return errors.New("Cert auth requires at least one CommonName or SAN")
+		}
+	}
+
 	return nil
 }
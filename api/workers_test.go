@@ -724,6 +724,21 @@ var _ = Describe("Workers API", func() {
 			Expect(t).To(Equal(ttl))
 		})
 
+		Context("when the worker reports its disk usage", func() {
+			BeforeEach(func() {
+				worker.DiskCapacityInBytes = 1000
+				worker.DiskUsageInBytes = 900
+			})
+
+			It("heartbeats with the disk usage", func() {
+				Expect(dbWorkerFactory.HeartbeatWorkerCallCount()).To(Equal(1))
+
+				w, _ := dbWorkerFactory.HeartbeatWorkerArgsForCall(0)
+				Expect(w.DiskCapacityInBytes).To(Equal(int64(1000)))
+				Expect(w.DiskUsageInBytes).To(Equal(int64(900)))
+			})
+		})
+
 		Context("when the TTL is invalid", func() {
 			BeforeEach(func() {
 				ttlStr = "invalid-duration"
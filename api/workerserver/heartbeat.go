@@ -46,6 +46,14 @@ func (s *Server) HeartbeatWorker(w http.ResponseWriter, r *http.Request) {
 		Containers: registration.ActiveContainers,
 	}.Emit(s.logger)
 
+	if registration.DiskCapacityInBytes > 0 {
+		metric.WorkerDiskUsage{
+			WorkerName:      registration.Name,
+			CapacityInBytes: registration.DiskCapacityInBytes,
+			UsageInBytes:    registration.DiskUsageInBytes,
+		}.Emit(s.logger)
+	}
+
 	savedWorker, err := s.dbWorkerFactory.HeartbeatWorker(registration, ttl)
 	if err == dbng.ErrWorkerNotPresent {
 		logger.Error("failed-to-find-worker", err)
@@ -0,0 +1,32 @@
+package atc
+
+const (
+	// APITokenScopeRead permits requests to routes which only read state.
+	APITokenScopeRead = "read"
+
+	// APITokenScopeWrite permits every request.
+	APITokenScopeWrite = "write"
+)
+
+const (
+	// APITokenKindPersonal tokens act as the user who created them, and are
+	// revoked along with the user's sessions.
+	APITokenKindPersonal = "personal"
+
+	// APITokenKindService tokens belong to the team, and outlive the user
+	// who created them.
+	APITokenKindService = "service"
+)
+
+type APIToken struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind,omitempty"`
+	Owner      string   `json:"owner,omitempty"`
+	Scopes     []string `json:"scopes"`
+	CreatedAt  int64    `json:"created_at,omitempty"`
+	ExpiresAt  int64    `json:"expires_at,omitempty"`
+	LastUsedAt int64    `json:"last_used_at,omitempty"`
+
+	// Token is only returned when the token is created.
+	Token string `json:"token,omitempty"`
+}
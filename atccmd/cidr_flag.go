@@ -0,0 +1,38 @@
+package atccmd
+
+import (
+	"fmt"
+	"net"
+)
+
+// CIDRFlag is a network in CIDR notation. A bare IP is taken to be a network
+// of just that address.
+type CIDRFlag struct {
+	*net.IPNet
+}
+
+func (f *CIDRFlag) UnmarshalFlag(value string) error {
+	_, network, err := net.ParseCIDR(value)
+	if err == nil {
+		f.IPNet = network
+		return nil
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return fmt.Errorf("invalid IP or CIDR: '%s'", value)
+	}
+
+	bits := 8 * net.IPv6len
+	if ip.To4() != nil {
+		ip = ip.To4()
+		bits = 8 * net.IPv4len
+	}
+
+	f.IPNet = &net.IPNet{
+		IP:   ip,
+		Mask: net.CIDRMask(bits, bits),
+	}
+
+	return nil
+}
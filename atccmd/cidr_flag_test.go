@@ -0,0 +1,48 @@
+package atccmd_test
+
+import (
+	"net"
+
+	"github.com/concourse/atc/atccmd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CIDRFlag", func() {
+	It("parses networks in CIDR notation", func() {
+		flag := atccmd.CIDRFlag{}
+
+		err := flag.UnmarshalFlag("10.0.0.0/8")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(flag.String()).To(Equal("10.0.0.0/8"))
+		Expect(flag.Contains(net.ParseIP("10.1.2.3"))).To(BeTrue())
+		Expect(flag.Contains(net.ParseIP("11.1.2.3"))).To(BeFalse())
+	})
+
+	It("parses bare IPv4 addresses as a network of just that address", func() {
+		flag := atccmd.CIDRFlag{}
+
+		err := flag.UnmarshalFlag("10.1.2.3")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(flag.String()).To(Equal("10.1.2.3/32"))
+	})
+
+	It("parses bare IPv6 addresses as a network of just that address", func() {
+		flag := atccmd.CIDRFlag{}
+
+		err := flag.UnmarshalFlag("fd00::1")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(flag.String()).To(Equal("fd00::1/128"))
+	})
+
+	It("returns an error for anything else", func() {
+		flag := atccmd.CIDRFlag{}
+
+		err := flag.UnmarshalFlag("example.com")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("invalid IP or CIDR: 'example.com'"))
+	})
+})
@@ -4,9 +4,11 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"net/url"
@@ -20,6 +22,7 @@ import (
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/ldap"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/db"
@@ -64,6 +67,8 @@ type ATCCommand struct {
 	TLSCert     FileFlag `long:"tls-cert"      description:"File containing an SSL certificate."`
 	TLSKey      FileFlag `long:"tls-key"       description:"File containing an RSA private key, used to encrypt HTTPS traffic."`
 
+	TLSClientCACert FileFlag `long:"tls-client-ca-cert" description:"File containing a CA bundle used to verify client certificates presented over HTTPS."`
+
 	ExternalURL URLFlag `long:"external-url" default:"http://127.0.0.1:8080" description:"URL used to reach any ATC from the outside world."`
 	PeerURL     URLFlag `long:"peer-url"     default:"http://127.0.0.1:8080" description:"URL used to reach this ATC from other ATCs in the cluster."`
 
@@ -76,14 +81,28 @@ type ATCCommand struct {
 	DebugBindIP   IPFlag `long:"debug-bind-ip"   default:"127.0.0.1" description:"IP address on which to listen for the pprof debugger endpoints."`
 	DebugBindPort uint16 `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
 
-	SessionSigningKey FileFlag `long:"session-signing-key" description:"File containing an RSA private key, used to sign session tokens."`
+	SessionSigningKeys []FileFlag `long:"session-signing-key" description:"File containing an RSA private key, used to sign session tokens. Can be specified multiple times to rotate keys; the first one signs new tokens, and tokens signed with any of them are accepted."`
 
-	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
-	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
-	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
+	ResourceCheckingInterval           time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
+	ResourceCheckingJitter             time.Duration `long:"resource-checking-jitter" default:"10s" description:"Maximum random delay to add to each resource checking interval, to spread checks out."`
+	ResourceCheckingMaxBackoff         time.Duration `long:"resource-checking-max-backoff" default:"1h" description:"Maximum interval to back off to when checking a resource keeps failing."`
+	ResourceCheckHistoryRetention      time.Duration `long:"resource-check-history-retention" default:"168h" description:"How long to keep the history of resource checks. Set to 0 to keep it forever."`
+	MaxConcurrentResourceChecks        int           `long:"max-concurrent-resource-checks" default:"0" description:"Maximum number of resource checks to run at once across all ATCs. Checks beyond this wait in a queue. 0 means no limit."`
+	MaxConcurrentResourceChecksPerTeam int           `long:"max-concurrent-resource-checks-per-team" default:"0" description:"Maximum number of resource checks to run at once for each team. 0 means no limit."`
+	OldResourceGracePeriod             time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
+	ResourceCacheCleanupInterval       time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
+	VolumeDiskHighWatermark            int           `long:"volume-disk-high-watermark" default:"90" description:"Percentage of a worker's disk above which the least-recently-used resource caches are evicted and new containers are placed on other workers. 0 disables this."`
 
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	ContainerPlacementStrategy string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" description:"Method by which a worker is selected during container placement."`
+	MaxActiveTasksPerWorker    int    `long:"max-active-tasks-per-worker" default:"0" description:"Maximum number of tasks running on a worker at once when using the limit-active-tasks placement strategy. Steps wait for a worker below the limit. 0 means no limit."`
+
+	WorkerWaitTimeout  time.Duration `long:"worker-wait-timeout" default:"5m" description:"How long a step waits for a compatible worker to become available before erroring. 0 errors immediately."`
+	WorkerLossAttempts int           `long:"worker-loss-attempts" default:"3" description:"Maximum number of times to run a get, or a task marked with retry_on_worker_loss, when its worker is lost mid-build. 1 disables retrying."`
+
+	EnableStateBasedGC bool `long:"enable-state-based-gc" description:"Collect orphaned build containers and their volumes through their recorded state instead of the container keepaliver. Resource caches, imported volumes and check containers are still collected by their TTLs."`
+
 	Developer struct {
 		DevelopmentMode bool `short:"d" long:"development-mode"  description:"Lax security rules to make local development easier."`
 		Noop            bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
@@ -97,14 +116,36 @@ type ATCCommand struct {
 		ResourceTypes   map[string]string `long:"resource"         description:"A resource type to advertise for the worker. Can be specified multiple times." value-name:"TYPE:IMAGE"`
 	} `group:"Static Worker (optional)" namespace:"worker"`
 
+	RateLimit struct {
+		Read             float64 `long:"read"               description:"Requests per second each client may make to read-only API endpoints. 0 means no limit."`
+		ReadBurst        int     `long:"read-burst"         description:"Number of read-only requests a client may make in a burst before being limited."`
+		Write            float64 `long:"write"              description:"Requests per second each client may make to API endpoints that make changes. 0 means no limit."`
+		WriteBurst       int     `long:"write-burst"        description:"Number of write requests a client may make in a burst before being limited."`
+		EventStream      float64 `long:"event-stream"       description:"Build event streams each client may open per second. 0 means no limit."`
+		EventStreamBurst int     `long:"event-stream-burst" description:"Number of build event streams a client may open in a burst before being limited."`
+		Hijack           float64 `long:"hijack"             description:"Container hijacks each client may start per second. 0 means no limit."`
+		HijackBurst      int     `long:"hijack-burst"       description:"Number of container hijacks a client may start in a burst before being limited."`
+
+		TrustedProxies []CIDRFlag `long:"trusted-proxy" description:"IP or CIDR range of a reverse proxy whose X-Forwarded-For header names the client. Can be specified multiple times."`
+
+		Team  RateLimitFlags `group:"API Rate Limiting (per team)" namespace:"team"`
+		Token RateLimitFlags `group:"API Rate Limiting (per token)" namespace:"token"`
+	} `group:"API Rate Limiting (per client IP)" namespace:"api-rate-limit"`
+
 	BasicAuth atc.BasicAuthFlag `group:"Basic Authentication" namespace:"basic-auth"`
 
 	GitHubAuth atc.GitHubAuthFlag `group:"GitHub Authentication" namespace:"github-auth"`
 
+	GitLabAuth atc.GitLabAuthFlag `group:"GitLab Authentication" namespace:"gitlab-auth"`
+
 	UAAAuth atc.UAAAuthFlag `group:"UAA Authentication" namespace:"uaa-auth"`
 
 	GenericOAuth atc.GenericOAuthFlag `group:"Generic OAuth Authentication (Allows access to ALL authenticated users)" namespace:"generic-oauth"`
 
+	OIDCAuth atc.OIDCAuthFlag `group:"OpenID Connect Authentication" namespace:"oidc-auth"`
+
+	LDAPAuth atc.LDAPAuthFlag `group:"LDAP Authentication" namespace:"ldap-auth"`
+
 	Metrics struct {
 		HostName   string            `long:"metrics-host-name"   description:"Host string to attach to emitted metrics."`
 		Tags       []string          `long:"metrics-tag"         description:"Tag to attach to emitted metrics. Can be specified multiple times." value-name:"TAG"`
@@ -161,32 +202,55 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	listener := pq.NewListener(cmd.PostgresDataSource, time.Second, time.Minute, nil)
 	bus := db.NewNotificationsBus(listener, dbConn)
 
-	sqlDB := db.NewSQL(dbConn, bus, lockFactory)
+	versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+
+	sqlDB := db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 	dbTeamFactory := dbng.NewTeamFactory(dbngConn)
 	dbWorkerFactory := dbng.NewWorkerFactory(dbngConn)
+	dbContainerFactory := dbng.NewContainerFactory(dbngConn)
+	dbVolumeFactory := dbng.NewVolumeFactory(dbngConn)
 	trackerFactory := resource.NewTrackerFactory()
 	resourceFetcherFactory := resource.NewFetcherFactory(sqlDB, clock.NewClock())
-	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
-	workerClient := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory, pipelineDBFactory, dbWorkerFactory)
+	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+	workerClient := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory, pipelineDBFactory, dbWorkerFactory, cmd.WorkerWaitTimeout)
+
+	// checks run while holding the resource's checking lock and a check
+	// limiter slot, so they fail fast rather than waiting for a worker
+	checkWorkerClient := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory, pipelineDBFactory, dbWorkerFactory, 0)
 
 	tracker := trackerFactory.TrackerFor(workerClient)
+	checkTracker := trackerFactory.TrackerFor(checkWorkerClient)
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
-	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory)
 
+	checkLimiter := radar.NewCheckLimiter(
+		clock.NewClock(),
+		sqlDB,
+		cmd.MaxConcurrentResourceChecks,
+		cmd.MaxConcurrentResourceChecksPerTeam,
+		time.Second,
+	)
+
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
-		tracker,
+		checkTracker,
 		cmd.ResourceCheckingInterval,
+		cmd.ResourceCheckingJitter,
+		cmd.ResourceCheckingMaxBackoff,
+		checkLimiter,
 		engine,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
-		tracker,
+		checkTracker,
 		cmd.ResourceCheckingInterval,
+		cmd.ResourceCheckingJitter,
+		cmd.ResourceCheckingMaxBackoff,
+		checkLimiter,
 		cmd.ExternalURL.String(),
 	)
 
-	signingKey, err := cmd.loadOrGenerateSigningKey()
+	signingKeys, err := cmd.loadOrGenerateSigningKeys()
 	if err != nil {
 		return nil, err
 	}
@@ -221,7 +285,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		dbTeamFactory,
 		dbWorkerFactory,
 		providerFactory,
-		signingKey,
+		signingKeys,
 		pipelineDBFactory,
 		engine,
 		workerClient,
@@ -238,7 +302,8 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		logger,
 		providerFactory,
 		teamDBFactory,
-		signingKey,
+		sqlDB,
+		signingKeys,
 		cmd.AuthDuration,
 	)
 	if err != nil {
@@ -256,6 +321,8 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, err
 	}
 
+	jwksHandler := auth.JWKSHandler{PublicKeys: signingKeys.PublicKeys()}
+
 	var httpHandler, httpsHandler http.Handler
 	if cmd.TLSBindPort != 0 {
 		httpHandler = cmd.constructHTTPHandler(
@@ -281,6 +348,8 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				externalHost: cmd.ExternalURL.URL().Host,
 				baseHandler:  oauthHandler,
 			},
+
+			jwksHandler,
 		)
 
 		httpsHandler = cmd.constructHTTPHandler(
@@ -288,6 +357,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			publicHandler,
 			apiHandler,
 			oauthHandler,
+			jwksHandler,
 		)
 	} else {
 		httpHandler = cmd.constructHTTPHandler(
@@ -295,6 +365,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			publicHandler,
 			apiHandler,
 			oauthHandler,
+			jwksHandler,
 		)
 	}
 
@@ -328,22 +399,6 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			Clock:     clock.NewClock(),
 		}},
 
-		{"baggage-collector", lockrunner.NewRunner(
-			logger.Session("baggage-collector-runner"),
-			lostandfound.NewBaggageCollector(
-				logger.Session("baggage-collector"),
-				workerClient,
-				sqlDB,
-				pipelineDBFactory,
-				cmd.OldResourceGracePeriod,
-				24*time.Hour,
-			),
-			"baggage-collector",
-			sqlDB,
-			clock.NewClock(),
-			cmd.ResourceCacheCleanupInterval,
-		)},
-
 		{"worker-collector", lockrunner.NewRunner(
 			logger.Session("worker-collector-runner"),
 			gcng.NewWorkerCollector(
@@ -356,19 +411,6 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			10*time.Second,
 		)},
 
-		{"container-keepaliver", lockrunner.NewRunner(
-			logger.Session("container-keepaliver-runner"),
-			containerkeepaliver.NewContainerKeepAliver(
-				logger.Session("container-keepaliver"),
-				workerClient,
-				sqlDB,
-			),
-			"container-keepaliver",
-			sqlDB,
-			clock.NewClock(),
-			30*time.Second,
-		)},
-
 		{"build-reaper", lockrunner.NewRunner(
 			logger.Session("build-reaper-runner"),
 			buildreaper.NewBuildReaper(
@@ -388,6 +430,8 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			dbgc.NewDBGarbageCollector(
 				logger.Session("dbgc"),
 				sqlDB,
+				cmd.ResourceCheckHistoryRetention,
+				cmd.AuthDuration,
 			),
 			"dbgc",
 			sqlDB,
@@ -396,6 +440,73 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		)},
 	}
 
+	// the baggage collector only expires and evicts resource caches and
+	// imported volumes, which the state-based collectors leave alone, so it
+	// runs in either mode
+	members = append(members, grouper.Member{"baggage-collector", lockrunner.NewRunner(
+		logger.Session("baggage-collector-runner"),
+		lostandfound.NewBaggageCollector(
+			logger.Session("baggage-collector"),
+			workerClient,
+			sqlDB,
+			pipelineDBFactory,
+			cmd.OldResourceGracePeriod,
+			24*time.Hour,
+			cmd.diskHighWatermark(),
+		),
+		"baggage-collector",
+		sqlDB,
+		clock.NewClock(),
+		cmd.ResourceCacheCleanupInterval,
+	)})
+
+	// the state-based collectors and the container keepaliver both decide
+	// when a build's containers go away, so only one of them runs
+	if cmd.EnableStateBasedGC {
+		members = append(members, grouper.Member{"container-collector", lockrunner.NewRunner(
+			logger.Session("container-collector-runner"),
+			gcng.NewContainerCollector(
+				logger.Session("container-collector"),
+				dbContainerFactory,
+				gcng.NewGardenClientFactory(
+					dbWorkerFactory,
+					logger.Session("garden-client-factory"),
+					retryhttp.NewExponentialBackOffFactory(5*time.Minute),
+				),
+			),
+			"container-collector",
+			sqlDB,
+			clock.NewClock(),
+			30*time.Second,
+		)})
+
+		members = append(members, grouper.Member{"volume-collector", lockrunner.NewRunner(
+			logger.Session("volume-collector-runner"),
+			gcng.NewVolumeCollector(
+				logger.Session("volume-collector"),
+				dbVolumeFactory,
+				gcng.NewBaggageclaimClientFactory(dbWorkerFactory),
+			),
+			"volume-collector",
+			sqlDB,
+			clock.NewClock(),
+			30*time.Second,
+		)})
+	} else {
+		members = append(members, grouper.Member{"container-keepaliver", lockrunner.NewRunner(
+			logger.Session("container-keepaliver-runner"),
+			containerkeepaliver.NewContainerKeepAliver(
+				logger.Session("container-keepaliver"),
+				workerClient,
+				sqlDB,
+			),
+			"container-keepaliver",
+			sqlDB,
+			clock.NewClock(),
+			30*time.Second,
+		)})
+	}
+
 	if cmd.Worker.GardenURL.URL() != nil {
 		members = cmd.appendStaticWorker(logger, sqlDB, members)
 	}
@@ -411,6 +522,16 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			NextProtos:   []string{"h2"},
 		}
 
+		if cmd.TLSClientCACert != "" {
+			clientCAs, err := cmd.loadTLSClientCAs()
+			if err != nil {
+				return nil, err
+			}
+
+			tlsConfig.ClientCAs = clientCAs
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+
 		members = append(members, grouper.Member{"web-tls", http_server.NewTLSServer(
 			cmd.tlsBindAddr(),
 			httpsHandler,
@@ -467,7 +588,7 @@ func (cmd *ATCCommand) oauthBaseURL() string {
 }
 
 func (cmd *ATCCommand) authConfigured() bool {
-	return cmd.BasicAuth.IsConfigured() || cmd.GitHubAuth.IsConfigured() || cmd.UAAAuth.IsConfigured() || cmd.GenericOAuth.IsConfigured()
+	return cmd.BasicAuth.IsConfigured() || cmd.GitHubAuth.IsConfigured() || cmd.GitLabAuth.IsConfigured() || cmd.UAAAuth.IsConfigured() || cmd.GenericOAuth.IsConfigured() || cmd.OIDCAuth.IsConfigured() || cmd.LDAPAuth.IsConfigured()
 }
 
 func (cmd *ATCCommand) validate() error {
@@ -494,6 +615,20 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.GitLabAuth.IsConfigured() {
+		if cmd.ExternalURL.URL() == nil {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --external-url to use OAuth"),
+			)
+		}
+
+		err := cmd.GitLabAuth.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
 	if cmd.GenericOAuth.IsConfigured() {
 		err := cmd.GenericOAuth.Validate()
 		if err != nil {
@@ -501,6 +636,27 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.OIDCAuth.IsConfigured() {
+		if cmd.ExternalURL.URL() == nil {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --external-url to use OpenID Connect"),
+			)
+		}
+
+		err := cmd.OIDCAuth.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	if cmd.LDAPAuth.IsConfigured() {
+		err := cmd.LDAPAuth.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
 	if cmd.BasicAuth.IsConfigured() {
 		err := cmd.BasicAuth.Validate()
 		if err != nil {
@@ -515,6 +671,34 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.WorkerWaitTimeout < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--worker-wait-timeout must not be negative"),
+		)
+	}
+
+	if cmd.WorkerLossAttempts < 1 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--worker-loss-attempts must be at least 1"),
+		)
+	}
+
+	if cmd.MaxActiveTasksPerWorker < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--max-active-tasks-per-worker must not be negative"),
+		)
+	}
+
+	if cmd.VolumeDiskHighWatermark < 0 || cmd.VolumeDiskHighWatermark > 100 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--volume-disk-high-watermark must be between 0 and 100"),
+		)
+	}
+
 	tlsFlagCount := 0
 	if cmd.TLSBindPort != 0 {
 		tlsFlagCount++
@@ -540,9 +724,61 @@ func (cmd *ATCCommand) validate() error {
 		)
 	}
 
+	for class, limit := range cmd.rateLimits() {
+		if limit.Rate < 0 || limit.Burst < 0 {
+			errs = multierror.Append(
+				errs,
+				fmt.Errorf("--api-rate-limit-%s and its burst must not be negative", class),
+			)
+		}
+	}
+
+	if cmd.TLSClientCACert != "" && tlsFlagCount != 3 {
+		errs = multierror.Append(
+			errs,
+			errors.New("must configure TLS to use --tls-client-ca-cert"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
+func (cmd *ATCCommand) loadTLSClientCAs() (*x509.CertPool, error) {
+	caCerts, err := ioutil.ReadFile(string(cmd.TLSClientCACert))
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCerts) {
+		return nil, fmt.Errorf("no certificates found in %s", cmd.TLSClientCACert)
+	}
+
+	return pool, nil
+}
+
+func (cmd *ATCCommand) trustedProxies() []*net.IPNet {
+	trustedProxies := []*net.IPNet{}
+	for _, trustedProxy := range cmd.RateLimit.TrustedProxies {
+		trustedProxies = append(trustedProxies, trustedProxy.IPNet)
+	}
+
+	return trustedProxies
+}
+
+func (cmd *ATCCommand) rateLimits() map[wrappa.Limiter]wrappa.RateLimits {
+	return map[wrappa.Limiter]wrappa.RateLimits{
+		wrappa.IPLimiter: {
+			wrappa.ReadRoutes:        {Rate: cmd.RateLimit.Read, Burst: cmd.RateLimit.ReadBurst},
+			wrappa.WriteRoutes:       {Rate: cmd.RateLimit.Write, Burst: cmd.RateLimit.WriteBurst},
+			wrappa.EventStreamRoutes: {Rate: cmd.RateLimit.EventStream, Burst: cmd.RateLimit.EventStreamBurst},
+			wrappa.HijackRoutes:      {Rate: cmd.RateLimit.Hijack, Burst: cmd.RateLimit.HijackBurst},
+		},
+		wrappa.TeamLimiter:  cmd.RateLimit.Team.rateLimits(),
+		wrappa.TokenLimiter: cmd.RateLimit.Token.rateLimits(),
+	}
+}
+
 func (cmd *ATCCommand) nonTLSBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
 }
@@ -654,6 +890,7 @@ func (cmd *ATCCommand) constructWorkerPool(
 	resourceFetcherFactory resource.FetcherFactory,
 	pipelineDBFactory db.PipelineDBFactory,
 	dbWorkerFactory dbng.WorkerFactory,
+	waitTimeout time.Duration,
 ) worker.Client {
 	return worker.NewPool(
 		worker.NewDBWorkerProvider(
@@ -665,32 +902,63 @@ func (cmd *ATCCommand) constructWorkerPool(
 			pipelineDBFactory,
 			dbWorkerFactory,
 		),
+		cmd.constructContainerPlacementStrategy(sqlDB),
+		waitTimeout,
+		clock.NewClock(),
 	)
 }
 
-func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
-	var signingKey *rsa.PrivateKey
+func (cmd *ATCCommand) constructContainerPlacementStrategy(sqlDB *db.SQLDB) worker.ContainerPlacementStrategy {
+	var strategy worker.ContainerPlacementStrategy
+
+	switch cmd.ContainerPlacementStrategy {
+	case "random":
+		strategy = worker.NewRandomPlacementStrategy()
+	case "fewest-build-containers":
+		strategy = worker.NewFewestBuildContainersPlacementStrategy()
+	case "limit-active-tasks":
+		strategy = worker.NewLimitActiveTasksPlacementStrategy(sqlDB, cmd.MaxActiveTasksPerWorker)
+	default:
+		strategy = worker.NewVolumeLocalityPlacementStrategy()
+	}
 
-	if cmd.SessionSigningKey == "" {
+	if cmd.VolumeDiskHighWatermark > 0 {
+		strategy = worker.NewDiskPressurePlacementStrategy(strategy, cmd.diskHighWatermark())
+	}
+
+	return strategy
+}
+
+func (cmd *ATCCommand) diskHighWatermark() float64 {
+	return float64(cmd.VolumeDiskHighWatermark) / 100
+}
+
+func (cmd *ATCCommand) loadOrGenerateSigningKeys() (auth.SigningKeys, error) {
+	if len(cmd.SessionSigningKeys) == 0 {
 		generatedKey, err := rsa.GenerateKey(rand.Reader, 2048)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate session signing key: %s", err)
 		}
 
-		signingKey = generatedKey
-	} else {
-		rsaKeyBlob, err := ioutil.ReadFile(string(cmd.SessionSigningKey))
+		return auth.SigningKeys{generatedKey}, nil
+	}
+
+	signingKeys := auth.SigningKeys{}
+	for _, keyFile := range cmd.SessionSigningKeys {
+		rsaKeyBlob, err := ioutil.ReadFile(string(keyFile))
 		if err != nil {
 			return nil, fmt.Errorf("failed to read session signing key file: %s", err)
 		}
 
-		signingKey, err = jwt.ParseRSAPrivateKeyFromPEM(rsaKeyBlob)
+		signingKey, err := jwt.ParseRSAPrivateKeyFromPEM(rsaKeyBlob)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse session signing key as RSA: %s", err)
+			return nil, fmt.Errorf("failed to parse session signing key %s as RSA: %s", keyFile, err)
 		}
+
+		signingKeys = append(signingKeys, signingKey)
 	}
 
-	return signingKey, nil
+	return signingKeys, nil
 }
 
 func (cmd *ATCCommand) configureAuthForDefaultTeam(teamDBFactory db.TeamDBFactory) error {
@@ -735,6 +1003,24 @@ func (cmd *ATCCommand) configureAuthForDefaultTeam(teamDBFactory db.TeamDBFactor
 		return err
 	}
 
+	var gitLabAuth *db.GitLabAuth
+	if cmd.GitLabAuth.IsConfigured() {
+		gitLabAuth = &db.GitLabAuth{
+			ClientID:     cmd.GitLabAuth.ClientID,
+			ClientSecret: cmd.GitLabAuth.ClientSecret,
+			Groups:       cmd.GitLabAuth.Groups,
+			Users:        cmd.GitLabAuth.Users,
+			AuthURL:      cmd.GitLabAuth.AuthURL,
+			TokenURL:     cmd.GitLabAuth.TokenURL,
+			APIURL:       cmd.GitLabAuth.APIURL,
+		}
+	}
+
+	_, err = teamDB.UpdateGitLabAuth(gitLabAuth)
+	if err != nil {
+		return err
+	}
+
 	var uaaAuth *db.UAAAuth
 	if cmd.UAAAuth.IsConfigured() {
 		cfCACert := ""
@@ -780,6 +1066,62 @@ func (cmd *ATCCommand) configureAuthForDefaultTeam(teamDBFactory db.TeamDBFactor
 		return err
 	}
 
+	var oidcAuth *db.OIDCAuth
+	if cmd.OIDCAuth.IsConfigured() {
+		oidcAuth = &db.OIDCAuth{
+			DisplayName:  cmd.OIDCAuth.DisplayName,
+			Issuer:       cmd.OIDCAuth.Issuer,
+			ClientID:     cmd.OIDCAuth.ClientID,
+			ClientSecret: cmd.OIDCAuth.ClientSecret,
+			Scopes:       cmd.OIDCAuth.Scopes,
+			Users:        cmd.OIDCAuth.Users,
+			EmailDomains: cmd.OIDCAuth.EmailDomains,
+			Groups:       cmd.OIDCAuth.Groups,
+			GroupsClaim:  cmd.OIDCAuth.GroupsClaim,
+		}
+	}
+
+	_, err = teamDB.UpdateOIDCAuth(oidcAuth)
+	if err != nil {
+		return err
+	}
+
+	var ldapAuth *db.LDAPAuth
+	if cmd.LDAPAuth.IsConfigured() {
+		ldapCACert := ""
+		if cmd.LDAPAuth.CACert != "" {
+			ldapCACertFileContents, err := ioutil.ReadFile(string(cmd.LDAPAuth.CACert))
+			if err != nil {
+				return err
+			}
+			ldapCACert = string(ldapCACertFileContents)
+		}
+
+		ldapAuth = &db.LDAPAuth{
+			DisplayName:          cmd.LDAPAuth.DisplayName,
+			Host:                 cmd.LDAPAuth.Host,
+			TLS:                  cmd.LDAPAuth.TLS,
+			StartTLS:             cmd.LDAPAuth.StartTLS,
+			InsecureSkipVerify:   cmd.LDAPAuth.InsecureSkipVerify,
+			CACert:               ldapCACert,
+			BindDN:               cmd.LDAPAuth.BindDN,
+			BindPassword:         cmd.LDAPAuth.BindPassword,
+			UserSearchBaseDN:     cmd.LDAPAuth.UserSearchBaseDN,
+			UserSearchFilter:     cmd.LDAPAuth.UserSearchFilter,
+			UsernameAttribute:    cmd.LDAPAuth.UsernameAttribute,
+			GroupSearchBaseDN:    cmd.LDAPAuth.GroupSearchBaseDN,
+			GroupSearchFilter:    cmd.LDAPAuth.GroupSearchFilter,
+			GroupMemberAttribute: cmd.LDAPAuth.GroupMemberAttribute,
+			GroupNameAttribute:   cmd.LDAPAuth.GroupNameAttribute,
+			Groups:               cmd.LDAPAuth.Groups,
+		}
+	}
+
+	_, err = teamDB.UpdateLDAPAuth(ldapAuth)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -800,6 +1142,7 @@ func (cmd *ATCCommand) constructEngine(
 		engine.NewBuildDelegateFactory(),
 		teamDBFactory,
 		cmd.ExternalURL.String(),
+		cmd.WorkerLossAttempts,
 	)
 
 	execV1Engine := engine.NewExecV1DummyEngine()
@@ -812,10 +1155,12 @@ func (cmd *ATCCommand) constructHTTPHandler(
 	publicHandler http.Handler,
 	apiHandler http.Handler,
 	oauthHandler http.Handler,
+	jwksHandler http.Handler,
 ) http.Handler {
 	webMux := http.NewServeMux()
 	webMux.Handle("/api/v1/", apiHandler)
 	webMux.Handle("/auth/", oauthHandler)
+	webMux.Handle("/.well-known/jwks.json", jwksHandler)
 	webMux.Handle("/public/", publicHandler)
 	webMux.Handle("/robots.txt", robotstxt.Handler{})
 	webMux.Handle("/", webHandler)
@@ -837,7 +1182,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 	dbTeamFactory dbng.TeamFactory,
 	dbWorkerFactory dbng.WorkerFactory,
 	providerFactory provider.OAuthFactory,
-	signingKey *rsa.PrivateKey,
+	signingKeys auth.SigningKeys,
 	pipelineDBFactory db.PipelineDBFactory,
 	engine engine.Engine,
 	workerClient worker.Client,
@@ -845,11 +1190,34 @@ func (cmd *ATCCommand) constructAPIHandler(
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
 ) (http.Handler, error) {
-	authValidator := auth.JWTValidator{
-		PublicKey: &signingKey.PublicKey,
+	jwtValidator := auth.JWTValidator{
+		PublicKeys:   signingKeys.PublicKeys(),
+		RevocationDB: sqlDB,
 	}
 
-	getTokenValidator := auth.NewTeamAuthValidator(teamDBFactory, authValidator)
+	// API tokens are accepted everywhere but GetAuthToken, so that they
+	// cannot be traded for a JWT that outlives their revocation. client
+	// certificates are accepted everywhere, as they can be presented directly.
+	authValidator := auth.NewValidatorBasket(
+		jwtValidator,
+		auth.APITokenValidator{DB: sqlDB},
+		auth.CertValidator{TeamsDB: sqlDB},
+	)
+
+	userContextReader := auth.NewUserContextReaderBasket(
+		auth.JWTReader{
+			PublicKeys:   signingKeys.PublicKeys(),
+			RevocationDB: sqlDB,
+		},
+		auth.APITokenReader{DB: sqlDB},
+		auth.CertReader{TeamsDB: sqlDB},
+	)
+
+	getTokenValidator := auth.NewTeamAuthValidator(
+		teamDBFactory,
+		jwtValidator,
+		ldap.NewAuthenticator(logger.Session("ldap"), ldap.NewDialer()),
+	)
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(
 		pipelineDBFactory,
@@ -867,12 +1235,24 @@ func (cmd *ATCCommand) constructAPIHandler(
 		wrappa.NewAPIAuthWrappa(
 			authValidator,
 			getTokenValidator,
-			auth.JWTReader{PublicKey: &signingKey.PublicKey},
+			userContextReader,
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
 			checkBuildWriteAccessHandlerFactory,
 			checkWorkerTeamAccessHandlerFactory,
 		),
+		// requests are limited per team and per token, so the rate limit
+		// wrappa reads the user context before the auth wrappa does; the token
+		// scope wrappa wraps both so that API tokens are still only looked up
+		// once
+		wrappa.NewRateLimitWrappa(
+			logger.Session("rate-limit"),
+			clock.NewClock(),
+			cmd.rateLimits(),
+			cmd.trustedProxies(),
+			userContextReader,
+		),
+		wrappa.NewAPITokenScopeWrappa(),
 		wrappa.NewConcourseVersionWrappa(Version),
 	}
 
@@ -881,7 +1261,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		cmd.ExternalURL.String(),
 		apiWrapper,
 
-		auth.NewTokenGenerator(signingKey),
+		auth.NewTokenGenerator(signingKeys.Current()),
 		providerFactory,
 		cmd.oauthBaseURL(),
 
@@ -970,6 +1350,7 @@ func (cmd *ATCCommand) constructPipelineSyncer(
 						Noop: cmd.Developer.Noop,
 
 						Interval: 10 * time.Second,
+						Debounce: 1 * time.Second,
 					},
 				},
 			})
@@ -0,0 +1,25 @@
+package atccmd
+
+import "github.com/concourse/atc/wrappa"
+
+// RateLimitFlags configures one of the API rate limiters, which each count
+// requests against something different: a team, or a token.
+type RateLimitFlags struct {
+	Read             float64 `long:"read"               description:"Requests per second to read-only API endpoints. 0 means no limit."`
+	ReadBurst        int     `long:"read-burst"         description:"Number of read-only requests that may be made in a burst before being limited."`
+	Write            float64 `long:"write"              description:"Requests per second to API endpoints that make changes. 0 means no limit."`
+	WriteBurst       int     `long:"write-burst"        description:"Number of write requests that may be made in a burst before being limited."`
+	EventStream      float64 `long:"event-stream"       description:"Build event streams that may be opened per second. 0 means no limit."`
+	EventStreamBurst int     `long:"event-stream-burst" description:"Number of build event streams that may be opened in a burst before being limited."`
+	Hijack           float64 `long:"hijack"             description:"Container hijacks that may be started per second. 0 means no limit."`
+	HijackBurst      int     `long:"hijack-burst"       description:"Number of container hijacks that may be started in a burst before being limited."`
+}
+
+func (flags RateLimitFlags) rateLimits() wrappa.RateLimits {
+	return wrappa.RateLimits{
+		wrappa.ReadRoutes:        {Rate: flags.Read, Burst: flags.ReadBurst},
+		wrappa.WriteRoutes:       {Rate: flags.Write, Burst: flags.WriteBurst},
+		wrappa.EventStreamRoutes: {Rate: flags.EventStream, Burst: flags.EventStreamBurst},
+		wrappa.HijackRoutes:      {Rate: flags.Hijack, Burst: flags.HijackBurst},
+	}
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// APITokenPrefix tells API tokens apart from JWTs in the Authorization header
+// so that JWT requests never cost a database lookup.
+const APITokenPrefix = "atc-token-"
+
+// APITokenUsageInterval is how often a token's last use is recorded, so that
+// automation polling the API does not cost a write per request.
+const APITokenUsageInterval = time.Minute
+
+var apiTokenScopeKey = "apiTokenScope"
+var apiTokenLookupKey = "apiTokenLookup"
+
+func GenerateAPIToken() (string, error) {
+	secret := make([]byte, 32)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return APITokenPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+//go:generate counterfeiter . APITokenDB
+
+type APITokenDB interface {
+	FindAPIToken(token string) (db.APIToken, bool, error)
+	MarkAPITokenUsed(tokenID int) error
+}
+
+type APITokenValidator struct {
+	DB APITokenDB
+}
+
+func (validator APITokenValidator) IsAuthenticated(r *http.Request) bool {
+	apiToken, found := findAPIToken(r, validator.DB)
+	if !found {
+		return false
+	}
+
+	if !scopesPermit(apiToken.Scopes, requiredAPITokenScope(r)) {
+		return false
+	}
+
+	if time.Since(apiToken.LastUsedAt) >= APITokenUsageInterval {
+		// failing to record usage should not lock automation out
+		_ = validator.DB.MarkAPITokenUsed(apiToken.ID)
+	}
+
+	return true
+}
+
+// APITokenScopeHandler records the scope that an API token must have to be
+// used for the handler's route. It also lets the token, or the teams accepting
+// a client certificate, be looked up just once for the request, however many
+// validators and readers ask for them. Requests that do not pass through it
+// require the write scope.
+func APITokenScopeHandler(handler http.Handler, scope string) http.Handler {
+	return apiTokenScopeHandler{
+		handler: handler,
+		scope:   scope,
+	}
+}
+
+type apiTokenScopeHandler struct {
+	handler http.Handler
+	scope   string
+}
+
+func (h apiTokenScopeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), apiTokenScopeKey, h.scope)
+	ctx = context.WithValue(ctx, apiTokenLookupKey, &apiTokenLookup{})
+	ctx = context.WithValue(ctx, certTeamsLookupKey, &certTeamsLookup{})
+	h.handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type apiTokenLookup struct {
+	done     bool
+	apiToken db.APIToken
+	found    bool
+}
+
+func requiredAPITokenScope(r *http.Request) string {
+	scope, present := r.Context().Value(apiTokenScopeKey).(string)
+	if !present {
+		return atc.APITokenScopeWrite
+	}
+
+	return scope
+}
+
+type APITokenReader struct {
+	DB APITokenDB
+}
+
+func (reader APITokenReader) GetTeam(r *http.Request) (string, bool, bool) {
+	apiToken, found := findAPIToken(r, reader.DB)
+	if !found {
+		return "", false, false
+	}
+
+	return apiToken.TeamName, apiToken.TeamAdmin, true
+}
+
+func (reader APITokenReader) GetTeams(r *http.Request) ([]string, bool) {
+	apiToken, found := findAPIToken(r, reader.DB)
+	if !found {
+		return nil, false
+	}
+
+	return []string{apiToken.TeamName}, true
+}
+
+// GetUsername returns the owner of personal tokens, which act as the user who
+// created them. Service tokens act as the team alone.
+func (reader APITokenReader) GetUsername(r *http.Request) (string, bool) {
+	apiToken, found := findAPIToken(r, reader.DB)
+	if !found || apiToken.Kind != atc.APITokenKindPersonal || apiToken.Owner == "" {
+		return "", false
+	}
+
+	return apiToken.Owner, true
+}
+
+func (reader APITokenReader) GetSystem(r *http.Request) (bool, bool) {
+	return false, false
+}
+
+func (reader APITokenReader) GetSession(r *http.Request) (string, time.Time, bool) {
+	return "", time.Time{}, false
+}
+
+// IsAPITokenRequest reports whether the request carries an API token rather
+// than a session token. It does not check that the token is valid.
+func IsAPITokenRequest(r *http.Request) bool {
+	_, present := bearerAPIToken(r)
+	return present
+}
+
+func bearerAPIToken(r *http.Request) (string, bool) {
+	ah := r.Header.Get("Authorization")
+	if len(ah) <= 7 || strings.ToUpper(ah[0:6]) != "BEARER" {
+		return "", false
+	}
+
+	token := ah[7:]
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		return "", false
+	}
+
+	return token, true
+}
+
+func findAPIToken(r *http.Request, apiTokenDB APITokenDB) (db.APIToken, bool) {
+	token, present := bearerAPIToken(r)
+	if !present {
+		return db.APIToken{}, false
+	}
+
+	lookup, cached := r.Context().Value(apiTokenLookupKey).(*apiTokenLookup)
+	if cached && lookup.done {
+		return lookup.apiToken, lookup.found
+	}
+
+	apiToken, found, err := apiTokenDB.FindAPIToken(token)
+	if err != nil || !found {
+		apiToken, found = db.APIToken{}, false
+	}
+
+	if cached {
+		lookup.done = true
+		lookup.apiToken = apiToken
+		lookup.found = found
+	}
+
+	return apiToken, found
+}
+
+func scopesPermit(scopes []string, required string) bool {
+	for _, scope := range scopes {
+		switch scope {
+		case atc.APITokenScopeWrite:
+			return true
+		case atc.APITokenScopeRead:
+			if required == atc.APITokenScopeRead {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+type FakeAPITokenDB struct {
+	FindAPITokenStub        func(token string) (db.APIToken, bool, error)
+	findAPITokenMutex       sync.RWMutex
+	findAPITokenArgsForCall []struct {
+		token string
+	}
+	findAPITokenReturns struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}
+	MarkAPITokenUsedStub        func(tokenID int) error
+	markAPITokenUsedMutex       sync.RWMutex
+	markAPITokenUsedArgsForCall []struct {
+		tokenID int
+	}
+	markAPITokenUsedReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAPITokenDB) FindAPIToken(token string) (db.APIToken, bool, error) {
+	fake.findAPITokenMutex.Lock()
+	fake.findAPITokenArgsForCall = append(fake.findAPITokenArgsForCall, struct {
+		token string
+	}{token})
+	fake.recordInvocation("FindAPIToken", []interface{}{token})
+	fake.findAPITokenMutex.Unlock()
+	if fake.FindAPITokenStub != nil {
+		return fake.FindAPITokenStub(token)
+	} else {
+		return fake.findAPITokenReturns.result1, fake.findAPITokenReturns.result2, fake.findAPITokenReturns.result3
+	}
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenCallCount() int {
+	fake.findAPITokenMutex.RLock()
+	defer fake.findAPITokenMutex.RUnlock()
+	return len(fake.findAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenArgsForCall(i int) string {
+	fake.findAPITokenMutex.RLock()
+	defer fake.findAPITokenMutex.RUnlock()
+	return fake.findAPITokenArgsForCall[i].token
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenReturns(result1 db.APIToken, result2 bool, result3 error) {
+	fake.FindAPITokenStub = nil
+	fake.findAPITokenReturns = struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenDB) MarkAPITokenUsed(tokenID int) error {
+	fake.markAPITokenUsedMutex.Lock()
+	fake.markAPITokenUsedArgsForCall = append(fake.markAPITokenUsedArgsForCall, struct {
+		tokenID int
+	}{tokenID})
+	fake.recordInvocation("MarkAPITokenUsed", []interface{}{tokenID})
+	fake.markAPITokenUsedMutex.Unlock()
+	if fake.MarkAPITokenUsedStub != nil {
+		return fake.MarkAPITokenUsedStub(tokenID)
+	} else {
+		return fake.markAPITokenUsedReturns.result1
+	}
+}
+
+func (fake *FakeAPITokenDB) MarkAPITokenUsedCallCount() int {
+	fake.markAPITokenUsedMutex.RLock()
+	defer fake.markAPITokenUsedMutex.RUnlock()
+	return len(fake.markAPITokenUsedArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) MarkAPITokenUsedArgsForCall(i int) int {
+	fake.markAPITokenUsedMutex.RLock()
+	defer fake.markAPITokenUsedMutex.RUnlock()
+	return fake.markAPITokenUsedArgsForCall[i].tokenID
+}
+
+func (fake *FakeAPITokenDB) MarkAPITokenUsedReturns(result1 error) {
+	fake.MarkAPITokenUsedStub = nil
+	fake.markAPITokenUsedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPITokenDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findAPITokenMutex.RLock()
+	defer fake.findAPITokenMutex.RUnlock()
+	fake.markAPITokenUsedMutex.RLock()
+	defer fake.markAPITokenUsedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAPITokenDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.APITokenDB = new(FakeAPITokenDB)
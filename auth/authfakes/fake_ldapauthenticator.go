@@ -0,0 +1,83 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+type FakeLDAPAuthenticator struct {
+	AuthenticateStub        func(ldapAuth *db.LDAPAuth, username string, password string) (bool, error)
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		ldapAuth *db.LDAPAuth
+		username string
+		password string
+	}
+	authenticateReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLDAPAuthenticator) Authenticate(ldapAuth *db.LDAPAuth, username string, password string) (bool, error) {
+	fake.authenticateMutex.Lock()
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		ldapAuth *db.LDAPAuth
+		username string
+		password string
+	}{ldapAuth, username, password})
+	fake.recordInvocation("Authenticate", []interface{}{ldapAuth, username, password})
+	fake.authenticateMutex.Unlock()
+	if fake.AuthenticateStub != nil {
+		return fake.AuthenticateStub(ldapAuth, username, password)
+	} else {
+		return fake.authenticateReturns.result1, fake.authenticateReturns.result2
+	}
+}
+
+func (fake *FakeLDAPAuthenticator) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeLDAPAuthenticator) AuthenticateArgsForCall(i int) (*db.LDAPAuth, string, string) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.authenticateArgsForCall[i].ldapAuth, fake.authenticateArgsForCall[i].username, fake.authenticateArgsForCall[i].password
+}
+
+func (fake *FakeLDAPAuthenticator) AuthenticateReturns(result1 bool, result2 error) {
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLDAPAuthenticator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLDAPAuthenticator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.LDAPAuthenticator = new(FakeLDAPAuthenticator)
@@ -0,0 +1,81 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+type FakeLocalUserDB struct {
+	GetLocalUserStub        func(username string) (db.LocalUser, bool, error)
+	getLocalUserMutex       sync.RWMutex
+	getLocalUserArgsForCall []struct {
+		username string
+	}
+	getLocalUserReturns struct {
+		result1 db.LocalUser
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLocalUserDB) GetLocalUser(username string) (db.LocalUser, bool, error) {
+	fake.getLocalUserMutex.Lock()
+	fake.getLocalUserArgsForCall = append(fake.getLocalUserArgsForCall, struct {
+		username string
+	}{username})
+	fake.recordInvocation("GetLocalUser", []interface{}{username})
+	fake.getLocalUserMutex.Unlock()
+	if fake.GetLocalUserStub != nil {
+		return fake.GetLocalUserStub(username)
+	} else {
+		return fake.getLocalUserReturns.result1, fake.getLocalUserReturns.result2, fake.getLocalUserReturns.result3
+	}
+}
+
+func (fake *FakeLocalUserDB) GetLocalUserCallCount() int {
+	fake.getLocalUserMutex.RLock()
+	defer fake.getLocalUserMutex.RUnlock()
+	return len(fake.getLocalUserArgsForCall)
+}
+
+func (fake *FakeLocalUserDB) GetLocalUserArgsForCall(i int) string {
+	fake.getLocalUserMutex.RLock()
+	defer fake.getLocalUserMutex.RUnlock()
+	return fake.getLocalUserArgsForCall[i].username
+}
+
+func (fake *FakeLocalUserDB) GetLocalUserReturns(result1 db.LocalUser, result2 bool, result3 error) {
+	fake.GetLocalUserStub = nil
+	fake.getLocalUserReturns = struct {
+		result1 db.LocalUser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLocalUserDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getLocalUserMutex.RLock()
+	defer fake.getLocalUserMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLocalUserDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.LocalUserDB = new(FakeLocalUserDB)
@@ -0,0 +1,85 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/auth"
+)
+
+type FakeRevocationDB struct {
+	IsSessionRevokedStub        func(teamName string, sessionID string, username string, issuedAt time.Time) (bool, error)
+	isSessionRevokedMutex       sync.RWMutex
+	isSessionRevokedArgsForCall []struct {
+		teamName  string
+		sessionID string
+		username  string
+		issuedAt  time.Time
+	}
+	isSessionRevokedReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRevocationDB) IsSessionRevoked(teamName string, sessionID string, username string, issuedAt time.Time) (bool, error) {
+	fake.isSessionRevokedMutex.Lock()
+	fake.isSessionRevokedArgsForCall = append(fake.isSessionRevokedArgsForCall, struct {
+		teamName  string
+		sessionID string
+		username  string
+		issuedAt  time.Time
+	}{teamName, sessionID, username, issuedAt})
+	fake.recordInvocation("IsSessionRevoked", []interface{}{teamName, sessionID, username, issuedAt})
+	fake.isSessionRevokedMutex.Unlock()
+	if fake.IsSessionRevokedStub != nil {
+		return fake.IsSessionRevokedStub(teamName, sessionID, username, issuedAt)
+	} else {
+		return fake.isSessionRevokedReturns.result1, fake.isSessionRevokedReturns.result2
+	}
+}
+
+func (fake *FakeRevocationDB) IsSessionRevokedCallCount() int {
+	fake.isSessionRevokedMutex.RLock()
+	defer fake.isSessionRevokedMutex.RUnlock()
+	return len(fake.isSessionRevokedArgsForCall)
+}
+
+func (fake *FakeRevocationDB) IsSessionRevokedArgsForCall(i int) (string, string, string, time.Time) {
+	fake.isSessionRevokedMutex.RLock()
+	defer fake.isSessionRevokedMutex.RUnlock()
+	return fake.isSessionRevokedArgsForCall[i].teamName, fake.isSessionRevokedArgsForCall[i].sessionID, fake.isSessionRevokedArgsForCall[i].username, fake.isSessionRevokedArgsForCall[i].issuedAt
+}
+
+func (fake *FakeRevocationDB) IsSessionRevokedReturns(result1 bool, result2 error) {
+	fake.IsSessionRevokedStub = nil
+	fake.isSessionRevokedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRevocationDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.isSessionRevokedMutex.RLock()
+	defer fake.isSessionRevokedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRevocationDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.RevocationDB = new(FakeRevocationDB)
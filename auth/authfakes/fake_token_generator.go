@@ -9,12 +9,16 @@ import (
 )
 
 type FakeTokenGenerator struct {
-	GenerateTokenStub        func(expiration time.Time, teamName string, isAdmin bool) (auth.TokenType, auth.TokenValue, error)
+	GenerateTokenStub        func(expiration time.Time, teamName string, isAdmin bool, teams []string, username string, sessionID string, issuedAt time.Time) (auth.TokenType, auth.TokenValue, error)
 	generateTokenMutex       sync.RWMutex
 	generateTokenArgsForCall []struct {
 		expiration time.Time
 		teamName   string
 		isAdmin    bool
+		teams      []string
+		username   string
+		sessionID  string
+		issuedAt   time.Time
 	}
 	generateTokenReturns struct {
 		result1 auth.TokenType
@@ -25,17 +29,26 @@ type FakeTokenGenerator struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool) (auth.TokenType, auth.TokenValue, error) {
+func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool, teams []string, username string, sessionID string, issuedAt time.Time) (auth.TokenType, auth.TokenValue, error) {
+	var teamsCopy []string
+	if teams != nil {
+		teamsCopy = make([]string, len(teams))
+		copy(teamsCopy, teams)
+	}
 	fake.generateTokenMutex.Lock()
 	fake.generateTokenArgsForCall = append(fake.generateTokenArgsForCall, struct {
 		expiration time.Time
 		teamName   string
 		isAdmin    bool
-	}{expiration, teamName, isAdmin})
-	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, isAdmin})
+		teams      []string
+		username   string
+		sessionID  string
+		issuedAt   time.Time
+	}{expiration, teamName, isAdmin, teamsCopy, username, sessionID, issuedAt})
+	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, isAdmin, teamsCopy, username, sessionID, issuedAt})
 	fake.generateTokenMutex.Unlock()
 	if fake.GenerateTokenStub != nil {
-		return fake.GenerateTokenStub(expiration, teamName, isAdmin)
+		return fake.GenerateTokenStub(expiration, teamName, isAdmin, teams, username, sessionID, issuedAt)
 	} else {
 		return fake.generateTokenReturns.result1, fake.generateTokenReturns.result2, fake.generateTokenReturns.result3
 	}
@@ -47,10 +60,10 @@ func (fake *FakeTokenGenerator) GenerateTokenCallCount() int {
 	return len(fake.generateTokenArgsForCall)
 }
 
-func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, bool) {
+func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, bool, []string, string, string, time.Time) {
 	fake.generateTokenMutex.RLock()
 	defer fake.generateTokenMutex.RUnlock()
-	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].isAdmin
+	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].isAdmin, fake.generateTokenArgsForCall[i].teams, fake.generateTokenArgsForCall[i].username, fake.generateTokenArgsForCall[i].sessionID, fake.generateTokenArgsForCall[i].issuedAt
 }
 
 func (fake *FakeTokenGenerator) GenerateTokenReturns(result1 auth.TokenType, result2 auth.TokenValue, result3 error) {
@@ -4,6 +4,7 @@ package authfakes
 import (
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/auth"
 )
@@ -19,6 +20,24 @@ type FakeUserContextReader struct {
 		result2 bool
 		result3 bool
 	}
+	GetTeamsStub        func(r *http.Request) ([]string, bool)
+	getTeamsMutex       sync.RWMutex
+	getTeamsArgsForCall []struct {
+		r *http.Request
+	}
+	getTeamsReturns struct {
+		result1 []string
+		result2 bool
+	}
+	GetUsernameStub        func(r *http.Request) (string, bool)
+	getUsernameMutex       sync.RWMutex
+	getUsernameArgsForCall []struct {
+		r *http.Request
+	}
+	getUsernameReturns struct {
+		result1 string
+		result2 bool
+	}
 	GetSystemStub        func(r *http.Request) (bool, bool)
 	getSystemMutex       sync.RWMutex
 	getSystemArgsForCall []struct {
@@ -28,6 +47,16 @@ type FakeUserContextReader struct {
 		result1 bool
 		result2 bool
 	}
+	GetSessionStub        func(r *http.Request) (string, time.Time, bool)
+	getSessionMutex       sync.RWMutex
+	getSessionArgsForCall []struct {
+		r *http.Request
+	}
+	getSessionReturns struct {
+		result1 string
+		result2 time.Time
+		result3 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -67,6 +96,74 @@ func (fake *FakeUserContextReader) GetTeamReturns(result1 string, result2 bool,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeUserContextReader) GetTeams(r *http.Request) ([]string, bool) {
+	fake.getTeamsMutex.Lock()
+	fake.getTeamsArgsForCall = append(fake.getTeamsArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetTeams", []interface{}{r})
+	fake.getTeamsMutex.Unlock()
+	if fake.GetTeamsStub != nil {
+		return fake.GetTeamsStub(r)
+	} else {
+		return fake.getTeamsReturns.result1, fake.getTeamsReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetTeamsCallCount() int {
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
+	return len(fake.getTeamsArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetTeamsArgsForCall(i int) *http.Request {
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
+	return fake.getTeamsArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetTeamsReturns(result1 []string, result2 bool) {
+	fake.GetTeamsStub = nil
+	fake.getTeamsReturns = struct {
+		result1 []string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetUsername(r *http.Request) (string, bool) {
+	fake.getUsernameMutex.Lock()
+	fake.getUsernameArgsForCall = append(fake.getUsernameArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetUsername", []interface{}{r})
+	fake.getUsernameMutex.Unlock()
+	if fake.GetUsernameStub != nil {
+		return fake.GetUsernameStub(r)
+	} else {
+		return fake.getUsernameReturns.result1, fake.getUsernameReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetUsernameCallCount() int {
+	fake.getUsernameMutex.RLock()
+	defer fake.getUsernameMutex.RUnlock()
+	return len(fake.getUsernameArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetUsernameArgsForCall(i int) *http.Request {
+	fake.getUsernameMutex.RLock()
+	defer fake.getUsernameMutex.RUnlock()
+	return fake.getUsernameArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetUsernameReturns(result1 string, result2 bool) {
+	fake.GetUsernameStub = nil
+	fake.getUsernameReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) GetSystem(r *http.Request) (bool, bool) {
 	fake.getSystemMutex.Lock()
 	fake.getSystemArgsForCall = append(fake.getSystemArgsForCall, struct {
@@ -101,13 +198,54 @@ func (fake *FakeUserContextReader) GetSystemReturns(result1 bool, result2 bool)
 	}{result1, result2}
 }
 
+func (fake *FakeUserContextReader) GetSession(r *http.Request) (string, time.Time, bool) {
+	fake.getSessionMutex.Lock()
+	fake.getSessionArgsForCall = append(fake.getSessionArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetSession", []interface{}{r})
+	fake.getSessionMutex.Unlock()
+	if fake.GetSessionStub != nil {
+		return fake.GetSessionStub(r)
+	} else {
+		return fake.getSessionReturns.result1, fake.getSessionReturns.result2, fake.getSessionReturns.result3
+	}
+}
+
+func (fake *FakeUserContextReader) GetSessionCallCount() int {
+	fake.getSessionMutex.RLock()
+	defer fake.getSessionMutex.RUnlock()
+	return len(fake.getSessionArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetSessionArgsForCall(i int) *http.Request {
+	fake.getSessionMutex.RLock()
+	defer fake.getSessionMutex.RUnlock()
+	return fake.getSessionArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetSessionReturns(result1 string, result2 time.Time, result3 bool) {
+	fake.GetSessionStub = nil
+	fake.getSessionReturns = struct {
+		result1 string
+		result2 time.Time
+		result3 bool
+	}{result1, result2, result3}
+}
+
 func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getTeamMutex.RLock()
 	defer fake.getTeamMutex.RUnlock()
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
+	fake.getUsernameMutex.RLock()
+	defer fake.getUsernameMutex.RUnlock()
 	fake.getSystemMutex.RLock()
 	defer fake.getSystemMutex.RUnlock()
+	fake.getSessionMutex.RLock()
+	defer fake.getSessionMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -2,19 +2,31 @@ package auth
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/concourse/atc/db"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+//go:generate counterfeiter . LocalUserDB
+
+type LocalUserDB interface {
+	GetLocalUser(username string) (db.LocalUser, bool, error)
+}
+
 type basicAuthValidator struct {
-	team db.SavedTeam
+	team        db.SavedTeam
+	localUserDB LocalUserDB
 }
 
-func NewBasicAuthValidator(team db.SavedTeam) Validator {
+// NewBasicAuthValidator returns a validator accepting the team's shared basic
+// auth credentials or those of any of its enabled local users. The local
+// user DB may be nil to only check the shared credentials.
+func NewBasicAuthValidator(team db.SavedTeam, localUserDB LocalUserDB) Validator {
 	return basicAuthValidator{
-		team: team,
+		team:        team,
+		localUserDB: localUserDB,
 	}
 }
 
@@ -25,10 +37,39 @@ func (v basicAuthValidator) IsAuthenticated(r *http.Request) bool {
 		return false
 	}
 
-	return v.correctCredentials(
+	if v.team.BasicAuth != nil && v.correctCredentials(
 		v.team.BasicAuth.BasicAuthUsername, v.team.BasicAuth.BasicAuthPassword,
 		username, password,
-	)
+	) {
+		return true
+	}
+
+	if v.localUserDB == nil {
+		return false
+	}
+
+	localUser, found, err := v.localUserDB.GetLocalUser(username)
+	if err != nil || !found {
+		// take as long as checking a real user's password would, so that
+		// usernames cannot be discovered by timing the response
+		bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		return false
+	}
+
+	return localUser.CheckPassword(password)
+}
+
+var (
+	dummyHash     []byte
+	dummyHashOnce sync.Once
+)
+
+func dummyPasswordHash() []byte {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+	})
+
+	return dummyHash
 }
 
 func (v basicAuthValidator) correctCredentials(
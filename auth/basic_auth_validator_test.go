@@ -2,6 +2,7 @@ package auth_test
 
 import (
 	"encoding/base64"
+	"errors"
 	"net/http"
 
 	"golang.org/x/crypto/bcrypt"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/db"
 )
 
@@ -18,9 +20,14 @@ var _ = Describe("BasicAuthValidator", func() {
 	username := "username"
 	password := "password"
 
-	var validator auth.Validator
+	var (
+		validator   auth.Validator
+		localUserDB *authfakes.FakeLocalUserDB
+	)
 
 	BeforeEach(func() {
+		localUserDB = new(authfakes.FakeLocalUserDB)
+
 		encryptedPassword, err := bcrypt.GenerateFromPassword([]byte(password), 4)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -34,7 +41,7 @@ var _ = Describe("BasicAuthValidator", func() {
 			},
 		}
 
-		validator = auth.NewBasicAuthValidator(team)
+		validator = auth.NewBasicAuthValidator(team, localUserDB)
 	})
 
 	Describe("IsAuthenticated", func() {
@@ -84,6 +91,71 @@ var _ = Describe("BasicAuthValidator", func() {
 			})
 		})
 
+		Context("when the request has a local user's credentials", func() {
+			var localUser db.LocalUser
+
+			BeforeEach(func() {
+				passwordHash, err := bcrypt.GenerateFromPassword([]byte("local-password"), 4)
+				Expect(err).ToNot(HaveOccurred())
+
+				localUser = db.LocalUser{
+					Username:     "local-user",
+					PasswordHash: string(passwordHash),
+					Enabled:      true,
+				}
+
+				localUserDB.GetLocalUserReturns(localUser, true, nil)
+
+				request.Header.Set("Authorization", "Basic "+b64("local-user:local-password"))
+			})
+
+			It("returns true", func() {
+				Expect(isAuthenticated).To(BeTrue())
+				Expect(localUserDB.GetLocalUserArgsForCall(0)).To(Equal("local-user"))
+			})
+
+			Context("when the user is disabled", func() {
+				BeforeEach(func() {
+					localUser.Enabled = false
+					localUserDB.GetLocalUserReturns(localUser, true, nil)
+				})
+
+				It("returns false", func() {
+					Expect(isAuthenticated).To(BeFalse())
+				})
+			})
+
+			Context("when the password is wrong", func() {
+				BeforeEach(func() {
+					request.Header.Set("Authorization", "Basic "+b64("local-user:bogus"))
+				})
+
+				It("returns false", func() {
+					Expect(isAuthenticated).To(BeFalse())
+				})
+			})
+
+			Context("when the user does not exist", func() {
+				BeforeEach(func() {
+					localUserDB.GetLocalUserReturns(db.LocalUser{}, false, nil)
+				})
+
+				It("returns false", func() {
+					Expect(isAuthenticated).To(BeFalse())
+				})
+			})
+
+			Context("when the user cannot be looked up", func() {
+				BeforeEach(func() {
+					localUserDB.GetLocalUserReturns(localUser, true, errors.New("disaster"))
+				})
+
+				It("returns false", func() {
+					Expect(isAuthenticated).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when the request's Authorization header isn't basic auth", func() {
 			BeforeEach(func() {
 				request.Header.Set("Authorization", "Bearer "+b64(username+":"+password))
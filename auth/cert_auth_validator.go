@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"crypto/x509"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/db"
+)
+
+var certTeamsLookupKey = "certTeamsLookup"
+
+type certAuthValidator struct {
+	team db.SavedTeam
+}
+
+// NewCertAuthValidator returns a validator accepting client certificates
+// whose subject common name or one of whose subject alternative names is
+// listed in the team's cert auth. Only certificates verified by the TLS
+// listener against its client CA bundle are considered.
+func NewCertAuthValidator(team db.SavedTeam) Validator {
+	return certAuthValidator{
+		team: team,
+	}
+}
+
+func (v certAuthValidator) IsAuthenticated(r *http.Request) bool {
+	if v.team.CertAuth == nil || r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return false
+	}
+
+	cert := r.TLS.VerifiedChains[0][0]
+
+	for _, commonName := range v.team.CertAuth.CommonNames {
+		if cert.Subject.CommonName == commonName {
+			return true
+		}
+	}
+
+	for _, san := range v.team.CertAuth.SANs {
+		if hasSAN(cert, san) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CertValidator authenticates API requests presenting a client certificate
+// that any team's cert auth accepts, so that clients need not trade their
+// certificate for a token first.
+//
+// Certificates are not sessions: like service API tokens, they keep working
+// when the team's sessions are revoked. They stop working once they are
+// removed from the team's cert auth or expire.
+type CertValidator struct {
+	TeamsDB TeamsDB
+}
+
+func (validator CertValidator) IsAuthenticated(r *http.Request) bool {
+	teams := certTeams(r, validator.TeamsDB)
+	return len(teams) > 0
+}
+
+// CertReader reads the teams whose cert auth accepts the request's client
+// certificate. The certificate belongs to each of them, so like a token it
+// grants access to all of them.
+type CertReader struct {
+	TeamsDB TeamsDB
+}
+
+func (reader CertReader) GetTeam(r *http.Request) (string, bool, bool) {
+	teams := certTeams(r, reader.TeamsDB)
+	if len(teams) == 0 {
+		return "", false, false
+	}
+
+	teamNames, isAdmin := Membership(teams[0], teams)
+	return teamNames[0], isAdmin, true
+}
+
+func (reader CertReader) GetTeams(r *http.Request) ([]string, bool) {
+	teams := certTeams(r, reader.TeamsDB)
+	if len(teams) == 0 {
+		return nil, false
+	}
+
+	teamNames, _ := Membership(teams[0], teams)
+	return teamNames, true
+}
+
+func (reader CertReader) GetUsername(r *http.Request) (string, bool) {
+	return "", false
+}
+
+func (reader CertReader) GetSystem(r *http.Request) (bool, bool) {
+	return false, false
+}
+
+func (reader CertReader) GetSession(r *http.Request) (string, time.Time, bool) {
+	return "", time.Time{}, false
+}
+
+type certTeamsLookup struct {
+	done  bool
+	teams []db.SavedTeam
+}
+
+func certTeams(r *http.Request, teamsDB TeamsDB) []db.SavedTeam {
+	// only look the teams up for requests that could match any of them
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return nil
+	}
+
+	lookup, cached := r.Context().Value(certTeamsLookupKey).(*certTeamsLookup)
+	if cached && lookup.done {
+		return lookup.teams
+	}
+
+	var certTeams []db.SavedTeam
+
+	teams, err := teamsDB.GetTeams()
+	if err == nil {
+		for _, team := range teams {
+			if NewCertAuthValidator(team).IsAuthenticated(r) {
+				certTeams = append(certTeams, team)
+			}
+		}
+	}
+
+	if cached {
+		lookup.done = true
+		lookup.teams = certTeams
+	}
+
+	return certTeams
+}
+
+func hasSAN(cert *x509.Certificate, san string) bool {
+	for _, dnsName := range cert.DNSNames {
+		if dnsName == san {
+			return true
+		}
+	}
+
+	for _, emailAddress := range cert.EmailAddresses {
+		if emailAddress == san {
+			return true
+		}
+	}
+
+	for _, ipAddress := range cert.IPAddresses {
+		if ipAddress.String() == san {
+			return true
+		}
+	}
+
+	return false
+}
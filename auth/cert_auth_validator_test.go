@@ -0,0 +1,272 @@
+package auth_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("CertAuthValidator", func() {
+	var (
+		team    db.SavedTeam
+		cert    *x509.Certificate
+		request *http.Request
+
+		isAuthenticated bool
+	)
+
+	BeforeEach(func() {
+		team = db.SavedTeam{
+			Team: db.Team{
+				Name: "some-team",
+				CertAuth: &db.CertAuth{
+					CommonNames: []string{"build-bot"},
+					SANs:        []string{"bots.example.com", "bot@example.com", "10.0.0.1"},
+				},
+			},
+		}
+
+		cert = &x509.Certificate{
+			Subject: pkix.Name{CommonName: "some-other-bot"},
+		}
+
+		var err error
+		request, err = http.NewRequest("GET", "https://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		request.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		isAuthenticated = auth.NewCertAuthValidator(team).IsAuthenticated(request)
+	})
+
+	Context("when the certificate's common name is listed", func() {
+		BeforeEach(func() {
+			cert.Subject.CommonName = "build-bot"
+		})
+
+		It("returns true", func() {
+			Expect(isAuthenticated).To(BeTrue())
+		})
+
+		Context("when the certificate was not verified", func() {
+			BeforeEach(func() {
+				request.TLS.VerifiedChains = nil
+			})
+
+			It("returns false", func() {
+				Expect(isAuthenticated).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when one of the certificate's DNS names is listed", func() {
+		BeforeEach(func() {
+			cert.DNSNames = []string{"other.example.com", "bots.example.com"}
+		})
+
+		It("returns true", func() {
+			Expect(isAuthenticated).To(BeTrue())
+		})
+	})
+
+	Context("when one of the certificate's email addresses is listed", func() {
+		BeforeEach(func() {
+			cert.EmailAddresses = []string{"bot@example.com"}
+		})
+
+		It("returns true", func() {
+			Expect(isAuthenticated).To(BeTrue())
+		})
+	})
+
+	Context("when one of the certificate's IP addresses is listed", func() {
+		BeforeEach(func() {
+			cert.IPAddresses = []net.IP{net.ParseIP("10.0.0.1")}
+		})
+
+		It("returns true", func() {
+			Expect(isAuthenticated).To(BeTrue())
+		})
+	})
+
+	Context("when nothing on the certificate is listed", func() {
+		It("returns false", func() {
+			Expect(isAuthenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the request was not made over TLS", func() {
+		BeforeEach(func() {
+			cert.Subject.CommonName = "build-bot"
+			request.TLS = nil
+		})
+
+		It("returns false", func() {
+			Expect(isAuthenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the team does not have cert auth", func() {
+		BeforeEach(func() {
+			cert.Subject.CommonName = "build-bot"
+			team.CertAuth = nil
+		})
+
+		It("returns false", func() {
+			Expect(isAuthenticated).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("CertValidator and CertReader", func() {
+	var (
+		fakeTeamsDB *authfakes.FakeTeamsDB
+		cert        *x509.Certificate
+		request     *http.Request
+
+		validator auth.CertValidator
+		reader    auth.CertReader
+	)
+
+	BeforeEach(func() {
+		fakeTeamsDB = new(authfakes.FakeTeamsDB)
+		fakeTeamsDB.GetTeamsReturns([]db.SavedTeam{
+			{
+				ID: 1,
+				Team: db.Team{
+					Name:     "some-team",
+					CertAuth: &db.CertAuth{CommonNames: []string{"build-bot"}},
+				},
+			},
+			{
+				ID: 2,
+				Team: db.Team{
+					Name: "main",
+				},
+			},
+			{
+				ID: 3,
+				Team: db.Team{
+					Name:     "other-team",
+					Admin:    true,
+					CertAuth: &db.CertAuth{SANs: []string{"bots.example.com"}},
+				},
+			},
+		}, nil)
+
+		cert = &x509.Certificate{
+			Subject:  pkix.Name{CommonName: "build-bot"},
+			DNSNames: []string{"bots.example.com"},
+		}
+
+		var err error
+		request, err = http.NewRequest("GET", "https://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		request.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert}},
+		}
+
+		validator = auth.CertValidator{TeamsDB: fakeTeamsDB}
+		reader = auth.CertReader{TeamsDB: fakeTeamsDB}
+	})
+
+	It("authenticates the request", func() {
+		Expect(validator.IsAuthenticated(request)).To(BeTrue())
+	})
+
+	It("reads every team accepting the certificate", func() {
+		teamName, isAdmin, found := reader.GetTeam(request)
+		Expect(found).To(BeTrue())
+		Expect(teamName).To(Equal("some-team"))
+		Expect(isAdmin).To(BeTrue())
+
+		teams, found := reader.GetTeams(request)
+		Expect(found).To(BeTrue())
+		Expect(teams).To(Equal([]string{"some-team", "other-team"}))
+
+		_, found = reader.GetUsername(request)
+		Expect(found).To(BeFalse())
+
+		_, found = reader.GetSystem(request)
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when the teams are read more than once for a request", func() {
+		It("only looks them up once", func() {
+			var scopedRequest *http.Request
+			auth.APITokenScopeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				scopedRequest = r
+			}), "read").ServeHTTP(httptest.NewRecorder(), request)
+
+			Expect(validator.IsAuthenticated(scopedRequest)).To(BeTrue())
+
+			_, _, found := reader.GetTeam(scopedRequest)
+			Expect(found).To(BeTrue())
+
+			_, found = reader.GetTeams(scopedRequest)
+			Expect(found).To(BeTrue())
+
+			Expect(fakeTeamsDB.GetTeamsCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when no team accepts the certificate", func() {
+		BeforeEach(func() {
+			cert.Subject.CommonName = "some-other-bot"
+			cert.DNSNames = nil
+		})
+
+		It("neither authenticates nor reads the request", func() {
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+
+			_, _, found := reader.GetTeam(request)
+			Expect(found).To(BeFalse())
+
+			_, found = reader.GetTeams(request)
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the request does not present a verified certificate", func() {
+		BeforeEach(func() {
+			request.TLS = nil
+		})
+
+		It("does not look up the teams", func() {
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+
+			_, _, found := reader.GetTeam(request)
+			Expect(found).To(BeFalse())
+
+			Expect(fakeTeamsDB.GetTeamsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the teams cannot be looked up", func() {
+		BeforeEach(func() {
+			fakeTeamsDB.GetTeamsReturns(nil, errors.New("nope"))
+		})
+
+		It("does not authenticate the request", func() {
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+		})
+	})
+})
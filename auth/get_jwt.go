@@ -1,28 +1,18 @@
 package auth
 
 import (
-	"crypto/rsa"
 	"errors"
-	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
-func getJWT(r *http.Request, publicKey *rsa.PublicKey) (token *jwt.Token, err error) {
-	fun := func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-
-		return publicKey, nil
-	}
-
+func getJWT(r *http.Request, publicKeys PublicKeys) (token *jwt.Token, err error) {
 	if ah := r.Header.Get("Authorization"); ah != "" {
 		// Should be a bearer token
 		if len(ah) > 6 && strings.ToUpper(ah[0:6]) == "BEARER" {
-			return jwt.Parse(ah[7:], fun)
+			return publicKeys.Parse(ah[7:])
 		}
 	}
 
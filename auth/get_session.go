@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+)
+
+// GetSession returns the ID and issue time of the session the request was
+// made in, if the token they authenticated with belongs to one.
+func GetSession(r *http.Request) (string, time.Time, bool) {
+	sessionID, sessionIDOK := r.Context().Value(sessionIDKey).(string)
+	issuedAt, issuedAtOK := r.Context().Value(issuedAtKey).(time.Time)
+	return sessionID, issuedAt, sessionIDOK && issuedAtOK
+}
@@ -6,11 +6,13 @@ type Team interface {
 	Name() string
 	IsAdmin() bool
 	IsAuthorized(teamName string) bool
+	Teams() []string
 }
 
 type team struct {
 	name    string
 	isAdmin bool
+	teams   []string
 }
 
 func (t *team) Name() string {
@@ -21,8 +23,28 @@ func (t *team) IsAdmin() bool {
 	return t.isAdmin
 }
 
+// IsAuthorized reports whether the user is a member of the given team.
 func (t *team) IsAuthorized(teamName string) bool {
-	return t.name == teamName
+	for _, name := range t.Teams() {
+		if name == teamName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Teams returns every team the user is a member of, starting with the one
+// they logged in to.
+func (t *team) Teams() []string {
+	teams := []string{t.name}
+	for _, name := range t.teams {
+		if name != t.name {
+			teams = append(teams, name)
+		}
+	}
+
+	return teams
 }
 
 func GetTeam(r *http.Request) (Team, bool) {
@@ -33,8 +55,11 @@ func GetTeam(r *http.Request) (Team, bool) {
 		return nil, false
 	}
 
+	teams, _ := r.Context().Value(teamsKey).([]string)
+
 	return &team{
 		name:    teamName,
 		isAdmin: isAdmin,
+		teams:   teams,
 	}, true
 }
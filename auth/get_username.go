@@ -0,0 +1,10 @@
+package auth
+
+import "net/http"
+
+// GetUsername returns the name of the user the request was made by, if the
+// token they authenticated with records one.
+func GetUsername(r *http.Request) (string, bool) {
+	username, present := r.Context().Value(usernameKey).(string)
+	return username, present
+}
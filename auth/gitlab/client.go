@@ -0,0 +1,126 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const DefaultAPIURL = "https://gitlab.com/api/v4/"
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	CurrentUser(*http.Client) (string, error)
+	Groups(*http.Client) ([]string, error)
+}
+
+type client struct {
+	baseURL string
+}
+
+func NewClient(baseURL string) Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	return &client{baseURL: baseURL}
+}
+
+type ErrorResponse struct {
+	Response *http.Response
+}
+
+func (err ErrorResponse) Error() string {
+	return fmt.Sprintf("%s %s: %s", err.Response.Request.Method, err.Response.Request.URL, err.Response.Status)
+}
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type group struct {
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+}
+
+func (c *client) CurrentUser(httpClient *http.Client) (string, error) {
+	var currentUser user
+	_, err := c.get(httpClient, "user", nil, &currentUser)
+	if err != nil {
+		return "", err
+	}
+
+	return currentUser.Username, nil
+}
+
+func (c *client) Groups(httpClient *http.Client) ([]string, error) {
+	nextPage := "1"
+	groups := []string{}
+
+	for nextPage != "" {
+		var page []group
+		response, err := c.get(httpClient, "groups", url.Values{
+			// only groups the user is a member of; admins can otherwise see
+			// every group on the instance
+			"min_access_level": []string{"10"},
+			"per_page":         []string{"100"},
+			"page":             []string{nextPage},
+		}, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range page {
+			// a nested group's own path is only unique within its parent, so
+			// it must not be mistaken for a top-level group of the same name.
+			// GitLab versions without nested groups do not send full_path.
+			if g.FullPath != "" {
+				groups = append(groups, g.FullPath)
+			} else {
+				groups = append(groups, g.Path)
+			}
+		}
+
+		nextPage = response.Header.Get("X-Next-Page")
+		if _, err := strconv.Atoi(nextPage); err != nil {
+			nextPage = ""
+		}
+	}
+
+	return groups, nil
+}
+
+func (c *client) get(httpClient *http.Client, path string, query url.Values, result interface{}) (*http.Response, error) {
+	requestURL, err := url.Parse(c.baseURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gitlab auth API URL '%s'", c.baseURL)
+	}
+
+	requestURL.RawQuery = query.Encode()
+
+	response, err := httpClient.Get(requestURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, ErrorResponse{Response: response}
+	}
+
+	err = json.NewDecoder(response.Body).Decode(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
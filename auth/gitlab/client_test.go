@@ -0,0 +1,119 @@
+package gitlab_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/atc/auth/gitlab"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		gitlabServer *ghttp.Server
+
+		client gitlab.Client
+	)
+
+	BeforeEach(func() {
+		gitlabServer = ghttp.NewServer()
+
+		client = gitlab.NewClient(gitlabServer.URL() + "/api/v4")
+	})
+
+	AfterEach(func() {
+		gitlabServer.Close()
+	})
+
+	Describe("CurrentUser", func() {
+		Context("when getting the current user succeeds", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+							"id":       1,
+							"username": "some-user",
+						}),
+					),
+				)
+			})
+
+			It("returns the user's username", func() {
+				user, err := client.CurrentUser(http.DefaultClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(user).To(Equal("some-user"))
+			})
+		})
+
+		Context("when getting the current user fails", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/user"),
+						ghttp.RespondWith(http.StatusUnauthorized, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.CurrentUser(http.DefaultClient)
+				Expect(err).To(BeAssignableToTypeOf(gitlab.ErrorResponse{}))
+			})
+		})
+	})
+
+	Describe("Groups", func() {
+		Context("when listing groups succeeds", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/groups", "min_access_level=10&page=1&per_page=100"),
+						ghttp.RespondWithJSONEncoded(
+							http.StatusOK,
+							[]map[string]string{
+								{"path": "group-1", "full_path": "group-1"},
+								{"path": "subgroup", "full_path": "group-1/subgroup"},
+							},
+							http.Header{"X-Next-Page": []string{"2"}},
+						),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/groups", "min_access_level=10&page=2&per_page=100"),
+						ghttp.RespondWithJSONEncoded(
+							http.StatusOK,
+							[]map[string]string{
+								{"path": "group-2"},
+							},
+							http.Header{"X-Next-Page": []string{""}},
+						),
+					),
+				)
+			})
+
+			It("returns the full paths of every group", func() {
+				groups, err := client.Groups(http.DefaultClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups).To(Equal([]string{"group-1", "group-1/subgroup", "group-2"}))
+			})
+		})
+
+		Context("when listing groups fails", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/groups"),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.Groups(http.DefaultClient)
+				Expect(err).To(BeAssignableToTypeOf(gitlab.ErrorResponse{}))
+			})
+		})
+	})
+})
@@ -0,0 +1,124 @@
+// This file was generated by counterfeiter
+package gitlabfakes
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/concourse/atc/auth/gitlab"
+)
+
+type FakeClient struct {
+	CurrentUserStub        func(arg1 *http.Client) (string, error)
+	currentUserMutex       sync.RWMutex
+	currentUserArgsForCall []struct {
+		arg1 *http.Client
+	}
+	currentUserReturns struct {
+		result1 string
+		result2 error
+	}
+	GroupsStub        func(arg1 *http.Client) ([]string, error)
+	groupsMutex       sync.RWMutex
+	groupsArgsForCall []struct {
+		arg1 *http.Client
+	}
+	groupsReturns struct {
+		result1 []string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) CurrentUser(arg1 *http.Client) (string, error) {
+	fake.currentUserMutex.Lock()
+	fake.currentUserArgsForCall = append(fake.currentUserArgsForCall, struct {
+		arg1 *http.Client
+	}{arg1})
+	fake.recordInvocation("CurrentUser", []interface{}{arg1})
+	fake.currentUserMutex.Unlock()
+	if fake.CurrentUserStub != nil {
+		return fake.CurrentUserStub(arg1)
+	} else {
+		return fake.currentUserReturns.result1, fake.currentUserReturns.result2
+	}
+}
+
+func (fake *FakeClient) CurrentUserCallCount() int {
+	fake.currentUserMutex.RLock()
+	defer fake.currentUserMutex.RUnlock()
+	return len(fake.currentUserArgsForCall)
+}
+
+func (fake *FakeClient) CurrentUserArgsForCall(i int) *http.Client {
+	fake.currentUserMutex.RLock()
+	defer fake.currentUserMutex.RUnlock()
+	return fake.currentUserArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) CurrentUserReturns(result1 string, result2 error) {
+	fake.CurrentUserStub = nil
+	fake.currentUserReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Groups(arg1 *http.Client) ([]string, error) {
+	fake.groupsMutex.Lock()
+	fake.groupsArgsForCall = append(fake.groupsArgsForCall, struct {
+		arg1 *http.Client
+	}{arg1})
+	fake.recordInvocation("Groups", []interface{}{arg1})
+	fake.groupsMutex.Unlock()
+	if fake.GroupsStub != nil {
+		return fake.GroupsStub(arg1)
+	} else {
+		return fake.groupsReturns.result1, fake.groupsReturns.result2
+	}
+}
+
+func (fake *FakeClient) GroupsCallCount() int {
+	fake.groupsMutex.RLock()
+	defer fake.groupsMutex.RUnlock()
+	return len(fake.groupsArgsForCall)
+}
+
+func (fake *FakeClient) GroupsArgsForCall(i int) *http.Client {
+	fake.groupsMutex.RLock()
+	defer fake.groupsMutex.RUnlock()
+	return fake.groupsArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) GroupsReturns(result1 []string, result2 error) {
+	fake.GroupsStub = nil
+	fake.groupsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.currentUserMutex.RLock()
+	defer fake.currentUserMutex.RUnlock()
+	fake.groupsMutex.RLock()
+	defer fake.groupsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gitlab.Client = new(FakeClient)
@@ -0,0 +1,107 @@
+package gitlab_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/auth/gitlab"
+	"github.com/concourse/atc/auth/gitlab/gitlabfakes"
+	"github.com/concourse/atc/auth/verifier"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GroupVerifier", func() {
+	var (
+		fakeClient *gitlabfakes.FakeClient
+
+		verifier verifier.Verifier
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(gitlabfakes.FakeClient)
+
+		verifier = NewGroupVerifier([]string{"some-group", "parent/some-subgroup"}, fakeClient)
+	})
+
+	Describe("Verify", func() {
+		var (
+			httpClient *http.Client
+
+			verified  bool
+			verifyErr error
+		)
+
+		BeforeEach(func() {
+			httpClient = &http.Client{}
+		})
+
+		JustBeforeEach(func() {
+			verified, verifyErr = verifier.Verify(lagertest.NewTestLogger("test"), httpClient)
+		})
+
+		Context("when the client returns groups", func() {
+			Context("including one of the desired groups", func() {
+				BeforeEach(func() {
+					fakeClient.GroupsReturns([]string{"other-group", "some-group"}, nil)
+				})
+
+				It("succeeds", func() {
+					Expect(verifyErr).ToNot(HaveOccurred())
+				})
+
+				It("returns true", func() {
+					Expect(verified).To(BeTrue())
+				})
+			})
+
+			Context("including a desired nested group", func() {
+				BeforeEach(func() {
+					fakeClient.GroupsReturns([]string{"parent/some-subgroup"}, nil)
+				})
+
+				It("returns true", func() {
+					Expect(verified).To(BeTrue())
+				})
+			})
+
+			Context("including a nested group named like a desired group", func() {
+				BeforeEach(func() {
+					fakeClient.GroupsReturns([]string{"other-parent/some-group"}, nil)
+				})
+
+				It("returns false", func() {
+					Expect(verified).To(BeFalse())
+				})
+			})
+
+			Context("not including the desired groups", func() {
+				BeforeEach(func() {
+					fakeClient.GroupsReturns([]string{"other-group", "other-parent/some-subgroup"}, nil)
+				})
+
+				It("succeeds", func() {
+					Expect(verifyErr).ToNot(HaveOccurred())
+				})
+
+				It("returns false", func() {
+					Expect(verified).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the client fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeClient.GroupsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(verifyErr).To(Equal(disaster))
+			})
+		})
+	})
+})
@@ -0,0 +1,64 @@
+package gitlab
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/concourse/atc/db"
+	"golang.org/x/oauth2"
+)
+
+const ProviderName = "gitlab"
+const DisplayName = "GitLab"
+
+var Scopes = []string{"api"}
+
+var Endpoint = oauth2.Endpoint{
+	AuthURL:  "https://gitlab.com/oauth/authorize",
+	TokenURL: "https://gitlab.com/oauth/token",
+}
+
+func NewProvider(
+	gitLabAuth *db.GitLabAuth,
+	redirectURL string,
+) Provider {
+	client := NewClient(gitLabAuth.APIURL)
+
+	endpoint := Endpoint
+	if gitLabAuth.AuthURL != "" && gitLabAuth.TokenURL != "" {
+		endpoint.AuthURL = gitLabAuth.AuthURL
+		endpoint.TokenURL = gitLabAuth.TokenURL
+	}
+
+	return Provider{
+		Verifier: verifier.NewVerifierBasket(
+			NewGroupVerifier(gitLabAuth.Groups, client),
+			NewUserVerifier(gitLabAuth.Users, client),
+		),
+		Config: &oauth2.Config{
+			ClientID:     gitLabAuth.ClientID,
+			ClientSecret: gitLabAuth.ClientSecret,
+			Endpoint:     endpoint,
+			Scopes:       Scopes,
+			RedirectURL:  redirectURL,
+		},
+	}
+}
+
+type Provider struct {
+	*oauth2.Config
+	// oauth2.Config implements the required Provider methods:
+	// AuthCodeURL(string, ...oauth2.AuthCodeOption) string
+	// Exchange(context.Context, string) (*oauth2.Token, error)
+	// Client(context.Context, *oauth2.Token) *http.Client
+
+	verifier.Verifier
+}
+
+func (Provider) PreTokenClient() (*http.Client, error) {
+	return &http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+		},
+	}, nil
+}
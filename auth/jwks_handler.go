@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// JWKSHandler publishes the public keys session tokens are verified with.
+type JWKSHandler struct {
+	PublicKeys PublicKeys
+}
+
+func (handler JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(handler.PublicKeys.JWKS())
+}
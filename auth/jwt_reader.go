@@ -1,22 +1,27 @@
 package auth
 
 import (
-	"crypto/rsa"
 	"net/http"
+	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
 type JWTReader struct {
-	PublicKey *rsa.PublicKey
+	PublicKeys   PublicKeys
+	RevocationDB RevocationDB
 }
 
 func (jr JWTReader) GetTeam(r *http.Request) (string, bool, bool) {
-	token, err := getJWT(r, jr.PublicKey)
+	token, err := getJWT(r, jr.PublicKeys)
 	if err != nil {
 		return "", false, false
 	}
 
+	if isRevoked(token, jr.RevocationDB) {
+		return "", false, false
+	}
+
 	claims := token.Claims.(jwt.MapClaims)
 	teamNameInterface, teamNameOK := claims[teamNameClaimKey]
 	isAdminInterface, isAdminOK := claims[isAdminClaimKey]
@@ -31,8 +36,81 @@ func (jr JWTReader) GetTeam(r *http.Request) (string, bool, bool) {
 	return teamName, isAdmin, true
 }
 
+// GetTeams returns every team the token was issued for. Tokens issued before
+// users could belong to several teams only carry the team they logged in to.
+func (jr JWTReader) GetTeams(r *http.Request) ([]string, bool) {
+	teamName, _, found := jr.GetTeam(r)
+	if !found {
+		return nil, false
+	}
+
+	token, err := getJWT(r, jr.PublicKeys)
+	if err != nil {
+		return nil, false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	teamsInterface, teamsOK := claims[teamsClaimKey].([]interface{})
+	if !teamsOK {
+		return []string{teamName}, true
+	}
+
+	teams := []string{}
+	for _, teamInterface := range teamsInterface {
+		if team, ok := teamInterface.(string); ok {
+			teams = append(teams, team)
+		}
+	}
+
+	return teams, true
+}
+
+func (jr JWTReader) GetUsername(r *http.Request) (string, bool) {
+	_, _, found := jr.GetTeam(r)
+	if !found {
+		return "", false
+	}
+
+	token, err := getJWT(r, jr.PublicKeys)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	username, usernameOK := claims[usernameClaimKey].(string)
+	if !usernameOK || username == "" {
+		return "", false
+	}
+
+	return username, true
+}
+
+// GetSession returns the ID and issue time of the session the token belongs
+// to. Tokens issued before sessions had issue times are not treated as
+// belonging to one.
+func (jr JWTReader) GetSession(r *http.Request) (string, time.Time, bool) {
+	_, _, found := jr.GetTeam(r)
+	if !found {
+		return "", time.Time{}, false
+	}
+
+	token, err := getJWT(r, jr.PublicKeys)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	sessionID, sessionIDOK := claims[sessionIDClaimKey].(string)
+	issuedAt, issuedAtOK := claims[issuedAtClaimKey].(float64)
+	if !sessionIDOK || sessionID == "" || !issuedAtOK {
+		return "", time.Time{}, false
+	}
+
+	return sessionID, time.Unix(int64(issuedAt), 0), true
+}
+
 func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
-	token, err := getJWT(r, jr.PublicKey)
+	token, err := getJWT(r, jr.PublicKeys)
 	if err != nil {
 		return false, false
 	}
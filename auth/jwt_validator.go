@@ -1,19 +1,17 @@
 package auth
 
-import (
-	"crypto/rsa"
-	"net/http"
-)
+import "net/http"
 
 type JWTValidator struct {
-	PublicKey *rsa.PublicKey
+	PublicKeys   PublicKeys
+	RevocationDB RevocationDB
 }
 
 func (validator JWTValidator) IsAuthenticated(r *http.Request) bool {
-	token, err := getJWT(r, validator.PublicKey)
+	token, err := getJWT(r, validator.PublicKeys)
 	if err != nil {
 		return false
 	}
 
-	return token.Valid
+	return token.Valid && !isRevoked(token, validator.RevocationDB)
 }
@@ -0,0 +1,194 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	goldap "gopkg.in/ldap.v2"
+)
+
+const DisplayName = "LDAP"
+
+const (
+	DefaultUsernameAttribute    = "uid"
+	DefaultGroupMemberAttribute = "member"
+	DefaultGroupNameAttribute   = "cn"
+)
+
+type Authenticator struct {
+	logger lager.Logger
+	dialer Dialer
+}
+
+func NewAuthenticator(logger lager.Logger, dialer Dialer) Authenticator {
+	return Authenticator{
+		logger: logger,
+		dialer: dialer,
+	}
+}
+
+// Authenticate looks the user up with the configured search filter, binds as
+// them to check the password, and then checks that they are a member of one
+// of the team's groups. With no groups configured, any user matched by the
+// search filter is let in.
+func (a Authenticator) Authenticate(ldapAuth *db.LDAPAuth, username string, password string) (bool, error) {
+	logger := a.logger.Session("authenticate", lager.Data{"username": username})
+
+	// a bind with an empty password is an unauthenticated bind, which most
+	// servers accept regardless of the DN
+	if username == "" || password == "" {
+		return false, nil
+	}
+
+	conn, err := a.dialer.Dial(ldapAuth)
+	if err != nil {
+		logger.Error("failed-to-dial", err, lager.Data{"host": ldapAuth.Host})
+		return false, err
+	}
+
+	defer conn.Close()
+
+	err = a.bindSearchUser(conn, ldapAuth)
+	if err != nil {
+		logger.Error("failed-to-bind-search-user", err)
+		return false, err
+	}
+
+	userDN, found, err := a.findUser(conn, ldapAuth, username)
+	if err != nil {
+		logger.Error("failed-to-search-for-user", err)
+		return false, err
+	}
+
+	if !found {
+		logger.Info("user-not-found")
+		return false, nil
+	}
+
+	err = conn.Bind(userDN, password)
+	if err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			logger.Info("invalid-credentials")
+			return false, nil
+		}
+
+		logger.Error("failed-to-bind-user", err)
+		return false, err
+	}
+
+	if len(ldapAuth.Groups) == 0 {
+		return true, nil
+	}
+
+	// the user may not be allowed to read group membership themselves
+	err = a.bindSearchUser(conn, ldapAuth)
+	if err != nil {
+		logger.Error("failed-to-bind-search-user", err)
+		return false, err
+	}
+
+	groups, err := a.findGroups(conn, ldapAuth, userDN)
+	if err != nil {
+		logger.Error("failed-to-search-for-groups", err)
+		return false, err
+	}
+
+	for _, group := range groups {
+		for _, permittedGroup := range ldapAuth.Groups {
+			if strings.EqualFold(group, permittedGroup) {
+				return true, nil
+			}
+		}
+	}
+
+	logger.Info("not-in-permitted-groups", lager.Data{"groups": groups})
+
+	return false, nil
+}
+
+func (a Authenticator) bindSearchUser(conn Conn, ldapAuth *db.LDAPAuth) error {
+	if ldapAuth.BindDN == "" {
+		return nil
+	}
+
+	return conn.Bind(ldapAuth.BindDN, ldapAuth.BindPassword)
+}
+
+func (a Authenticator) findUser(conn Conn, ldapAuth *db.LDAPAuth, username string) (string, bool, error) {
+	usernameAttribute := ldapAuth.UsernameAttribute
+	if usernameAttribute == "" {
+		usernameAttribute = DefaultUsernameAttribute
+	}
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		ldapAuth.UserSearchBaseDN,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		andFilter(ldapAuth.UserSearchFilter, usernameAttribute, username),
+		[]string{"dn"},
+		nil,
+	))
+	if err != nil {
+		return "", false, err
+	}
+
+	switch len(result.Entries) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return result.Entries[0].DN, true, nil
+	default:
+		return "", false, fmt.Errorf("found %d users matching %q", len(result.Entries), username)
+	}
+}
+
+func (a Authenticator) findGroups(conn Conn, ldapAuth *db.LDAPAuth, userDN string) ([]string, error) {
+	memberAttribute := ldapAuth.GroupMemberAttribute
+	if memberAttribute == "" {
+		memberAttribute = DefaultGroupMemberAttribute
+	}
+
+	nameAttribute := ldapAuth.GroupNameAttribute
+	if nameAttribute == "" {
+		nameAttribute = DefaultGroupNameAttribute
+	}
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		ldapAuth.GroupSearchBaseDN,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		andFilter(ldapAuth.GroupSearchFilter, memberAttribute, userDN),
+		[]string{nameAttribute},
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []string{}
+	for _, entry := range result.Entries {
+		groups = append(groups, entry.GetAttributeValues(nameAttribute)...)
+	}
+
+	return groups, nil
+}
+
+// andFilter narrows the configured filter down to entries whose attribute
+// equals the given value, escaping the value so that it cannot widen the
+// search.
+func andFilter(filter string, attribute string, value string) string {
+	match := fmt.Sprintf("(%s=%s)", attribute, goldap.EscapeFilter(value))
+	if filter == "" {
+		return match
+	}
+
+	return fmt.Sprintf("(&%s%s)", filter, match)
+}
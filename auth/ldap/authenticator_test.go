@@ -0,0 +1,194 @@
+package ldap_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/ldap/ldapfakes"
+	"github.com/concourse/atc/auth/ldap/ldaptest"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Authenticator", func() {
+	var (
+		directory *ldaptest.Directory
+		ldapAuth  *db.LDAPAuth
+
+		authenticator ldap.Authenticator
+
+		username string
+		password string
+
+		authenticated bool
+		authErr       error
+	)
+
+	BeforeEach(func() {
+		directory = ldaptest.NewDirectory()
+
+		directory.AddEntry("cn=concourse,ou=services,dc=example,dc=com", "service-password", map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {"concourse"},
+		})
+
+		directory.AddEntry("uid=jdoe,ou=people,dc=example,dc=com", "some-password", map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {"jdoe"},
+		})
+
+		directory.AddEntry("uid=rroe,ou=people,dc=example,dc=com", "other-password", map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {"rroe"},
+		})
+
+		directory.AddEntry("cn=developers,ou=groups,dc=example,dc=com", "", map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"cn":          {"developers"},
+			"member":      {"uid=jdoe,ou=people,dc=example,dc=com"},
+		})
+
+		directory.AddEntry("cn=operators,ou=groups,dc=example,dc=com", "", map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"cn":          {"operators"},
+			"member":      {"uid=rroe,ou=people,dc=example,dc=com"},
+		})
+
+		ldapAuth = &db.LDAPAuth{
+			Host:              "ldap.example.com:389",
+			BindDN:            "cn=concourse,ou=services,dc=example,dc=com",
+			BindPassword:      "service-password",
+			UserSearchBaseDN:  "ou=people,dc=example,dc=com",
+			UserSearchFilter:  "(objectClass=person)",
+			GroupSearchBaseDN: "ou=groups,dc=example,dc=com",
+			GroupSearchFilter: "(objectClass=groupOfNames)",
+			Groups:            []string{"developers"},
+		}
+
+		authenticator = ldap.NewAuthenticator(lagertest.NewTestLogger("test"), directory)
+
+		username = "jdoe"
+		password = "some-password"
+	})
+
+	JustBeforeEach(func() {
+		authenticated, authErr = authenticator.Authenticate(ldapAuth, username, password)
+	})
+
+	Context("when the user is in a permitted group", func() {
+		It("authenticates", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeTrue())
+		})
+	})
+
+	Context("when the password is wrong", func() {
+		BeforeEach(func() {
+			password = "bogus"
+		})
+
+		It("does not authenticate", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the password is empty", func() {
+		BeforeEach(func() {
+			password = ""
+		})
+
+		It("does not authenticate", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the user does not exist", func() {
+		BeforeEach(func() {
+			username = "nobody"
+		})
+
+		It("does not authenticate", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the username tries to widen the search filter", func() {
+		BeforeEach(func() {
+			username = "*"
+		})
+
+		It("does not authenticate", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the user is excluded by the search filter", func() {
+		BeforeEach(func() {
+			ldapAuth.UserSearchFilter = "(objectClass=inetOrgPerson)"
+		})
+
+		It("does not authenticate", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the user is not in a permitted group", func() {
+		BeforeEach(func() {
+			username = "rroe"
+			password = "other-password"
+		})
+
+		It("does not authenticate", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+
+		Context("when no groups are configured", func() {
+			BeforeEach(func() {
+				ldapAuth.Groups = nil
+			})
+
+			It("authenticates", func() {
+				Expect(authErr).NotTo(HaveOccurred())
+				Expect(authenticated).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when the bind credentials are wrong", func() {
+		BeforeEach(func() {
+			ldapAuth.BindPassword = "bogus"
+		})
+
+		It("errors", func() {
+			Expect(authErr).To(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the directory cannot be reached", func() {
+		var disaster error
+
+		BeforeEach(func() {
+			disaster = errors.New("connection refused")
+
+			dialer := new(ldapfakes.FakeDialer)
+			dialer.DialReturns(nil, disaster)
+
+			authenticator = ldap.NewAuthenticator(lagertest.NewTestLogger("test"), dialer)
+		})
+
+		It("errors", func() {
+			Expect(authErr).To(Equal(disaster))
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,97 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/concourse/atc/db"
+	goldap "gopkg.in/ldap.v2"
+)
+
+type Conn interface {
+	Bind(username string, password string) error
+	Search(searchRequest *goldap.SearchRequest) (*goldap.SearchResult, error)
+	Close()
+}
+
+//go:generate counterfeiter . Dialer
+
+type Dialer interface {
+	Dial(ldapAuth *db.LDAPAuth) (Conn, error)
+}
+
+const (
+	// DialTimeout bounds connecting to the LDAP server, including the TLS
+	// handshake.
+	DialTimeout = 10 * time.Second
+
+	// RequestTimeout bounds each request made over the connection.
+	RequestTimeout = 30 * time.Second
+)
+
+type dialer struct{}
+
+// NewDialer returns a Dialer that gives up on unresponsive LDAP servers after
+// DialTimeout or RequestTimeout, rather than holding up logins indefinitely.
+func NewDialer() Dialer {
+	return dialer{}
+}
+
+func (dialer) Dial(ldapAuth *db.LDAPAuth) (Conn, error) {
+	tlsConfig, err := tlsConfig(ldapAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	netDialer := &net.Dialer{Timeout: DialTimeout}
+
+	var netConn net.Conn
+	if ldapAuth.TLS {
+		netConn, err = tls.DialWithDialer(netDialer, "tcp", ldapAuth.Host, tlsConfig)
+	} else {
+		netConn, err = netDialer.Dial("tcp", ldapAuth.Host)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn := goldap.NewConn(netConn, ldapAuth.TLS)
+	conn.SetTimeout(RequestTimeout)
+	conn.Start()
+
+	if !ldapAuth.TLS && ldapAuth.StartTLS {
+		err = conn.StartTLS(tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func tlsConfig(ldapAuth *db.LDAPAuth) (*tls.Config, error) {
+	serverName, _, err := net.SplitHostPort(ldapAuth.Host)
+	if err != nil {
+		serverName = ldapAuth.Host
+	}
+
+	config := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: ldapAuth.InsecureSkipVerify,
+	}
+
+	if ldapAuth.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ldapAuth.CACert)) {
+			return nil, errors.New("LDAP CA certificate is invalid")
+		}
+
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
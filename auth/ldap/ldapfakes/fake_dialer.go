@@ -0,0 +1,79 @@
+// This file was generated by counterfeiter
+package ldapfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/db"
+)
+
+type FakeDialer struct {
+	DialStub        func(ldapAuth *db.LDAPAuth) (ldap.Conn, error)
+	dialMutex       sync.RWMutex
+	dialArgsForCall []struct {
+		ldapAuth *db.LDAPAuth
+	}
+	dialReturns struct {
+		result1 ldap.Conn
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDialer) Dial(ldapAuth *db.LDAPAuth) (ldap.Conn, error) {
+	fake.dialMutex.Lock()
+	fake.dialArgsForCall = append(fake.dialArgsForCall, struct {
+		ldapAuth *db.LDAPAuth
+	}{ldapAuth})
+	fake.recordInvocation("Dial", []interface{}{ldapAuth})
+	fake.dialMutex.Unlock()
+	if fake.DialStub != nil {
+		return fake.DialStub(ldapAuth)
+	} else {
+		return fake.dialReturns.result1, fake.dialReturns.result2
+	}
+}
+
+func (fake *FakeDialer) DialCallCount() int {
+	fake.dialMutex.RLock()
+	defer fake.dialMutex.RUnlock()
+	return len(fake.dialArgsForCall)
+}
+
+func (fake *FakeDialer) DialArgsForCall(i int) *db.LDAPAuth {
+	fake.dialMutex.RLock()
+	defer fake.dialMutex.RUnlock()
+	return fake.dialArgsForCall[i].ldapAuth
+}
+
+func (fake *FakeDialer) DialReturns(result1 ldap.Conn, result2 error) {
+	fake.DialStub = nil
+	fake.dialReturns = struct {
+		result1 ldap.Conn
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDialer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.dialMutex.RLock()
+	defer fake.dialMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeDialer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ldap.Dialer = new(FakeDialer)
@@ -0,0 +1,267 @@
+// Package ldaptest provides an in-process LDAP directory for tests.
+package ldaptest
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/db"
+	goldap "gopkg.in/ldap.v2"
+)
+
+// Directory stands in for an LDAP server. It holds entries in memory and
+// understands enough of simple binds, subtree searches and RFC 4515 filters
+// (equality, presence, &, | and !) to exercise the authenticator.
+type Directory struct {
+	lock      sync.Mutex
+	entries   []*goldap.Entry
+	passwords map[string]string
+}
+
+func NewDirectory() *Directory {
+	return &Directory{
+		passwords: map[string]string{},
+	}
+}
+
+// AddEntry adds an entry to the directory. Entries with a password can be
+// bound as.
+func (directory *Directory) AddEntry(dn string, password string, attributes map[string][]string) {
+	directory.lock.Lock()
+	defer directory.lock.Unlock()
+
+	entry := &goldap.Entry{DN: dn}
+	for name, values := range attributes {
+		entry.Attributes = append(entry.Attributes, &goldap.EntryAttribute{
+			Name:   name,
+			Values: values,
+		})
+	}
+
+	directory.entries = append(directory.entries, entry)
+
+	if password != "" {
+		directory.passwords[strings.ToLower(dn)] = password
+	}
+}
+
+func (directory *Directory) Dial(*db.LDAPAuth) (ldap.Conn, error) {
+	return &conn{directory: directory}, nil
+}
+
+type conn struct {
+	directory *Directory
+	bound     bool
+}
+
+func (conn *conn) Bind(dn string, password string) error {
+	conn.directory.lock.Lock()
+	defer conn.directory.lock.Unlock()
+
+	conn.bound = false
+
+	expected, found := conn.directory.passwords[strings.ToLower(dn)]
+	if !found || password == "" || expected != password {
+		return goldap.NewError(goldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+
+	conn.bound = true
+
+	return nil
+}
+
+func (conn *conn) Search(request *goldap.SearchRequest) (*goldap.SearchResult, error) {
+	conn.directory.lock.Lock()
+	defer conn.directory.lock.Unlock()
+
+	if !conn.bound {
+		return nil, goldap.NewError(goldap.LDAPResultInsufficientAccessRights, errors.New("bind required"))
+	}
+
+	match, rest, err := parseFilter(request.Filter)
+	if err != nil || rest != "" {
+		return nil, goldap.NewError(goldap.LDAPResultFilterError, fmt.Errorf("invalid filter %q", request.Filter))
+	}
+
+	result := &goldap.SearchResult{}
+	for _, entry := range conn.directory.entries {
+		if !underBase(entry.DN, request.BaseDN) || !match(entry) {
+			continue
+		}
+
+		found := &goldap.Entry{DN: entry.DN}
+		for _, attribute := range entry.Attributes {
+			for _, requested := range request.Attributes {
+				if strings.EqualFold(attribute.Name, requested) {
+					found.Attributes = append(found.Attributes, attribute)
+				}
+			}
+		}
+
+		result.Entries = append(result.Entries, found)
+	}
+
+	return result, nil
+}
+
+func (conn *conn) Close() {}
+
+func underBase(dn string, baseDN string) bool {
+	dn = strings.ToLower(dn)
+	baseDN = strings.ToLower(baseDN)
+
+	return baseDN == "" || dn == baseDN || strings.HasSuffix(dn, ","+baseDN)
+}
+
+type matcher func(*goldap.Entry) bool
+
+func parseFilter(filter string) (matcher, string, error) {
+	if !strings.HasPrefix(filter, "(") {
+		return nil, "", errors.New("expected (")
+	}
+
+	filter = filter[1:]
+
+	var match matcher
+	var err error
+
+	switch {
+	case strings.HasPrefix(filter, "&"), strings.HasPrefix(filter, "|"):
+		operator := filter[0]
+		filter = filter[1:]
+
+		var matchers []matcher
+		for strings.HasPrefix(filter, "(") {
+			var sub matcher
+			sub, filter, err = parseFilter(filter)
+			if err != nil {
+				return nil, "", err
+			}
+
+			matchers = append(matchers, sub)
+		}
+
+		if operator == '&' {
+			match = func(entry *goldap.Entry) bool {
+				for _, sub := range matchers {
+					if !sub(entry) {
+						return false
+					}
+				}
+
+				return true
+			}
+		} else {
+			match = func(entry *goldap.Entry) bool {
+				for _, sub := range matchers {
+					if sub(entry) {
+						return true
+					}
+				}
+
+				return false
+			}
+		}
+
+	case strings.HasPrefix(filter, "!"):
+		var sub matcher
+		sub, filter, err = parseFilter(filter[1:])
+		if err != nil {
+			return nil, "", err
+		}
+
+		match = func(entry *goldap.Entry) bool {
+			return !sub(entry)
+		}
+
+	default:
+		end := strings.Index(filter, ")")
+		if end == -1 {
+			return nil, "", errors.New("expected )")
+		}
+
+		match, err = parseEquality(filter[:end])
+		if err != nil {
+			return nil, "", err
+		}
+
+		filter = filter[end:]
+	}
+
+	if !strings.HasPrefix(filter, ")") {
+		return nil, "", errors.New("expected )")
+	}
+
+	return match, filter[1:], nil
+}
+
+func parseEquality(item string) (matcher, error) {
+	parts := strings.SplitN(item, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, fmt.Errorf("unsupported filter item %q", item)
+	}
+
+	name := parts[0]
+
+	if parts[1] == "*" {
+		return func(entry *goldap.Entry) bool {
+			return len(attributeValues(entry, name)) > 0
+		}, nil
+	}
+
+	value, err := unescape(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return func(entry *goldap.Entry) bool {
+		for _, v := range attributeValues(entry, name) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+
+		return false
+	}, nil
+}
+
+func attributeValues(entry *goldap.Entry, name string) []string {
+	for _, attribute := range entry.Attributes {
+		if strings.EqualFold(attribute.Name, name) {
+			return attribute.Values
+		}
+	}
+
+	return nil
+}
+
+func unescape(value string) (string, error) {
+	var unescaped []byte
+
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if i+3 > len(value) {
+				return "", fmt.Errorf("truncated escape in %q", value)
+			}
+
+			b, err := hex.DecodeString(value[i+1 : i+3])
+			if err != nil {
+				return "", err
+			}
+
+			unescaped = append(unescaped, b...)
+			i += 2
+		case '*', '(', ')':
+			return "", fmt.Errorf("unsupported character in %q", value)
+		default:
+			unescaped = append(unescaped, value[i])
+		}
+	}
+
+	return string(unescaped), nil
+}
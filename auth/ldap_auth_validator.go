@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . LDAPAuthenticator
+
+type LDAPAuthenticator interface {
+	Authenticate(ldapAuth *db.LDAPAuth, username string, password string) (bool, error)
+}
+
+type ldapAuthValidator struct {
+	team          db.SavedTeam
+	authenticator LDAPAuthenticator
+}
+
+func NewLDAPAuthValidator(team db.SavedTeam, authenticator LDAPAuthenticator) Validator {
+	return ldapAuthValidator{
+		team:          team,
+		authenticator: authenticator,
+	}
+}
+
+func (v ldapAuthValidator) IsAuthenticated(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	username, password, err := extractUsernameAndPassword(auth)
+	if err != nil {
+		return false
+	}
+
+	authenticated, err := v.authenticator.Authenticate(v.team.LDAPAuth, username, password)
+	if err != nil {
+		return false
+	}
+
+	return authenticated
+}
@@ -4,22 +4,56 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	jwt "github.com/dgrijalva/jwt-go"
 )
 
 type LogOutHandler struct {
-	logger lager.Logger
+	logger        lager.Logger
+	publicKeys    PublicKeys
+	teamDBFactory db.TeamDBFactory
 }
 
-func NewLogOutHandler(logger lager.Logger) http.Handler {
-	return &LogOutHandler{logger: logger}
+func NewLogOutHandler(
+	logger lager.Logger,
+	publicKeys PublicKeys,
+	teamDBFactory db.TeamDBFactory,
+) http.Handler {
+	return &LogOutHandler{
+		logger:        logger,
+		publicKeys:    publicKeys,
+		teamDBFactory: teamDBFactory,
+	}
 }
 
 func (handler *LogOutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	handler.logger.Session("logout")
+	hLog := handler.logger.Session("logout")
 
 	http.SetCookie(w, &http.Cookie{
 		Name:   CookieName,
 		Path:   "/",
 		MaxAge: -1,
 	})
+
+	// revoke the session too, so that copies of the token stop working
+	token, err := getJWT(r, handler.publicKeys)
+	if err != nil || !token.Valid {
+		return
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	teamNames := tokenTeams(claims)
+	sessionID, sessionIDOK := claims[sessionIDClaimKey].(string)
+	if len(teamNames) == 0 || !sessionIDOK {
+		return
+	}
+
+	for _, teamName := range teamNames {
+		err = handler.teamDBFactory.GetTeamDB(teamName).RevokeSession(sessionID)
+		if err != nil {
+			hLog.Error("failed-to-revoke-session", err, lager.Data{"team": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
 }
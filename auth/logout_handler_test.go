@@ -3,6 +3,8 @@ package auth_test
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"time"
@@ -14,12 +16,15 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/db/dbfakes"
+	jwt "github.com/dgrijalva/jwt-go"
 )
 
 var _ = Describe("LogOutHandler", func() {
 	Describe("GET /auth/logout", func() {
 		var (
 			fakeProviderFactory *authfakes.FakeProviderFactory
+			fakeTeamDBFactory   *dbfakes.FakeTeamDBFactory
+			fakeTeamDB          *dbfakes.FakeTeamDB
 			signingKey          *rsa.PrivateKey
 			server              *httptest.Server
 			client              *http.Client
@@ -31,7 +36,9 @@ var _ = Describe("LogOutHandler", func() {
 
 		BeforeEach(func() {
 			fakeProviderFactory = new(authfakes.FakeProviderFactory)
-			fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
+			fakeTeamDBFactory = new(dbfakes.FakeTeamDBFactory)
+			fakeTeamDB = new(dbfakes.FakeTeamDB)
+			fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
 			signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
 			Expect(err).ToNot(HaveOccurred())
 			expire = 24 * time.Hour
@@ -40,7 +47,8 @@ var _ = Describe("LogOutHandler", func() {
 				lagertest.NewTestLogger("test"),
 				fakeProviderFactory,
 				fakeTeamDBFactory,
-				signingKey,
+				new(authfakes.FakeTeamsDB),
+				auth.SigningKeys{signingKey},
 				expire,
 			)
 			Expect(err).ToNot(HaveOccurred())
@@ -71,5 +79,113 @@ var _ = Describe("LogOutHandler", func() {
 			Expect(deletedCookie.Name).To(Equal(auth.CookieName))
 			Expect(deletedCookie.MaxAge).To(Equal(-1))
 		})
+
+		It("does not revoke anything without a session", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(fakeTeamDB.RevokeSessionCallCount()).To(BeZero())
+		})
+
+		Context("with a session", func() {
+			var sessionID string
+
+			BeforeEach(func() {
+				tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(
+					time.Now().Add(time.Hour),
+					"some-team",
+					false,
+					[]string{"some-team"},
+					"",
+					"some-session",
+					time.Now(),
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				token, err := jwt.Parse(string(tokenValue), func(*jwt.Token) (interface{}, error) {
+					return &signingKey.PublicKey, nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				sessionID = token.Claims.(jwt.MapClaims)["jti"].(string)
+
+				request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, tokenValue))
+			})
+
+			It("revokes the session", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeTeamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+				Expect(fakeTeamDB.RevokeSessionCallCount()).To(Equal(1))
+				Expect(fakeTeamDB.RevokeSessionArgsForCall(0)).To(Equal(sessionID))
+			})
+
+			Context("when revoking the session fails", func() {
+				BeforeEach(func() {
+					fakeTeamDB.RevokeSessionReturns(errors.New("nope"))
+				})
+
+				It("still deletes the cookie", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					Expect(response.Cookies()).To(HaveLen(1))
+				})
+			})
+		})
+
+		Context("with a session granting access to other teams", func() {
+			var sessionID string
+
+			BeforeEach(func() {
+				tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(
+					time.Now().Add(time.Hour),
+					"some-team",
+					false,
+					[]string{"some-team", "other-team"},
+					"",
+					"some-session",
+					time.Now(),
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				token, err := jwt.Parse(string(tokenValue), func(*jwt.Token) (interface{}, error) {
+					return &signingKey.PublicKey, nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				sessionID = token.Claims.(jwt.MapClaims)["jti"].(string)
+
+				request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, tokenValue))
+			})
+
+			It("revokes the session in every team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeTeamDBFactory.GetTeamDBCallCount()).To(Equal(2))
+				Expect(fakeTeamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+				Expect(fakeTeamDBFactory.GetTeamDBArgsForCall(1)).To(Equal("other-team"))
+				Expect(fakeTeamDB.RevokeSessionCallCount()).To(Equal(2))
+				Expect(fakeTeamDB.RevokeSessionArgsForCall(1)).To(Equal(sessionID))
+			})
+		})
+
+		Context("with a session signed by another key", func() {
+			BeforeEach(func() {
+				otherKey, err := rsa.GenerateKey(rand.Reader, 1024)
+				Expect(err).NotTo(HaveOccurred())
+
+				tokenType, tokenValue, err := auth.NewTokenGenerator(otherKey).GenerateToken(
+					time.Now().Add(time.Hour),
+					"some-team",
+					false,
+					[]string{"some-team"},
+					"",
+					"some-session",
+					time.Now(),
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, tokenValue))
+			})
+
+			It("does not revoke anything", func() {
+				Expect(fakeTeamDB.RevokeSessionCallCount()).To(BeZero())
+			})
+		})
 	})
 })
@@ -62,7 +62,8 @@ var _ = Describe("OAuthBeginHandler", func() {
 			lagertest.NewTestLogger("test"),
 			fakeProviderFactory,
 			fakeTeamDBFactory,
-			signingKey,
+			new(authfakes.FakeTeamsDB),
+			auth.SigningKeys{signingKey},
 			expire,
 		)
 		Expect(err).ToNot(HaveOccurred())
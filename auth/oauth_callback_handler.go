@@ -23,6 +23,7 @@ type OAuthCallbackHandler struct {
 	privateKey      *rsa.PrivateKey
 	tokenGenerator  TokenGenerator
 	teamDBFactory   db.TeamDBFactory
+	teamsDB         TeamsDB
 	expire          time.Duration
 }
 
@@ -31,6 +32,7 @@ func NewOAuthCallbackHandler(
 	providerFactory ProviderFactory,
 	privateKey *rsa.PrivateKey,
 	teamDBFactory db.TeamDBFactory,
+	teamsDB TeamsDB,
 	expire time.Duration,
 ) http.Handler {
 	return &OAuthCallbackHandler{
@@ -39,6 +41,7 @@ func NewOAuthCallbackHandler(
 		privateKey:      privateKey,
 		tokenGenerator:  NewTokenGenerator(privateKey),
 		teamDBFactory:   teamDBFactory,
+		teamsDB:         teamsDB,
 		expire:          expire,
 	}
 }
@@ -159,15 +162,43 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	var memberTeams []db.SavedTeam
+
+	allTeams, err := handler.teamsDB.GetTeams()
+	if err != nil {
+		// still let the user in to the team they verified against
+		hLog.Error("failed-to-get-teams", err)
+	} else {
+		memberTeams = OAuthTeams(hLog.Session("membership"), handler.providerFactory, allTeams, team, providerName, httpClient)
+	}
+
+	teams, isAdmin := Membership(team, memberTeams)
+
 	exp := time.Now().Add(handler.expire)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.Admin)
+	sessionID, err := NewSessionID()
+	if err != nil {
+		hLog.Error("failed-to-generate-session-id", err)
+		http.Error(w, "failed to generate session id", http.StatusInternalServerError)
+		return
+	}
+
+	issuedAt := time.Now()
+
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, isAdmin, teams, "", sessionID, issuedAt)
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
 		return
 	}
 
+	err = handler.teamDBFactory.GetTeamDB(team.Name).SaveSession(sessionID, "", issuedAt, exp)
+	if err != nil {
+		hLog.Error("failed-to-save-session", err)
+		http.Error(w, "failed to save session", http.StatusInternalServerError)
+		return
+	}
+
 	tokenStr := string(tokenType) + " " + string(signedToken)
 
 	http.SetCookie(w, &http.Cookie{
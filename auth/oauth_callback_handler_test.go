@@ -60,7 +60,8 @@ var _ = Describe("OAuthCallbackHandler", func() {
 
 		fakeProviderFactory *authfakes.FakeProviderFactory
 
-		fakeTeamDB *dbfakes.FakeTeamDB
+		fakeTeamDB  *dbfakes.FakeTeamDB
+		fakeTeamsDB *authfakes.FakeTeamsDB
 
 		signingKey *rsa.PrivateKey
 
@@ -103,11 +104,14 @@ var _ = Describe("OAuthCallbackHandler", func() {
 		fakeTeamDB.GetTeamReturns(team, true, nil)
 		fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
 
+		fakeTeamsDB = new(authfakes.FakeTeamsDB)
+
 		handler, err := auth.NewOAuthHandler(
 			lagertest.NewTestLogger("test"),
 			fakeProviderFactory,
 			fakeTeamDBFactory,
-			signingKey,
+			fakeTeamsDB,
+			auth.SigningKeys{signingKey},
 			expire,
 		)
 		Expect(err).ToNot(HaveOccurred())
@@ -279,6 +283,48 @@ var _ = Describe("OAuthCallbackHandler", func() {
 								Expect(claims["teamName"]).To(Equal(team.Name))
 								Expect(token.Valid).To(BeTrue())
 							})
+
+							It("contains the teams the user belongs to", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["teams"]).To(Equal([]interface{}{team.Name}))
+							})
+
+							It("records the token's session for the team", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+
+								Expect(fakeTeamDB.SaveSessionCallCount()).To(Equal(1))
+								sessionID, username, issuedAt, expiresAt := fakeTeamDB.SaveSessionArgsForCall(0)
+								Expect(sessionID).To(Equal(claims["jti"]))
+								Expect(username).To(BeEmpty())
+								Expect(issuedAt.Unix()).To(BeNumerically("==", claims["issuedAt"]))
+								Expect(expiresAt.Unix()).To(BeNumerically("==", claims["exp"]))
+							})
+						})
+
+						Context("when the session cannot be saved", func() {
+							BeforeEach(func() {
+								fakeTeamDB.SaveSessionReturns(errors.New("nope"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+
+						Context("when the teams cannot be listed", func() {
+							BeforeEach(func() {
+								fakeTeamsDB.GetTeamsReturns(nil, errors.New("nope"))
+							})
+
+							It("still logs in to the team", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							})
 						})
 
 						It("does not redirect", func() {
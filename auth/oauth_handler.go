@@ -1,7 +1,6 @@
 package auth
 
 import (
-	"crypto/rsa"
 	"net/http"
 	"time"
 
@@ -24,7 +23,8 @@ func NewOAuthHandler(
 	logger lager.Logger,
 	providerFactory ProviderFactory,
 	teamDBFactory db.TeamDBFactory,
-	signingKey *rsa.PrivateKey,
+	teamsDB TeamsDB,
+	signingKeys SigningKeys,
 	expire time.Duration,
 ) (http.Handler, error) {
 	return rata.NewRouter(
@@ -33,19 +33,22 @@ func NewOAuthHandler(
 			OAuthBegin: NewOAuthBeginHandler(
 				logger.Session("oauth-begin"),
 				providerFactory,
-				signingKey,
+				signingKeys.Current(),
 				teamDBFactory,
 				expire,
 			),
 			OAuthCallback: NewOAuthCallbackHandler(
 				logger.Session("oauth-callback"),
 				providerFactory,
-				signingKey,
+				signingKeys.Current(),
 				teamDBFactory,
+				teamsDB,
 				expire,
 			),
 			LogOut: NewLogOutHandler(
 				logger.Session("logout"),
+				signingKeys.PublicKeys(),
+				teamDBFactory,
 			),
 		},
 	)
@@ -0,0 +1,59 @@
+package oidc
+
+import (
+	"sync"
+	"time"
+)
+
+// CacheTTL is how long a discovery document or key set is reused before it
+// is fetched from the issuer again. Without it every team using the issuer,
+// and every login, would fetch both.
+var CacheTTL = 10 * time.Minute
+
+var (
+	discoveries = newCache()
+	keySets     = newCache()
+)
+
+type cache struct {
+	lock    sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value     interface{}
+	fetchedAt time.Time
+}
+
+func newCache() *cache {
+	return &cache{entries: map[string]cacheEntry{}}
+}
+
+// get returns the value cached under the key, calling fetch to replace it if
+// it is missing or older than CacheTTL. Failed fetches are not cached.
+func (c *cache) get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	c.lock.Lock()
+	entry, found := c.entries[key]
+	c.lock.Unlock()
+
+	if found && time.Since(entry.fetchedAt) < CacheTTL {
+		return entry.value, nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.entries[key] = cacheEntry{value: value, fetchedAt: time.Now()}
+	c.lock.Unlock()
+
+	return value, nil
+}
+
+func (c *cache) forget(key string) {
+	c.lock.Lock()
+	delete(c.entries, key)
+	c.lock.Unlock()
+}
@@ -0,0 +1,60 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type Discovery struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+// Discover fetches the issuer's provider metadata from its
+// .well-known/openid-configuration document.
+func Discover(client *http.Client, issuer string) (Discovery, error) {
+	configURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+
+	response, err := client.Get(configURL)
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return Discovery{}, fmt.Errorf("unexpected response from %s: %s", configURL, response.Status)
+	}
+
+	var discovery Discovery
+	err = json.NewDecoder(response.Body).Decode(&discovery)
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	if discovery.Issuer != issuer {
+		return Discovery{}, fmt.Errorf("issuer %q does not match configured issuer %q", discovery.Issuer, issuer)
+	}
+
+	if discovery.AuthURL == "" || discovery.TokenURL == "" || discovery.JWKSURL == "" {
+		return Discovery{}, fmt.Errorf("discovery document for %s is missing endpoints", issuer)
+	}
+
+	return discovery, nil
+}
+
+// CachedDiscover is Discover, reusing the issuer's metadata for CacheTTL.
+func CachedDiscover(client *http.Client, issuer string) (Discovery, error) {
+	discovery, err := discoveries.get(issuer, func() (interface{}, error) {
+		return Discover(client, issuer)
+	})
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	return discovery.(Discovery), nil
+}
@@ -0,0 +1,202 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/oauth2"
+)
+
+type IDTokenVerifier struct {
+	client   *http.Client
+	issuer   string
+	clientID string
+	jwksURL  string
+
+	users        []string
+	emailDomains []string
+	groups       []string
+	groupsClaim  string
+}
+
+func NewIDTokenVerifier(
+	client *http.Client,
+	issuer string,
+	clientID string,
+	jwksURL string,
+	users []string,
+	emailDomains []string,
+	groups []string,
+	groupsClaim string,
+) verifier.Verifier {
+	return IDTokenVerifier{
+		client:   client,
+		issuer:   issuer,
+		clientID: clientID,
+		jwksURL:  jwksURL,
+
+		users:        users,
+		emailDomains: emailDomains,
+		groups:       groups,
+		groupsClaim:  groupsClaim,
+	}
+}
+
+// Verify checks the signature, issuer, audience and expiry of the ID token
+// returned alongside the access token, and then whether its claims match
+// one of the permitted users, email domains or groups.
+func (verifier IDTokenVerifier) Verify(logger lager.Logger, httpClient *http.Client) (bool, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return false, errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return false, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return false, errors.New("token response does not contain an id_token")
+	}
+
+	keySet, err := CachedKeySet(verifier.client, verifier.jwksURL)
+	if err != nil {
+		return false, err
+	}
+
+	idToken, err := jwt.Parse(rawIDToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		keyID, _ := token.Header["kid"].(string)
+
+		key, err := keySet.PublicKey(keyID)
+		if err == nil {
+			return key, nil
+		}
+
+		// the issuer may have rotated its keys since they were cached
+		ForgetKeySet(verifier.jwksURL)
+
+		freshKeySet, fetchErr := CachedKeySet(verifier.client, verifier.jwksURL)
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+
+		return freshKeySet.PublicKey(keyID)
+	})
+	if err != nil {
+		logger.Info("invalid-id-token", lager.Data{"error": err.Error()})
+		return false, nil
+	}
+
+	claims, ok := idToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return false, errors.New("unexpected id_token claims")
+	}
+
+	if issuer, _ := claims["iss"].(string); issuer != verifier.issuer {
+		logger.Info("id-token-issuer-mismatch", lager.Data{
+			"have": claims["iss"],
+			"want": verifier.issuer,
+		})
+
+		return false, nil
+	}
+
+	if !containsString(stringsClaim(claims["aud"]), verifier.clientID) {
+		logger.Info("id-token-audience-mismatch", lager.Data{
+			"have": claims["aud"],
+			"want": verifier.clientID,
+		})
+
+		return false, nil
+	}
+
+	if verifier.isPermitted(claims) {
+		return true, nil
+	}
+
+	logger.Info("not-permitted", lager.Data{
+		"subject": claims["sub"],
+		"email":   claims["email"],
+	})
+
+	return false, nil
+}
+
+// isPermitted matches users on the subject, which unlike preferred_username
+// is unique and never reassigned by the issuer. The issuer is checked before
+// this, so the subject identifies a single account. Email addresses are only
+// trusted if the issuer says it has verified them.
+func (verifier IDTokenVerifier) isPermitted(claims jwt.MapClaims) bool {
+	subject, _ := claims["sub"].(string)
+
+	email, _ := claims["email"].(string)
+	if claims["email_verified"] != true {
+		email = ""
+	}
+
+	for _, user := range verifier.users {
+		if user == subject && subject != "" {
+			return true
+		}
+	}
+
+	if at := strings.LastIndex(email, "@"); at != -1 {
+		emailDomain := email[at+1:]
+
+		for _, domain := range verifier.emailDomains {
+			if strings.EqualFold(domain, emailDomain) {
+				return true
+			}
+		}
+	}
+
+	userGroups := stringsClaim(claims[verifier.groupsClaim])
+	for _, group := range verifier.groups {
+		if containsString(userGroups, group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stringsClaim reads a claim that may be either a single string or a list of
+// strings, like "aud".
+func stringsClaim(claim interface{}) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := []string{}
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+
+		return values
+	}
+
+	return nil
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
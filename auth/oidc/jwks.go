@@ -0,0 +1,109 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+type JSONWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use,omitempty"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+func FetchKeySet(client *http.Client, jwksURL string) (JSONWebKeySet, error) {
+	response, err := client.Get(jwksURL)
+	if err != nil {
+		return JSONWebKeySet{}, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return JSONWebKeySet{}, fmt.Errorf("unexpected response from %s: %s", jwksURL, response.Status)
+	}
+
+	var keySet JSONWebKeySet
+	err = json.NewDecoder(response.Body).Decode(&keySet)
+	if err != nil {
+		return JSONWebKeySet{}, err
+	}
+
+	return keySet, nil
+}
+
+// CachedKeySet is FetchKeySet, reusing the key set for CacheTTL.
+func CachedKeySet(client *http.Client, jwksURL string) (JSONWebKeySet, error) {
+	keySet, err := keySets.get(jwksURL, func() (interface{}, error) {
+		return FetchKeySet(client, jwksURL)
+	})
+	if err != nil {
+		return JSONWebKeySet{}, err
+	}
+
+	return keySet.(JSONWebKeySet), nil
+}
+
+// ForgetKeySet drops the cached key set, so that keys the issuer has rotated
+// in since it was fetched are picked up.
+func ForgetKeySet(jwksURL string) {
+	keySets.forget(jwksURL)
+}
+
+// PublicKey returns the RSA signing key with the given ID. If no ID is given
+// and there is only one signing key, that key is returned.
+func (keySet JSONWebKeySet) PublicKey(keyID string) (*rsa.PublicKey, error) {
+	var candidates []JSONWebKey
+	for _, key := range keySet.Keys {
+		if key.KeyType != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+
+		if keyID == "" || key.KeyID == keyID {
+			candidates = append(candidates, key)
+		}
+	}
+
+	if len(candidates) != 1 {
+		return nil, fmt.Errorf("no unique signing key found for key ID %q", keyID)
+	}
+
+	return candidates[0].RSAPublicKey()
+}
+
+func (key JSONWebKey) RSAPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(e) == 0 || len(e) > 3 {
+		return nil, errors.New("key has an unsupported exponent")
+	}
+
+	var exponent int
+	for _, b := range e {
+		exponent = exponent<<8 | int(b)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: exponent,
+	}, nil
+}
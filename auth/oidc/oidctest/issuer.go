@@ -0,0 +1,161 @@
+// Package oidctest provides a fake OpenID Connect issuer for tests.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/dgrijalva/jwt-go"
+)
+
+const KeyID = "some-key-id"
+
+// Issuer serves a discovery document, a key set and a token endpoint. The
+// token endpoint accepts any code and returns an ID token with the claims set
+// through SetIDTokenClaims, signed with the issuer's key, or the raw token
+// set through SetRawIDToken.
+type Issuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	claimsLock sync.Mutex
+	claims     jwt.MapClaims
+	rawIDToken string
+
+	requestsLock      sync.Mutex
+	discoveryRequests int
+	keySetRequests    int
+}
+
+func NewIssuer() *Issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	issuer := &Issuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.serveDiscovery)
+	mux.HandleFunc("/keys", issuer.serveKeys)
+	mux.HandleFunc("/token", issuer.serveToken)
+
+	issuer.server = httptest.NewServer(mux)
+
+	return issuer
+}
+
+func (issuer *Issuer) URL() string {
+	return issuer.server.URL
+}
+
+func (issuer *Issuer) Close() {
+	issuer.server.Close()
+}
+
+func (issuer *Issuer) SetIDTokenClaims(claims jwt.MapClaims) {
+	issuer.claimsLock.Lock()
+	issuer.claims = claims
+	issuer.rawIDToken = ""
+	issuer.claimsLock.Unlock()
+}
+
+func (issuer *Issuer) SetRawIDToken(rawIDToken string) {
+	issuer.claimsLock.Lock()
+	issuer.claims = nil
+	issuer.rawIDToken = rawIDToken
+	issuer.claimsLock.Unlock()
+}
+
+// SignIDToken signs the claims with the issuer's key, or with the given key
+// to simulate a forged token.
+func (issuer *Issuer) SignIDToken(claims jwt.MapClaims, key *rsa.PrivateKey) string {
+	if key == nil {
+		key = issuer.key
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = KeyID
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		panic(err)
+	}
+
+	return signed
+}
+
+// DiscoveryRequests returns how many times the discovery document has been
+// fetched.
+func (issuer *Issuer) DiscoveryRequests() int {
+	issuer.requestsLock.Lock()
+	defer issuer.requestsLock.Unlock()
+	return issuer.discoveryRequests
+}
+
+// KeySetRequests returns how many times the key set has been fetched.
+func (issuer *Issuer) KeySetRequests() int {
+	issuer.requestsLock.Lock()
+	defer issuer.requestsLock.Unlock()
+	return issuer.keySetRequests
+}
+
+func (issuer *Issuer) serveDiscovery(w http.ResponseWriter, r *http.Request) {
+	issuer.requestsLock.Lock()
+	issuer.discoveryRequests++
+	issuer.requestsLock.Unlock()
+
+	json.NewEncoder(w).Encode(oidc.Discovery{
+		Issuer:   issuer.server.URL,
+		AuthURL:  issuer.server.URL + "/authorize",
+		TokenURL: issuer.server.URL + "/token",
+		JWKSURL:  issuer.server.URL + "/keys",
+	})
+}
+
+func (issuer *Issuer) serveKeys(w http.ResponseWriter, r *http.Request) {
+	issuer.requestsLock.Lock()
+	issuer.keySetRequests++
+	issuer.requestsLock.Unlock()
+
+	json.NewEncoder(w).Encode(oidc.JSONWebKeySet{
+		Keys: []oidc.JSONWebKey{
+			{
+				KeyType: "RSA",
+				KeyID:   KeyID,
+				Use:     "sig",
+				N:       base64.RawURLEncoding.EncodeToString(issuer.key.N.Bytes()),
+				E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(issuer.key.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func (issuer *Issuer) serveToken(w http.ResponseWriter, r *http.Request) {
+	issuer.claimsLock.Lock()
+	claims := issuer.claims
+	rawIDToken := issuer.rawIDToken
+	issuer.claimsLock.Unlock()
+
+	response := map[string]interface{}{
+		"access_token": "some-access-token",
+		"token_type":   "bearer",
+		"expires_in":   3600,
+	}
+
+	if claims != nil {
+		response["id_token"] = issuer.SignIDToken(claims, nil)
+	} else if rawIDToken != "" {
+		response["id_token"] = rawIDToken
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
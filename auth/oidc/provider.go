@@ -0,0 +1,89 @@
+package oidc
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/concourse/atc/db"
+	"golang.org/x/oauth2"
+)
+
+const ProviderName = "oidc"
+
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+const DefaultGroupsClaim = "groups"
+
+// RequestTimeout bounds each request to the issuer, so that an unresponsive
+// issuer cannot hold up logins indefinitely.
+const RequestTimeout = 10 * time.Second
+
+// NewProvider discovers the endpoints of the team's OpenID Connect issuer and
+// returns a provider that only lets in users whose ID token is signed by the
+// issuer and matches the team's users, email domains or groups.
+func NewProvider(
+	oidcAuth *db.OIDCAuth,
+	redirectURL string,
+) (Provider, error) {
+	client := preTokenClient()
+
+	discovery, err := CachedDiscover(client, oidcAuth.Issuer)
+	if err != nil {
+		return Provider{}, err
+	}
+
+	scopes := append([]string{}, DefaultScopes...)
+	scopes = append(scopes, oidcAuth.Scopes...)
+
+	groupsClaim := oidcAuth.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = DefaultGroupsClaim
+	}
+
+	return Provider{
+		Verifier: NewIDTokenVerifier(
+			client,
+			discovery.Issuer,
+			oidcAuth.ClientID,
+			discovery.JWKSURL,
+			oidcAuth.Users,
+			oidcAuth.EmailDomains,
+			oidcAuth.Groups,
+			groupsClaim,
+		),
+		Config: &oauth2.Config{
+			ClientID:     oidcAuth.ClientID,
+			ClientSecret: oidcAuth.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  discovery.AuthURL,
+				TokenURL: discovery.TokenURL,
+			},
+			Scopes:      scopes,
+			RedirectURL: redirectURL,
+		},
+	}, nil
+}
+
+type Provider struct {
+	*oauth2.Config
+	// oauth2.Config implements the required Provider methods:
+	// AuthCodeURL(string, ...oauth2.AuthCodeOption) string
+	// Exchange(context.Context, string) (*oauth2.Token, error)
+	// Client(context.Context, *oauth2.Token) *http.Client
+
+	verifier.Verifier
+}
+
+func (Provider) PreTokenClient() (*http.Client, error) {
+	return preTokenClient(), nil
+}
+
+func preTokenClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+		},
+		Timeout: RequestTimeout,
+	}
+}
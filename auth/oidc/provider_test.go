@@ -0,0 +1,310 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/auth/oidc/oidctest"
+	"github.com/concourse/atc/db"
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OIDC Provider", func() {
+	var (
+		issuer   *oidctest.Issuer
+		oidcAuth *db.OIDCAuth
+
+		oidcProvider oidc.Provider
+		providerErr  error
+	)
+
+	BeforeEach(func() {
+		issuer = oidctest.NewIssuer()
+
+		oidcAuth = &db.OIDCAuth{
+			Issuer:       issuer.URL(),
+			ClientID:     "some-client-id",
+			ClientSecret: "some-client-secret",
+			Users:        []string{"some-user"},
+			EmailDomains: []string{"example.com"},
+			Groups:       []string{"some-group"},
+		}
+	})
+
+	AfterEach(func() {
+		issuer.Close()
+	})
+
+	JustBeforeEach(func() {
+		oidcProvider, providerErr = oidc.NewProvider(oidcAuth, "https://atc.example.com/auth/oidc/callback")
+	})
+
+	It("discovers the issuer's endpoints", func() {
+		Expect(providerErr).NotTo(HaveOccurred())
+		Expect(oidcProvider.Config.Endpoint.AuthURL).To(Equal(issuer.URL() + "/authorize"))
+		Expect(oidcProvider.Config.Endpoint.TokenURL).To(Equal(issuer.URL() + "/token"))
+	})
+
+	It("asks for the openid scope", func() {
+		authURL := oidcProvider.AuthCodeURL("some-state")
+		Expect(authURL).To(ContainSubstring("scope=openid+email+profile"))
+		Expect(authURL).To(ContainSubstring("state=some-state"))
+	})
+
+	It("constructs HTTP client with disable keep alive context", func() {
+		httpClient, err := oidcProvider.PreTokenClient()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(httpClient.Transport.(*http.Transport).DisableKeepAlives).To(BeTrue())
+	})
+
+	It("reuses the discovery document for other teams using the same issuer", func() {
+		_, err := oidc.NewProvider(oidcAuth, "https://atc.example.com/auth/oidc/callback")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(issuer.DiscoveryRequests()).To(Equal(1))
+	})
+
+	It("gives up on requests to the issuer that take too long", func() {
+		httpClient, err := oidcProvider.PreTokenClient()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(httpClient.Timeout).To(Equal(oidc.RequestTimeout))
+	})
+
+	Context("when the issuer does not match the discovery document", func() {
+		BeforeEach(func() {
+			oidcAuth.Issuer = issuer.URL() + "/"
+		})
+
+		It("errors", func() {
+			Expect(providerErr).To(HaveOccurred())
+		})
+	})
+
+	Context("when the issuer cannot be reached", func() {
+		BeforeEach(func() {
+			oidcAuth.Issuer = "http://127.0.0.1:1"
+		})
+
+		It("errors", func() {
+			Expect(providerErr).To(HaveOccurred())
+		})
+	})
+
+	Describe("Verify", func() {
+		var claims jwt.MapClaims
+
+		var verified bool
+		var verifyErr error
+
+		BeforeEach(func() {
+			claims = jwt.MapClaims{
+				"iss":   issuer.URL(),
+				"aud":   "some-client-id",
+				"sub":   "some-subject",
+				"exp":   time.Now().Add(time.Hour).Unix(),
+				"email": "someone@elsewhere.com",
+			}
+
+			issuer.SetIDTokenClaims(claims)
+		})
+
+		JustBeforeEach(func() {
+			Expect(providerErr).NotTo(HaveOccurred())
+
+			ctx := context.WithValue(oauth2.NoContext, oauth2.HTTPClient, http.DefaultClient)
+
+			token, err := oidcProvider.Exchange(ctx, "some-code")
+			Expect(err).NotTo(HaveOccurred())
+
+			verified, verifyErr = oidcProvider.Verify(lagertest.NewTestLogger("test"), oidcProvider.Client(ctx, token))
+		})
+
+		Context("when the user is not permitted", func() {
+			It("does not verify", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+
+		Context("when the user is permitted by subject", func() {
+			BeforeEach(func() {
+				claims["sub"] = "some-user"
+			})
+
+			It("verifies", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeTrue())
+			})
+		})
+
+		Context("when only the user's preferred username is permitted", func() {
+			BeforeEach(func() {
+				claims["preferred_username"] = "some-user"
+			})
+
+			It("does not verify", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+
+		Context("when the user's email is in a permitted domain", func() {
+			BeforeEach(func() {
+				claims["email"] = "someone@example.com"
+				claims["email_verified"] = true
+			})
+
+			It("verifies", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeTrue())
+			})
+
+			Context("but the issuer does not say whether the email is verified", func() {
+				BeforeEach(func() {
+					delete(claims, "email_verified")
+				})
+
+				It("does not verify", func() {
+					Expect(verifyErr).NotTo(HaveOccurred())
+					Expect(verified).To(BeFalse())
+				})
+			})
+
+			Context("but the email is not verified", func() {
+				BeforeEach(func() {
+					claims["email_verified"] = false
+				})
+
+				It("does not verify", func() {
+					Expect(verifyErr).NotTo(HaveOccurred())
+					Expect(verified).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the user is in a permitted group", func() {
+			BeforeEach(func() {
+				claims["groups"] = []string{"other-group", "some-group"}
+			})
+
+			It("verifies", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeTrue())
+			})
+
+			Context("when groups come from a different claim", func() {
+				BeforeEach(func() {
+					oidcAuth.GroupsClaim = "roles"
+				})
+
+				It("does not verify", func() {
+					Expect(verifyErr).NotTo(HaveOccurred())
+					Expect(verified).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the audience lists the client among others", func() {
+			BeforeEach(func() {
+				claims["sub"] = "some-user"
+				claims["aud"] = []string{"other-client-id", "some-client-id"}
+			})
+
+			It("verifies", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeTrue())
+			})
+		})
+
+		Context("when the token was issued for another client", func() {
+			BeforeEach(func() {
+				claims["sub"] = "some-user"
+				claims["aud"] = "other-client-id"
+			})
+
+			It("does not verify", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+
+		Context("when the token was issued by another issuer", func() {
+			BeforeEach(func() {
+				claims["sub"] = "some-user"
+				claims["iss"] = "https://evil.example.com"
+			})
+
+			It("does not verify", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+
+		Context("when the token has expired", func() {
+			BeforeEach(func() {
+				claims["sub"] = "some-user"
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			})
+
+			It("does not verify", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+
+		Context("when the token is not signed by the issuer", func() {
+			BeforeEach(func() {
+				claims["sub"] = "some-user"
+
+				forgingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				Expect(err).NotTo(HaveOccurred())
+
+				issuer.SetRawIDToken(issuer.SignIDToken(claims, forgingKey))
+			})
+
+			It("does not verify", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+
+		Context("when users log in repeatedly", func() {
+			BeforeEach(func() {
+				claims["sub"] = "some-user"
+			})
+
+			It("reuses the issuer's keys", func() {
+				ctx := context.WithValue(oauth2.NoContext, oauth2.HTTPClient, http.DefaultClient)
+
+				token, err := oidcProvider.Exchange(ctx, "some-code")
+				Expect(err).NotTo(HaveOccurred())
+
+				verified, err := oidcProvider.Verify(lagertest.NewTestLogger("test"), oidcProvider.Client(ctx, token))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(verified).To(BeTrue())
+
+				Expect(issuer.KeySetRequests()).To(Equal(1))
+			})
+		})
+
+		Context("when no ID token is returned", func() {
+			BeforeEach(func() {
+				issuer.SetIDTokenClaims(nil)
+			})
+
+			It("errors", func() {
+				Expect(verifyErr).To(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+	})
+})
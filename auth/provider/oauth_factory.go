@@ -5,6 +5,8 @@ import (
 	"code.cloudfoundry.org/urljoiner"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/gitlab"
+	"github.com/concourse/atc/auth/oidc"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 	"github.com/tedsuo/rata"
@@ -43,6 +45,13 @@ func (of OAuthFactory) GetProvider(team db.SavedTeam, providerName string) (Prov
 
 		return github.NewProvider(team.GitHubAuth, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
 
+	case gitlab.ProviderName:
+		if team.GitLabAuth == nil {
+			return nil, false, nil
+		}
+
+		return gitlab.NewProvider(team.GitLabAuth, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
+
 	case uaa.ProviderName:
 		if team.UAAAuth == nil {
 			of.logger.Error("failed-to-construct-redirect-url", err, lager.Data{"provider": providerName})
@@ -58,6 +67,18 @@ func (of OAuthFactory) GetProvider(team db.SavedTeam, providerName string) (Prov
 
 		return genericoauth.NewProvider(team.GenericOAuth, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
 
+	case oidc.ProviderName:
+		if team.OIDCAuth == nil {
+			return nil, false, nil
+		}
+
+		oidcProvider, err := oidc.NewProvider(team.OIDCAuth, urljoiner.Join(of.atcExternalURL, redirectURL))
+		if err != nil {
+			of.logger.Error("failed-to-construct-oidc-provider", err, lager.Data{"issuer": team.OIDCAuth.Issuer})
+			return nil, false, err
+		}
+
+		return oidcProvider, true, nil
 	}
 
 	return nil, false, nil
@@ -5,6 +5,9 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/gitlab"
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/auth/oidc/oidctest"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
@@ -89,6 +92,38 @@ var _ = Describe("OAuthFactory", func() {
 			})
 		})
 
+		Context("when asking for gitlab provider", func() {
+			Context("when gitlab provider is setup", func() {
+				It("returns back GitLab's auth provider", func() {
+					provider, found, err := oauthFactory.GetProvider(db.SavedTeam{
+						Team: db.Team{
+							Name: "some-team",
+							GitLabAuth: &db.GitLabAuth{
+								ClientID:     "user1",
+								ClientSecret: "password1",
+								Groups:       []string{"some-group"},
+							},
+						},
+					}, gitlab.ProviderName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(provider).NotTo(BeNil())
+				})
+			})
+
+			Context("when gitlab provider is not setup", func() {
+				It("returns false", func() {
+					_, found, err := oauthFactory.GetProvider(db.SavedTeam{
+						Team: db.Team{
+							Name: "some-team",
+						},
+					}, gitlab.ProviderName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when asking for generic oauth", func() {
 			Context("when Generic OAuth provider is setup", func() {
 				It("returns back GOA's auth provider", func() {
@@ -120,6 +155,65 @@ var _ = Describe("OAuthFactory", func() {
 			})
 		})
 
+		Context("when asking for oidc", func() {
+			var issuer *oidctest.Issuer
+
+			BeforeEach(func() {
+				issuer = oidctest.NewIssuer()
+			})
+
+			AfterEach(func() {
+				issuer.Close()
+			})
+
+			Context("when OIDC provider is setup", func() {
+				It("returns back the OIDC auth provider", func() {
+					provider, found, err := oauthFactory.GetProvider(db.SavedTeam{
+						Team: db.Team{
+							Name: "some-team",
+							OIDCAuth: &db.OIDCAuth{
+								Issuer:       issuer.URL(),
+								ClientID:     "user1",
+								ClientSecret: "password1",
+								Groups:       []string{"some-group"},
+							},
+						},
+					}, oidc.ProviderName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(provider).NotTo(BeNil())
+				})
+
+				Context("when the issuer cannot be discovered", func() {
+					It("returns an error", func() {
+						_, _, err := oauthFactory.GetProvider(db.SavedTeam{
+							Team: db.Team{
+								Name: "some-team",
+								OIDCAuth: &db.OIDCAuth{
+									Issuer:       issuer.URL() + "/bogus",
+									ClientID:     "user1",
+									ClientSecret: "password1",
+								},
+							},
+						}, oidc.ProviderName)
+						Expect(err).To(HaveOccurred())
+					})
+				})
+			})
+
+			Context("when OIDC provider is not setup", func() {
+				It("returns false", func() {
+					_, found, err := oauthFactory.GetProvider(db.SavedTeam{
+						Team: db.Team{
+							Name: "some-team",
+						},
+					}, oidc.ProviderName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when asking for unknown provider", func() {
 			It("returns false", func() {
 				_, found, err := oauthFactory.GetProvider(db.SavedTeam{
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"github.com/concourse/atc/auth/genericoauth"
+	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/gitlab"
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/auth/uaa"
+	"github.com/concourse/atc/db"
+)
+
+// SameIdentityProvider reports whether both teams configure the provider
+// against the same OAuth client and endpoints, so that an access token issued
+// when logging in to one can be checked against the other's rules without
+// handing it to a server the user never logged in to.
+func SameIdentityProvider(providerName string, team db.SavedTeam, otherTeam db.SavedTeam) bool {
+	switch providerName {
+	case github.ProviderName:
+		a, b := team.GitHubAuth, otherTeam.GitHubAuth
+		return a != nil && b != nil &&
+			a.ClientID == b.ClientID &&
+			a.AuthURL == b.AuthURL &&
+			a.TokenURL == b.TokenURL &&
+			a.APIURL == b.APIURL
+
+	case gitlab.ProviderName:
+		a, b := team.GitLabAuth, otherTeam.GitLabAuth
+		return a != nil && b != nil &&
+			a.ClientID == b.ClientID &&
+			a.AuthURL == b.AuthURL &&
+			a.TokenURL == b.TokenURL &&
+			a.APIURL == b.APIURL
+
+	case uaa.ProviderName:
+		a, b := team.UAAAuth, otherTeam.UAAAuth
+		return a != nil && b != nil &&
+			a.ClientID == b.ClientID &&
+			a.AuthURL == b.AuthURL &&
+			a.TokenURL == b.TokenURL &&
+			a.CFURL == b.CFURL &&
+			a.CFCACert == b.CFCACert
+
+	case genericoauth.ProviderName:
+		a, b := team.GenericOAuth, otherTeam.GenericOAuth
+		return a != nil && b != nil &&
+			a.ClientID == b.ClientID &&
+			a.AuthURL == b.AuthURL &&
+			a.TokenURL == b.TokenURL
+
+	case oidc.ProviderName:
+		a, b := team.OIDCAuth, otherTeam.OIDCAuth
+		return a != nil && b != nil &&
+			a.ClientID == b.ClientID &&
+			a.Issuer == b.Issuer
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package provider_test
+
+import (
+	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/auth/provider"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SameIdentityProvider", func() {
+	var team, otherTeam db.SavedTeam
+
+	BeforeEach(func() {
+		team = db.SavedTeam{
+			Team: db.Team{
+				Name: "some-team",
+				GitHubAuth: &db.GitHubAuth{
+					ClientID:      "some-client",
+					ClientSecret:  "some-secret",
+					Organizations: []string{"some-org"},
+				},
+			},
+		}
+
+		otherTeam = db.SavedTeam{
+			Team: db.Team{
+				Name: "other-team",
+				GitHubAuth: &db.GitHubAuth{
+					ClientID:     "some-client",
+					ClientSecret: "some-secret",
+					Users:        []string{"some-user"},
+				},
+			},
+		}
+	})
+
+	It("matches teams using the same client, whatever their rules", func() {
+		Expect(provider.SameIdentityProvider(github.ProviderName, team, otherTeam)).To(BeTrue())
+	})
+
+	It("does not match teams using another client", func() {
+		otherTeam.GitHubAuth.ClientID = "other-client"
+		Expect(provider.SameIdentityProvider(github.ProviderName, team, otherTeam)).To(BeFalse())
+	})
+
+	It("does not match teams sending tokens to another API", func() {
+		otherTeam.GitHubAuth.APIURL = "https://evil.example.com/"
+		Expect(provider.SameIdentityProvider(github.ProviderName, team, otherTeam)).To(BeFalse())
+	})
+
+	It("does not match teams without the provider", func() {
+		otherTeam.GitHubAuth = nil
+		Expect(provider.SameIdentityProvider(github.ProviderName, team, otherTeam)).To(BeFalse())
+	})
+
+	It("does not match other providers", func() {
+		Expect(provider.SameIdentityProvider(oidc.ProviderName, team, otherTeam)).To(BeFalse())
+	})
+})
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+//go:generate counterfeiter . RevocationDB
+
+type RevocationDB interface {
+	IsSessionRevoked(teamName string, sessionID string, username string, issuedAt time.Time) (bool, error)
+}
+
+// isRevoked reports whether the token's session has been revoked. Tokens
+// that are not issued to a team, like system tokens, are never revoked, and
+// tokens are treated as revoked if revocations cannot be checked.
+func isRevoked(token *jwt.Token, revocationDB RevocationDB) bool {
+	if revocationDB == nil {
+		return false
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return true
+	}
+
+	teamNames := tokenTeams(claims)
+	if len(teamNames) == 0 {
+		return false
+	}
+
+	sessionID, _ := claims[sessionIDClaimKey].(string)
+	username, _ := claims[usernameClaimKey].(string)
+
+	// tokens issued before sessions had issue times are only revoked by
+	// revoking all of the team's sessions
+	var issuedAt time.Time
+	if unix, ok := claims[issuedAtClaimKey].(float64); ok {
+		issuedAt = time.Unix(int64(unix), 0)
+	}
+
+	// the token grants access to every team it lists, so revoking the session
+	// in any of them revokes it everywhere. the username is only meaningful to
+	// the team the user logged in to.
+	for i, teamName := range teamNames {
+		teamUsername := ""
+		if i == 0 {
+			teamUsername = username
+		}
+
+		revoked, err := revocationDB.IsSessionRevoked(teamName, sessionID, teamUsername, issuedAt)
+		if err != nil || revoked {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tokenTeams returns the team the token was issued by followed by the other
+// teams it grants access to.
+func tokenTeams(claims jwt.MapClaims) []string {
+	teamName, ok := claims[teamNameClaimKey].(string)
+	if !ok {
+		return nil
+	}
+
+	teams := []string{teamName}
+
+	teamsInterface, _ := claims[teamsClaimKey].([]interface{})
+	for _, teamInterface := range teamsInterface {
+		team, ok := teamInterface.(string)
+		if ok && team != teamName {
+			teams = append(teams, team)
+		}
+	}
+
+	return teams
+}
@@ -0,0 +1,229 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+)
+
+var _ = Describe("Session revocation", func() {
+	var (
+		signingKey       *rsa.PrivateKey
+		fakeRevocationDB *authfakes.FakeRevocationDB
+		request          *http.Request
+		token            *jwt.Token
+	)
+
+	BeforeEach(func() {
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeRevocationDB = new(authfakes.FakeRevocationDB)
+
+		tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(
+			time.Now().Add(time.Hour),
+			"some-team",
+			false,
+			[]string{"some-team"},
+			"",
+			"some-session",
+			time.Now(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, tokenValue))
+
+		token, err = jwt.Parse(string(tokenValue), func(*jwt.Token) (interface{}, error) {
+			return &signingKey.PublicKey, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("generated tokens", func() {
+		It("carry the ID and issue time of their session", func() {
+			claims := token.Claims.(jwt.MapClaims)
+			Expect(claims["jti"]).To(Equal("some-session"))
+			Expect(claims["issuedAt"]).To(BeNumerically("~", time.Now().Unix(), 5))
+		})
+	})
+
+	Describe("NewSessionID", func() {
+		It("generates distinct IDs", func() {
+			sessionID, err := auth.NewSessionID()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessionID).NotTo(BeEmpty())
+
+			otherSessionID, err := auth.NewSessionID()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherSessionID).NotTo(Equal(sessionID))
+		})
+	})
+
+	Describe("JWTValidator", func() {
+		var validator auth.JWTValidator
+
+		BeforeEach(func() {
+			validator = auth.JWTValidator{
+				PublicKeys:   auth.PublicKeys{&signingKey.PublicKey},
+				RevocationDB: fakeRevocationDB,
+			}
+		})
+
+		It("authenticates sessions that have not been revoked", func() {
+			Expect(validator.IsAuthenticated(request)).To(BeTrue())
+
+			teamName, sessionID, username, issuedAt := fakeRevocationDB.IsSessionRevokedArgsForCall(0)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(sessionID).To(Equal(token.Claims.(jwt.MapClaims)["jti"]))
+			Expect(username).To(BeEmpty())
+			Expect(issuedAt).To(BeTemporally("~", time.Now(), 5*time.Second))
+		})
+
+		It("does not authenticate revoked sessions", func() {
+			fakeRevocationDB.IsSessionRevokedReturns(true, nil)
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+		})
+
+		It("does not authenticate sessions whose revocation cannot be checked", func() {
+			fakeRevocationDB.IsSessionRevokedReturns(false, errors.New("nope"))
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+		})
+
+		Context("with a token granting access to other teams", func() {
+			BeforeEach(func() {
+				tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(
+					time.Now().Add(time.Hour),
+					"some-team",
+					false,
+					[]string{"some-team", "other-team"},
+					"",
+					"some-session",
+					time.Now(),
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, tokenValue))
+			})
+
+			It("checks for revocation in every team", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeTrue())
+				Expect(fakeRevocationDB.IsSessionRevokedCallCount()).To(Equal(2))
+
+				teamName, _, _, _ := fakeRevocationDB.IsSessionRevokedArgsForCall(0)
+				Expect(teamName).To(Equal("some-team"))
+
+				teamName, _, _, _ = fakeRevocationDB.IsSessionRevokedArgsForCall(1)
+				Expect(teamName).To(Equal("other-team"))
+			})
+
+			It("does not authenticate sessions revoked by any of the teams", func() {
+				fakeRevocationDB.IsSessionRevokedStub = func(teamName string, sessionID string, username string, issuedAt time.Time) (bool, error) {
+					return teamName == "other-team", nil
+				}
+
+				Expect(validator.IsAuthenticated(request)).To(BeFalse())
+			})
+		})
+
+		Context("with a token issued to a user", func() {
+			BeforeEach(func() {
+				tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(
+					time.Now().Add(time.Hour),
+					"some-team",
+					false,
+					[]string{"some-team", "other-team"},
+					"some-user",
+					"some-session",
+					time.Now(),
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, tokenValue))
+			})
+
+			It("checks for the user's revocations in the team they logged in to", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeTrue())
+
+				teamName, _, username, _ := fakeRevocationDB.IsSessionRevokedArgsForCall(0)
+				Expect(teamName).To(Equal("some-team"))
+				Expect(username).To(Equal("some-user"))
+
+				teamName, _, username, _ = fakeRevocationDB.IsSessionRevokedArgsForCall(1)
+				Expect(teamName).To(Equal("other-team"))
+				Expect(username).To(BeEmpty())
+			})
+		})
+
+		Context("with a token that is not issued to a team", func() {
+			BeforeEach(func() {
+				systemToken := jwt.NewWithClaims(auth.SigningMethod, jwt.MapClaims{
+					"exp":    time.Now().Add(time.Hour).Unix(),
+					"system": true,
+				})
+
+				signed, err := systemToken.SignedString(signingKey)
+				Expect(err).NotTo(HaveOccurred())
+
+				request.Header.Set("Authorization", "Bearer "+signed)
+			})
+
+			It("does not check for revocation", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeTrue())
+				Expect(fakeRevocationDB.IsSessionRevokedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("JWTReader", func() {
+		var reader auth.JWTReader
+
+		BeforeEach(func() {
+			reader = auth.JWTReader{
+				PublicKeys:   auth.PublicKeys{&signingKey.PublicKey},
+				RevocationDB: fakeRevocationDB,
+			}
+		})
+
+		It("reads the team of sessions that have not been revoked", func() {
+			teamName, isAdmin, found := reader.GetTeam(request)
+			Expect(found).To(BeTrue())
+			Expect(teamName).To(Equal("some-team"))
+			Expect(isAdmin).To(BeFalse())
+		})
+
+		It("does not read the team of revoked sessions", func() {
+			fakeRevocationDB.IsSessionRevokedReturns(true, nil)
+
+			_, _, found := reader.GetTeam(request)
+			Expect(found).To(BeFalse())
+		})
+
+		It("reads the session of the token", func() {
+			sessionID, issuedAt, found := reader.GetSession(request)
+			Expect(found).To(BeTrue())
+			Expect(sessionID).To(Equal("some-session"))
+			Expect(issuedAt).To(BeTemporally("~", time.Now(), 5*time.Second))
+		})
+
+		It("does not read the session of revoked sessions", func() {
+			fakeRevocationDB.IsSessionRevokedReturns(true, nil)
+
+			_, _, found := reader.GetSession(request)
+			Expect(found).To(BeFalse())
+		})
+	})
+})
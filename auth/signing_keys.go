@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/dgrijalva/jwt-go"
+)
+
+const keyIDHeader = "kid"
+
+var errNoKeyID = errors.New("token does not name its signing key")
+
+// SigningKeys are the keys session tokens are signed with. New tokens are
+// signed with the first key, and tokens signed with any of them are accepted,
+// so that keys can be rotated without logging everyone out.
+type SigningKeys []*rsa.PrivateKey
+
+func (keys SigningKeys) Current() *rsa.PrivateKey {
+	return keys[0]
+}
+
+func (keys SigningKeys) PublicKeys() PublicKeys {
+	publicKeys := PublicKeys{}
+	for _, key := range keys {
+		publicKeys = append(publicKeys, &key.PublicKey)
+	}
+
+	return publicKeys
+}
+
+// PublicKeys verify session tokens against the key named in their header.
+type PublicKeys []*rsa.PublicKey
+
+// Parse parses and verifies a token. Tokens issued before keys had IDs do not
+// name their key, so they are checked against each key in turn.
+func (keys PublicKeys) Parse(tokenString string) (*jwt.Token, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		err := checkSigningMethod(token)
+		if err != nil {
+			return nil, err
+		}
+
+		keyID, _ := token.Header[keyIDHeader].(string)
+		if keyID == "" {
+			return nil, errNoKeyID
+		}
+
+		for _, key := range keys {
+			if KeyID(key) == keyID {
+				return key, nil
+			}
+		}
+
+		return nil, fmt.Errorf("unknown signing key: %s", keyID)
+	})
+
+	if validationErr, ok := err.(*jwt.ValidationError); ok && validationErr.Inner == errNoKeyID {
+		for _, key := range keys {
+			token, err = jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+				err := checkSigningMethod(token)
+				if err != nil {
+					return nil, err
+				}
+
+				return key, nil
+			})
+			if err == nil {
+				break
+			}
+		}
+	}
+
+	return token, err
+}
+
+func checkSigningMethod(token *jwt.Token) error {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return nil
+}
+
+// JWKS returns the keys as a JSON Web Key Set, so that other services can
+// verify session tokens.
+func (keys PublicKeys) JWKS() oidc.JSONWebKeySet {
+	keySet := oidc.JSONWebKeySet{Keys: []oidc.JSONWebKey{}}
+	for _, key := range keys {
+		keySet.Keys = append(keySet.Keys, jsonWebKey(key))
+	}
+
+	return keySet
+}
+
+// KeyID identifies a key by its JWK thumbprint (RFC 7638), so that the same
+// key file gets the same ID on every ATC.
+func KeyID(key *rsa.PublicKey) string {
+	return jsonWebKey(key).KeyID
+}
+
+func jsonWebKey(key *rsa.PublicKey) oidc.JSONWebKey {
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+
+	// required members in lexicographic order, without whitespace
+	thumbprint := sha256.Sum256([]byte(fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, e, n)))
+
+	return oidc.JSONWebKey{
+		KeyType: "RSA",
+		KeyID:   base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+		Use:     "sig",
+		N:       n,
+		E:       e,
+	}
+}
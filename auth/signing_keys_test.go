@@ -0,0 +1,185 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/oidc"
+)
+
+var _ = Describe("Signing keys", func() {
+	var (
+		currentKey  *rsa.PrivateKey
+		previousKey *rsa.PrivateKey
+		unknownKey  *rsa.PrivateKey
+
+		signingKeys auth.SigningKeys
+	)
+
+	BeforeEach(func() {
+		var err error
+		currentKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		previousKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		unknownKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		signingKeys = auth.SigningKeys{currentKey, previousKey}
+	})
+
+	generateToken := func(key *rsa.PrivateKey) string {
+		_, tokenValue, err := auth.NewTokenGenerator(key).GenerateToken(
+			time.Now().Add(time.Hour),
+			"some-team",
+			false,
+			[]string{"some-team"},
+			"",
+			"some-session",
+			time.Now(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(tokenValue)
+	}
+
+	Describe("KeyID", func() {
+		It("is the key's JWK thumbprint", func() {
+			// example from RFC 7638, section 3.1
+			publicKey, err := oidc.JSONWebKey{
+				KeyType: "RSA",
+				N:       "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+				E:       "AQAB",
+			}.RSAPublicKey()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(auth.KeyID(publicKey)).To(Equal("NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"))
+		})
+	})
+
+	Describe("generated tokens", func() {
+		It("name the key they were signed with", func() {
+			token, err := jwt.Parse(generateToken(currentKey), func(*jwt.Token) (interface{}, error) {
+				return &currentKey.PublicKey, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(token.Header["kid"]).To(Equal(auth.KeyID(&currentKey.PublicKey)))
+		})
+	})
+
+	Describe("PublicKeys.Parse", func() {
+		It("accepts tokens signed with the current key", func() {
+			token, err := signingKeys.PublicKeys().Parse(generateToken(currentKey))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Valid).To(BeTrue())
+		})
+
+		It("accepts tokens signed with a previous key", func() {
+			token, err := signingKeys.PublicKeys().Parse(generateToken(previousKey))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Valid).To(BeTrue())
+		})
+
+		It("rejects tokens signed with any other key", func() {
+			_, err := signingKeys.PublicKeys().Parse(generateToken(unknownKey))
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("when the token does not name its key", func() {
+			signWithoutKeyID := func(key *rsa.PrivateKey) string {
+				tokenValue, err := jwt.NewWithClaims(auth.SigningMethod, jwt.MapClaims{
+					"exp":      time.Now().Add(time.Hour).Unix(),
+					"teamName": "some-team",
+					"isAdmin":  false,
+				}).SignedString(key)
+				Expect(err).NotTo(HaveOccurred())
+
+				return tokenValue
+			}
+
+			It("accepts it if any key verifies it", func() {
+				token, err := signingKeys.PublicKeys().Parse(signWithoutKeyID(previousKey))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(token.Valid).To(BeTrue())
+			})
+
+			It("rejects it if no key verifies it", func() {
+				_, err := signingKeys.PublicKeys().Parse(signWithoutKeyID(unknownKey))
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("rejects it if it uses another signing method", func() {
+				tokenValue, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+					"exp":      time.Now().Add(time.Hour).Unix(),
+					"teamName": "some-team",
+					"isAdmin":  false,
+				}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = signingKeys.PublicKeys().Parse(tokenValue)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		It("rejects tokens with other signing methods", func() {
+			tokenValue, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"teamName": "some-team",
+			}).SignedString([]byte("some-secret"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = signingKeys.PublicKeys().Parse(tokenValue)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("JWKSHandler", func() {
+		var keySet oidc.JSONWebKeySet
+
+		BeforeEach(func() {
+			request, err := http.NewRequest("GET", "http://example.com/.well-known/jwks.json", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := httptest.NewRecorder()
+			auth.JWKSHandler{PublicKeys: signingKeys.PublicKeys()}.ServeHTTP(recorder, request)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+			err = json.NewDecoder(recorder.Body).Decode(&keySet)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("publishes every public key under its ID", func() {
+			Expect(keySet.Keys).To(HaveLen(2))
+
+			for _, key := range []*rsa.PrivateKey{currentKey, previousKey} {
+				publicKey, err := keySet.PublicKey(auth.KeyID(&key.PublicKey))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(publicKey.N.Cmp(key.PublicKey.N)).To(BeZero())
+				Expect(publicKey.E).To(Equal(key.PublicKey.E))
+			}
+		})
+
+		It("lets other services verify tokens", func() {
+			tokenValue := generateToken(currentKey)
+
+			token, err := jwt.Parse(tokenValue, func(token *jwt.Token) (interface{}, error) {
+				return keySet.PublicKey(token.Header["kid"].(string))
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Valid).To(BeTrue())
+		})
+	})
+})
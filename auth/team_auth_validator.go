@@ -7,17 +7,20 @@ import (
 )
 
 type teamAuthValidator struct {
-	teamDBFactory db.TeamDBFactory
-	jwtValidator  Validator
+	teamDBFactory     db.TeamDBFactory
+	jwtValidator      Validator
+	ldapAuthenticator LDAPAuthenticator
 }
 
 func NewTeamAuthValidator(
 	teamDBFactory db.TeamDBFactory,
 	jwtValidator Validator,
+	ldapAuthenticator LDAPAuthenticator,
 ) Validator {
 	return &teamAuthValidator{
-		teamDBFactory: teamDBFactory,
-		jwtValidator:  jwtValidator,
+		teamDBFactory:     teamDBFactory,
+		jwtValidator:      jwtValidator,
+		ldapAuthenticator: ldapAuthenticator,
 	}
 }
 
@@ -30,10 +33,25 @@ func (v teamAuthValidator) IsAuthenticated(r *http.Request) bool {
 	}
 
 	if !team.IsAuthConfigured() {
+		localUsers, err := teamDB.GetLocalUsers()
+		if err != nil {
+			return false
+		}
+
+		if len(localUsers) == 0 {
+			return true
+		}
+	}
+
+	if NewBasicAuthValidator(team, teamDB).IsAuthenticated(r) {
+		return true
+	}
+
+	if team.LDAPAuth != nil && NewLDAPAuthValidator(team, v.ldapAuthenticator).IsAuthenticated(r) {
 		return true
 	}
 
-	if team.BasicAuth != nil && NewBasicAuthValidator(team).IsAuthenticated(r) {
+	if team.CertAuth != nil && NewCertAuthValidator(team).IsAuthenticated(r) {
 		return true
 	}
 
@@ -1,6 +1,10 @@
 package auth_test
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
 	"net/http"
 
 	"golang.org/x/crypto/bcrypt"
@@ -22,6 +26,8 @@ var _ = Describe("TeamAuthValidator", func() {
 		teamDB       *dbfakes.FakeTeamDB
 		jwtValidator *authfakes.FakeValidator
 
+		ldapAuthenticator *authfakes.FakeLDAPAuthenticator
+
 		request           *http.Request
 		isAuthenticated   bool
 		username          string
@@ -48,7 +54,9 @@ var _ = Describe("TeamAuthValidator", func() {
 		teamDB = new(dbfakes.FakeTeamDB)
 		teamDBFactory.GetTeamDBReturns(teamDB)
 
-		validator = auth.NewTeamAuthValidator(teamDBFactory, jwtValidator)
+		ldapAuthenticator = new(authfakes.FakeLDAPAuthenticator)
+
+		validator = auth.NewTeamAuthValidator(teamDBFactory, jwtValidator, ldapAuthenticator)
 
 		request, err = http.NewRequest("GET", "http://example.com", nil)
 		Expect(err).ToNot(HaveOccurred())
@@ -99,6 +107,144 @@ var _ = Describe("TeamAuthValidator", func() {
 			})
 		})
 
+		Context("when team only has local users", func() {
+			BeforeEach(func() {
+				localUser := db.LocalUser{
+					Username:     username,
+					PasswordHash: string(encryptedPassword),
+					Enabled:      true,
+				}
+
+				teamDB.GetLocalUsersReturns([]db.LocalUser{localUser}, nil)
+				teamDB.GetLocalUserReturns(localUser, true, nil)
+			})
+
+			Context("when the request has a user's credentials", func() {
+				BeforeEach(func() {
+					request.Header.Set("Authorization", "Basic "+b64(username+":"+password))
+				})
+
+				It("returns true", func() {
+					Expect(isAuthenticated).To(BeTrue())
+				})
+
+				It("looks up the user by name", func() {
+					Expect(teamDB.GetLocalUserCallCount()).To(Equal(1))
+					Expect(teamDB.GetLocalUserArgsForCall(0)).To(Equal(username))
+				})
+			})
+
+			Context("when the request has no credentials", func() {
+				It("returns false", func() {
+					Expect(isAuthenticated).To(BeFalse())
+				})
+			})
+
+			Context("when the users cannot be listed", func() {
+				BeforeEach(func() {
+					teamDB.GetLocalUsersReturns(nil, errors.New("disaster"))
+					request.Header.Set("Authorization", "Basic "+b64(username+":"+password))
+				})
+
+				It("returns false", func() {
+					Expect(isAuthenticated).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when team has ldap auth configured", func() {
+			BeforeEach(func() {
+				team.LDAPAuth = &db.LDAPAuth{
+					Host: "ldap.example.com:389",
+				}
+				teamDB.GetTeamReturns(team, true, nil)
+
+				request.Header.Set("Authorization", "Basic "+b64(username+":"+password))
+			})
+
+			It("authenticates the credentials against the team's directory", func() {
+				Expect(ldapAuthenticator.AuthenticateCallCount()).To(Equal(1))
+
+				ldapAuth, actualUsername, actualPassword := ldapAuthenticator.AuthenticateArgsForCall(0)
+				Expect(ldapAuth).To(Equal(team.LDAPAuth))
+				Expect(actualUsername).To(Equal(username))
+				Expect(actualPassword).To(Equal(password))
+			})
+
+			Context("when the credentials are accepted", func() {
+				BeforeEach(func() {
+					ldapAuthenticator.AuthenticateReturns(true, nil)
+				})
+
+				It("returns true", func() {
+					Expect(isAuthenticated).To(BeTrue())
+				})
+			})
+
+			Context("when the credentials are rejected", func() {
+				BeforeEach(func() {
+					ldapAuthenticator.AuthenticateReturns(false, nil)
+				})
+
+				It("returns false", func() {
+					Expect(isAuthenticated).To(BeFalse())
+				})
+			})
+
+			Context("when the directory cannot be reached", func() {
+				BeforeEach(func() {
+					ldapAuthenticator.AuthenticateReturns(true, errors.New("disaster"))
+				})
+
+				It("returns false", func() {
+					Expect(isAuthenticated).To(BeFalse())
+				})
+			})
+
+			Context("when the request has no basic credentials", func() {
+				BeforeEach(func() {
+					request.Header.Del("Authorization")
+				})
+
+				It("does not contact the directory", func() {
+					Expect(ldapAuthenticator.AuthenticateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when team has cert auth configured", func() {
+			BeforeEach(func() {
+				team.CertAuth = &db.CertAuth{
+					CommonNames: []string{"build-bot"},
+				}
+				teamDB.GetTeamReturns(team, true, nil)
+			})
+
+			Context("when the request has a verified certificate for the team", func() {
+				BeforeEach(func() {
+					cert := &x509.Certificate{Subject: pkix.Name{CommonName: "build-bot"}}
+					request.TLS = &tls.ConnectionState{
+						PeerCertificates: []*x509.Certificate{cert},
+						VerifiedChains:   [][]*x509.Certificate{{cert}},
+					}
+				})
+
+				It("returns true", func() {
+					Expect(isAuthenticated).To(BeTrue())
+				})
+			})
+
+			Context("when the request has no certificate", func() {
+				BeforeEach(func() {
+					jwtValidator.IsAuthenticatedReturns(false)
+				})
+
+				It("returns false", func() {
+					Expect(isAuthenticated).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when team has uaa auth configured", func() {
 			BeforeEach(func() {
 				team.UAAAuth = &db.UAAAuth{
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/provider"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . TeamsDB
+
+type TeamsDB interface {
+	GetTeams() ([]db.SavedTeam, error)
+}
+
+// Membership returns the names of the teams a user belongs to, starting with
+// the team they logged in to, and whether any of them is an admin team.
+func Membership(loginTeam db.SavedTeam, memberTeams []db.SavedTeam) ([]string, bool) {
+	teams := []string{loginTeam.Name}
+	isAdmin := loginTeam.Admin
+
+	for _, team := range memberTeams {
+		if team.ID == loginTeam.ID {
+			continue
+		}
+
+		teams = append(teams, team.Name)
+		isAdmin = isAdmin || team.Admin
+	}
+
+	return teams, isAdmin
+}
+
+// BasicAuthTeams returns the teams whose shared basic auth credentials match
+// the ones on the request. Local users are scoped to their own team, so they
+// never grant membership of other teams.
+func BasicAuthTeams(teams []db.SavedTeam, r *http.Request) []db.SavedTeam {
+	memberTeams := []db.SavedTeam{}
+	for _, team := range teams {
+		if team.BasicAuth != nil && NewBasicAuthValidator(team, nil).IsAuthenticated(r) {
+			memberTeams = append(memberTeams, team)
+		}
+	}
+
+	return memberTeams
+}
+
+// OAuthTeams returns the other teams that trust the same identity provider
+// and whose rules the user logged in with the given client satisfies.
+func OAuthTeams(
+	logger lager.Logger,
+	providerFactory ProviderFactory,
+	teams []db.SavedTeam,
+	loginTeam db.SavedTeam,
+	providerName string,
+	httpClient *http.Client,
+) []db.SavedTeam {
+	memberTeams := []db.SavedTeam{}
+	for _, team := range teams {
+		if team.ID == loginTeam.ID || !provider.SameIdentityProvider(providerName, loginTeam, team) {
+			continue
+		}
+
+		teamProvider, found, err := providerFactory.GetProvider(team, providerName)
+		if err != nil {
+			logger.Error("failed-to-get-provider", err, lager.Data{"team": team.Name})
+			continue
+		}
+
+		if !found {
+			continue
+		}
+
+		verified, err := teamProvider.Verify(logger.Session("verify", lager.Data{"team": team.Name}), httpClient)
+		if err != nil {
+			logger.Error("failed-to-verify-membership", err, lager.Data{"team": team.Name})
+			continue
+		}
+
+		if verified {
+			memberTeams = append(memberTeams, team)
+		}
+	}
+
+	return memberTeams
+}
@@ -0,0 +1,245 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"golang.org/x/crypto/bcrypt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/provider"
+	"github.com/concourse/atc/auth/provider/providerfakes"
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("Team membership", func() {
+	Describe("Membership", func() {
+		It("lists the login team first and is admin if any team is", func() {
+			loginTeam := db.SavedTeam{ID: 1, Team: db.Team{Name: "some-team"}}
+
+			teams, isAdmin := auth.Membership(loginTeam, []db.SavedTeam{
+				{ID: 2, Team: db.Team{Name: "main", Admin: true}},
+				loginTeam,
+				{ID: 3, Team: db.Team{Name: "other-team"}},
+			})
+
+			Expect(teams).To(Equal([]string{"some-team", "main", "other-team"}))
+			Expect(isAdmin).To(BeTrue())
+		})
+
+		It("is not admin if no team is", func() {
+			teams, isAdmin := auth.Membership(db.SavedTeam{ID: 1, Team: db.Team{Name: "some-team"}}, nil)
+			Expect(teams).To(Equal([]string{"some-team"}))
+			Expect(isAdmin).To(BeFalse())
+		})
+	})
+
+	Describe("BasicAuthTeams", func() {
+		It("returns the teams accepting the request's credentials", func() {
+			password, err := bcrypt.GenerateFromPassword([]byte("some-password"), 4)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherPassword, err := bcrypt.GenerateFromPassword([]byte("other-password"), 4)
+			Expect(err).NotTo(HaveOccurred())
+
+			matchingTeam := db.SavedTeam{
+				ID: 1,
+				Team: db.Team{
+					Name:      "some-team",
+					BasicAuth: &db.BasicAuth{BasicAuthUsername: "some-user", BasicAuthPassword: string(password)},
+				},
+			}
+
+			teams := []db.SavedTeam{
+				matchingTeam,
+				{
+					ID: 2,
+					Team: db.Team{
+						Name:      "other-password-team",
+						BasicAuth: &db.BasicAuth{BasicAuthUsername: "some-user", BasicAuthPassword: string(otherPassword)},
+					},
+				},
+				{
+					ID: 3,
+					Team: db.Team{
+						Name:      "other-user-team",
+						BasicAuth: &db.BasicAuth{BasicAuthUsername: "other-user", BasicAuthPassword: string(password)},
+					},
+				},
+				{ID: 4, Team: db.Team{Name: "no-auth-team"}},
+			}
+
+			request, err := http.NewRequest("GET", "http://example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.SetBasicAuth("some-user", "some-password")
+
+			Expect(auth.BasicAuthTeams(teams, request)).To(Equal([]db.SavedTeam{matchingTeam}))
+		})
+	})
+
+	Describe("OAuthTeams", func() {
+		var (
+			fakeProviderFactory *authfakes.FakeProviderFactory
+			providers           map[string]*providerfakes.FakeProvider
+
+			loginTeam db.SavedTeam
+			teams     []db.SavedTeam
+
+			httpClient *http.Client
+		)
+
+		githubTeam := func(id int, name string, clientID string) db.SavedTeam {
+			return db.SavedTeam{
+				ID: id,
+				Team: db.Team{
+					Name:       name,
+					GitHubAuth: &db.GitHubAuth{ClientID: clientID, ClientSecret: "some-secret"},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			loginTeam = githubTeam(1, "some-team", "some-client")
+
+			teams = []db.SavedTeam{
+				loginTeam,
+				githubTeam(2, "member-team", "some-client"),
+				githubTeam(3, "non-member-team", "some-client"),
+				githubTeam(4, "other-client-team", "other-client"),
+				githubTeam(5, "broken-team", "some-client"),
+			}
+
+			providers = map[string]*providerfakes.FakeProvider{}
+			for _, team := range teams {
+				providers[team.Name] = new(providerfakes.FakeProvider)
+			}
+
+			providers["member-team"].VerifyReturns(true, nil)
+			providers["other-client-team"].VerifyReturns(true, nil)
+			providers["broken-team"].VerifyReturns(false, errors.New("nope"))
+
+			fakeProviderFactory = new(authfakes.FakeProviderFactory)
+			fakeProviderFactory.GetProviderStub = func(team db.SavedTeam, providerName string) (provider.Provider, bool, error) {
+				return providers[team.Name], true, nil
+			}
+
+			httpClient = &http.Client{Timeout: time.Minute}
+		})
+
+		It("returns the other teams using the same client whose rules the user satisfies", func() {
+			memberTeams := auth.OAuthTeams(
+				lagertest.NewTestLogger("test"),
+				fakeProviderFactory,
+				teams,
+				loginTeam,
+				github.ProviderName,
+				httpClient,
+			)
+
+			Expect(memberTeams).To(Equal([]db.SavedTeam{teams[1]}))
+
+			_, verifyClient := providers["member-team"].VerifyArgsForCall(0)
+			Expect(verifyClient).To(Equal(httpClient))
+		})
+
+		It("never hands the token to teams using another client", func() {
+			auth.OAuthTeams(lagertest.NewTestLogger("test"), fakeProviderFactory, teams, loginTeam, github.ProviderName, httpClient)
+
+			Expect(providers["other-client-team"].VerifyCallCount()).To(BeZero())
+			Expect(providers["some-team"].VerifyCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("JWTReader", func() {
+		var (
+			signingKey *rsa.PrivateKey
+			reader     auth.JWTReader
+			request    *http.Request
+		)
+
+		BeforeEach(func() {
+			var err error
+			signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+			Expect(err).NotTo(HaveOccurred())
+
+			reader = auth.JWTReader{PublicKeys: auth.PublicKeys{&signingKey.PublicKey}}
+
+			request, err = http.NewRequest("GET", "http://example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the teams the token was issued for", func() {
+			tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(
+				time.Now().Add(time.Hour),
+				"some-team",
+				false,
+				[]string{"some-team", "other-team"},
+				"",
+				"some-session",
+				time.Now(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, tokenValue))
+
+			teams, found := reader.GetTeams(request)
+			Expect(found).To(BeTrue())
+			Expect(teams).To(Equal([]string{"some-team", "other-team"}))
+		})
+
+		It("returns nothing without a token", func() {
+			_, found := reader.GetTeams(request)
+			Expect(found).To(BeFalse())
+
+			_, found = reader.GetUsername(request)
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the username the token was issued to", func() {
+			tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(
+				time.Now().Add(time.Hour),
+				"some-team",
+				false,
+				[]string{"some-team"},
+				"local-user",
+				"some-session",
+				time.Now(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, tokenValue))
+
+			username, found := reader.GetUsername(request)
+			Expect(found).To(BeTrue())
+			Expect(username).To(Equal("local-user"))
+		})
+
+		It("does not return a username for tokens issued without one", func() {
+			tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(
+				time.Now().Add(time.Hour),
+				"some-team",
+				false,
+				[]string{"some-team"},
+				"",
+				"some-session",
+				time.Now(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, tokenValue))
+
+			_, found := reader.GetUsername(request)
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
+	"encoding/hex"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -16,9 +18,16 @@ const TokenTypeBearer = "Bearer"
 const expClaimKey = "exp"
 const teamNameClaimKey = "teamName"
 const isAdminClaimKey = "isAdmin"
+const teamsClaimKey = "teams"
+const sessionIDClaimKey = "jti"
+const usernameClaimKey = "username"
+
+// not "iat", as jwt-go rejects tokens issued after the local time, which
+// clock skew between ATCs would trip over
+const issuedAtClaimKey = "issuedAt"
 
 type TokenGenerator interface {
-	GenerateToken(expiration time.Time, teamName string, isAdmin bool) (TokenType, TokenValue, error)
+	GenerateToken(expiration time.Time, teamName string, isAdmin bool, teams []string, username string, sessionID string, issuedAt time.Time) (TokenType, TokenValue, error)
 }
 
 type tokenGenerator struct {
@@ -31,12 +40,27 @@ func NewTokenGenerator(privateKey *rsa.PrivateKey) TokenGenerator {
 	}
 }
 
-func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool) (TokenType, TokenValue, error) {
-	jwtToken := jwt.NewWithClaims(SigningMethod, jwt.MapClaims{
-		expClaimKey:      expiration.Unix(),
-		teamNameClaimKey: teamName,
-		isAdminClaimKey:  isAdmin,
-	})
+// GenerateToken signs a token for the team the user logged in to, along with
+// every team the user turned out to be a member of. The username is only
+// recorded when the user is known by name, e.g. a team's local user. The
+// session ID and issue time are those of the session the user logged in to,
+// which stay the same as its tokens are renewed.
+func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool, teams []string, username string, sessionID string, issuedAt time.Time) (TokenType, TokenValue, error) {
+	claims := jwt.MapClaims{
+		expClaimKey:       expiration.Unix(),
+		teamNameClaimKey:  teamName,
+		isAdminClaimKey:   isAdmin,
+		teamsClaimKey:     teams,
+		sessionIDClaimKey: sessionID,
+		issuedAtClaimKey:  issuedAt.Unix(),
+	}
+
+	if username != "" {
+		claims[usernameClaimKey] = username
+	}
+
+	jwtToken := jwt.NewWithClaims(SigningMethod, claims)
+	jwtToken.Header[keyIDHeader] = KeyID(&generator.privateKey.PublicKey)
 
 	signed, err := jwtToken.SignedString(generator.privateKey)
 	if err != nil {
@@ -45,3 +69,15 @@ func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName st
 
 	return TokenTypeBearer, TokenValue(signed), err
 }
+
+// NewSessionID generates the ID of a new session.
+func NewSessionID() (string, error) {
+	id := make([]byte, 16)
+
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
@@ -1,10 +1,16 @@
 package auth
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 //go:generate counterfeiter . UserContextReader
 
 type UserContextReader interface {
 	GetTeam(r *http.Request) (string, bool, bool)
+	GetTeams(r *http.Request) ([]string, bool)
+	GetUsername(r *http.Request) (string, bool)
 	GetSystem(r *http.Request) (bool, bool)
+	GetSession(r *http.Request) (string, time.Time, bool)
 }
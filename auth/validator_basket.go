@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+)
+
+type validatorBasket struct {
+	validators []Validator
+}
+
+// NewValidatorBasket returns a validator that authenticates a request if any
+// of the given validators does.
+func NewValidatorBasket(validators ...Validator) Validator {
+	return validatorBasket{validators: validators}
+}
+
+func (vb validatorBasket) IsAuthenticated(r *http.Request) bool {
+	for _, validator := range vb.validators {
+		if validator.IsAuthenticated(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type userContextReaderBasket struct {
+	readers []UserContextReader
+}
+
+// NewUserContextReaderBasket returns a reader that answers with the first of
+// the given readers that finds a user context on the request.
+func NewUserContextReaderBasket(readers ...UserContextReader) UserContextReader {
+	return userContextReaderBasket{readers: readers}
+}
+
+func (rb userContextReaderBasket) GetTeam(r *http.Request) (string, bool, bool) {
+	for _, reader := range rb.readers {
+		teamName, isAdmin, found := reader.GetTeam(r)
+		if found {
+			return teamName, isAdmin, true
+		}
+	}
+
+	return "", false, false
+}
+
+func (rb userContextReaderBasket) GetTeams(r *http.Request) ([]string, bool) {
+	for _, reader := range rb.readers {
+		teams, found := reader.GetTeams(r)
+		if found {
+			return teams, true
+		}
+	}
+
+	return nil, false
+}
+
+func (rb userContextReaderBasket) GetUsername(r *http.Request) (string, bool) {
+	for _, reader := range rb.readers {
+		username, found := reader.GetUsername(r)
+		if found {
+			return username, true
+		}
+	}
+
+	return "", false
+}
+
+func (rb userContextReaderBasket) GetSystem(r *http.Request) (bool, bool) {
+	for _, reader := range rb.readers {
+		isSystem, found := reader.GetSystem(r)
+		if found {
+			return isSystem, true
+		}
+	}
+
+	return false, false
+}
+
+func (rb userContextReaderBasket) GetSession(r *http.Request) (string, time.Time, bool) {
+	for _, reader := range rb.readers {
+		sessionID, issuedAt, found := reader.GetSession(r)
+		if found {
+			return sessionID, issuedAt, true
+		}
+	}
+
+	return "", time.Time{}, false
+}
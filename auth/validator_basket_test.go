@@ -0,0 +1,89 @@
+package auth_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+)
+
+var _ = Describe("Baskets", func() {
+	var request *http.Request
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("NewValidatorBasket", func() {
+		var (
+			fakeValidatorA *authfakes.FakeValidator
+			fakeValidatorB *authfakes.FakeValidator
+			basket         auth.Validator
+		)
+
+		BeforeEach(func() {
+			fakeValidatorA = new(authfakes.FakeValidator)
+			fakeValidatorB = new(authfakes.FakeValidator)
+			basket = auth.NewValidatorBasket(fakeValidatorA, fakeValidatorB)
+		})
+
+		It("is authenticated if any validator authenticates", func() {
+			fakeValidatorB.IsAuthenticatedReturns(true)
+			Expect(basket.IsAuthenticated(request)).To(BeTrue())
+		})
+
+		It("stops at the first validator that authenticates", func() {
+			fakeValidatorA.IsAuthenticatedReturns(true)
+			Expect(basket.IsAuthenticated(request)).To(BeTrue())
+			Expect(fakeValidatorB.IsAuthenticatedCallCount()).To(Equal(0))
+		})
+
+		It("is not authenticated if no validator authenticates", func() {
+			Expect(basket.IsAuthenticated(request)).To(BeFalse())
+		})
+	})
+
+	Describe("NewUserContextReaderBasket", func() {
+		var (
+			fakeReaderA *authfakes.FakeUserContextReader
+			fakeReaderB *authfakes.FakeUserContextReader
+			basket      auth.UserContextReader
+		)
+
+		BeforeEach(func() {
+			fakeReaderA = new(authfakes.FakeUserContextReader)
+			fakeReaderB = new(authfakes.FakeUserContextReader)
+			basket = auth.NewUserContextReaderBasket(fakeReaderA, fakeReaderB)
+		})
+
+		It("returns the team from the first reader that finds one", func() {
+			fakeReaderB.GetTeamReturns("some-team", true, true)
+
+			teamName, isAdmin, found := basket.GetTeam(request)
+			Expect(found).To(BeTrue())
+			Expect(teamName).To(Equal("some-team"))
+			Expect(isAdmin).To(BeTrue())
+		})
+
+		It("returns the system flag from the first reader that finds one", func() {
+			fakeReaderA.GetSystemReturns(true, true)
+
+			isSystem, found := basket.GetSystem(request)
+			Expect(found).To(BeTrue())
+			Expect(isSystem).To(BeTrue())
+		})
+
+		It("finds nothing when no reader does", func() {
+			_, _, found := basket.GetTeam(request)
+			Expect(found).To(BeFalse())
+
+			_, found = basket.GetSystem(request)
+			Expect(found).To(BeFalse())
+		})
+	})
+})
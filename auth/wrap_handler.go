@@ -8,7 +8,11 @@ import (
 var authenticated = "authenticated"
 var teamNameKey = "teamName"
 var isAdminKey = "isAdmin"
+var teamsKey = "teams"
+var usernameKey = "username"
 var isSystemKey = "system"
+var sessionIDKey = "sessionID"
+var issuedAtKey = "issuedAt"
 
 func WrapHandler(
 	handler http.Handler,
@@ -36,9 +40,25 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, isAdminKey, isAdmin)
 	}
 
+	teams, found := h.userContextReader.GetTeams(r)
+	if found {
+		ctx = context.WithValue(ctx, teamsKey, teams)
+	}
+
+	username, found := h.userContextReader.GetUsername(r)
+	if found {
+		ctx = context.WithValue(ctx, usernameKey, username)
+	}
+
 	isSystem, found := h.userContextReader.GetSystem(r)
 	if found {
 		ctx = context.WithValue(ctx, isSystemKey, isSystem)
 	}
+
+	sessionID, issuedAt, found := h.userContextReader.GetSession(r)
+	if found {
+		ctx = context.WithValue(ctx, sessionIDKey, sessionID)
+		ctx = context.WithValue(ctx, issuedAtKey, issuedAt)
+	}
 	h.handler.ServeHTTP(w, r.WithContext(ctx))
 }
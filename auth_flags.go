@@ -68,6 +68,40 @@ func (auth *GitHubAuthFlag) Validate() error {
 	return errs.ErrorOrNil()
 }
 
+type GitLabAuthFlag struct {
+	ClientID     string   `long:"client-id"     description:"Application client ID for enabling GitLab OAuth."`
+	ClientSecret string   `long:"client-secret" description:"Application client secret for enabling GitLab OAuth."`
+	Groups       []string `long:"group"         description:"GitLab group whose members will have access. Nested groups are given by their full path." value-name:"GROUP"`
+	Users        []string `long:"user"          description:"GitLab user to permit access." value-name:"USERNAME"`
+	AuthURL      string   `long:"auth-url"      description:"Override default endpoint AuthURL for self-hosted GitLab."`
+	TokenURL     string   `long:"token-url"     description:"Override default endpoint TokenURL for self-hosted GitLab."`
+	APIURL       string   `long:"api-url"       description:"Override default API endpoint URL for self-hosted GitLab."`
+}
+
+func (auth *GitLabAuthFlag) IsConfigured() bool {
+	return auth.ClientID != "" ||
+		auth.ClientSecret != "" ||
+		len(auth.Groups) > 0 ||
+		len(auth.Users) > 0
+}
+
+func (auth *GitLabAuthFlag) Validate() error {
+	var errs *multierror.Error
+	if auth.ClientID == "" || auth.ClientSecret == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --gitlab-auth-client-id and --gitlab-auth-client-secret to use GitLab OAuth."),
+		)
+	}
+	if len(auth.Groups) == 0 && len(auth.Users) == 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("at least one of the following is required for gitlab-auth: groups, users."),
+		)
+	}
+	return errs.ErrorOrNil()
+}
+
 type GenericOAuthFlag struct {
 	DisplayName   string            `long:"display-name"   description:"Name for this auth method on the web UI."`
 	ClientID      string            `long:"client-id"      description:"Application client ID for enabling generic OAuth."`
@@ -150,3 +184,103 @@ func (auth *UAAAuthFlag) Validate() error {
 	}
 	return errs.ErrorOrNil()
 }
+
+type OIDCAuthFlag struct {
+	DisplayName  string   `long:"display-name"  description:"Name for this auth method on the web UI."`
+	Issuer       string   `long:"issuer"        description:"OpenID Connect issuer URL. Endpoints are discovered from its .well-known/openid-configuration document."`
+	ClientID     string   `long:"client-id"     description:"Application client ID for enabling OpenID Connect."`
+	ClientSecret string   `long:"client-secret" description:"Application client secret for enabling OpenID Connect."`
+	Scopes       []string `long:"scope"         description:"Additional scope to request. Can be specified multiple times."`
+	Users        []string `long:"user"          description:"Subject (sub claim) of a user to permit access." value-name:"SUBJECT"`
+	EmailDomains []string `long:"email-domain"  description:"Email domain whose verified users will have access." value-name:"DOMAIN"`
+	Groups       []string `long:"group"         description:"Group whose members will have access." value-name:"GROUP"`
+	GroupsClaim  string   `long:"groups-claim"  description:"ID token claim listing the user's groups." default:"groups"`
+}
+
+func (auth *OIDCAuthFlag) IsConfigured() bool {
+	return auth.Issuer != "" ||
+		auth.ClientID != "" ||
+		auth.ClientSecret != "" ||
+		auth.DisplayName != "" ||
+		len(auth.Users) > 0 ||
+		len(auth.EmailDomains) > 0 ||
+		len(auth.Groups) > 0
+}
+
+func (auth *OIDCAuthFlag) Validate() error {
+	var errs *multierror.Error
+	if auth.ClientID == "" || auth.ClientSecret == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --oidc-auth-client-id and --oidc-auth-client-secret to use OpenID Connect."),
+		)
+	}
+	if auth.Issuer == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --oidc-auth-issuer to use OpenID Connect."),
+		)
+	}
+	if auth.DisplayName == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --oidc-auth-display-name to use OpenID Connect."),
+		)
+	}
+	if len(auth.Users) == 0 && len(auth.EmailDomains) == 0 && len(auth.Groups) == 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("at least one of the following is required for oidc-auth: user, email-domain, group."),
+		)
+	}
+	return errs.ErrorOrNil()
+}
+
+type LDAPAuthFlag struct {
+	DisplayName          string   `long:"display-name"           description:"Name for this auth method on the web UI." default:"LDAP"`
+	Host                 string   `long:"host"                   description:"LDAP server address, including the port."`
+	TLS                  bool     `long:"tls"                    description:"Connect to the LDAP server over TLS (ldaps)."`
+	StartTLS             bool     `long:"start-tls"              description:"Upgrade the connection to the LDAP server with StartTLS."`
+	InsecureSkipVerify   bool     `long:"insecure-skip-verify"   description:"Skip verification of the LDAP server's certificate."`
+	CACert               PathFlag `long:"ca-cert"                description:"Path to PEM-encoded CA certificate file for the LDAP server."`
+	BindDN               string   `long:"bind-dn"                description:"DN to bind as when searching for users and groups."`
+	BindPassword         string   `long:"bind-password"          description:"Password for the bind DN."`
+	UserSearchBaseDN     string   `long:"user-search-base-dn"    description:"Base DN to search for users under."`
+	UserSearchFilter     string   `long:"user-search-filter"     description:"Filter that users must match, e.g. (objectClass=person)."`
+	UsernameAttribute    string   `long:"username-attribute"     description:"Attribute matched against the username." default:"uid"`
+	GroupSearchBaseDN    string   `long:"group-search-base-dn"   description:"Base DN to search for groups under."`
+	GroupSearchFilter    string   `long:"group-search-filter"    description:"Filter that groups must match, e.g. (objectClass=groupOfNames)."`
+	GroupMemberAttribute string   `long:"group-member-attribute" description:"Group attribute listing the DNs of its members." default:"member"`
+	GroupNameAttribute   string   `long:"group-name-attribute"   description:"Group attribute holding its name." default:"cn"`
+	Groups               []string `long:"group"                  description:"LDAP group whose members will have access." value-name:"GROUP"`
+}
+
+func (auth *LDAPAuthFlag) IsConfigured() bool {
+	return auth.Host != "" ||
+		auth.BindDN != "" ||
+		auth.UserSearchBaseDN != "" ||
+		len(auth.Groups) > 0
+}
+
+func (auth *LDAPAuthFlag) Validate() error {
+	var errs *multierror.Error
+	if auth.Host == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --ldap-auth-host to use LDAP."),
+		)
+	}
+	if auth.UserSearchBaseDN == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --ldap-auth-user-search-base-dn to use LDAP."),
+		)
+	}
+	if len(auth.Groups) > 0 && auth.GroupSearchBaseDN == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --ldap-auth-group-search-base-dn to use --ldap-auth-group."),
+		)
+	}
+	return errs.ErrorOrNil()
+}
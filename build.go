@@ -60,4 +60,20 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+	InputsExplanation   map[string]InputExplanation       `json:"inputs_explanation,omitempty"`
+	Locks               map[string]BuildPreparationStatus `json:"locks,omitempty"`
+}
+
+type InputExplanation struct {
+	Constraints            []InputConstraintExplanation `json:"constraints"`
+	JobsWithoutCommonBuild []string                     `json:"jobs_without_common_build,omitempty"`
+	PinnedVersionID        int                          `json:"pinned_version_id,omitempty"`
+	DisabledVersions       int                          `json:"disabled_versions,omitempty"`
+	Satisfied              bool                         `json:"satisfied"`
+}
+
+type InputConstraintExplanation struct {
+	Constraint string `json:"constraint"`
+	Job        string `json:"job,omitempty"`
+	Candidates int    `json:"candidates"`
 }
@@ -106,6 +106,10 @@ type JobConfig struct {
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
+	// names of team-wide locks that a build of the job must hold before it
+	// is scheduled; their capacity is configured on the team
+	Locks []string `yaml:"locks,omitempty" json:"locks,omitempty" mapstructure:"locks"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Failure *PlanConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
@@ -345,6 +349,8 @@ type PlanConfig struct {
 	Task string `yaml:"task,omitempty" json:"task,omitempty" mapstructure:"task"`
 	// run task privileged
 	Privileged bool `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
+	// re-run the task on another worker if its worker is lost
+	RetryOnWorkerLoss bool `yaml:"retry_on_worker_loss,omitempty" json:"retry_on_worker_loss,omitempty" mapstructure:"retry_on_worker_loss"`
 	// task config path, e.g. foo/build.yml
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
@@ -433,6 +439,90 @@ func (config PlanConfig) Hooks() Hooks {
 	return Hooks{config.Failure, config.Ensure, config.Success}
 }
 
+// PassedJob splits an entry of a get step's passed constraints into the
+// pipeline and the job it names. Jobs in the same pipeline are named as-is,
+// with an empty pipeline name; jobs in other pipelines of the same team are
+// named 'pipeline/job'. Pipeline names cannot contain a '/', so everything
+// after the first one is the job name, unless the entry names a job of the
+// config itself.
+func (config Config) PassedJob(passed string) (string, string) {
+	if _, found := config.Jobs.Lookup(passed); found {
+		return "", passed
+	}
+
+	segs := strings.SplitN(passed, "/", 2)
+	if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+		return "", passed
+	}
+
+	return segs[0], segs[1]
+}
+
+// PipelineJob names a job in a pipeline of the same team.
+type PipelineJob struct {
+	Pipeline string
+	Job      string
+}
+
+// PassedJobsInOtherPipelines returns the jobs in pipelines other than the
+// named one which the config's passed constraints refer to.
+func (config Config) PassedJobsInOtherPipelines(pipelineName string) []PipelineJob {
+	passedJobs := []PipelineJob{}
+	seen := map[PipelineJob]bool{}
+
+	for _, job := range config.Jobs {
+		for _, input := range job.Inputs() {
+			for _, passed := range input.Passed {
+				passedPipeline, passedJob := config.PassedJob(passed)
+				if passedPipeline == "" || passedPipeline == pipelineName {
+					continue
+				}
+
+				pipelineJob := PipelineJob{Pipeline: passedPipeline, Job: passedJob}
+				if seen[pipelineJob] {
+					continue
+				}
+
+				seen[pipelineJob] = true
+				passedJobs = append(passedJobs, pipelineJob)
+			}
+		}
+	}
+
+	return passedJobs
+}
+
+// ValidatePipelineName returns an error if the name cannot be used for a
+// pipeline. Names cannot contain a '/', as passed constraints use it to
+// separate the pipeline from the job.
+func ValidatePipelineName(name string) error {
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("pipeline name cannot contain '/' ('%s')", name)
+	}
+
+	return nil
+}
+
+// SameIdentityAs returns whether both configs describe the same underlying
+// resource, regardless of what each pipeline calls it.
+func (config ResourceConfig) SameIdentityAs(other ResourceConfig) bool {
+	if config.Type != other.Type {
+		return false
+	}
+
+	source, err := json.Marshal(config.Source)
+	if err != nil {
+		return false
+	}
+
+	otherSource, err := json.Marshal(other.Source)
+	if err != nil {
+		return false
+	}
+
+	return string(source) == string(otherSource)
+}
+
 type ResourceConfigs []ResourceConfig
 
 func (resources ResourceConfigs) Lookup(name string) (ResourceConfig, bool) {
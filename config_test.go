@@ -100,4 +100,70 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("PassedJob", func() {
+		config := Config{
+			Jobs: JobConfigs{
+				{Name: "some-job"},
+				{Name: "some/job"},
+			},
+		}
+
+		It("names a job in the same pipeline as-is", func() {
+			pipelineName, jobName := config.PassedJob("some-job")
+			Expect(pipelineName).To(BeEmpty())
+			Expect(jobName).To(Equal("some-job"))
+		})
+
+		It("keeps slashes in the names of jobs in the same pipeline", func() {
+			pipelineName, jobName := config.PassedJob("some/job")
+			Expect(pipelineName).To(BeEmpty())
+			Expect(jobName).To(Equal("some/job"))
+		})
+
+		It("splits a job in another pipeline from its pipeline", func() {
+			pipelineName, jobName := config.PassedJob("some-pipeline/some/job")
+			Expect(pipelineName).To(Equal("some-pipeline"))
+			Expect(jobName).To(Equal("some/job"))
+		})
+
+		It("names an unknown job without a pipeline as-is", func() {
+			pipelineName, jobName := config.PassedJob("unknown-job")
+			Expect(pipelineName).To(BeEmpty())
+			Expect(jobName).To(Equal("unknown-job"))
+		})
+	})
+
+	Describe("PassedJobsInOtherPipelines", func() {
+		It("returns each job in another pipeline once", func() {
+			config := Config{
+				Jobs: JobConfigs{
+					{
+						Name: "some-job",
+						Plan: PlanSequence{
+							{Get: "some-resource", Passed: []string{"other-pipeline/unit", "some-pipeline/build"}},
+							{Get: "other-resource", Passed: []string{"other-pipeline/unit"}},
+						},
+					},
+					{
+						Name: "build",
+					},
+				},
+			}
+
+			Expect(config.PassedJobsInOtherPipelines("some-pipeline")).To(Equal([]PipelineJob{
+				{Pipeline: "other-pipeline", Job: "unit"},
+			}))
+		})
+	})
+
+	Describe("ValidatePipelineName", func() {
+		It("accepts names without a slash", func() {
+			Expect(ValidatePipelineName("some-pipeline")).To(Succeed())
+		})
+
+		It("rejects names with a slash", func() {
+			Expect(ValidatePipelineName("some/pipeline")).To(MatchError("pipeline name cannot contain '/' ('some/pipeline')"))
+		})
+	})
 })
@@ -4,6 +4,7 @@ import "time"
 
 type VersionsDB struct {
 	ResourceVersions []ResourceVersion
+	DisabledVersions []ResourceVersion
 	BuildOutputs     []BuildOutput
 	BuildInputs      []BuildInput
 	JobIDs           map[string]int
@@ -41,6 +42,27 @@ func (db VersionsDB) IsVersionFirstOccurrence(versionID int, jobID int, inputNam
 	return true
 }
 
+func (db VersionsDB) JobName(jobID int) string {
+	for name, id := range db.JobIDs {
+		if id == jobID {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func (db VersionsDB) DisabledVersionsOfResource(resourceID int) int {
+	disabled := 0
+	for _, v := range db.DisabledVersions {
+		if v.ResourceID == resourceID {
+			disabled++
+		}
+	}
+
+	return disabled
+}
+
 func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
 	candidates := VersionCandidates{}
 	for _, output := range db.ResourceVersions {
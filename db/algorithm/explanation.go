@@ -0,0 +1,93 @@
+package algorithm
+
+const (
+	ConstraintLatest      = "latest"
+	ConstraintEvery       = "every"
+	ConstraintPinned      = "pinned"
+	ConstraintPassed      = "passed"
+	ConstraintCommonBuild = "common-build"
+	ConstraintCombination = "combination"
+)
+
+type Explanation map[string]InputExplanation
+
+type InputExplanation struct {
+	Constraints            []ConstraintExplanation
+	JobsWithoutCommonBuild []string
+	PinnedVersionID        int
+	DisabledVersions       int
+	Satisfied              bool
+}
+
+type ConstraintExplanation struct {
+	Constraint string
+	Job        string
+	Candidates int
+}
+
+// inputExplainer records how an input is resolved. A nil explainer records
+// nothing, so that resolving without explaining costs nothing extra.
+type inputExplainer struct {
+	db          *VersionsDB
+	explanation InputExplanation
+}
+
+func (explainer *inputExplainer) record(constraint string, jobID int, candidates int) {
+	if explainer == nil {
+		return
+	}
+
+	job := ""
+	if jobID != 0 {
+		job = explainer.db.JobName(jobID)
+	}
+
+	explainer.explanation.Constraints = append(explainer.explanation.Constraints, ConstraintExplanation{
+		Constraint: constraint,
+		Job:        job,
+		Candidates: candidates,
+	})
+
+	explainer.explanation.Satisfied = candidates > 0
+}
+
+func (explainer *inputExplainer) withoutCommonBuild(jobID int) {
+	if explainer == nil {
+		return
+	}
+
+	explainer.explanation.JobsWithoutCommonBuild = append(explainer.explanation.JobsWithoutCommonBuild, explainer.db.JobName(jobID))
+}
+
+func (explainer *inputExplainer) satisfied(satisfied bool) {
+	if explainer == nil {
+		return
+	}
+
+	explainer.explanation.Satisfied = satisfied
+}
+
+// Explain resolves the inputs just as Resolve does, recording how many
+// candidate versions each input has left after every constraint is applied
+// and which input could not be combined with the others.
+func (configs InputConfigs) Explain(db *VersionsDB) Explanation {
+	explainers := map[string]*inputExplainer{}
+	for _, inputConfig := range configs {
+		explainers[inputConfig.Name] = &inputExplainer{
+			db: db,
+			explanation: InputExplanation{
+				PinnedVersionID:  inputConfig.PinnedVersionID,
+				DisabledVersions: db.DisabledVersionsOfResource(inputConfig.ResourceID),
+			},
+		}
+	}
+
+	configs.resolve(db, explainers)
+
+	explanation := Explanation{}
+	for name, explainer := range explainers {
+		explanation[name] = explainer.explanation
+	}
+
+	return explanation
+}
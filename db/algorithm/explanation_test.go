@@ -0,0 +1,196 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var (
+		versionsDB   *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+		explanation  algorithm.Explanation
+		resolved     bool
+	)
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 22, CheckOrder: 1},
+			},
+			DisabledVersions: []algorithm.ResourceVersion{
+				{VersionID: 4, ResourceID: 22, CheckOrder: 2},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           12,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         32,
+					JobID:           13,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 3, ResourceID: 22, CheckOrder: 1},
+					BuildID:         33,
+					JobID:           13,
+				},
+			},
+			BuildInputs: []algorithm.BuildInput{},
+			JobIDs:      map[string]int{"current": 11, "upstream-a": 12, "upstream-b": 13},
+			ResourceIDs: map[string]int{"r1": 21, "r2": 22},
+		}
+	})
+
+	JustBeforeEach(func() {
+		explanation = inputConfigs.Explain(versionsDB)
+		_, resolved = inputConfigs.Resolve(versionsDB)
+	})
+
+	Context("when an input has no passed constraints", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					Passed:     algorithm.JobSet{},
+					ResourceID: 22,
+					JobID:      11,
+				},
+			}
+		})
+
+		It("explains the latest version and counts disabled versions", func() {
+			Expect(explanation).To(Equal(algorithm.Explanation{
+				"some-input": algorithm.InputExplanation{
+					Constraints: []algorithm.ConstraintExplanation{
+						{Constraint: algorithm.ConstraintLatest, Candidates: 1},
+					},
+					DisabledVersions: 1,
+					Satisfied:        true,
+				},
+			}))
+			Expect(resolved).To(BeTrue())
+		})
+	})
+
+	Context("when an input must have passed jobs that share no versions", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					Passed:     algorithm.JobSet{12: struct{}{}, 13: struct{}{}},
+					ResourceID: 21,
+					JobID:      11,
+				},
+			}
+		})
+
+		It("explains the candidates remaining after each passed job", func() {
+			Expect(explanation["some-input"].Constraints).To(Equal([]algorithm.ConstraintExplanation{
+				{Constraint: algorithm.ConstraintPassed, Job: "upstream-a", Candidates: 1},
+				{Constraint: algorithm.ConstraintPassed, Job: "upstream-b", Candidates: 0},
+			}))
+			Expect(explanation["some-input"].Satisfied).To(BeFalse())
+			Expect(resolved).To(BeFalse())
+		})
+
+		Context("along with another input that has versions", func() {
+			BeforeEach(func() {
+				inputConfigs = append(inputConfigs, algorithm.InputConfig{
+					Name:       "other-input",
+					Passed:     algorithm.JobSet{},
+					ResourceID: 22,
+					JobID:      11,
+				})
+			})
+
+			It("still explains the other input", func() {
+				Expect(explanation["other-input"]).To(Equal(algorithm.InputExplanation{
+					Constraints: []algorithm.ConstraintExplanation{
+						{Constraint: algorithm.ConstraintLatest, Candidates: 1},
+					},
+					DisabledVersions: 1,
+					Satisfied:        true,
+				}))
+			})
+		})
+	})
+
+	Context("when two inputs passed the same job through different builds", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "input-a",
+					Passed:     algorithm.JobSet{13: struct{}{}},
+					ResourceID: 21,
+					JobID:      11,
+				},
+				{
+					Name:       "input-b",
+					Passed:     algorithm.JobSet{13: struct{}{}},
+					ResourceID: 22,
+					JobID:      11,
+				},
+			}
+		})
+
+		It("reports the job without a common build", func() {
+			Expect(explanation).To(Equal(algorithm.Explanation{
+				"input-a": algorithm.InputExplanation{
+					Constraints: []algorithm.ConstraintExplanation{
+						{Constraint: algorithm.ConstraintPassed, Job: "upstream-b", Candidates: 1},
+						{Constraint: algorithm.ConstraintCommonBuild, Job: "upstream-b", Candidates: 0},
+					},
+					JobsWithoutCommonBuild: []string{"upstream-b"},
+				},
+				"input-b": algorithm.InputExplanation{
+					Constraints: []algorithm.ConstraintExplanation{
+						{Constraint: algorithm.ConstraintPassed, Job: "upstream-b", Candidates: 1},
+						{Constraint: algorithm.ConstraintCommonBuild, Job: "upstream-b", Candidates: 0},
+					},
+					JobsWithoutCommonBuild: []string{"upstream-b"},
+					DisabledVersions:       1,
+				},
+			}))
+			Expect(resolved).To(BeFalse())
+		})
+	})
+
+	Context("when an input with passed constraints is pinned", func() {
+		BeforeEach(func() {
+			versionsDB.BuildOutputs = append(versionsDB.BuildOutputs, algorithm.BuildOutput{
+				ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				BuildID:         34,
+				JobID:           13,
+			})
+
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:            "some-input",
+					Passed:          algorithm.JobSet{13: struct{}{}},
+					PinnedVersionID: 1,
+					ResourceID:      21,
+					JobID:           11,
+				},
+			}
+		})
+
+		It("explains the pinned version after the passed constraints", func() {
+			Expect(explanation["some-input"]).To(Equal(algorithm.InputExplanation{
+				Constraints: []algorithm.ConstraintExplanation{
+					{Constraint: algorithm.ConstraintPassed, Job: "upstream-b", Candidates: 2},
+					{Constraint: algorithm.ConstraintCommonBuild, Job: "upstream-b", Candidates: 2},
+					{Constraint: algorithm.ConstraintPinned, Candidates: 1},
+				},
+				PinnedVersionID: 1,
+				Satisfied:       true,
+			}))
+			Expect(resolved).To(BeTrue())
+		})
+	})
+})
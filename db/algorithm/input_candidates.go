@@ -17,6 +17,9 @@ type InputVersionCandidates struct {
 	ExistingBuildResolver *ExistingBuildResolver
 	usingEveryVersion     *bool
 
+	// explainer, if set, records how the input is resolved
+	explainer *inputExplainer
+
 	VersionCandidates
 }
 
@@ -53,8 +56,33 @@ func (candidates InputCandidates) String() string {
 	return fmt.Sprintf("[%s]", strings.Join(lens, "; "))
 }
 
+// Reduce resolves a version for every input. The first, outermost call
+// records in the inputs' explanations how the candidates were narrowed down
+// and, if no combination of versions works out, which input it gave up on.
 func (candidates InputCandidates) Reduce(depth int, jobs JobSet) (ResolvedInputs, bool) {
-	newInputCandidates := candidates.pruneToCommonBuilds(jobs)
+	resolved, ok := candidates.reduce(depth, jobs)
+
+	if ok && depth == 0 {
+		for _, inputVersionCandidates := range candidates {
+			inputVersionCandidates.explainer.satisfied(true)
+		}
+	}
+
+	return resolved, ok
+}
+
+func (candidates InputCandidates) reduce(depth int, jobs JobSet) (ResolvedInputs, bool) {
+	explain := depth == 0
+
+	newInputCandidates := candidates.pruneToCommonBuilds(jobs, explain)
+
+	for _, inputVersionCandidates := range newInputCandidates {
+		if inputVersionCandidates.IsEmpty() {
+			// pinning only narrows candidates down further, so this input
+			// can never be resolved
+			return nil, false
+		}
+	}
 
 	for i, inputVersionCandidates := range newInputCandidates {
 		if inputVersionCandidates.Len() == 1 {
@@ -64,6 +92,16 @@ func (candidates InputCandidates) Reduce(depth int, jobs JobSet) (ResolvedInputs
 
 		if inputVersionCandidates.PinnedVersionID != 0 {
 			newInputCandidates.Pin(i, inputVersionCandidates.PinnedVersionID)
+
+			if explain && len(inputVersionCandidates.Passed) != 0 {
+				inputVersionCandidates.explainer.record(ConstraintPinned, 0, newInputCandidates[i].Count())
+			}
+
+			if newInputCandidates[i].IsEmpty() {
+				// the pinned version is not a candidate
+				return nil, false
+			}
+
 			continue
 		}
 
@@ -75,6 +113,10 @@ func (candidates InputCandidates) Reduce(depth int, jobs JobSet) (ResolvedInputs
 			id, ok := versionIDs.Next()
 			if !ok {
 				// exhaused available versions
+				if explain {
+					inputVersionCandidates.explainer.record(ConstraintCombination, 0, 0)
+				}
+
 				return nil, false
 			}
 
@@ -119,11 +161,11 @@ func (candidates InputCandidates) Unpin(input int, inputCandidates InputVersionC
 	candidates[input] = inputCandidates
 }
 
-func (candidates InputCandidates) pruneToCommonBuilds(jobs JobSet) InputCandidates {
+func (candidates InputCandidates) pruneToCommonBuilds(jobs JobSet, explain bool) InputCandidates {
 	newCandidates := make(InputCandidates, len(candidates))
 	copy(newCandidates, candidates)
 
-	for jobID, _ := range jobs {
+	for _, jobID := range jobs.sortedIDs() {
 		commonBuildIDs := newCandidates.commonBuildIDs(jobID)
 
 		for i, versionCandidates := range newCandidates {
@@ -131,11 +173,36 @@ func (candidates InputCandidates) pruneToCommonBuilds(jobs JobSet) InputCandidat
 			inputCandidates.VersionCandidates = versionCandidates.PruneVersionsOfOtherBuildIDs(jobID, commonBuildIDs)
 			newCandidates[i] = inputCandidates
 		}
+
+		if explain {
+			newCandidates.explainCommonBuilds(jobID, commonBuildIDs)
+		}
 	}
 
 	return newCandidates
 }
 
+func (candidates InputCandidates) explainCommonBuilds(jobID int, commonBuildIDs BuildSet) {
+	sharedBy := 0
+	for _, inputCandidates := range candidates {
+		if inputCandidates.Passed.Contains(jobID) {
+			sharedBy++
+		}
+	}
+
+	for _, inputCandidates := range candidates {
+		if !inputCandidates.Passed.Contains(jobID) {
+			continue
+		}
+
+		if sharedBy > 1 && len(commonBuildIDs) == 0 {
+			inputCandidates.explainer.withoutCommonBuild(jobID)
+		}
+
+		inputCandidates.explainer.record(ConstraintCommonBuild, jobID, inputCandidates.Count())
+	}
+}
+
 func (candidates InputCandidates) commonBuildIDs(jobID int) BuildSet {
 	firstTick := true
 
@@ -13,20 +13,33 @@ type InputConfig struct {
 }
 
 func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
+	return configs.resolve(db, nil)
+}
+
+// resolve records how each input was resolved with the given explainers, if
+// any. When explaining, every input's candidates are gathered even once one
+// of them has none, so that all of them are explained.
+func (configs InputConfigs) resolve(db *VersionsDB, explainers map[string]*inputExplainer) (InputMapping, bool) {
 	jobs := JobSet{}
 	inputCandidates := InputCandidates{}
+	missingCandidates := false
 
 	for _, inputConfig := range configs {
 		versionCandidates := VersionCandidates{}
 
+		explainer := explainers[inputConfig.Name]
+
 		if len(inputConfig.Passed) == 0 {
 			if inputConfig.UseEveryVersion {
 				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
+				explainer.record(ConstraintEvery, 0, versionCandidates.Count())
 			} else {
 				var versionCandidate VersionCandidate
 				var found bool
 
+				constraint := ConstraintLatest
 				if inputConfig.PinnedVersionID != 0 {
+					constraint = ConstraintPinned
 					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
 				} else {
 					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
@@ -35,22 +48,35 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 				if found {
 					versionCandidates.Add(versionCandidate)
 				}
-			}
 
-			if versionCandidates.IsEmpty() {
-				return nil, false
+				explainer.record(constraint, 0, versionCandidates.Count())
 			}
 		} else {
 			jobs = jobs.Union(inputConfig.Passed)
 
+			if explainer != nil {
+				// explain how each job narrows the candidates down
+				passed := JobSet{}
+				for _, jobID := range inputConfig.Passed.sortedIDs() {
+					passed[jobID] = struct{}{}
+
+					candidates := db.VersionsOfResourcePassedJobs(inputConfig.ResourceID, passed)
+					explainer.record(ConstraintPassed, jobID, candidates.Count())
+				}
+			}
+
 			versionCandidates = db.VersionsOfResourcePassedJobs(
 				inputConfig.ResourceID,
 				inputConfig.Passed,
 			)
+		}
 
-			if versionCandidates.IsEmpty() {
+		if versionCandidates.IsEmpty() {
+			if explainers == nil {
 				return nil, false
 			}
+
+			missingCandidates = true
 		}
 
 		existingBuildResolver := &ExistingBuildResolver{
@@ -66,9 +92,14 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			PinnedVersionID:       inputConfig.PinnedVersionID,
 			VersionCandidates:     versionCandidates,
 			ExistingBuildResolver: existingBuildResolver,
+			explainer:             explainer,
 		})
 	}
 
+	if missingCandidates {
+		return nil, false
+	}
+
 	basicMapping, ok := inputCandidates.Reduce(0, jobs)
 	if !ok {
 		return nil, false
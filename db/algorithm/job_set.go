@@ -54,6 +54,17 @@ func (set JobSet) Equal(otherSet JobSet) bool {
 	return true
 }
 
+func (set JobSet) sortedIDs() []int {
+	ids := []int{}
+	for id, _ := range set {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+
+	return ids
+}
+
 func (set JobSet) String() string {
 	xs := []string{}
 	for x, _ := range set {
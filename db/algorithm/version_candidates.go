@@ -65,6 +65,20 @@ func (candidates VersionCandidates) Len() int {
 	return len(candidates.versions)
 }
 
+func (candidates VersionCandidates) Count() int {
+	count := 0
+
+	versionIDs := candidates.VersionIDs()
+	for {
+		_, ok := versionIDs.Next()
+		if !ok {
+			return count
+		}
+
+		count++
+	}
+}
+
 func (candidates VersionCandidates) IntersectByVersion(other VersionCandidates) VersionCandidates {
 	intersected := VersionCandidates{}
 
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var ErrAPITokenExists = errors.New("an API token with that name already exists")
+
+type APIToken struct {
+	ID         int
+	TeamID     int
+	TeamName   string
+	TeamAdmin  bool
+	Name       string
+	Kind       string
+	Owner      string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+}
+
+// HashAPIToken returns the digest stored in place of the token. Tokens are
+// long and random, so a fast unsalted hash is enough to make a leaked
+// api_tokens table useless while still allowing lookups by token.
+func HashAPIToken(token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(digest[:])
+}
+
+const apiTokenColumns = "a.id, a.team_id, t.name, t.admin, a.name, a.kind, a.owner, a.scopes, a.created_at, a.expires_at, a.last_used_at"
+
+// CreateAPIToken creates a token of the given kind for the team. The owner is
+// the user who created it, and may be empty for service tokens.
+func (db *teamDB) CreateAPIToken(name string, token string, kind string, owner string, scopes []string, expiresAt time.Time) (APIToken, error) {
+	scopesPayload, err := json.Marshal(scopes)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	var expires pq.NullTime
+	if !expiresAt.IsZero() {
+		expires = pq.NullTime{Time: expiresAt, Valid: true}
+	}
+
+	var ownerValue sql.NullString
+	if owner != "" {
+		ownerValue = sql.NullString{String: owner, Valid: true}
+	}
+
+	apiToken, err := scanAPIToken(db.conn.QueryRow(`
+		WITH a AS (
+			INSERT INTO api_tokens (team_id, name, token_hash, scopes, expires_at, kind, owner)
+			SELECT id, $2, $3, $4, $5, $6, $7
+			FROM teams
+			WHERE LOWER(name) = LOWER($1)
+			RETURNING *
+		)
+		SELECT `+apiTokenColumns+`
+		FROM a
+		JOIN teams t ON t.id = a.team_id
+	`, db.teamName, name, HashAPIToken(token), string(scopesPayload), expires, kind, ownerValue))
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code.Name() == "unique_violation" {
+			return APIToken{}, ErrAPITokenExists
+		}
+
+		return APIToken{}, err
+	}
+
+	return apiToken, nil
+}
+
+func (db *teamDB) GetAPITokens() ([]APIToken, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		JOIN teams t ON t.id = a.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+		ORDER BY a.name ASC
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	apiTokens := []APIToken{}
+	for rows.Next() {
+		apiToken, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		apiTokens = append(apiTokens, apiToken)
+	}
+
+	return apiTokens, nil
+}
+
+func (db *teamDB) RevokeAPIToken(name string) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM api_tokens a
+		USING teams t
+		WHERE t.id = a.team_id
+		AND LOWER(t.name) = LOWER($1)
+		AND a.name = $2
+	`, db.teamName, name)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// FindAPIToken looks up an unexpired token by its value.
+func (db *SQLDB) FindAPIToken(token string) (APIToken, bool, error) {
+	apiToken, err := scanAPIToken(db.conn.QueryRow(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		JOIN teams t ON t.id = a.team_id
+		WHERE a.token_hash = $1
+		AND (a.expires_at IS NULL OR a.expires_at > now())
+	`, HashAPIToken(token)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return APIToken{}, false, nil
+		}
+
+		return APIToken{}, false, err
+	}
+
+	return apiToken, true, nil
+}
+
+func (db *SQLDB) MarkAPITokenUsed(tokenID int) error {
+	_, err := db.conn.Exec(`
+		UPDATE api_tokens
+		SET last_used_at = now()
+		WHERE id = $1
+	`, tokenID)
+	return err
+}
+
+func scanAPIToken(row scannable) (APIToken, error) {
+	var apiToken APIToken
+	var scopes string
+	var owner sql.NullString
+	var expiresAt, lastUsedAt pq.NullTime
+
+	err := row.Scan(
+		&apiToken.ID,
+		&apiToken.TeamID,
+		&apiToken.TeamName,
+		&apiToken.TeamAdmin,
+		&apiToken.Name,
+		&apiToken.Kind,
+		&owner,
+		&scopes,
+		&apiToken.CreatedAt,
+		&expiresAt,
+		&lastUsedAt,
+	)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	err = json.Unmarshal([]byte(scopes), &apiToken.Scopes)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	if owner.Valid {
+		apiToken.Owner = owner.String
+	}
+
+	if expiresAt.Valid {
+		apiToken.ExpiresAt = expiresAt.Time
+	}
+
+	if lastUsedAt.Valid {
+		apiToken.LastUsedAt = lastUsedAt.Time
+	}
+
+	return apiToken, nil
+}
@@ -0,0 +1,257 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API tokens", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		database    *db.SQLDB
+		teamDB      db.TeamDB
+		otherTeamDB db.TeamDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+
+		_, err := database.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = database.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("CreateAPIToken", func() {
+		It("saves the token for the team", func() {
+			expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+			apiToken, err := teamDB.CreateAPIToken("deploy-bot", "some-token", atc.APITokenKindService, "", []string{"read"}, expiresAt)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(apiToken.Name).To(Equal("deploy-bot"))
+			Expect(apiToken.TeamName).To(Equal("some-team"))
+			Expect(apiToken.Scopes).To(Equal([]string{"read"}))
+			Expect(apiToken.ExpiresAt.Unix()).To(Equal(expiresAt.Unix()))
+			Expect(apiToken.LastUsedAt.IsZero()).To(BeTrue())
+		})
+
+		It("does not store the token itself", func() {
+			_, err := teamDB.CreateAPIToken("deploy-bot", "some-token", atc.APITokenKindService, "", []string{"read"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var tokenHash string
+			err = dbConn.QueryRow(`SELECT token_hash FROM api_tokens`).Scan(&tokenHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenHash).To(Equal(db.HashAPIToken("some-token")))
+			Expect(tokenHash).NotTo(ContainSubstring("some-token"))
+		})
+
+		It("rejects a second token with the same name", func() {
+			_, err := teamDB.CreateAPIToken("deploy-bot", "some-token", atc.APITokenKindService, "", []string{"read"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateAPIToken("deploy-bot", "other-token", atc.APITokenKindService, "", []string{"read"}, time.Time{})
+			Expect(err).To(Equal(db.ErrAPITokenExists))
+		})
+
+		It("allows the same name in another team", func() {
+			_, err := teamDB.CreateAPIToken("deploy-bot", "some-token", atc.APITokenKindService, "", []string{"read"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = otherTeamDB.CreateAPIToken("deploy-bot", "other-token", atc.APITokenKindService, "", []string{"read"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("GetAPITokens", func() {
+		BeforeEach(func() {
+			_, err := teamDB.CreateAPIToken("deploy-bot", "some-token", atc.APITokenKindService, "", []string{"read", "write"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateAPIToken("audit-bot", "audit-token", atc.APITokenKindService, "", []string{"read"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = otherTeamDB.CreateAPIToken("other-bot", "other-token", atc.APITokenKindService, "", []string{"read"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the team's tokens ordered by name", func() {
+			apiTokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(apiTokens).To(HaveLen(2))
+			Expect(apiTokens[0].Name).To(Equal("audit-bot"))
+			Expect(apiTokens[1].Name).To(Equal("deploy-bot"))
+			Expect(apiTokens[1].Scopes).To(Equal([]string{"read", "write"}))
+		})
+	})
+
+	Describe("FindAPIToken", func() {
+		var apiToken db.APIToken
+
+		BeforeEach(func() {
+			var err error
+			apiToken, err = teamDB.CreateAPIToken("deploy-bot", "some-token", atc.APITokenKindService, "", []string{"write"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finds the token by its value", func() {
+			found, ok, err := database.FindAPIToken("some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(found.ID).To(Equal(apiToken.ID))
+			Expect(found.TeamName).To(Equal("some-team"))
+			Expect(found.TeamAdmin).To(BeFalse())
+		})
+
+		It("does not find unknown tokens", func() {
+			_, ok, err := database.FindAPIToken("bogus-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("does not find expired tokens", func() {
+			_, err := teamDB.CreateAPIToken("expired-bot", "expired-token", atc.APITokenKindService, "", []string{"write"}, time.Now().Add(-time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok, err := database.FindAPIToken("expired-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("does not find revoked tokens", func() {
+			revoked, err := teamDB.RevokeAPIToken("deploy-bot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+
+			_, ok, err := database.FindAPIToken("some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("records when the token was last used", func() {
+			err := database.MarkAPITokenUsed(apiToken.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, _, err := database.FindAPIToken("some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.LastUsedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+	})
+
+	Describe("revoking a user's sessions", func() {
+		BeforeEach(func() {
+			_, err := teamDB.CreateAPIToken("alice-token", "alice-token", atc.APITokenKindPersonal, "alice", []string{"write"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateAPIToken("bob-token", "bob-token", atc.APITokenKindPersonal, "bob", []string{"write"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateAPIToken("deploy-bot", "some-token", atc.APITokenKindService, "", []string{"write"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = teamDB.RevokeUserSessions("alice")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes the user's personal tokens", func() {
+			_, found, err := database.FindAPIToken("alice-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("keeps other users' personal tokens", func() {
+			apiToken, found, err := database.FindAPIToken("bob-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(apiToken.Kind).To(Equal(atc.APITokenKindPersonal))
+			Expect(apiToken.Owner).To(Equal("bob"))
+		})
+
+		It("keeps service tokens", func() {
+			_, found, err := database.FindAPIToken("some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Describe("revoking all sessions", func() {
+		BeforeEach(func() {
+			_, err := teamDB.CreateAPIToken("alice-token", "alice-token", atc.APITokenKindPersonal, "alice", []string{"write"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateAPIToken("deploy-bot", "some-token", atc.APITokenKindService, "", []string{"write"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = teamDB.RevokeAllSessions()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes personal tokens", func() {
+			_, found, err := database.FindAPIToken("alice-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("keeps service tokens", func() {
+			_, found, err := database.FindAPIToken("some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Describe("RevokeAPIToken", func() {
+		BeforeEach(func() {
+			_, err := teamDB.CreateAPIToken("deploy-bot", "some-token", atc.APITokenKindService, "", []string{"write"}, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not revoke another team's token", func() {
+			revoked, err := otherTeamDB.RevokeAPIToken("deploy-bot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+
+			_, ok, err := database.FindAPIToken("some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+	})
+})
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/event"
 )
 
@@ -102,6 +103,8 @@ type build struct {
 	bus  *notificationsBus
 
 	lockFactory LockFactory
+
+	versionsDBCaches *VersionsDBCaches
 }
 
 func (b *build) ID() int {
@@ -174,7 +177,7 @@ func (b *build) IsRunning() bool {
 }
 
 func (b *build) Reload() (bool, error) {
-	buildFactory := newBuildFactory(b.conn, b.bus, b.lockFactory)
+	buildFactory := newBuildFactory(b.conn, b.bus, b.lockFactory, b.versionsDBCaches)
 	newBuild, found, err := buildFactory.ScanBuild(b.conn.QueryRow(`
 		SELECT `+qualifiedBuildColumns+`
 		FROM builds b
@@ -342,6 +345,14 @@ func (b *build) Finish(status Status) error {
 		return err
 	}
 
+	releasedLocks, err := checkIfRowsUpdated(tx, `
+		DELETE FROM team_lock_holders
+		WHERE build_id = $1
+	`, b.id)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -352,6 +363,66 @@ func (b *build) Finish(status Status) error {
 		return err
 	}
 
+	if releasedLocks {
+		err = notifyTeamPipelinesScheduling(b.conn, b.bus, b.teamID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if b.pipelineID != 0 {
+		err = b.bus.Notify(pipelineSchedulingChannel(b.pipelineID))
+		if err != nil {
+			return err
+		}
+
+		if status == StatusSucceeded {
+			err = b.notifyDownstreamPipelines()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// notifyDownstreamPipelines wakes up the other pipelines of the team with
+// passed constraints naming the build's job, as it may have new versions for
+// them.
+func (b *build) notifyDownstreamPipelines() error {
+	rows, err := b.conn.Query(`
+		SELECT DISTINCT pj.pipeline_id
+		FROM pipeline_passed_jobs pj
+		JOIN pipelines p ON p.id = pj.pipeline_id
+		WHERE p.team_id = $1
+		AND pj.passed_pipeline_name = $2
+		AND pj.passed_job_name = $3
+	`, b.teamID, b.pipelineName, b.jobName)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	pipelineIDs := []int{}
+	for rows.Next() {
+		var pipelineID int
+		err := rows.Scan(&pipelineID)
+		if err != nil {
+			return err
+		}
+
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+
+	for _, pipelineID := range pipelineIDs {
+		err := b.bus.Notify(pipelineSchedulingChannel(pipelineID))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -571,6 +642,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 			MaxRunningBuilds:    BuildPreparationStatusNotBlocking,
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusNotBlocking,
+			Locks:               map[string]BuildPreparationStatus{},
 			MissingInputReasons: MissingInputReasons{},
 		}, true, nil
 	}
@@ -613,7 +685,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
-	tdbf := NewTeamDBFactory(b.conn, b.bus, b.lockFactory)
+	tdbf := NewTeamDBFactory(b.conn, b.bus, b.lockFactory, b.versionsDBCaches)
 	tdb := tdbf.GetTeamDB(b.teamName)
 	savedPipeline, found, err := tdb.GetPipelineByName(b.pipelineName)
 	if err != nil {
@@ -624,7 +696,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, nil
 	}
 
-	pdbf := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
+	pdbf := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory, b.versionsDBCaches)
 	pdb := pdbf.Build(savedPipeline)
 	if err != nil {
 		return BuildPreparation{}, false, err
@@ -635,6 +707,11 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, nil
 	}
 
+	locks, err := teamLockStatuses(b.conn, savedPipeline.TeamID, b.id, jobConfig.Locks)
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
 	configInputs := config.JobInputs(jobConfig)
 
 	nextBuildInputs, found, err := pdb.GetNextBuildInputs(jobName)
@@ -642,6 +719,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 	inputsSatisfiedStatus := BuildPreparationStatusBlocking
 	inputs := map[string]BuildPreparationStatus{}
 	missingInputReasons := MissingInputReasons{}
+	var inputsExplanation algorithm.Explanation
 
 	if found {
 		inputsSatisfiedStatus = BuildPreparationStatusNotBlocking
@@ -654,6 +732,11 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 			return BuildPreparation{}, false, err
 		}
 
+		inputsExplanation, _, err = pdb.GetInputsExplanation(jobName)
+		if err != nil {
+			return BuildPreparation{}, false, err
+		}
+
 		for _, configInput := range configInputs {
 			found := false
 			for _, buildInput := range buildInputs {
@@ -710,6 +793,8 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
+		InputsExplanation:   inputsExplanation,
+		Locks:               locks,
 	}
 
 	return buildPreparation, true, nil
@@ -728,7 +813,7 @@ func (b *build) SaveInput(input BuildInput) (SavedVersionedResource, error) {
 		return SavedVersionedResource{}, err
 	}
 
-	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
+	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory, b.versionsDBCaches)
 
 	pipelineDB := pipelineDBFactory.Build(savedPipeline)
 
@@ -747,7 +832,7 @@ func (b *build) SaveOutput(vr VersionedResource, explicit bool) (SavedVersionedR
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
-	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
+	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory, b.versionsDBCaches)
 	pipelineDB := pipelineDBFactory.Build(savedPipeline)
 
 	return pipelineDB.SaveOutput(b.id, vr, explicit)
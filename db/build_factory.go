@@ -6,11 +6,12 @@ import (
 	"github.com/lib/pq"
 )
 
-func newBuildFactory(conn Conn, bus *notificationsBus, lockFactory LockFactory) *buildFactory {
+func newBuildFactory(conn Conn, bus *notificationsBus, lockFactory LockFactory, versionsDBCaches *VersionsDBCaches) *buildFactory {
 	return &buildFactory{
-		conn:        conn,
-		lockFactory: lockFactory,
-		bus:         bus,
+		conn:             conn,
+		lockFactory:      lockFactory,
+		bus:              bus,
+		versionsDBCaches: versionsDBCaches,
 	}
 }
 
@@ -19,6 +20,8 @@ type buildFactory struct {
 	bus  *notificationsBus
 
 	lockFactory LockFactory
+
+	versionsDBCaches *VersionsDBCaches
 }
 
 func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
@@ -48,6 +51,8 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		bus:         f.bus,
 		lockFactory: f.lockFactory,
 
+		versionsDBCaches: f.versionsDBCaches,
+
 		id:                  id,
 		name:                name,
 		status:              Status(status),
@@ -1,6 +1,10 @@
 package db
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/concourse/atc/db/algorithm"
+)
 
 type BuildPreparationStatus string
 
@@ -38,4 +42,6 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus
 	InputsSatisfied     BuildPreparationStatus
 	MissingInputReasons MissingInputReasons
+	InputsExplanation   algorithm.Explanation
+	Locks               map[string]BuildPreparationStatus
 }
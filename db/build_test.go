@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
@@ -41,7 +42,8 @@ var _ = Describe("Build", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 
 		pipelineConfig = atc.Config{
@@ -73,7 +75,7 @@ var _ = Describe("Build", func() {
 		pipeline, _, err = teamDB.SaveConfigToBeDeprecated("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		pipelineDB = pipelineDBFactory.Build(pipeline)
 	})
 
@@ -476,6 +478,7 @@ var _ = Describe("Build", func() {
 				Inputs:              map[string]db.BuildPreparationStatus{},
 				InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
 				MissingInputReasons: db.MissingInputReasons{},
+				Locks:               map[string]db.BuildPreparationStatus{},
 			}
 		})
 
@@ -618,6 +621,34 @@ var _ = Describe("Build", func() {
 					})
 				})
 
+				Context("when the job has team locks", func() {
+					BeforeEach(func() {
+						pipelineConfig.Jobs[0].Locks = []string{"deploy", "smoke-env"}
+
+						_, _, err := teamDB.SaveConfigToBeDeprecated("some-pipeline", pipelineConfig, pipeline.Version, db.PipelineUnpaused)
+						Expect(err).NotTo(HaveOccurred())
+
+						otherBuild, err := pipelineDB.CreateJobBuild("some-other-job")
+						Expect(err).NotTo(HaveOccurred())
+
+						acquired, err := pipelineDB.AcquireTeamLocks(lagertest.NewTestLogger("test"), otherBuild.ID(), []string{"deploy"})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(acquired).To(BeTrue())
+
+						expectedBuildPrep.Locks = map[string]db.BuildPreparationStatus{
+							"deploy":    db.BuildPreparationStatusBlocking,
+							"smoke-env": db.BuildPreparationStatusNotBlocking,
+						}
+					})
+
+					It("returns build preparation with the locks held by other builds blocking", func() {
+						buildPrep, found, err := build.GetPreparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
+
 				Context("when max running builds is de-reached", func() {
 					BeforeEach(func() {
 						err := pipelineDB.SetMaxInFlightReached("some-job", true)
@@ -744,6 +775,30 @@ var _ = Describe("Build", func() {
 					Expect(found).To(BeTrue())
 					Expect(buildPrep).To(Equal(expectedBuildPrep))
 				})
+
+				Context("when the scheduler has explained why the inputs are unsatisfied", func() {
+					BeforeEach(func() {
+						explanation := algorithm.Explanation{
+							"input3": algorithm.InputExplanation{
+								Constraints: []algorithm.ConstraintExplanation{
+									{Constraint: algorithm.ConstraintPassed, Job: "some-upstream-job", Candidates: 0},
+								},
+							},
+						}
+
+						err := pipelineDB.SaveInputsExplanation(explanation, "some-job")
+						Expect(err).NotTo(HaveOccurred())
+
+						expectedBuildPrep.InputsExplanation = explanation
+					})
+
+					It("returns the explanation", func() {
+						buildPrep, found, err := build.GetPreparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
 			})
 		})
 	})
@@ -107,6 +107,7 @@ type DB interface {
 	ReapVolume(string) error
 	SetVolumeTTLAndSizeInBytes(string, time.Duration, int64) error
 	SetVolumeTTL(string, time.Duration) error
+	MarkVolumeAsUsed(handle string) error
 	GetVolumeTTL(volumeHandle string) (time.Duration, bool, error)
 	GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
 )
 
 var _ = Describe("Keeping track of containers", func() {
@@ -42,8 +43,9 @@ var _ = Describe("Keeping track of containers", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
 
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 
 		config := atc.Config{
 			Jobs: atc.JobConfigs{
@@ -79,7 +81,7 @@ var _ = Describe("Keeping track of containers", func() {
 		Expect(err).NotTo(HaveOccurred())
 		teamID = savedTeam.ID
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		teamDB = teamDBFactory.GetTeamDB("team-name")
 
 		build, err = teamDB.CreateOneOffBuild()
@@ -91,7 +93,7 @@ var _ = Describe("Keeping track of containers", func() {
 		_, _, err = teamDB.SaveConfigToBeDeprecated("some-other-pipeline", config, 0, db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
 
 		_, err = database.SaveWorker(db.WorkerInfo{
@@ -545,6 +547,109 @@ var _ = Describe("Keeping track of containers", func() {
 		})
 	})
 
+	Describe("ActiveTaskCounts", func() {
+		BeforeEach(func() {
+			started, err := build.Start("some-engine", "some-metadata")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			finishedBuild, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = finishedBuild.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			containers := []db.Container{
+				{
+					ContainerIdentifier: db.ContainerIdentifier{
+						Stage:   db.ContainerStageRun,
+						PlanID:  "some-task-plan",
+						BuildID: build.ID(),
+					},
+					ContainerMetadata: db.ContainerMetadata{
+						Handle:     "some-task-handle",
+						Type:       db.ContainerTypeTask,
+						WorkerName: "some-worker",
+						TeamID:     teamID,
+					},
+				},
+				{
+					ContainerIdentifier: db.ContainerIdentifier{
+						Stage:   db.ContainerStageRun,
+						PlanID:  "some-other-task-plan",
+						BuildID: build.ID(),
+					},
+					ContainerMetadata: db.ContainerMetadata{
+						Handle:     "some-other-task-handle",
+						Type:       db.ContainerTypeTask,
+						WorkerName: "some-worker",
+						TeamID:     teamID,
+					},
+				},
+				{
+					ContainerIdentifier: db.ContainerIdentifier{
+						Stage:   db.ContainerStageRun,
+						PlanID:  "some-get-plan",
+						BuildID: build.ID(),
+					},
+					ContainerMetadata: db.ContainerMetadata{
+						Handle:     "some-get-handle",
+						Type:       db.ContainerTypeGet,
+						WorkerName: "updated-resource-type-worker",
+						TeamID:     teamID,
+					},
+				},
+				{
+					ContainerIdentifier: db.ContainerIdentifier{
+						Stage:   db.ContainerStageRun,
+						PlanID:  "some-finished-task-plan",
+						BuildID: finishedBuild.ID(),
+					},
+					ContainerMetadata: db.ContainerMetadata{
+						Handle:     "some-finished-task-handle",
+						Type:       db.ContainerTypeTask,
+						WorkerName: "updated-resource-type-worker",
+						PipelineID: savedPipeline.ID,
+						TeamID:     teamID,
+					},
+				},
+			}
+
+			for _, container := range containers {
+				_, err := database.CreateContainer(container, 5*time.Minute, time.Duration(0), []string{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("counts the task containers of running builds on each worker", func() {
+			counts, err := database.ActiveTaskCounts()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int{"some-worker": 2}))
+		})
+
+		Context("when task steps of a running build have finished", func() {
+			BeforeEach(func() {
+				err := build.SaveEvent(event.FinishTask{
+					ExitStatus: 0,
+					Origin:     event.Origin{ID: "some-task-plan"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvent(event.Error{
+					Message: "oh no",
+					Origin:  event.Origin{ID: "some-other-task-plan"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not count their containers", func() {
+				counts, err := database.ActiveTaskCounts()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(counts).To(BeEmpty())
+			})
+		})
+	})
+
 	It("can reap a container", func() {
 		containerToCreate := db.Container{
 			ContainerIdentifier: db.ContainerIdentifier{
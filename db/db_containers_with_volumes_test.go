@@ -37,8 +37,9 @@ var _ = Describe("Keeping track of containers", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		database = db.NewSQL(dbConn, bus, lockFactory)
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 
 		savedTeam, err := database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
@@ -34,13 +34,14 @@ var _ = Describe("Image Versions", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 
 		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		teamDB := teamDBFactory.GetTeamDB("some-team")
 
 		config := atc.Config{
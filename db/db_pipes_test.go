@@ -33,7 +33,8 @@ var _ = Describe("Pipes", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 
 		savedTeam, err = database.CreateTeam(db.Team{Name: "team-name"})
 		Expect(err).NotTo(HaveOccurred())
@@ -39,9 +39,10 @@ var _ = Describe("SQL DB Teams", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 
 		database.DeleteTeamByName(atc.DefaultTeamName)
 	})
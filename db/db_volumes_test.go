@@ -40,10 +40,11 @@ var _ = Describe("Keeping track of volumes", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		sqlDB := db.NewSQL(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		sqlDB := db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 		database = sqlDB
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		team, err := database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 		teamID = team.ID
@@ -55,7 +56,7 @@ var _ = Describe("Keeping track of volumes", func() {
 				},
 			},
 		}
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 		savedPipeline, _, err := teamDB.SaveConfigToBeDeprecated("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
@@ -261,6 +262,45 @@ var _ = Describe("Keeping track of volumes", func() {
 			})
 		})
 
+		Describe("MarkVolumeAsUsed", func() {
+			var identifier db.VolumeIdentifier
+
+			BeforeEach(func() {
+				identifier = db.VolumeIdentifier{
+					COW: &db.COWIdentifier{
+						ParentVolumeHandle: "parent-volume-handle",
+					},
+				}
+
+				err := database.InsertVolume(db.Volume{
+					Handle:     "volume-1-handle",
+					WorkerName: "some-worker",
+					TTL:        5 * time.Minute,
+					Identifier: identifier,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("records when the volume was last used", func() {
+				volumes, err := database.GetVolumesByIdentifier(identifier)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(HaveLen(1))
+
+				createdAt := volumes[0].LastUsedAt
+				Expect(createdAt).NotTo(BeZero())
+
+				time.Sleep(10 * time.Millisecond)
+
+				err = database.MarkVolumeAsUsed("volume-1-handle")
+				Expect(err).NotTo(HaveOccurred())
+
+				volumes, err = database.GetVolumesByIdentifier(identifier)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(HaveLen(1))
+				Expect(volumes[0].LastUsedAt).To(BeTemporally(">", createdAt))
+			})
+		})
+
 		Describe("cow volumes", func() {
 			var cowIdentifier db.VolumeIdentifier
 
@@ -33,7 +33,8 @@ var _ = Describe("Keeping track of workers", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 	})
 
 	AfterEach(func() {
@@ -113,6 +113,13 @@ type FakePipelineDB struct {
 		result2 bool
 		result3 error
 	}
+	SchedulingNotifierStub        func() (db.Notifier, error)
+	schedulingNotifierMutex       sync.RWMutex
+	schedulingNotifierArgsForCall []struct{}
+	schedulingNotifierReturns     struct {
+		result1 db.Notifier
+		result2 error
+	}
 	GetResourceStub        func(resourceName string) (db.SavedResource, bool, error)
 	getResourceMutex       sync.RWMutex
 	getResourceArgsForCall []struct {
@@ -232,13 +239,44 @@ type FakePipelineDB struct {
 	setResourceCheckErrorReturns struct {
 		result1 error
 	}
-	AcquireResourceCheckingLockStub        func(logger lager.Logger, resource db.SavedResource, length time.Duration, immediate bool) (db.Lock, bool, error)
+	SetResourceNextCheckAtStub        func(resource db.SavedResource, nextCheckAt time.Time) error
+	setResourceNextCheckAtMutex       sync.RWMutex
+	setResourceNextCheckAtArgsForCall []struct {
+		resource    db.SavedResource
+		nextCheckAt time.Time
+	}
+	setResourceNextCheckAtReturns struct {
+		result1 error
+	}
+	SaveResourceCheckStub        func(resource db.SavedResource, check db.ResourceCheck) error
+	saveResourceCheckMutex       sync.RWMutex
+	saveResourceCheckArgsForCall []struct {
+		resource db.SavedResource
+		check    db.ResourceCheck
+	}
+	saveResourceCheckReturns struct {
+		result1 error
+	}
+	GetResourceChecksStub        func(resourceName string, page db.Page) ([]db.SavedResourceCheck, db.Pagination, bool, error)
+	getResourceChecksMutex       sync.RWMutex
+	getResourceChecksArgsForCall []struct {
+		resourceName string
+		page         db.Page
+	}
+	getResourceChecksReturns struct {
+		result1 []db.SavedResourceCheck
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}
+	AcquireResourceCheckingLockStub        func(logger lager.Logger, resource db.SavedResource, resourceHash string, length time.Duration, immediate bool) (db.Lock, bool, error)
 	acquireResourceCheckingLockMutex       sync.RWMutex
 	acquireResourceCheckingLockArgsForCall []struct {
-		logger    lager.Logger
-		resource  db.SavedResource
-		length    time.Duration
-		immediate bool
+		logger       lager.Logger
+		resource     db.SavedResource
+		resourceHash string
+		length       time.Duration
+		immediate    bool
 	}
 	acquireResourceCheckingLockReturns struct {
 		result1 db.Lock
@@ -392,6 +430,17 @@ type FakePipelineDB struct {
 	useInputsForBuildReturns struct {
 		result1 error
 	}
+	AcquireTeamLocksStub        func(logger lager.Logger, buildID int, locks []string) (bool, error)
+	acquireTeamLocksMutex       sync.RWMutex
+	acquireTeamLocksArgsForCall []struct {
+		logger  lager.Logger
+		buildID int
+		locks   []string
+	}
+	acquireTeamLocksReturns struct {
+		result1 bool
+		result2 error
+	}
 	LoadVersionsDBStub        func() (*algorithm.VersionsDB, error)
 	loadVersionsDBMutex       sync.RWMutex
 	loadVersionsDBArgsForCall []struct{}
@@ -455,6 +504,25 @@ type FakePipelineDB struct {
 	deleteNextInputMappingReturns struct {
 		result1 error
 	}
+	SaveInputsExplanationStub        func(explanation algorithm.Explanation, jobName string) error
+	saveInputsExplanationMutex       sync.RWMutex
+	saveInputsExplanationArgsForCall []struct {
+		explanation algorithm.Explanation
+		jobName     string
+	}
+	saveInputsExplanationReturns struct {
+		result1 error
+	}
+	GetInputsExplanationStub        func(jobName string) (algorithm.Explanation, bool, error)
+	getInputsExplanationMutex       sync.RWMutex
+	getInputsExplanationArgsForCall []struct {
+		jobName string
+	}
+	getInputsExplanationReturns struct {
+		result1 algorithm.Explanation
+		result2 bool
+		result3 error
+	}
 	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups []string) ([]db.Build, error)
 	getRunningBuildsBySerialGroupMutex       sync.RWMutex
 	getRunningBuildsBySerialGroupArgsForCall []struct {
@@ -952,6 +1020,32 @@ func (fake *FakePipelineDB) AcquireSchedulingLockReturns(result1 db.Lock, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipelineDB) SchedulingNotifier() (db.Notifier, error) {
+	fake.schedulingNotifierMutex.Lock()
+	fake.schedulingNotifierArgsForCall = append(fake.schedulingNotifierArgsForCall, struct{}{})
+	fake.recordInvocation("SchedulingNotifier", []interface{}{})
+	fake.schedulingNotifierMutex.Unlock()
+	if fake.SchedulingNotifierStub != nil {
+		return fake.SchedulingNotifierStub()
+	} else {
+		return fake.schedulingNotifierReturns.result1, fake.schedulingNotifierReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) SchedulingNotifierCallCount() int {
+	fake.schedulingNotifierMutex.RLock()
+	defer fake.schedulingNotifierMutex.RUnlock()
+	return len(fake.schedulingNotifierArgsForCall)
+}
+
+func (fake *FakePipelineDB) SchedulingNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.SchedulingNotifierStub = nil
+	fake.schedulingNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) GetResource(resourceName string) (db.SavedResource, bool, error) {
 	fake.getResourceMutex.Lock()
 	fake.getResourceArgsForCall = append(fake.getResourceArgsForCall, struct {
@@ -1395,18 +1489,124 @@ func (fake *FakePipelineDB) SetResourceCheckErrorReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakePipelineDB) AcquireResourceCheckingLock(logger lager.Logger, resource db.SavedResource, length time.Duration, immediate bool) (db.Lock, bool, error) {
+func (fake *FakePipelineDB) SetResourceNextCheckAt(resource db.SavedResource, nextCheckAt time.Time) error {
+	fake.setResourceNextCheckAtMutex.Lock()
+	fake.setResourceNextCheckAtArgsForCall = append(fake.setResourceNextCheckAtArgsForCall, struct {
+		resource    db.SavedResource
+		nextCheckAt time.Time
+	}{resource, nextCheckAt})
+	fake.recordInvocation("SetResourceNextCheckAt", []interface{}{resource, nextCheckAt})
+	fake.setResourceNextCheckAtMutex.Unlock()
+	if fake.SetResourceNextCheckAtStub != nil {
+		return fake.SetResourceNextCheckAtStub(resource, nextCheckAt)
+	} else {
+		return fake.setResourceNextCheckAtReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SetResourceNextCheckAtCallCount() int {
+	fake.setResourceNextCheckAtMutex.RLock()
+	defer fake.setResourceNextCheckAtMutex.RUnlock()
+	return len(fake.setResourceNextCheckAtArgsForCall)
+}
+
+func (fake *FakePipelineDB) SetResourceNextCheckAtArgsForCall(i int) (db.SavedResource, time.Time) {
+	fake.setResourceNextCheckAtMutex.RLock()
+	defer fake.setResourceNextCheckAtMutex.RUnlock()
+	return fake.setResourceNextCheckAtArgsForCall[i].resource, fake.setResourceNextCheckAtArgsForCall[i].nextCheckAt
+}
+
+func (fake *FakePipelineDB) SetResourceNextCheckAtReturns(result1 error) {
+	fake.SetResourceNextCheckAtStub = nil
+	fake.setResourceNextCheckAtReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) SaveResourceCheck(resource db.SavedResource, check db.ResourceCheck) error {
+	fake.saveResourceCheckMutex.Lock()
+	fake.saveResourceCheckArgsForCall = append(fake.saveResourceCheckArgsForCall, struct {
+		resource db.SavedResource
+		check    db.ResourceCheck
+	}{resource, check})
+	fake.recordInvocation("SaveResourceCheck", []interface{}{resource, check})
+	fake.saveResourceCheckMutex.Unlock()
+	if fake.SaveResourceCheckStub != nil {
+		return fake.SaveResourceCheckStub(resource, check)
+	} else {
+		return fake.saveResourceCheckReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SaveResourceCheckCallCount() int {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return len(fake.saveResourceCheckArgsForCall)
+}
+
+func (fake *FakePipelineDB) SaveResourceCheckArgsForCall(i int) (db.SavedResource, db.ResourceCheck) {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return fake.saveResourceCheckArgsForCall[i].resource, fake.saveResourceCheckArgsForCall[i].check
+}
+
+func (fake *FakePipelineDB) SaveResourceCheckReturns(result1 error) {
+	fake.SaveResourceCheckStub = nil
+	fake.saveResourceCheckReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetResourceChecks(resourceName string, page db.Page) ([]db.SavedResourceCheck, db.Pagination, bool, error) {
+	fake.getResourceChecksMutex.Lock()
+	fake.getResourceChecksArgsForCall = append(fake.getResourceChecksArgsForCall, struct {
+		resourceName string
+		page         db.Page
+	}{resourceName, page})
+	fake.recordInvocation("GetResourceChecks", []interface{}{resourceName, page})
+	fake.getResourceChecksMutex.Unlock()
+	if fake.GetResourceChecksStub != nil {
+		return fake.GetResourceChecksStub(resourceName, page)
+	} else {
+		return fake.getResourceChecksReturns.result1, fake.getResourceChecksReturns.result2, fake.getResourceChecksReturns.result3, fake.getResourceChecksReturns.result4
+	}
+}
+
+func (fake *FakePipelineDB) GetResourceChecksCallCount() int {
+	fake.getResourceChecksMutex.RLock()
+	defer fake.getResourceChecksMutex.RUnlock()
+	return len(fake.getResourceChecksArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetResourceChecksArgsForCall(i int) (string, db.Page) {
+	fake.getResourceChecksMutex.RLock()
+	defer fake.getResourceChecksMutex.RUnlock()
+	return fake.getResourceChecksArgsForCall[i].resourceName, fake.getResourceChecksArgsForCall[i].page
+}
+
+func (fake *FakePipelineDB) GetResourceChecksReturns(result1 []db.SavedResourceCheck, result2 db.Pagination, result3 bool, result4 error) {
+	fake.GetResourceChecksStub = nil
+	fake.getResourceChecksReturns = struct {
+		result1 []db.SavedResourceCheck
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakePipelineDB) AcquireResourceCheckingLock(logger lager.Logger, resource db.SavedResource, resourceHash string, length time.Duration, immediate bool) (db.Lock, bool, error) {
 	fake.acquireResourceCheckingLockMutex.Lock()
 	fake.acquireResourceCheckingLockArgsForCall = append(fake.acquireResourceCheckingLockArgsForCall, struct {
-		logger    lager.Logger
-		resource  db.SavedResource
-		length    time.Duration
-		immediate bool
-	}{logger, resource, length, immediate})
-	fake.recordInvocation("AcquireResourceCheckingLock", []interface{}{logger, resource, length, immediate})
+		logger       lager.Logger
+		resource     db.SavedResource
+		resourceHash string
+		length       time.Duration
+		immediate    bool
+	}{logger, resource, resourceHash, length, immediate})
+	fake.recordInvocation("AcquireResourceCheckingLock", []interface{}{logger, resource, resourceHash, length, immediate})
 	fake.acquireResourceCheckingLockMutex.Unlock()
 	if fake.AcquireResourceCheckingLockStub != nil {
-		return fake.AcquireResourceCheckingLockStub(logger, resource, length, immediate)
+		return fake.AcquireResourceCheckingLockStub(logger, resource, resourceHash, length, immediate)
 	} else {
 		return fake.acquireResourceCheckingLockReturns.result1, fake.acquireResourceCheckingLockReturns.result2, fake.acquireResourceCheckingLockReturns.result3
 	}
@@ -1418,10 +1618,10 @@ func (fake *FakePipelineDB) AcquireResourceCheckingLockCallCount() int {
 	return len(fake.acquireResourceCheckingLockArgsForCall)
 }
 
-func (fake *FakePipelineDB) AcquireResourceCheckingLockArgsForCall(i int) (lager.Logger, db.SavedResource, time.Duration, bool) {
+func (fake *FakePipelineDB) AcquireResourceCheckingLockArgsForCall(i int) (lager.Logger, db.SavedResource, string, time.Duration, bool) {
 	fake.acquireResourceCheckingLockMutex.RLock()
 	defer fake.acquireResourceCheckingLockMutex.RUnlock()
-	return fake.acquireResourceCheckingLockArgsForCall[i].logger, fake.acquireResourceCheckingLockArgsForCall[i].resource, fake.acquireResourceCheckingLockArgsForCall[i].length, fake.acquireResourceCheckingLockArgsForCall[i].immediate
+	return fake.acquireResourceCheckingLockArgsForCall[i].logger, fake.acquireResourceCheckingLockArgsForCall[i].resource, fake.acquireResourceCheckingLockArgsForCall[i].resourceHash, fake.acquireResourceCheckingLockArgsForCall[i].length, fake.acquireResourceCheckingLockArgsForCall[i].immediate
 }
 
 func (fake *FakePipelineDB) AcquireResourceCheckingLockReturns(result1 db.Lock, result2 bool, result3 error) {
@@ -1973,6 +2173,47 @@ func (fake *FakePipelineDB) UseInputsForBuildReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) AcquireTeamLocks(logger lager.Logger, buildID int, locks []string) (bool, error) {
+	var locksCopy []string
+	if locks != nil {
+		locksCopy = make([]string, len(locks))
+		copy(locksCopy, locks)
+	}
+	fake.acquireTeamLocksMutex.Lock()
+	fake.acquireTeamLocksArgsForCall = append(fake.acquireTeamLocksArgsForCall, struct {
+		logger  lager.Logger
+		buildID int
+		locks   []string
+	}{logger, buildID, locksCopy})
+	fake.recordInvocation("AcquireTeamLocks", []interface{}{logger, buildID, locksCopy})
+	fake.acquireTeamLocksMutex.Unlock()
+	if fake.AcquireTeamLocksStub != nil {
+		return fake.AcquireTeamLocksStub(logger, buildID, locks)
+	} else {
+		return fake.acquireTeamLocksReturns.result1, fake.acquireTeamLocksReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) AcquireTeamLocksCallCount() int {
+	fake.acquireTeamLocksMutex.RLock()
+	defer fake.acquireTeamLocksMutex.RUnlock()
+	return len(fake.acquireTeamLocksArgsForCall)
+}
+
+func (fake *FakePipelineDB) AcquireTeamLocksArgsForCall(i int) (lager.Logger, int, []string) {
+	fake.acquireTeamLocksMutex.RLock()
+	defer fake.acquireTeamLocksMutex.RUnlock()
+	return fake.acquireTeamLocksArgsForCall[i].logger, fake.acquireTeamLocksArgsForCall[i].buildID, fake.acquireTeamLocksArgsForCall[i].locks
+}
+
+func (fake *FakePipelineDB) AcquireTeamLocksReturns(result1 bool, result2 error) {
+	fake.AcquireTeamLocksStub = nil
+	fake.acquireTeamLocksReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	fake.loadVersionsDBMutex.Lock()
 	fake.loadVersionsDBArgsForCall = append(fake.loadVersionsDBArgsForCall, struct{}{})
@@ -2205,6 +2446,75 @@ func (fake *FakePipelineDB) DeleteNextInputMappingReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) SaveInputsExplanation(explanation algorithm.Explanation, jobName string) error {
+	fake.saveInputsExplanationMutex.Lock()
+	fake.saveInputsExplanationArgsForCall = append(fake.saveInputsExplanationArgsForCall, struct {
+		explanation algorithm.Explanation
+		jobName     string
+	}{explanation, jobName})
+	fake.recordInvocation("SaveInputsExplanation", []interface{}{explanation, jobName})
+	fake.saveInputsExplanationMutex.Unlock()
+	if fake.SaveInputsExplanationStub != nil {
+		return fake.SaveInputsExplanationStub(explanation, jobName)
+	} else {
+		return fake.saveInputsExplanationReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SaveInputsExplanationCallCount() int {
+	fake.saveInputsExplanationMutex.RLock()
+	defer fake.saveInputsExplanationMutex.RUnlock()
+	return len(fake.saveInputsExplanationArgsForCall)
+}
+
+func (fake *FakePipelineDB) SaveInputsExplanationArgsForCall(i int) (algorithm.Explanation, string) {
+	fake.saveInputsExplanationMutex.RLock()
+	defer fake.saveInputsExplanationMutex.RUnlock()
+	return fake.saveInputsExplanationArgsForCall[i].explanation, fake.saveInputsExplanationArgsForCall[i].jobName
+}
+
+func (fake *FakePipelineDB) SaveInputsExplanationReturns(result1 error) {
+	fake.SaveInputsExplanationStub = nil
+	fake.saveInputsExplanationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetInputsExplanation(jobName string) (algorithm.Explanation, bool, error) {
+	fake.getInputsExplanationMutex.Lock()
+	fake.getInputsExplanationArgsForCall = append(fake.getInputsExplanationArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetInputsExplanation", []interface{}{jobName})
+	fake.getInputsExplanationMutex.Unlock()
+	if fake.GetInputsExplanationStub != nil {
+		return fake.GetInputsExplanationStub(jobName)
+	} else {
+		return fake.getInputsExplanationReturns.result1, fake.getInputsExplanationReturns.result2, fake.getInputsExplanationReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) GetInputsExplanationCallCount() int {
+	fake.getInputsExplanationMutex.RLock()
+	defer fake.getInputsExplanationMutex.RUnlock()
+	return len(fake.getInputsExplanationArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetInputsExplanationArgsForCall(i int) string {
+	fake.getInputsExplanationMutex.RLock()
+	defer fake.getInputsExplanationMutex.RUnlock()
+	return fake.getInputsExplanationArgsForCall[i].jobName
+}
+
+func (fake *FakePipelineDB) GetInputsExplanationReturns(result1 algorithm.Explanation, result2 bool, result3 error) {
+	fake.GetInputsExplanationStub = nil
+	fake.getInputsExplanationReturns = struct {
+		result1 algorithm.Explanation
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error) {
 	var serialGroupsCopy []string
 	if serialGroups != nil {
@@ -2569,6 +2879,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.destroyMutex.RUnlock()
 	fake.acquireSchedulingLockMutex.RLock()
 	defer fake.acquireSchedulingLockMutex.RUnlock()
+	fake.schedulingNotifierMutex.RLock()
+	defer fake.schedulingNotifierMutex.RUnlock()
 	fake.getResourceMutex.RLock()
 	defer fake.getResourceMutex.RUnlock()
 	fake.getResourcesMutex.RLock()
@@ -2595,6 +2907,12 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.disableVersionedResourceMutex.RUnlock()
 	fake.setResourceCheckErrorMutex.RLock()
 	defer fake.setResourceCheckErrorMutex.RUnlock()
+	fake.setResourceNextCheckAtMutex.RLock()
+	defer fake.setResourceNextCheckAtMutex.RUnlock()
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	fake.getResourceChecksMutex.RLock()
+	defer fake.getResourceChecksMutex.RUnlock()
 	fake.acquireResourceCheckingLockMutex.RLock()
 	defer fake.acquireResourceCheckingLockMutex.RUnlock()
 	fake.acquireResourceTypeCheckingLockMutex.RLock()
@@ -2629,6 +2947,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getAllPendingBuildsMutex.RUnlock()
 	fake.useInputsForBuildMutex.RLock()
 	defer fake.useInputsForBuildMutex.RUnlock()
+	fake.acquireTeamLocksMutex.RLock()
+	defer fake.acquireTeamLocksMutex.RUnlock()
 	fake.loadVersionsDBMutex.RLock()
 	defer fake.loadVersionsDBMutex.RUnlock()
 	fake.getVersionedResourceByVersionMutex.RLock()
@@ -2643,6 +2963,10 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getNextBuildInputsMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
+	fake.saveInputsExplanationMutex.RLock()
+	defer fake.saveInputsExplanationMutex.RUnlock()
+	fake.getInputsExplanationMutex.RLock()
+	defer fake.getInputsExplanationMutex.RUnlock()
 	fake.getRunningBuildsBySerialGroupMutex.RLock()
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -92,6 +93,42 @@ type FakeTeamDB struct {
 		result1 db.SavedTeam
 		result2 error
 	}
+	UpdateOIDCAuthStub        func(oidcAuth *db.OIDCAuth) (db.SavedTeam, error)
+	updateOIDCAuthMutex       sync.RWMutex
+	updateOIDCAuthArgsForCall []struct {
+		oidcAuth *db.OIDCAuth
+	}
+	updateOIDCAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateLDAPAuthStub        func(ldapAuth *db.LDAPAuth) (db.SavedTeam, error)
+	updateLDAPAuthMutex       sync.RWMutex
+	updateLDAPAuthArgsForCall []struct {
+		ldapAuth *db.LDAPAuth
+	}
+	updateLDAPAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateGitLabAuthStub        func(gitLabAuth *db.GitLabAuth) (db.SavedTeam, error)
+	updateGitLabAuthMutex       sync.RWMutex
+	updateGitLabAuthArgsForCall []struct {
+		gitLabAuth *db.GitLabAuth
+	}
+	updateGitLabAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateCertAuthStub        func(certAuth *db.CertAuth) (db.SavedTeam, error)
+	updateCertAuthMutex       sync.RWMutex
+	updateCertAuthArgsForCall []struct {
+		certAuth *db.CertAuth
+	}
+	updateCertAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
 	GetConfigStub        func(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
 	getConfigMutex       sync.RWMutex
 	getConfigArgsForCall []struct {
@@ -166,6 +203,165 @@ type FakeTeamDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	SetLockCapacityStub        func(lockName string, capacity int) error
+	setLockCapacityMutex       sync.RWMutex
+	setLockCapacityArgsForCall []struct {
+		lockName string
+		capacity int
+	}
+	setLockCapacityReturns struct {
+		result1 error
+	}
+	GetLockCapacitiesStub        func() (map[string]int, error)
+	getLockCapacitiesMutex       sync.RWMutex
+	getLockCapacitiesArgsForCall []struct{}
+	getLockCapacitiesReturns     struct {
+		result1 map[string]int
+		result2 error
+	}
+	GetLockHoldersStub        func() ([]db.TeamLockHolder, error)
+	getLockHoldersMutex       sync.RWMutex
+	getLockHoldersArgsForCall []struct{}
+	getLockHoldersReturns     struct {
+		result1 []db.TeamLockHolder
+		result2 error
+	}
+	ReleaseLockStub        func(lockName string, buildID int) (bool, error)
+	releaseLockMutex       sync.RWMutex
+	releaseLockArgsForCall []struct {
+		lockName string
+		buildID  int
+	}
+	releaseLockReturns struct {
+		result1 bool
+		result2 error
+	}
+	CreateAPITokenStub        func(name string, token string, kind string, owner string, scopes []string, expiresAt time.Time) (db.APIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		name      string
+		token     string
+		kind      string
+		owner     string
+		scopes    []string
+		expiresAt time.Time
+	}
+	createAPITokenReturns struct {
+		result1 db.APIToken
+		result2 error
+	}
+	GetAPITokensStub        func() ([]db.APIToken, error)
+	getAPITokensMutex       sync.RWMutex
+	getAPITokensArgsForCall []struct{}
+	getAPITokensReturns     struct {
+		result1 []db.APIToken
+		result2 error
+	}
+	RevokeAPITokenStub        func(name string) (bool, error)
+	revokeAPITokenMutex       sync.RWMutex
+	revokeAPITokenArgsForCall []struct {
+		name string
+	}
+	revokeAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	SaveSessionStub        func(sessionID string, username string, issuedAt time.Time, expiresAt time.Time) error
+	saveSessionMutex       sync.RWMutex
+	saveSessionArgsForCall []struct {
+		sessionID string
+		username  string
+		issuedAt  time.Time
+		expiresAt time.Time
+	}
+	saveSessionReturns struct {
+		result1 error
+	}
+	GetSessionsStub        func() ([]db.Session, error)
+	getSessionsMutex       sync.RWMutex
+	getSessionsArgsForCall []struct{}
+	getSessionsReturns     struct {
+		result1 []db.Session
+		result2 error
+	}
+	RevokeSessionStub        func(sessionID string) error
+	revokeSessionMutex       sync.RWMutex
+	revokeSessionArgsForCall []struct {
+		sessionID string
+	}
+	revokeSessionReturns struct {
+		result1 error
+	}
+	RevokeAllSessionsStub        func() error
+	revokeAllSessionsMutex       sync.RWMutex
+	revokeAllSessionsArgsForCall []struct{}
+	revokeAllSessionsReturns     struct {
+		result1 error
+	}
+	RevokeUserSessionsStub        func(username string) error
+	revokeUserSessionsMutex       sync.RWMutex
+	revokeUserSessionsArgsForCall []struct {
+		username string
+	}
+	revokeUserSessionsReturns struct {
+		result1 error
+	}
+	CreateLocalUserStub        func(username string, password string) (db.LocalUser, error)
+	createLocalUserMutex       sync.RWMutex
+	createLocalUserArgsForCall []struct {
+		username string
+		password string
+	}
+	createLocalUserReturns struct {
+		result1 db.LocalUser
+		result2 error
+	}
+	GetLocalUsersStub        func() ([]db.LocalUser, error)
+	getLocalUsersMutex       sync.RWMutex
+	getLocalUsersArgsForCall []struct{}
+	getLocalUsersReturns     struct {
+		result1 []db.LocalUser
+		result2 error
+	}
+	GetLocalUserStub        func(username string) (db.LocalUser, bool, error)
+	getLocalUserMutex       sync.RWMutex
+	getLocalUserArgsForCall []struct {
+		username string
+	}
+	getLocalUserReturns struct {
+		result1 db.LocalUser
+		result2 bool
+		result3 error
+	}
+	SetLocalUserPasswordStub        func(username string, password string) (bool, error)
+	setLocalUserPasswordMutex       sync.RWMutex
+	setLocalUserPasswordArgsForCall []struct {
+		username string
+		password string
+	}
+	setLocalUserPasswordReturns struct {
+		result1 bool
+		result2 error
+	}
+	SetLocalUserEnabledStub        func(username string, enabled bool) (bool, error)
+	setLocalUserEnabledMutex       sync.RWMutex
+	setLocalUserEnabledArgsForCall []struct {
+		username string
+		enabled  bool
+	}
+	setLocalUserEnabledReturns struct {
+		result1 bool
+		result2 error
+	}
+	DeleteLocalUserStub        func(username string) (bool, error)
+	deleteLocalUserMutex       sync.RWMutex
+	deleteLocalUserArgsForCall []struct {
+		username string
+	}
+	deleteLocalUserReturns struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -484,6 +680,142 @@ func (fake *FakeTeamDB) UpdateGenericOAuthReturns(result1 db.SavedTeam, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) UpdateOIDCAuth(oidcAuth *db.OIDCAuth) (db.SavedTeam, error) {
+	fake.updateOIDCAuthMutex.Lock()
+	fake.updateOIDCAuthArgsForCall = append(fake.updateOIDCAuthArgsForCall, struct {
+		oidcAuth *db.OIDCAuth
+	}{oidcAuth})
+	fake.recordInvocation("UpdateOIDCAuth", []interface{}{oidcAuth})
+	fake.updateOIDCAuthMutex.Unlock()
+	if fake.UpdateOIDCAuthStub != nil {
+		return fake.UpdateOIDCAuthStub(oidcAuth)
+	} else {
+		return fake.updateOIDCAuthReturns.result1, fake.updateOIDCAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateOIDCAuthCallCount() int {
+	fake.updateOIDCAuthMutex.RLock()
+	defer fake.updateOIDCAuthMutex.RUnlock()
+	return len(fake.updateOIDCAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateOIDCAuthArgsForCall(i int) *db.OIDCAuth {
+	fake.updateOIDCAuthMutex.RLock()
+	defer fake.updateOIDCAuthMutex.RUnlock()
+	return fake.updateOIDCAuthArgsForCall[i].oidcAuth
+}
+
+func (fake *FakeTeamDB) UpdateOIDCAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateOIDCAuthStub = nil
+	fake.updateOIDCAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuth(ldapAuth *db.LDAPAuth) (db.SavedTeam, error) {
+	fake.updateLDAPAuthMutex.Lock()
+	fake.updateLDAPAuthArgsForCall = append(fake.updateLDAPAuthArgsForCall, struct {
+		ldapAuth *db.LDAPAuth
+	}{ldapAuth})
+	fake.recordInvocation("UpdateLDAPAuth", []interface{}{ldapAuth})
+	fake.updateLDAPAuthMutex.Unlock()
+	if fake.UpdateLDAPAuthStub != nil {
+		return fake.UpdateLDAPAuthStub(ldapAuth)
+	} else {
+		return fake.updateLDAPAuthReturns.result1, fake.updateLDAPAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthCallCount() int {
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	return len(fake.updateLDAPAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthArgsForCall(i int) *db.LDAPAuth {
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	return fake.updateLDAPAuthArgsForCall[i].ldapAuth
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateLDAPAuthStub = nil
+	fake.updateLDAPAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateGitLabAuth(gitLabAuth *db.GitLabAuth) (db.SavedTeam, error) {
+	fake.updateGitLabAuthMutex.Lock()
+	fake.updateGitLabAuthArgsForCall = append(fake.updateGitLabAuthArgsForCall, struct {
+		gitLabAuth *db.GitLabAuth
+	}{gitLabAuth})
+	fake.recordInvocation("UpdateGitLabAuth", []interface{}{gitLabAuth})
+	fake.updateGitLabAuthMutex.Unlock()
+	if fake.UpdateGitLabAuthStub != nil {
+		return fake.UpdateGitLabAuthStub(gitLabAuth)
+	} else {
+		return fake.updateGitLabAuthReturns.result1, fake.updateGitLabAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateGitLabAuthCallCount() int {
+	fake.updateGitLabAuthMutex.RLock()
+	defer fake.updateGitLabAuthMutex.RUnlock()
+	return len(fake.updateGitLabAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateGitLabAuthArgsForCall(i int) *db.GitLabAuth {
+	fake.updateGitLabAuthMutex.RLock()
+	defer fake.updateGitLabAuthMutex.RUnlock()
+	return fake.updateGitLabAuthArgsForCall[i].gitLabAuth
+}
+
+func (fake *FakeTeamDB) UpdateGitLabAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateGitLabAuthStub = nil
+	fake.updateGitLabAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateCertAuth(certAuth *db.CertAuth) (db.SavedTeam, error) {
+	fake.updateCertAuthMutex.Lock()
+	fake.updateCertAuthArgsForCall = append(fake.updateCertAuthArgsForCall, struct {
+		certAuth *db.CertAuth
+	}{certAuth})
+	fake.recordInvocation("UpdateCertAuth", []interface{}{certAuth})
+	fake.updateCertAuthMutex.Unlock()
+	if fake.UpdateCertAuthStub != nil {
+		return fake.UpdateCertAuthStub(certAuth)
+	} else {
+		return fake.updateCertAuthReturns.result1, fake.updateCertAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateCertAuthCallCount() int {
+	fake.updateCertAuthMutex.RLock()
+	defer fake.updateCertAuthMutex.RUnlock()
+	return len(fake.updateCertAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateCertAuthArgsForCall(i int) *db.CertAuth {
+	fake.updateCertAuthMutex.RLock()
+	defer fake.updateCertAuthMutex.RUnlock()
+	return fake.updateCertAuthArgsForCall[i].certAuth
+}
+
+func (fake *FakeTeamDB) UpdateCertAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateCertAuthStub = nil
+	fake.updateCertAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error) {
 	fake.getConfigMutex.Lock()
 	fake.getConfigArgsForCall = append(fake.getConfigArgsForCall, struct {
@@ -740,6 +1072,584 @@ func (fake *FakeTeamDB) GetVolumesReturns(result1 []db.SavedVolume, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) SetLockCapacity(lockName string, capacity int) error {
+	fake.setLockCapacityMutex.Lock()
+	fake.setLockCapacityArgsForCall = append(fake.setLockCapacityArgsForCall, struct {
+		lockName string
+		capacity int
+	}{lockName, capacity})
+	fake.recordInvocation("SetLockCapacity", []interface{}{lockName, capacity})
+	fake.setLockCapacityMutex.Unlock()
+	if fake.SetLockCapacityStub != nil {
+		return fake.SetLockCapacityStub(lockName, capacity)
+	} else {
+		return fake.setLockCapacityReturns.result1
+	}
+}
+
+func (fake *FakeTeamDB) SetLockCapacityCallCount() int {
+	fake.setLockCapacityMutex.RLock()
+	defer fake.setLockCapacityMutex.RUnlock()
+	return len(fake.setLockCapacityArgsForCall)
+}
+
+func (fake *FakeTeamDB) SetLockCapacityArgsForCall(i int) (string, int) {
+	fake.setLockCapacityMutex.RLock()
+	defer fake.setLockCapacityMutex.RUnlock()
+	return fake.setLockCapacityArgsForCall[i].lockName, fake.setLockCapacityArgsForCall[i].capacity
+}
+
+func (fake *FakeTeamDB) SetLockCapacityReturns(result1 error) {
+	fake.SetLockCapacityStub = nil
+	fake.setLockCapacityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamDB) GetLockCapacities() (map[string]int, error) {
+	fake.getLockCapacitiesMutex.Lock()
+	fake.getLockCapacitiesArgsForCall = append(fake.getLockCapacitiesArgsForCall, struct{}{})
+	fake.recordInvocation("GetLockCapacities", []interface{}{})
+	fake.getLockCapacitiesMutex.Unlock()
+	if fake.GetLockCapacitiesStub != nil {
+		return fake.GetLockCapacitiesStub()
+	} else {
+		return fake.getLockCapacitiesReturns.result1, fake.getLockCapacitiesReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetLockCapacitiesCallCount() int {
+	fake.getLockCapacitiesMutex.RLock()
+	defer fake.getLockCapacitiesMutex.RUnlock()
+	return len(fake.getLockCapacitiesArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetLockCapacitiesReturns(result1 map[string]int, result2 error) {
+	fake.GetLockCapacitiesStub = nil
+	fake.getLockCapacitiesReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetLockHolders() ([]db.TeamLockHolder, error) {
+	fake.getLockHoldersMutex.Lock()
+	fake.getLockHoldersArgsForCall = append(fake.getLockHoldersArgsForCall, struct{}{})
+	fake.recordInvocation("GetLockHolders", []interface{}{})
+	fake.getLockHoldersMutex.Unlock()
+	if fake.GetLockHoldersStub != nil {
+		return fake.GetLockHoldersStub()
+	} else {
+		return fake.getLockHoldersReturns.result1, fake.getLockHoldersReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetLockHoldersCallCount() int {
+	fake.getLockHoldersMutex.RLock()
+	defer fake.getLockHoldersMutex.RUnlock()
+	return len(fake.getLockHoldersArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetLockHoldersReturns(result1 []db.TeamLockHolder, result2 error) {
+	fake.GetLockHoldersStub = nil
+	fake.getLockHoldersReturns = struct {
+		result1 []db.TeamLockHolder
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) ReleaseLock(lockName string, buildID int) (bool, error) {
+	fake.releaseLockMutex.Lock()
+	fake.releaseLockArgsForCall = append(fake.releaseLockArgsForCall, struct {
+		lockName string
+		buildID  int
+	}{lockName, buildID})
+	fake.recordInvocation("ReleaseLock", []interface{}{lockName, buildID})
+	fake.releaseLockMutex.Unlock()
+	if fake.ReleaseLockStub != nil {
+		return fake.ReleaseLockStub(lockName, buildID)
+	} else {
+		return fake.releaseLockReturns.result1, fake.releaseLockReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) ReleaseLockCallCount() int {
+	fake.releaseLockMutex.RLock()
+	defer fake.releaseLockMutex.RUnlock()
+	return len(fake.releaseLockArgsForCall)
+}
+
+func (fake *FakeTeamDB) ReleaseLockArgsForCall(i int) (string, int) {
+	fake.releaseLockMutex.RLock()
+	defer fake.releaseLockMutex.RUnlock()
+	return fake.releaseLockArgsForCall[i].lockName, fake.releaseLockArgsForCall[i].buildID
+}
+
+func (fake *FakeTeamDB) ReleaseLockReturns(result1 bool, result2 error) {
+	fake.ReleaseLockStub = nil
+	fake.releaseLockReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) CreateAPIToken(name string, token string, kind string, owner string, scopes []string, expiresAt time.Time) (db.APIToken, error) {
+	var scopesCopy []string
+	if scopes != nil {
+		scopesCopy = make([]string, len(scopes))
+		copy(scopesCopy, scopes)
+	}
+	fake.createAPITokenMutex.Lock()
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		name      string
+		token     string
+		kind      string
+		owner     string
+		scopes    []string
+		expiresAt time.Time
+	}{name, token, kind, owner, scopesCopy, expiresAt})
+	fake.recordInvocation("CreateAPIToken", []interface{}{name, token, kind, owner, scopesCopy, expiresAt})
+	fake.createAPITokenMutex.Unlock()
+	if fake.CreateAPITokenStub != nil {
+		return fake.CreateAPITokenStub(name, token, kind, owner, scopes, expiresAt)
+	} else {
+		return fake.createAPITokenReturns.result1, fake.createAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) CreateAPITokenArgsForCall(i int) (string, string, string, string, []string, time.Time) {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return fake.createAPITokenArgsForCall[i].name, fake.createAPITokenArgsForCall[i].token, fake.createAPITokenArgsForCall[i].kind, fake.createAPITokenArgsForCall[i].owner, fake.createAPITokenArgsForCall[i].scopes, fake.createAPITokenArgsForCall[i].expiresAt
+}
+
+func (fake *FakeTeamDB) CreateAPITokenReturns(result1 db.APIToken, result2 error) {
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetAPITokens() ([]db.APIToken, error) {
+	fake.getAPITokensMutex.Lock()
+	fake.getAPITokensArgsForCall = append(fake.getAPITokensArgsForCall, struct{}{})
+	fake.recordInvocation("GetAPITokens", []interface{}{})
+	fake.getAPITokensMutex.Unlock()
+	if fake.GetAPITokensStub != nil {
+		return fake.GetAPITokensStub()
+	} else {
+		return fake.getAPITokensReturns.result1, fake.getAPITokensReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetAPITokensCallCount() int {
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	return len(fake.getAPITokensArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetAPITokensReturns(result1 []db.APIToken, result2 error) {
+	fake.GetAPITokensStub = nil
+	fake.getAPITokensReturns = struct {
+		result1 []db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) RevokeAPIToken(name string) (bool, error) {
+	fake.revokeAPITokenMutex.Lock()
+	fake.revokeAPITokenArgsForCall = append(fake.revokeAPITokenArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("RevokeAPIToken", []interface{}{name})
+	fake.revokeAPITokenMutex.Unlock()
+	if fake.RevokeAPITokenStub != nil {
+		return fake.RevokeAPITokenStub(name)
+	} else {
+		return fake.revokeAPITokenReturns.result1, fake.revokeAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) RevokeAPITokenCallCount() int {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	return len(fake.revokeAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) RevokeAPITokenArgsForCall(i int) string {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	return fake.revokeAPITokenArgsForCall[i].name
+}
+
+func (fake *FakeTeamDB) RevokeAPITokenReturns(result1 bool, result2 error) {
+	fake.RevokeAPITokenStub = nil
+	fake.revokeAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) SaveSession(sessionID string, username string, issuedAt time.Time, expiresAt time.Time) error {
+	fake.saveSessionMutex.Lock()
+	fake.saveSessionArgsForCall = append(fake.saveSessionArgsForCall, struct {
+		sessionID string
+		username  string
+		issuedAt  time.Time
+		expiresAt time.Time
+	}{sessionID, username, issuedAt, expiresAt})
+	fake.recordInvocation("SaveSession", []interface{}{sessionID, username, issuedAt, expiresAt})
+	fake.saveSessionMutex.Unlock()
+	if fake.SaveSessionStub != nil {
+		return fake.SaveSessionStub(sessionID, username, issuedAt, expiresAt)
+	} else {
+		return fake.saveSessionReturns.result1
+	}
+}
+
+func (fake *FakeTeamDB) SaveSessionCallCount() int {
+	fake.saveSessionMutex.RLock()
+	defer fake.saveSessionMutex.RUnlock()
+	return len(fake.saveSessionArgsForCall)
+}
+
+func (fake *FakeTeamDB) SaveSessionArgsForCall(i int) (string, string, time.Time, time.Time) {
+	fake.saveSessionMutex.RLock()
+	defer fake.saveSessionMutex.RUnlock()
+	return fake.saveSessionArgsForCall[i].sessionID, fake.saveSessionArgsForCall[i].username, fake.saveSessionArgsForCall[i].issuedAt, fake.saveSessionArgsForCall[i].expiresAt
+}
+
+func (fake *FakeTeamDB) SaveSessionReturns(result1 error) {
+	fake.SaveSessionStub = nil
+	fake.saveSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamDB) GetSessions() ([]db.Session, error) {
+	fake.getSessionsMutex.Lock()
+	fake.getSessionsArgsForCall = append(fake.getSessionsArgsForCall, struct{}{})
+	fake.recordInvocation("GetSessions", []interface{}{})
+	fake.getSessionsMutex.Unlock()
+	if fake.GetSessionsStub != nil {
+		return fake.GetSessionsStub()
+	} else {
+		return fake.getSessionsReturns.result1, fake.getSessionsReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetSessionsCallCount() int {
+	fake.getSessionsMutex.RLock()
+	defer fake.getSessionsMutex.RUnlock()
+	return len(fake.getSessionsArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetSessionsReturns(result1 []db.Session, result2 error) {
+	fake.GetSessionsStub = nil
+	fake.getSessionsReturns = struct {
+		result1 []db.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) RevokeSession(sessionID string) error {
+	fake.revokeSessionMutex.Lock()
+	fake.revokeSessionArgsForCall = append(fake.revokeSessionArgsForCall, struct {
+		sessionID string
+	}{sessionID})
+	fake.recordInvocation("RevokeSession", []interface{}{sessionID})
+	fake.revokeSessionMutex.Unlock()
+	if fake.RevokeSessionStub != nil {
+		return fake.RevokeSessionStub(sessionID)
+	} else {
+		return fake.revokeSessionReturns.result1
+	}
+}
+
+func (fake *FakeTeamDB) RevokeSessionCallCount() int {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	return len(fake.revokeSessionArgsForCall)
+}
+
+func (fake *FakeTeamDB) RevokeSessionArgsForCall(i int) string {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	return fake.revokeSessionArgsForCall[i].sessionID
+}
+
+func (fake *FakeTeamDB) RevokeSessionReturns(result1 error) {
+	fake.RevokeSessionStub = nil
+	fake.revokeSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamDB) RevokeAllSessions() error {
+	fake.revokeAllSessionsMutex.Lock()
+	fake.revokeAllSessionsArgsForCall = append(fake.revokeAllSessionsArgsForCall, struct{}{})
+	fake.recordInvocation("RevokeAllSessions", []interface{}{})
+	fake.revokeAllSessionsMutex.Unlock()
+	if fake.RevokeAllSessionsStub != nil {
+		return fake.RevokeAllSessionsStub()
+	} else {
+		return fake.revokeAllSessionsReturns.result1
+	}
+}
+
+func (fake *FakeTeamDB) RevokeAllSessionsCallCount() int {
+	fake.revokeAllSessionsMutex.RLock()
+	defer fake.revokeAllSessionsMutex.RUnlock()
+	return len(fake.revokeAllSessionsArgsForCall)
+}
+
+func (fake *FakeTeamDB) RevokeAllSessionsReturns(result1 error) {
+	fake.RevokeAllSessionsStub = nil
+	fake.revokeAllSessionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamDB) RevokeUserSessions(username string) error {
+	fake.revokeUserSessionsMutex.Lock()
+	fake.revokeUserSessionsArgsForCall = append(fake.revokeUserSessionsArgsForCall, struct {
+		username string
+	}{username})
+	fake.recordInvocation("RevokeUserSessions", []interface{}{username})
+	fake.revokeUserSessionsMutex.Unlock()
+	if fake.RevokeUserSessionsStub != nil {
+		return fake.RevokeUserSessionsStub(username)
+	} else {
+		return fake.revokeUserSessionsReturns.result1
+	}
+}
+
+func (fake *FakeTeamDB) RevokeUserSessionsCallCount() int {
+	fake.revokeUserSessionsMutex.RLock()
+	defer fake.revokeUserSessionsMutex.RUnlock()
+	return len(fake.revokeUserSessionsArgsForCall)
+}
+
+func (fake *FakeTeamDB) RevokeUserSessionsArgsForCall(i int) string {
+	fake.revokeUserSessionsMutex.RLock()
+	defer fake.revokeUserSessionsMutex.RUnlock()
+	return fake.revokeUserSessionsArgsForCall[i].username
+}
+
+func (fake *FakeTeamDB) RevokeUserSessionsReturns(result1 error) {
+	fake.RevokeUserSessionsStub = nil
+	fake.revokeUserSessionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamDB) CreateLocalUser(username string, password string) (db.LocalUser, error) {
+	fake.createLocalUserMutex.Lock()
+	fake.createLocalUserArgsForCall = append(fake.createLocalUserArgsForCall, struct {
+		username string
+		password string
+	}{username, password})
+	fake.recordInvocation("CreateLocalUser", []interface{}{username, password})
+	fake.createLocalUserMutex.Unlock()
+	if fake.CreateLocalUserStub != nil {
+		return fake.CreateLocalUserStub(username, password)
+	} else {
+		return fake.createLocalUserReturns.result1, fake.createLocalUserReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) CreateLocalUserCallCount() int {
+	fake.createLocalUserMutex.RLock()
+	defer fake.createLocalUserMutex.RUnlock()
+	return len(fake.createLocalUserArgsForCall)
+}
+
+func (fake *FakeTeamDB) CreateLocalUserArgsForCall(i int) (string, string) {
+	fake.createLocalUserMutex.RLock()
+	defer fake.createLocalUserMutex.RUnlock()
+	return fake.createLocalUserArgsForCall[i].username, fake.createLocalUserArgsForCall[i].password
+}
+
+func (fake *FakeTeamDB) CreateLocalUserReturns(result1 db.LocalUser, result2 error) {
+	fake.CreateLocalUserStub = nil
+	fake.createLocalUserReturns = struct {
+		result1 db.LocalUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetLocalUsers() ([]db.LocalUser, error) {
+	fake.getLocalUsersMutex.Lock()
+	fake.getLocalUsersArgsForCall = append(fake.getLocalUsersArgsForCall, struct{}{})
+	fake.recordInvocation("GetLocalUsers", []interface{}{})
+	fake.getLocalUsersMutex.Unlock()
+	if fake.GetLocalUsersStub != nil {
+		return fake.GetLocalUsersStub()
+	} else {
+		return fake.getLocalUsersReturns.result1, fake.getLocalUsersReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetLocalUsersCallCount() int {
+	fake.getLocalUsersMutex.RLock()
+	defer fake.getLocalUsersMutex.RUnlock()
+	return len(fake.getLocalUsersArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetLocalUsersReturns(result1 []db.LocalUser, result2 error) {
+	fake.GetLocalUsersStub = nil
+	fake.getLocalUsersReturns = struct {
+		result1 []db.LocalUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetLocalUser(username string) (db.LocalUser, bool, error) {
+	fake.getLocalUserMutex.Lock()
+	fake.getLocalUserArgsForCall = append(fake.getLocalUserArgsForCall, struct {
+		username string
+	}{username})
+	fake.recordInvocation("GetLocalUser", []interface{}{username})
+	fake.getLocalUserMutex.Unlock()
+	if fake.GetLocalUserStub != nil {
+		return fake.GetLocalUserStub(username)
+	} else {
+		return fake.getLocalUserReturns.result1, fake.getLocalUserReturns.result2, fake.getLocalUserReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetLocalUserCallCount() int {
+	fake.getLocalUserMutex.RLock()
+	defer fake.getLocalUserMutex.RUnlock()
+	return len(fake.getLocalUserArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetLocalUserArgsForCall(i int) string {
+	fake.getLocalUserMutex.RLock()
+	defer fake.getLocalUserMutex.RUnlock()
+	return fake.getLocalUserArgsForCall[i].username
+}
+
+func (fake *FakeTeamDB) GetLocalUserReturns(result1 db.LocalUser, result2 bool, result3 error) {
+	fake.GetLocalUserStub = nil
+	fake.getLocalUserReturns = struct {
+		result1 db.LocalUser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) SetLocalUserPassword(username string, password string) (bool, error) {
+	fake.setLocalUserPasswordMutex.Lock()
+	fake.setLocalUserPasswordArgsForCall = append(fake.setLocalUserPasswordArgsForCall, struct {
+		username string
+		password string
+	}{username, password})
+	fake.recordInvocation("SetLocalUserPassword", []interface{}{username, password})
+	fake.setLocalUserPasswordMutex.Unlock()
+	if fake.SetLocalUserPasswordStub != nil {
+		return fake.SetLocalUserPasswordStub(username, password)
+	} else {
+		return fake.setLocalUserPasswordReturns.result1, fake.setLocalUserPasswordReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) SetLocalUserPasswordCallCount() int {
+	fake.setLocalUserPasswordMutex.RLock()
+	defer fake.setLocalUserPasswordMutex.RUnlock()
+	return len(fake.setLocalUserPasswordArgsForCall)
+}
+
+func (fake *FakeTeamDB) SetLocalUserPasswordArgsForCall(i int) (string, string) {
+	fake.setLocalUserPasswordMutex.RLock()
+	defer fake.setLocalUserPasswordMutex.RUnlock()
+	return fake.setLocalUserPasswordArgsForCall[i].username, fake.setLocalUserPasswordArgsForCall[i].password
+}
+
+func (fake *FakeTeamDB) SetLocalUserPasswordReturns(result1 bool, result2 error) {
+	fake.SetLocalUserPasswordStub = nil
+	fake.setLocalUserPasswordReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) SetLocalUserEnabled(username string, enabled bool) (bool, error) {
+	fake.setLocalUserEnabledMutex.Lock()
+	fake.setLocalUserEnabledArgsForCall = append(fake.setLocalUserEnabledArgsForCall, struct {
+		username string
+		enabled  bool
+	}{username, enabled})
+	fake.recordInvocation("SetLocalUserEnabled", []interface{}{username, enabled})
+	fake.setLocalUserEnabledMutex.Unlock()
+	if fake.SetLocalUserEnabledStub != nil {
+		return fake.SetLocalUserEnabledStub(username, enabled)
+	} else {
+		return fake.setLocalUserEnabledReturns.result1, fake.setLocalUserEnabledReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) SetLocalUserEnabledCallCount() int {
+	fake.setLocalUserEnabledMutex.RLock()
+	defer fake.setLocalUserEnabledMutex.RUnlock()
+	return len(fake.setLocalUserEnabledArgsForCall)
+}
+
+func (fake *FakeTeamDB) SetLocalUserEnabledArgsForCall(i int) (string, bool) {
+	fake.setLocalUserEnabledMutex.RLock()
+	defer fake.setLocalUserEnabledMutex.RUnlock()
+	return fake.setLocalUserEnabledArgsForCall[i].username, fake.setLocalUserEnabledArgsForCall[i].enabled
+}
+
+func (fake *FakeTeamDB) SetLocalUserEnabledReturns(result1 bool, result2 error) {
+	fake.SetLocalUserEnabledStub = nil
+	fake.setLocalUserEnabledReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) DeleteLocalUser(username string) (bool, error) {
+	fake.deleteLocalUserMutex.Lock()
+	fake.deleteLocalUserArgsForCall = append(fake.deleteLocalUserArgsForCall, struct {
+		username string
+	}{username})
+	fake.recordInvocation("DeleteLocalUser", []interface{}{username})
+	fake.deleteLocalUserMutex.Unlock()
+	if fake.DeleteLocalUserStub != nil {
+		return fake.DeleteLocalUserStub(username)
+	} else {
+		return fake.deleteLocalUserReturns.result1, fake.deleteLocalUserReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) DeleteLocalUserCallCount() int {
+	fake.deleteLocalUserMutex.RLock()
+	defer fake.deleteLocalUserMutex.RUnlock()
+	return len(fake.deleteLocalUserArgsForCall)
+}
+
+func (fake *FakeTeamDB) DeleteLocalUserArgsForCall(i int) string {
+	fake.deleteLocalUserMutex.RLock()
+	defer fake.deleteLocalUserMutex.RUnlock()
+	return fake.deleteLocalUserArgsForCall[i].username
+}
+
+func (fake *FakeTeamDB) DeleteLocalUserReturns(result1 bool, result2 error) {
+	fake.DeleteLocalUserStub = nil
+	fake.deleteLocalUserReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -763,6 +1673,14 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.updateUAAAuthMutex.RUnlock()
 	fake.updateGenericOAuthMutex.RLock()
 	defer fake.updateGenericOAuthMutex.RUnlock()
+	fake.updateOIDCAuthMutex.RLock()
+	defer fake.updateOIDCAuthMutex.RUnlock()
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	fake.updateGitLabAuthMutex.RLock()
+	defer fake.updateGitLabAuthMutex.RUnlock()
+	fake.updateCertAuthMutex.RLock()
+	defer fake.updateCertAuthMutex.RUnlock()
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
 	fake.saveConfigToBeDeprecatedMutex.RLock()
@@ -779,6 +1697,42 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.findContainersByDescriptorsMutex.RUnlock()
 	fake.getVolumesMutex.RLock()
 	defer fake.getVolumesMutex.RUnlock()
+	fake.setLockCapacityMutex.RLock()
+	defer fake.setLockCapacityMutex.RUnlock()
+	fake.getLockCapacitiesMutex.RLock()
+	defer fake.getLockCapacitiesMutex.RUnlock()
+	fake.getLockHoldersMutex.RLock()
+	defer fake.getLockHoldersMutex.RUnlock()
+	fake.releaseLockMutex.RLock()
+	defer fake.releaseLockMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	fake.saveSessionMutex.RLock()
+	defer fake.saveSessionMutex.RUnlock()
+	fake.getSessionsMutex.RLock()
+	defer fake.getSessionsMutex.RUnlock()
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	fake.revokeAllSessionsMutex.RLock()
+	defer fake.revokeAllSessionsMutex.RUnlock()
+	fake.revokeUserSessionsMutex.RLock()
+	defer fake.revokeUserSessionsMutex.RUnlock()
+	fake.createLocalUserMutex.RLock()
+	defer fake.createLocalUserMutex.RUnlock()
+	fake.getLocalUsersMutex.RLock()
+	defer fake.getLocalUsersMutex.RUnlock()
+	fake.getLocalUserMutex.RLock()
+	defer fake.getLocalUserMutex.RUnlock()
+	fake.setLocalUserPasswordMutex.RLock()
+	defer fake.setLocalUserPasswordMutex.RUnlock()
+	fake.setLocalUserEnabledMutex.RLock()
+	defer fake.setLocalUserEnabledMutex.RUnlock()
+	fake.deleteLocalUserMutex.RLock()
+	defer fake.deleteLocalUserMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,179 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrLocalUserExists = errors.New("a user with that name already exists")
+
+type LocalUser struct {
+	ID           int
+	TeamID       int
+	Username     string
+	PasswordHash string
+	Enabled      bool
+	CreatedAt    time.Time
+}
+
+// CheckPassword reports whether the password matches the user's hash.
+// Disabled users never match.
+func (user LocalUser) CheckPassword(password string) bool {
+	if !user.Enabled {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+}
+
+const localUserColumns = "u.id, u.team_id, u.username, u.password_hash, u.enabled, u.created_at"
+
+func (db *teamDB) CreateLocalUser(username string, password string) (LocalUser, error) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return LocalUser{}, err
+	}
+
+	localUser, err := scanLocalUser(db.conn.QueryRow(`
+		WITH u AS (
+			INSERT INTO local_users (team_id, username, password_hash)
+			SELECT id, $2, $3
+			FROM teams
+			WHERE LOWER(name) = LOWER($1)
+			RETURNING *
+		)
+		SELECT `+localUserColumns+`
+		FROM u
+	`, db.teamName, username, string(passwordHash)))
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code.Name() == "unique_violation" {
+			return LocalUser{}, ErrLocalUserExists
+		}
+
+		return LocalUser{}, err
+	}
+
+	return localUser, nil
+}
+
+func (db *teamDB) GetLocalUsers() ([]LocalUser, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+localUserColumns+`
+		FROM local_users u
+		JOIN teams t ON t.id = u.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+		ORDER BY u.username ASC
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	localUsers := []LocalUser{}
+	for rows.Next() {
+		localUser, err := scanLocalUser(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		localUsers = append(localUsers, localUser)
+	}
+
+	return localUsers, nil
+}
+
+func (db *teamDB) GetLocalUser(username string) (LocalUser, bool, error) {
+	localUser, err := scanLocalUser(db.conn.QueryRow(`
+		SELECT `+localUserColumns+`
+		FROM local_users u
+		JOIN teams t ON t.id = u.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+		AND u.username = $2
+	`, db.teamName, username))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return LocalUser{}, false, nil
+		}
+
+		return LocalUser{}, false, err
+	}
+
+	return localUser, true, nil
+}
+
+func (db *teamDB) SetLocalUserPassword(username string, password string) (bool, error) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return false, err
+	}
+
+	return db.updateLocalUser("password_hash = $3", username, string(passwordHash))
+}
+
+func (db *teamDB) SetLocalUserEnabled(username string, enabled bool) (bool, error) {
+	return db.updateLocalUser("enabled = $3", username, enabled)
+}
+
+func (db *teamDB) DeleteLocalUser(username string) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM local_users u
+		USING teams t
+		WHERE t.id = u.team_id
+		AND LOWER(t.name) = LOWER($1)
+		AND u.username = $2
+	`, db.teamName, username)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (db *teamDB) updateLocalUser(set string, username string, value interface{}) (bool, error) {
+	result, err := db.conn.Exec(`
+		UPDATE local_users u
+		SET `+set+`
+		FROM teams t
+		WHERE t.id = u.team_id
+		AND LOWER(t.name) = LOWER($1)
+		AND u.username = $2
+	`, db.teamName, username, value)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func scanLocalUser(row scannable) (LocalUser, error) {
+	var localUser LocalUser
+
+	err := row.Scan(
+		&localUser.ID,
+		&localUser.TeamID,
+		&localUser.Username,
+		&localUser.PasswordHash,
+		&localUser.Enabled,
+		&localUser.CreatedAt,
+	)
+	if err != nil {
+		return LocalUser{}, err
+	}
+
+	return localUser, nil
+}
@@ -0,0 +1,207 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Local users", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		teamDB      db.TeamDB
+		otherTeamDB db.TeamDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		database := db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+
+		_, err := database.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = database.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("CreateLocalUser", func() {
+		It("saves an enabled user with a hashed password", func() {
+			localUser, err := teamDB.CreateLocalUser("alice", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(localUser.Username).To(Equal("alice"))
+			Expect(localUser.Enabled).To(BeTrue())
+			Expect(localUser.PasswordHash).NotTo(Equal("some-password"))
+			Expect(localUser.CheckPassword("some-password")).To(BeTrue())
+			Expect(localUser.CheckPassword("bogus")).To(BeFalse())
+		})
+
+		It("does not allow two users with the same name in a team", func() {
+			_, err := teamDB.CreateLocalUser("alice", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateLocalUser("alice", "other-password")
+			Expect(err).To(Equal(db.ErrLocalUserExists))
+		})
+
+		It("allows users with the same name in different teams", func() {
+			_, err := teamDB.CreateLocalUser("alice", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = otherTeamDB.CreateLocalUser("alice", "other-password")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("GetLocalUsers", func() {
+		BeforeEach(func() {
+			_, err := teamDB.CreateLocalUser("bob", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateLocalUser("alice", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = otherTeamDB.CreateLocalUser("carol", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the team's users ordered by name", func() {
+			localUsers, err := teamDB.GetLocalUsers()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(localUsers).To(HaveLen(2))
+			Expect(localUsers[0].Username).To(Equal("alice"))
+			Expect(localUsers[1].Username).To(Equal("bob"))
+		})
+	})
+
+	Describe("GetLocalUser", func() {
+		BeforeEach(func() {
+			_, err := teamDB.CreateLocalUser("alice", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finds the user by name", func() {
+			localUser, found, err := teamDB.GetLocalUser("alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(localUser.Username).To(Equal("alice"))
+		})
+
+		It("does not find users of other teams", func() {
+			_, found, err := otherTeamDB.GetLocalUser("alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("SetLocalUserPassword", func() {
+		BeforeEach(func() {
+			_, err := teamDB.CreateLocalUser("alice", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("changes the password", func() {
+			updated, err := teamDB.SetLocalUserPassword("alice", "new-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(BeTrue())
+
+			localUser, _, err := teamDB.GetLocalUser("alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localUser.CheckPassword("new-password")).To(BeTrue())
+			Expect(localUser.CheckPassword("some-password")).To(BeFalse())
+		})
+
+		It("does not change users of other teams", func() {
+			updated, err := otherTeamDB.SetLocalUserPassword("alice", "new-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(BeFalse())
+		})
+	})
+
+	Describe("SetLocalUserEnabled", func() {
+		BeforeEach(func() {
+			_, err := teamDB.CreateLocalUser("alice", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("disables and re-enables the user", func() {
+			updated, err := teamDB.SetLocalUserEnabled("alice", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(BeTrue())
+
+			localUser, _, err := teamDB.GetLocalUser("alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localUser.Enabled).To(BeFalse())
+			Expect(localUser.CheckPassword("some-password")).To(BeFalse())
+
+			_, err = teamDB.SetLocalUserEnabled("alice", true)
+			Expect(err).NotTo(HaveOccurred())
+
+			localUser, _, err = teamDB.GetLocalUser("alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localUser.CheckPassword("some-password")).To(BeTrue())
+		})
+
+		It("reports unknown users", func() {
+			updated, err := teamDB.SetLocalUserEnabled("bogus", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(BeFalse())
+		})
+	})
+
+	Describe("DeleteLocalUser", func() {
+		BeforeEach(func() {
+			_, err := teamDB.CreateLocalUser("alice", "some-password")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the user", func() {
+			deleted, err := teamDB.DeleteLocalUser("alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			_, found, err := teamDB.GetLocalUser("alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not delete users of other teams", func() {
+			deleted, err := otherTeamDB.DeleteLocalUser("alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+	})
+})
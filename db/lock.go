@@ -18,14 +18,17 @@ const (
 	LockTypeResourceCheckingForJob
 	LockTypeBatch
 	LockTypeVolumeCreating
+	LockTypeTeamLock
+	LockTypeResourceCheckSlot
+	LockTypeTeamResourceCheckSlot
 )
 
 func buildTrackingLockID(buildID int) LockID {
 	return LockID{LockTypeBuildTracking, buildID}
 }
 
-func resourceCheckingLockID(resourceID int) LockID {
-	return LockID{LockTypeResourceChecking, resourceID}
+func resourceConfigCheckingLockID(resourceHash string) LockID {
+	return LockID{LockTypeResourceChecking, lockIDFromString(resourceHash)}
 }
 
 func resourceTypeCheckingLockID(resourceTypeID int) LockID {
@@ -48,6 +51,18 @@ func volumeCreatingLockID(volumeID int) LockID {
 	return LockID{LockTypeVolumeCreating, volumeID}
 }
 
+func teamLockLockID(teamID int, lockName string) LockID {
+	return LockID{LockTypeTeamLock, lockIDFromString(fmt.Sprintf("%d/%s", teamID, lockName))}
+}
+
+func resourceCheckSlotLockID(slot int) LockID {
+	return LockID{LockTypeResourceCheckSlot, slot}
+}
+
+func teamResourceCheckSlotLockID(teamID int, slot int) LockID {
+	return LockID{LockTypeTeamResourceCheckSlot, lockIDFromString(fmt.Sprintf("%d/%d", teamID, slot))}
+}
+
 //go:generate counterfeiter . LockFactory
 
 type LockFactory interface {
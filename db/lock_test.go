@@ -50,10 +50,11 @@ var _ = Describe("Locks", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory = db.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 
 		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
@@ -469,13 +470,13 @@ var _ = Describe("Locks", func() {
 		Context("when there has been a check recently", func() {
 			Context("when acquiring immediately", func() {
 				It("gets the lock", func() {
-					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, false)
+					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, false)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
 					lock.Release()
 
-					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, true)
+					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, true)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
@@ -485,13 +486,13 @@ var _ = Describe("Locks", func() {
 
 			Context("when not acquiring immediately", func() {
 				It("does not get the lock", func() {
-					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, false)
+					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, false)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
 					lock.Release()
 
-					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, false)
+					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, false)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeFalse())
 				})
@@ -501,12 +502,12 @@ var _ = Describe("Locks", func() {
 		Context("when there has not been a check recently", func() {
 			Context("when acquiring immediately", func() {
 				It("gets and keeps the lock and stops others from periodically getting it", func() {
-					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, true)
+					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, true)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
 					Consistently(func() bool {
-						_, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, false)
+						_, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, false)
 						Expect(err).NotTo(HaveOccurred())
 
 						return acquired
@@ -516,7 +517,7 @@ var _ = Describe("Locks", func() {
 
 					time.Sleep(time.Second)
 
-					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, true)
+					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, true)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
@@ -524,12 +525,12 @@ var _ = Describe("Locks", func() {
 				})
 
 				It("gets and keeps the lock and stops others from immediately getting it", func() {
-					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, true)
+					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, true)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
 					Consistently(func() bool {
-						_, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, true)
+						_, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, true)
 						Expect(err).NotTo(HaveOccurred())
 
 						return acquired
@@ -539,7 +540,7 @@ var _ = Describe("Locks", func() {
 
 					time.Sleep(time.Second)
 
-					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, true)
+					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, true)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
@@ -549,12 +550,12 @@ var _ = Describe("Locks", func() {
 
 			Context("when not acquiring immediately", func() {
 				It("gets and keeps the lock and stops others from periodically getting it", func() {
-					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, false)
+					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, false)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
 					Consistently(func() bool {
-						_, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, false)
+						_, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, false)
 						Expect(err).NotTo(HaveOccurred())
 
 						return acquired
@@ -564,7 +565,7 @@ var _ = Describe("Locks", func() {
 
 					time.Sleep(time.Second)
 
-					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, false)
+					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, false)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
@@ -572,12 +573,12 @@ var _ = Describe("Locks", func() {
 				})
 
 				It("gets and keeps the lock and stops others from immediately getting it", func() {
-					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, false)
+					lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, false)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
 					Consistently(func() bool {
-						_, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, true)
+						_, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, true)
 						Expect(err).NotTo(HaveOccurred())
 
 						return acquired
@@ -587,7 +588,7 @@ var _ = Describe("Locks", func() {
 
 					time.Sleep(time.Second)
 
-					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, 1*time.Second, false)
+					lock, acquired, err = pipelineDB.AcquireResourceCheckingLock(logger, someResource, "some-resource-hash", 1*time.Second, false)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(acquired).To(BeTrue())
 
@@ -790,6 +791,67 @@ var _ = Describe("Locks", func() {
 		})
 	})
 
+	Describe("AcquireResourceCheckSlot", func() {
+		It("hands out no more than the global limit of slots", func() {
+			slot1, acquired, err := sqlDB.AcquireResourceCheckSlot(logger, 1, 2, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			slot2, acquired, err := sqlDB.AcquireResourceCheckSlot(logger, 2, 2, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired, err = sqlDB.AcquireResourceCheckSlot(logger, 3, 2, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeFalse())
+
+			err = slot1.Release()
+			Expect(err).NotTo(HaveOccurred())
+
+			slot3, acquired, err := sqlDB.AcquireResourceCheckSlot(logger, 3, 2, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			slot2.Release()
+			slot3.Release()
+		})
+
+		It("hands out no more than the team limit of slots to each team", func() {
+			slot1, acquired, err := sqlDB.AcquireResourceCheckSlot(logger, 1, 10, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired, err = sqlDB.AcquireResourceCheckSlot(logger, 1, 10, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeFalse())
+
+			slot2, acquired, err := sqlDB.AcquireResourceCheckSlot(logger, 2, 10, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			slot1.Release()
+			slot2.Release()
+		})
+
+		It("gives back the team slot when there is no global slot", func() {
+			slot1, acquired, err := sqlDB.AcquireResourceCheckSlot(logger, 1, 1, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired, err = sqlDB.AcquireResourceCheckSlot(logger, 2, 1, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeFalse())
+
+			slot1.Release()
+
+			slot2, acquired, err := sqlDB.AcquireResourceCheckSlot(logger, 2, 1, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			slot2.Release()
+		})
+	})
+
 	Describe("GetTaskLock", func() {
 		Context("when something got the lock recently", func() {
 			It("does not get the lock", func() {
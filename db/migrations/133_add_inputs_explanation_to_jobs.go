@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddInputsExplanationToJobs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+			ADD COLUMN inputs_explanation json
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
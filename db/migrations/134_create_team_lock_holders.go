@@ -0,0 +1,28 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateTeamLockHolders(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE team_lock_holders (
+			id serial PRIMARY KEY,
+			team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			acquired_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (team_id, name, build_id)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX team_lock_holders_build_id ON team_lock_holders (build_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
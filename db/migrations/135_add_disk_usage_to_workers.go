@@ -0,0 +1,24 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddDiskUsageToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+			ADD COLUMN disk_capacity_in_bytes bigint NOT NULL DEFAULT 0,
+			ADD COLUMN disk_usage_in_bytes bigint NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+			ADD COLUMN last_used_at timestamp with time zone NOT NULL DEFAULT now()
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddStateToContainersAndVolumes(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TYPE container_state AS ENUM (
+			'creating',
+			'created',
+			'destroying'
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE containers
+		ADD COLUMN state container_state DEFAULT 'created' NOT NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TYPE volume_state AS ENUM (
+			'creating',
+			'created',
+			'destroying'
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN state volume_state DEFAULT 'created' NOT NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddResourceConfigChecks(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE resources ADD COLUMN resource_hash text
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE resource_config_checks (
+			resource_hash text NOT NULL,
+			last_checked timestamp NOT NULL DEFAULT 'epoch'
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// sources can be too large to index directly
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX resource_config_checks_resource_hash ON resource_config_checks (md5(resource_hash))
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX resources_resource_hash ON resources (md5(resource_hash))
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
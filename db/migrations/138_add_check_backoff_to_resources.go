@@ -0,0 +1,16 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddCheckBackoffToResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE resources
+			ADD COLUMN consecutive_check_failures integer NOT NULL DEFAULT 0,
+			ADD COLUMN next_check_at timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateResourceChecks(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE resource_checks (
+			id serial PRIMARY KEY,
+			resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+			start_time timestamp with time zone NOT NULL,
+			end_time timestamp with time zone NOT NULL,
+			check_error text,
+			new_versions integer NOT NULL DEFAULT 0,
+			worker_name text
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX resource_checks_resource_id ON resource_checks (resource_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX resource_checks_end_time ON resource_checks (end_time)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateAPITokens(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE api_tokens (
+			id serial PRIMARY KEY,
+			team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			token_hash text NOT NULL,
+			scopes json NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			expires_at timestamp with time zone NULL,
+			last_used_at timestamp with time zone NULL,
+			UNIQUE (team_id, name),
+			UNIQUE (token_hash)
+		)
+	`)
+	return err
+}
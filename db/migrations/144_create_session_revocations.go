@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateSessionRevocations(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE session_revocations (
+			id serial PRIMARY KEY,
+			team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			session_id text NULL,
+			revoked_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	return err
+}
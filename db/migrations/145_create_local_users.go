@@ -0,0 +1,18 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateLocalUsers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE local_users (
+			id serial PRIMARY KEY,
+			team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			username text NOT NULL,
+			password_hash text NOT NULL,
+			enabled boolean NOT NULL DEFAULT true,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (team_id, username)
+		)
+	`)
+	return err
+}
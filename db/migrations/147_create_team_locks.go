@@ -0,0 +1,16 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateTeamLocks(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE team_locks (
+			id serial PRIMARY KEY,
+			team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			capacity integer NOT NULL,
+			UNIQUE (team_id, name)
+		)
+	`)
+	return err
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func DigestResourceHashes(tx migration.LimitedTx) error {
+	// the hashes held resource sources, and so credentials, verbatim; they are
+	// recorded again as digests the next time each resource is checked
+	_, err := tx.Exec(`
+		UPDATE resources SET resource_hash = NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM resource_config_checks
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DROP INDEX resource_config_checks_resource_hash
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DROP INDEX resources_resource_hash
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX resource_config_checks_resource_hash ON resource_config_checks (resource_hash)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX resources_resource_hash ON resources (resource_hash)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
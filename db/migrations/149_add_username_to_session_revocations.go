@@ -0,0 +1,10 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddUsernameToSessionRevocations(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE session_revocations ADD COLUMN username text NULL
+	`)
+	return err
+}
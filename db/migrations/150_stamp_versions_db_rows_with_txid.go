@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/concourse/atc/dbng/migration"
+)
+
+func StampVersionsDBRowsWithTxid(tx migration.LimitedTx) error {
+	for _, table := range []string{"versioned_resources", "builds", "build_inputs", "build_outputs"} {
+		_, err := tx.Exec(fmt.Sprintf(`
+			ALTER TABLE %[1]s ADD COLUMN txid bigint NOT NULL DEFAULT txid_current()
+		`, table))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`
+			CREATE INDEX %[1]s_txid ON %[1]s (txid)
+		`, table))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.Exec(`
+		CREATE FUNCTION stamp_txid() RETURNS trigger AS $$
+		BEGIN
+			NEW.txid := txid_current();
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TRIGGER versioned_resources_stamp_txid
+		BEFORE UPDATE ON versioned_resources
+		FOR EACH ROW
+		WHEN (OLD.enabled IS DISTINCT FROM NEW.enabled OR OLD.check_order IS DISTINCT FROM NEW.check_order)
+		EXECUTE PROCEDURE stamp_txid()
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TRIGGER builds_stamp_txid
+		BEFORE UPDATE ON builds
+		FOR EACH ROW
+		WHEN (OLD.status IS DISTINCT FROM NEW.status)
+		EXECUTE PROCEDURE stamp_txid()
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE FUNCTION stamp_build_txid() RETURNS trigger AS $$
+		BEGIN
+			UPDATE builds SET txid = txid_current() WHERE id = OLD.build_id;
+			RETURN OLD;
+		END;
+		$$ LANGUAGE plpgsql
+	`)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{"build_inputs", "build_outputs"} {
+		_, err := tx.Exec(fmt.Sprintf(`
+			CREATE TRIGGER %[1]s_stamp_build_txid
+			AFTER DELETE ON %[1]s
+			FOR EACH ROW
+			EXECUTE PROCEDURE stamp_build_txid()
+		`, table))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package migrations
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng/migration"
+)
+
+func CreatePipelinePassedJobs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE pipeline_passed_jobs (
+			pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+			passed_pipeline_name text NOT NULL,
+			passed_job_name text NOT NULL,
+			UNIQUE (pipeline_id, passed_pipeline_name, passed_job_name)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX pipeline_passed_jobs_passed_pipeline_name_passed_job_name ON pipeline_passed_jobs (passed_pipeline_name, passed_job_name)
+	`)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, name, config
+		FROM pipelines
+	`)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	type pipelineConfig struct {
+		name   string
+		config atc.Config
+	}
+
+	pipelineConfigs := map[int]pipelineConfig{}
+
+	for rows.Next() {
+		var pipelineID int
+		var pipelineName string
+		var pipelineConfigPayload []byte
+		err := rows.Scan(&pipelineID, &pipelineName, &pipelineConfigPayload)
+		if err != nil {
+			return err
+		}
+
+		var config atc.Config
+		err = json.Unmarshal(pipelineConfigPayload, &config)
+		if err != nil {
+			return err
+		}
+
+		pipelineConfigs[pipelineID] = pipelineConfig{pipelineName, config}
+	}
+
+	for pipelineID, pipelineConfig := range pipelineConfigs {
+		for _, passed := range pipelineConfig.config.PassedJobsInOtherPipelines(pipelineConfig.name) {
+			_, err := tx.Exec(`
+				INSERT INTO pipeline_passed_jobs (pipeline_id, passed_pipeline_name, passed_job_name)
+				VALUES ($1, $2, $3)
+			`, pipelineID, passed.Pipeline, passed.Job)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddOwnerAndKindToAPITokens(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE api_tokens
+		ADD COLUMN owner text NULL,
+		ADD COLUMN kind text NOT NULL DEFAULT 'service'
+	`)
+	return err
+}
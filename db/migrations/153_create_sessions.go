@@ -0,0 +1,17 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateSessions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE sessions (
+			team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			session_id text NOT NULL,
+			username text NULL,
+			issued_at timestamp with time zone NOT NULL,
+			expires_at timestamp with time zone NOT NULL,
+			PRIMARY KEY (team_id, session_id)
+		)
+	`)
+	return err
+}
@@ -134,4 +134,25 @@ var Migrations = []migration.Migrator{
 	AddRunningWorkerMustHaveAddrConstraint,
 	AddInterruptibleToJob,
 	AddLandedWorkerCannotHaveAddrConstraint,
+	AddInputsExplanationToJobs,
+	CreateTeamLockHolders,
+	AddDiskUsageToWorkers,
+	AddStateToContainersAndVolumes,
+	AddResourceConfigChecks,
+	AddCheckBackoffToResources,
+	CreateResourceChecks,
+	AddOIDCAuthToTeams,
+	AddLDAPAuthToTeams,
+	AddGitLabAuthToTeams,
+	CreateAPITokens,
+	CreateSessionRevocations,
+	CreateLocalUsers,
+	AddCertAuthToTeams,
+	CreateTeamLocks,
+	DigestResourceHashes,
+	AddUsernameToSessionRevocations,
+	StampVersionsDBRowsWithTxid,
+	CreatePipelinePassedJobs,
+	AddOwnerAndKindToAPITokens,
+	CreateSessions,
 }
@@ -1,7 +1,9 @@
 package db
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +13,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . PipelineDB
@@ -34,6 +37,7 @@ type PipelineDB interface {
 	Destroy() error
 
 	AcquireSchedulingLock(lager.Logger, time.Duration) (Lock, bool, error)
+	SchedulingNotifier() (Notifier, error)
 
 	GetResource(resourceName string) (SavedResource, bool, error)
 	GetResources() ([]SavedResource, bool, error)
@@ -50,7 +54,10 @@ type PipelineDB interface {
 	EnableVersionedResource(versionedResourceID int) error
 	DisableVersionedResource(versionedResourceID int) error
 	SetResourceCheckError(resource SavedResource, err error) error
-	AcquireResourceCheckingLock(logger lager.Logger, resource SavedResource, length time.Duration, immediate bool) (Lock, bool, error)
+	SetResourceNextCheckAt(resource SavedResource, nextCheckAt time.Time) error
+	SaveResourceCheck(resource SavedResource, check ResourceCheck) error
+	GetResourceChecks(resourceName string, page Page) ([]SavedResourceCheck, Pagination, bool, error)
+	AcquireResourceCheckingLock(logger lager.Logger, resource SavedResource, resourceHash string, length time.Duration, immediate bool) (Lock, bool, error)
 	AcquireResourceTypeCheckingLock(logger lager.Logger, resourceType SavedResourceType, length time.Duration, immediate bool) (Lock, bool, error)
 
 	GetJobs() ([]SavedJob, error)
@@ -71,6 +78,7 @@ type PipelineDB interface {
 	GetPendingBuildsForJob(jobName string) ([]Build, error)
 	GetAllPendingBuilds() (map[string][]Build, error)
 	UseInputsForBuild(buildID int, inputs []BuildInput) error
+	AcquireTeamLocks(logger lager.Logger, buildID int, locks []string) (bool, error)
 
 	LoadVersionsDB() (*algorithm.VersionsDB, error)
 	GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error)
@@ -79,6 +87,8 @@ type PipelineDB interface {
 	SaveNextInputMapping(inputMapping algorithm.InputMapping, jobName string) error
 	GetNextBuildInputs(jobName string) ([]BuildInput, bool, error)
 	DeleteNextInputMapping(jobName string) error
+	SaveInputsExplanation(explanation algorithm.Explanation, jobName string) error
+	GetInputsExplanation(jobName string) (algorithm.Explanation, bool, error)
 
 	GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]Build, error)
 	GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (Build, bool, error)
@@ -101,7 +111,8 @@ type pipelineDB struct {
 
 	SavedPipeline
 
-	versionsDB *algorithm.VersionsDB
+	versionsDBCache  *versionsDBCache
+	versionsDBCaches *VersionsDBCaches
 
 	lockFactory  LockFactory
 	buildFactory *buildFactory
@@ -133,6 +144,17 @@ func (e FirstLoggedBuildIDDecreasedError) Error() string {
 	return fmt.Sprintf("first logged build id for job '%s' decreased from %d to %d", e.Job, e.OldID, e.NewID)
 }
 
+// PipelineReferencedError is returned when renaming or destroying a pipeline
+// whose jobs are named by passed constraints of other pipelines.
+type PipelineReferencedError struct {
+	Pipeline     string
+	ReferencedBy []string
+}
+
+func (e PipelineReferencedError) Error() string {
+	return fmt.Sprintf("pipeline '%s' is referenced by passed constraints of pipelines: %s", e.Pipeline, strings.Join(e.ReferencedBy, ", "))
+}
+
 func (pdb *pipelineDB) Pipeline() SavedPipeline {
 	return pdb.SavedPipeline
 }
@@ -171,7 +193,11 @@ func (pdb *pipelineDB) Unpause() error {
 		SET paused = false
 		WHERE id = $1
 	`, pdb.ID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return pdb.notifySchedulingNeeded()
 }
 
 func (pdb *pipelineDB) Pause() error {
@@ -184,12 +210,28 @@ func (pdb *pipelineDB) Pause() error {
 }
 
 func (pdb *pipelineDB) UpdateName(newName string) error {
-	_, err := pdb.conn.Exec(`
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = pdb.checkNotReferenced(tx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
 		UPDATE pipelines
 		SET name = $1
 		WHERE id = $2
 	`, newName, pdb.ID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (pdb *pipelineDB) Destroy() error {
@@ -200,6 +242,11 @@ func (pdb *pipelineDB) Destroy() error {
 
 	defer tx.Rollback()
 
+	err = pdb.checkNotReferenced(tx)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP TABLE pipeline_build_events_%d
 	`, pdb.ID))
@@ -214,7 +261,63 @@ func (pdb *pipelineDB) Destroy() error {
 		return err
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	pdb.versionsDBCaches.remove(pdb.ID)
+
+	return nil
+}
+
+// checkNotReferenced returns a PipelineReferencedError if passed constraints
+// of other pipelines name the pipeline's jobs, as renaming or destroying the
+// pipeline would leave them dangling.
+func (pdb *pipelineDB) checkNotReferenced(tx Tx) error {
+	var name string
+	err := tx.QueryRow(`
+		SELECT name
+		FROM pipelines
+		WHERE id = $1
+	`, pdb.ID).Scan(&name)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT DISTINCT p.name
+		FROM pipeline_passed_jobs pj
+		JOIN pipelines p ON p.id = pj.pipeline_id
+		WHERE p.team_id = $1
+		AND pj.passed_pipeline_name = $2
+		ORDER BY p.name
+	`, pdb.TeamID(), name)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	referencedBy := []string{}
+	for rows.Next() {
+		var pipelineName string
+		err := rows.Scan(&pipelineName)
+		if err != nil {
+			return err
+		}
+
+		referencedBy = append(referencedBy, pipelineName)
+	}
+
+	if len(referencedBy) > 0 {
+		return PipelineReferencedError{
+			Pipeline:     name,
+			ReferencedBy: referencedBy,
+		}
+	}
+
+	return nil
 }
 
 func (pdb *pipelineDB) Reload() (bool, error) {
@@ -261,7 +364,7 @@ func (pdb *pipelineDB) GetResource(resourceName string) (SavedResource, bool, er
 
 func (pdb *pipelineDB) GetResources() ([]SavedResource, bool, error) {
 	rows, err := pdb.conn.Query(`
-			SELECT id, name, config, check_error, paused
+			SELECT id, name, config, check_error, paused, consecutive_check_failures, next_check_at
 			FROM resources
 			WHERE pipeline_id = $1
 				AND active = true
@@ -291,7 +394,49 @@ func (pdb *pipelineDB) GetResources() ([]SavedResource, bool, error) {
 	return savedResources, true, nil
 }
 
-func (pdb *pipelineDB) AcquireResourceCheckingLock(logger lager.Logger, resource SavedResource, interval time.Duration, immediate bool) (Lock, bool, error) {
+// AcquireResourceCheckingLock acquires the lock for checking the given
+// resource. The interval and the lock are shared by every resource in the
+// team with the same resource hash, so that resources with identical
+// configuration in different pipelines are only checked once. A resource
+// whose hash has changed, e.g. because it was just configured, is checked
+// immediately so that it does not have to wait for new versions to show up.
+func (pdb *pipelineDB) AcquireResourceCheckingLock(logger lager.Logger, resource SavedResource, resourceHash string, interval time.Duration, immediate bool) (Lock, bool, error) {
+	resourceHash = pdb.resourceCheckHash(resource, resourceHash)
+
+	result, err := pdb.conn.Exec(`
+		UPDATE resources
+		SET resource_hash = $3
+		WHERE name = $1
+			AND pipeline_id = $2
+			AND resource_hash IS DISTINCT FROM $3
+	`, resource.Name, pdb.ID, resourceHash)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hashChanged, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if hashChanged > 0 {
+		immediate = true
+	}
+
+	_, err = pdb.conn.Exec(`
+		INSERT INTO resource_config_checks (resource_hash)
+		SELECT $1
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM resource_config_checks
+			WHERE resource_hash = $1
+		)
+	`, resourceHash)
+	err = swallowUniqueViolation(err)
+	if err != nil {
+		return nil, false, err
+	}
+
 	tx, err := pdb.conn.Begin()
 	if err != nil {
 		return nil, false, err
@@ -299,19 +444,18 @@ func (pdb *pipelineDB) AcquireResourceCheckingLock(logger lager.Logger, resource
 
 	defer tx.Rollback()
 
-	params := []interface{}{resource.Name, pdb.ID}
+	params := []interface{}{resourceHash}
 
 	condition := ""
 	if !immediate {
-		condition = "AND now() - last_checked > ($3 || ' SECONDS')::INTERVAL"
+		condition = "AND now() - last_checked > ($2 || ' SECONDS')::INTERVAL"
 		params = append(params, interval.Seconds())
 	}
 
 	updated, err := checkIfRowsUpdated(tx, `
-		UPDATE resources
+		UPDATE resource_config_checks
 		SET last_checked = now()
-		WHERE name = $1
-			AND pipeline_id = $2
+		WHERE resource_hash = $1
 	`+condition, params...)
 	if err != nil {
 		return nil, false, err
@@ -321,11 +465,20 @@ func (pdb *pipelineDB) AcquireResourceCheckingLock(logger lager.Logger, resource
 		return nil, false, nil
 	}
 
+	_, err = tx.Exec(`
+		UPDATE resources
+		SET last_checked = now()
+		WHERE resource_hash = $1
+	`, resourceHash)
+	if err != nil {
+		return nil, false, err
+	}
+
 	lock := pdb.lockFactory.NewLock(
 		logger.Session("lock", lager.Data{
 			"resource": resource.Name,
 		}),
-		resourceCheckingLockID(resource.ID),
+		resourceConfigCheckingLockID(resourceHash),
 	)
 
 	acquired, err := lock.Acquire()
@@ -346,6 +499,20 @@ func (pdb *pipelineDB) AcquireResourceCheckingLock(logger lager.Logger, resource
 	return lock, true, nil
 }
 
+// resourceCheckHash digests the resource hash, which contains the resource's
+// source verbatim, scoped to the team. A resource whose type is defined by
+// the pipeline gets a hash of its own, as another pipeline may define a type
+// of the same name differently.
+func (pdb *pipelineDB) resourceCheckHash(resource SavedResource, resourceHash string) string {
+	scope := fmt.Sprintf("team:%d", pdb.TeamID())
+	if _, found := pdb.Config().ResourceTypes.Lookup(resource.Config.Type); found {
+		scope = fmt.Sprintf("resource:%d", resource.ID)
+	}
+
+	digest := sha256.Sum256([]byte(scope + "\n" + resourceHash))
+	return hex.EncodeToString(digest[:])
+}
+
 func (pdb *pipelineDB) AcquireResourceTypeCheckingLock(logger lager.Logger, resourceType SavedResourceType, interval time.Duration, immediate bool) (Lock, bool, error) {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -602,7 +769,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, error) {
 	return pdb.scanResource(tx.QueryRow(`
-			SELECT id, name, config, check_error, paused
+			SELECT id, name, config, check_error, paused, consecutive_check_failures, next_check_at
 			FROM resources
 			WHERE name = $1
 				AND pipeline_id = $2
@@ -612,10 +779,11 @@ func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, err
 
 func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error) {
 	var checkErr sql.NullString
+	var nextCheckAt pq.NullTime
 	var resource SavedResource
 	var configBlob []byte
 
-	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused)
+	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused, &resource.ConsecutiveCheckFailures, &nextCheckAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResource{}, false, nil
@@ -637,6 +805,10 @@ func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error)
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
+	if nextCheckAt.Valid {
+		resource.NextCheckAt = nextCheckAt.Time
+	}
+
 	return resource, true, nil
 }
 
@@ -731,7 +903,12 @@ func (pdb *pipelineDB) updatePaused(resource string, pause bool) error {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return pdb.notifySchedulingNeeded()
 }
 
 func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
@@ -742,6 +919,8 @@ func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions
 
 	defer tx.Rollback()
 
+	newVersions := false
+
 	for _, version := range versions {
 		vr := VersionedResource{
 			Resource: config.Name,
@@ -763,23 +942,142 @@ func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions
 			return ResourceNotFoundError{Name: vr.Resource}
 		}
 
-		_, _, err = pdb.saveVersionedResource(tx, savedResource, vr)
+		_, created, err := pdb.saveVersionedResource(tx, savedResource, vr)
 		if err != nil {
 			return err
 		}
 
+		if created {
+			newVersions = true
+		}
+
 		err = pdb.incrementCheckOrderWhenNewerVersion(tx, savedResource.ID, vr.Type, string(versionJSON))
 		if err != nil {
 			return err
 		}
 	}
 
+	sharingPipelineIDs, err := pdb.saveSharedResourceVersions(tx, config, versions)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	for _, pipelineID := range sharingPipelineIDs {
+		err = pdb.bus.Notify(pipelineSchedulingChannel(pipelineID))
+		if err != nil {
+			return err
+		}
+	}
+
+	if !newVersions {
+		return nil
+	}
+
+	return pdb.notifySchedulingNeeded()
+}
+
+// saveSharedResourceVersions saves the versions for every other resource in
+// the team with the same resource hash, returning the IDs of their pipelines.
+func (pdb *pipelineDB) saveSharedResourceVersions(tx Tx, config atc.ResourceConfig, versions []atc.Version) ([]int, error) {
+	sharingResources, err := pdb.findSharingResources(tx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineIDs := []int{}
+	seenPipelines := map[int]bool{}
+
+	for _, r := range sharingResources {
+		for _, version := range versions {
+			vr := VersionedResource{
+				Resource: r.name,
+				Type:     config.Type,
+				Version:  Version(version),
+			}
+
+			versionJSON, err := json.Marshal(vr.Version)
+			if err != nil {
+				return nil, err
+			}
+
+			_, _, err = pdb.saveVersionedResource(tx, SavedResource{ID: r.id}, vr)
+			if err != nil {
+				return nil, err
+			}
+
+			err = pdb.incrementCheckOrderWhenNewerVersion(tx, r.id, vr.Type, string(versionJSON))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !seenPipelines[r.pipelineID] {
+			seenPipelines[r.pipelineID] = true
+			pipelineIDs = append(pipelineIDs, r.pipelineID)
+		}
+	}
+
+	return pipelineIDs, nil
+}
+
+type sharingResource struct {
+	id         int
+	name       string
+	pipelineID int
+}
+
+// findSharingResources returns every other resource in the team with the
+// same resource hash, which are checked on this resource's behalf. Resources
+// and pipelines that are paused are left out, just as if they had not been
+// checked, and so are resources whose type is defined by their pipeline.
+func (pdb *pipelineDB) findSharingResources(tx Tx, config atc.ResourceConfig) ([]sharingResource, error) {
+	if _, found := pdb.Config().ResourceTypes.Lookup(config.Type); found {
+		return []sharingResource{}, nil
+	}
+
+	rows, err := tx.Query(`
+		SELECT r.id, r.name, r.pipeline_id
+		FROM resources r
+		JOIN pipelines p ON p.id = r.pipeline_id
+		JOIN resources checked ON checked.resource_hash = r.resource_hash
+		WHERE checked.name = $1
+			AND checked.pipeline_id = $2
+			AND p.team_id = $3
+			AND r.id != checked.id
+			AND r.active = true
+			AND r.paused = false
+			AND p.paused = false
+			AND NOT EXISTS (
+				SELECT 1
+				FROM resource_types rt
+				WHERE rt.pipeline_id = r.pipeline_id
+					AND rt.name = $4
+					AND rt.active = true
+			)
+	`, config.Name, pdb.ID, pdb.TeamID(), config.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	sharingResources := []sharingResource{}
+	for rows.Next() {
+		var r sharingResource
+		err := rows.Scan(&r.id, &r.name, &r.pipelineID)
+		if err != nil {
+			return nil, err
+		}
+
+		sharingResources = append(sharingResources, r)
+	}
+
+	return sharingResources, nil
 }
 
 func (pdb *pipelineDB) SaveResourceTypeVersion(resourceType atc.ResourceType, version atc.Version) error {
@@ -938,25 +1236,198 @@ func (pdb *pipelineDB) GetLatestVersionedResource(resourceName string) (SavedVer
 }
 
 func (pdb *pipelineDB) SetResourceCheckError(resource SavedResource, cause error) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = setResourceCheckError(tx, resource.ID, cause)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func setResourceCheckError(tx Tx, resourceID int, cause error) error {
 	var err error
 
 	if cause == nil {
-		_, err = pdb.conn.Exec(`
+		_, err = tx.Exec(`
 			UPDATE resources
-			SET check_error = NULL
+			SET check_error = NULL, consecutive_check_failures = 0
 			WHERE id = $1
-			`, resource.ID)
+			`, resourceID)
 	} else {
-		_, err = pdb.conn.Exec(`
+		_, err = tx.Exec(`
 			UPDATE resources
-			SET check_error = $2
+			SET check_error = $2, consecutive_check_failures = consecutive_check_failures + 1
 			WHERE id = $1
-		`, resource.ID, cause.Error())
+		`, resourceID, cause.Error())
 	}
 
 	return err
 }
 
+func (pdb *pipelineDB) SetResourceNextCheckAt(resource SavedResource, nextCheckAt time.Time) error {
+	_, err := pdb.conn.Exec(`
+		UPDATE resources
+		SET next_check_at = $2
+		WHERE id = $1
+	`, resource.ID, nextCheckAt)
+	return err
+}
+
+// SaveResourceCheck records the check and its outcome for the resource and
+// every resource that shares its checks, setting or clearing their check
+// errors and counting their consecutive failures.
+func (pdb *pipelineDB) SaveResourceCheck(resource SavedResource, check ResourceCheck) error {
+	var checkErr sql.NullString
+	if check.CheckError != nil {
+		checkErr = sql.NullString{String: check.CheckError.Error(), Valid: true}
+	}
+
+	var workerName sql.NullString
+	if check.WorkerName != "" {
+		workerName = sql.NullString{String: check.WorkerName, Valid: true}
+	}
+
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	sharingResources, err := pdb.findSharingResources(tx, resource.Config)
+	if err != nil {
+		return err
+	}
+
+	resourceIDs := []int{resource.ID}
+	for _, r := range sharingResources {
+		resourceIDs = append(resourceIDs, r.id)
+	}
+
+	for _, resourceID := range resourceIDs {
+		_, err = tx.Exec(`
+			INSERT INTO resource_checks (resource_id, start_time, end_time, check_error, new_versions, worker_name)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, resourceID, check.StartTime, check.EndTime, checkErr, check.NewVersions, workerName)
+		if err != nil {
+			return err
+		}
+
+		err = setResourceCheckError(tx, resourceID, check.CheckError)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (pdb *pipelineDB) GetResourceChecks(resourceName string, page Page) ([]SavedResourceCheck, Pagination, bool, error) {
+	dbResource, found, err := pdb.GetResource(resourceName)
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	if !found {
+		return nil, Pagination{}, false, nil
+	}
+
+	query := `
+		SELECT c.id, c.start_time, c.end_time, c.check_error, c.new_versions, c.worker_name
+		FROM resource_checks c
+		WHERE c.resource_id = $1
+	`
+
+	var rows *sql.Rows
+	if page.Until != 0 {
+		rows, err = pdb.conn.Query(fmt.Sprintf(`
+			SELECT sub.*
+			FROM (%s
+					AND c.id > $2
+				ORDER BY c.id ASC
+				LIMIT $3
+			) sub
+			ORDER BY sub.id DESC
+		`, query), dbResource.ID, page.Until, page.Limit)
+	} else if page.Since != 0 {
+		rows, err = pdb.conn.Query(fmt.Sprintf(`
+			%s
+				AND c.id < $2
+			ORDER BY c.id DESC
+			LIMIT $3
+		`, query), dbResource.ID, page.Since, page.Limit)
+	} else {
+		rows, err = pdb.conn.Query(fmt.Sprintf(`
+			%s
+			ORDER BY c.id DESC
+			LIMIT $2
+		`, query), dbResource.ID, page.Limit)
+	}
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	defer rows.Close()
+
+	checks := []SavedResourceCheck{}
+	for rows.Next() {
+		var check SavedResourceCheck
+		var checkErr, workerName sql.NullString
+
+		err := rows.Scan(&check.ID, &check.StartTime, &check.EndTime, &checkErr, &check.NewVersions, &workerName)
+		if err != nil {
+			return nil, Pagination{}, false, err
+		}
+
+		if checkErr.Valid {
+			check.CheckError = errors.New(checkErr.String)
+		}
+
+		check.WorkerName = workerName.String
+
+		checks = append(checks, check)
+	}
+
+	if len(checks) == 0 {
+		return []SavedResourceCheck{}, Pagination{}, true, nil
+	}
+
+	var maxID, minID int
+	err = pdb.conn.QueryRow(`
+		SELECT COALESCE(MAX(id), 0), COALESCE(MIN(id), 0)
+		FROM resource_checks
+		WHERE resource_id = $1
+	`, dbResource.ID).Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	var pagination Pagination
+
+	if checks[0].ID < maxID {
+		pagination.Previous = &Page{
+			Until: checks[0].ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if checks[len(checks)-1].ID > minID {
+		pagination.Next = &Page{
+			Since: checks[len(checks)-1].ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return checks, pagination, true, nil
+}
+
 func (pdb *pipelineDB) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, resourceType string, version string) error {
 	_, err := tx.Exec(`
 		WITH max_checkorder AS (
@@ -1411,6 +1882,11 @@ func (pdb *pipelineDB) SaveOutput(buildID int, vr VersionedResource, explicit bo
 		return SavedVersionedResource{}, err
 	}
 
+	err = pdb.notifySchedulingNeeded()
+	if err != nil {
+		return SavedVersionedResource{}, err
+	}
+
 	return svr, nil
 }
 
@@ -1642,178 +2118,213 @@ func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 	return rows == 1, nil
 }
 
-func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
-	var max_modified_time time.Time
-
-	err := pdb.conn.QueryRow(`
-	SELECT
-		CASE
-			WHEN bo_max > vr_max AND bo_max > bi_max THEN bo_max
-			WHEN bi_max > vr_max THEN bi_max
-			ELSE vr_max
-		END
-	FROM
-		(
-			SELECT COALESCE(MAX(bo.modified_time), 'epoch') as bo_max
-			FROM build_outputs bo
-			LEFT OUTER JOIN versioned_resources v ON v.id = bo.versioned_resource_id
-			LEFT OUTER JOIN resources r ON r.id = v.resource_id
-			WHERE r.pipeline_id = $1
-		) bo,
-		(
-			SELECT COALESCE(MAX(bi.modified_time), 'epoch') as bi_max
-			FROM build_inputs bi
-			LEFT OUTER JOIN versioned_resources v ON v.id = bi.versioned_resource_id
-			LEFT OUTER JOIN resources r ON r.id = v.resource_id
-			WHERE r.pipeline_id = $1
-		) bi,
-		(
-			SELECT COALESCE(MAX(vr.modified_time), 'epoch') as vr_max
-			FROM versioned_resources vr
-			LEFT OUTER JOIN resources r ON r.id = vr.resource_id
-			WHERE r.pipeline_id = $1
-		) vr
-	`, pdb.ID).Scan(&max_modified_time)
-
-	return max_modified_time, err
-}
-
 func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := pdb.getLatestModifiedTime()
-	if err != nil {
-		return nil, err
+	return pdb.versionsDBCache.LoadWithPassedJobs(pdb.ConfigVersion(), pdb.loadPassedJobsInOtherPipelines)
+}
+
+// loadPassedJobsInOtherPipelines adds the inputs and outputs of successful
+// builds of jobs in other pipelines that are named by passed constraints.
+// Their versions are mapped onto the versions of this pipeline's resources
+// with the same type and source, so the algorithm can treat them like any
+// other job's outputs. The IDs of those jobs are returned too.
+func (pdb *pipelineDB) loadPassedJobsInOtherPipelines(versionsDB *algorithm.VersionsDB) (*algorithm.VersionsDB, []int, error) {
+	config := pdb.Config()
+
+	type passedJob struct {
+		Passed   string `json:"passed"`
+		Pipeline string `json:"pipeline"`
+		Job      string `json:"job"`
+
+		ResourceID     int    `json:"resource_id"`
+		ResourceType   string `json:"resource_type"`
+		ResourceSource string `json:"resource_source"`
+	}
+
+	passedJobs := []passedJob{}
+	sameJobIDs := map[string]int{}
+
+	for _, job := range config.Jobs {
+		for _, input := range job.Inputs() {
+			for _, passed := range input.Passed {
+				pipelineName, jobName := config.PassedJob(passed)
+				if pipelineName == "" {
+					continue
+				}
+
+				if pipelineName == pdb.Name {
+					sameJobIDs[passed] = versionsDB.JobIDs[jobName]
+					continue
+				}
+
+				resource, found := config.Resources.Lookup(input.ResourceName())
+				if !found {
+					continue
+				}
+
+				source, err := json.Marshal(resource.Source)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				passedJobs = append(passedJobs, passedJob{
+					Passed:   passed,
+					Pipeline: pipelineName,
+					Job:      jobName,
+
+					ResourceID:     versionsDB.ResourceIDs[resource.Name],
+					ResourceType:   resource.Type,
+					ResourceSource: string(source),
+				})
+			}
+		}
 	}
 
-	if pdb.versionsDB != nil && pdb.versionsDB.CachedAt.Equal(latestModifiedTime) {
-		return pdb.versionsDB, nil
+	if len(passedJobs) == 0 && len(sameJobIDs) == 0 {
+		return versionsDB, nil, nil
 	}
 
 	db := &algorithm.VersionsDB{
-		BuildOutputs:     []algorithm.BuildOutput{},
-		BuildInputs:      []algorithm.BuildInput{},
-		ResourceVersions: []algorithm.ResourceVersion{},
+		ResourceVersions: versionsDB.ResourceVersions,
+		DisabledVersions: versionsDB.DisabledVersions,
+		BuildInputs:      versionsDB.BuildInputs,
+		BuildOutputs:     make([]algorithm.BuildOutput, len(versionsDB.BuildOutputs)),
 		JobIDs:           map[string]int{},
-		ResourceIDs:      map[string]int{},
-		CachedAt:         latestModifiedTime,
+		ResourceIDs:      versionsDB.ResourceIDs,
+		CachedAt:         versionsDB.CachedAt,
 	}
 
-	rows, err := pdb.conn.Query(`
-    SELECT v.id, v.check_order, r.id, o.build_id, j.id
-    FROM build_outputs o, builds b, versioned_resources v, jobs j, resources r
-    WHERE v.id = o.versioned_resource_id
-    AND b.id = o.build_id
-    AND j.id = b.job_id
-    AND r.id = v.resource_id
-    AND v.enabled
-		AND b.status = 'succeeded'
-		AND r.pipeline_id = $1
-  `, pdb.ID)
-	if err != nil {
-		return nil, err
-	}
+	copy(db.BuildOutputs, versionsDB.BuildOutputs)
 
-	for rows.Next() {
-		var output algorithm.BuildOutput
-		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
-		if err != nil {
-			return nil, err
-		}
-
-		output.ResourceVersion.CheckOrder = output.CheckOrder
-
-		db.BuildOutputs = append(db.BuildOutputs, output)
+	for name, id := range versionsDB.JobIDs {
+		db.JobIDs[name] = id
 	}
 
-	rows, err = pdb.conn.Query(`
-    SELECT v.id, v.check_order, r.id, i.build_id, i.name, j.id
-    FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
-    WHERE v.id = i.versioned_resource_id
-    AND b.id = i.build_id
-    AND j.id = b.job_id
-    AND r.id = v.resource_id
-    AND v.enabled
-		AND r.pipeline_id = $1
-  `, pdb.ID)
-	if err != nil {
-		return nil, err
+	for passed, id := range sameJobIDs {
+		db.JobIDs[passed] = id
 	}
 
-	for rows.Next() {
-		var input algorithm.BuildInput
-		err := rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID)
-		if err != nil {
-			return nil, err
-		}
-
-		input.ResourceVersion.CheckOrder = input.CheckOrder
+	if len(passedJobs) == 0 {
+		return db, nil, nil
+	}
 
-		db.BuildInputs = append(db.BuildInputs, input)
+	passedJobsJSON, err := json.Marshal(passedJobs)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	rows, err = pdb.conn.Query(`
-    SELECT v.id, v.check_order, r.id
-    FROM versioned_resources v, resources r
-    WHERE r.id = v.resource_id
-    AND v.enabled
-		AND r.pipeline_id = $1
-  `, pdb.ID)
+	// resources are matched by their config as saved with their pipeline,
+	// which is marshalled the same way as the source above
+	rows, err := pdb.conn.Query(`
+		WITH passed AS (
+			SELECT
+				p ->> 'passed' AS passed,
+				p ->> 'pipeline' AS pipeline_name,
+				p ->> 'job' AS job_name,
+				(p ->> 'resource_id')::int AS resource_id,
+				p ->> 'resource_type' AS resource_type,
+				p ->> 'resource_source' AS resource_source
+			FROM json_array_elements($2::json) AS p
+		), passed_jobs AS (
+			SELECT passed.*, j.id AS job_id, j.pipeline_id
+			FROM passed
+			JOIN pipelines op ON op.name = passed.pipeline_name
+			JOIN jobs j ON j.pipeline_id = op.id AND j.name = passed.job_name
+			WHERE op.team_id = $1
+		), passed_versions AS (
+			SELECT b.job_id, o.build_id, o.versioned_resource_id
+			FROM build_outputs o
+			JOIN builds b ON b.id = o.build_id
+			WHERE b.status = 'succeeded'
+			AND b.job_id IN (SELECT job_id FROM passed_jobs)
+			UNION
+			SELECT b.job_id, i.build_id, i.versioned_resource_id
+			FROM build_inputs i
+			JOIN builds b ON b.id = i.build_id
+			WHERE b.status = 'succeeded'
+			AND b.job_id IN (SELECT job_id FROM passed_jobs)
+		)
+		SELECT pj.passed, pj.job_id, v.id, v.check_order, v.resource_id, pv.build_id
+		FROM passed_jobs pj
+		JOIN passed_versions pv ON pv.job_id = pj.job_id
+		JOIN versioned_resources ov ON ov.id = pv.versioned_resource_id
+		JOIN resources r ON r.id = ov.resource_id
+		JOIN versioned_resources v ON v.version = ov.version AND v.type = ov.type
+		WHERE r.pipeline_id = pj.pipeline_id
+		AND r.config ->> 'type' = pj.resource_type
+		AND (r.config -> 'source')::text = pj.resource_source
+		AND ov.enabled
+		AND v.resource_id = pj.resource_id
+		AND v.enabled
+		UNION ALL
+		SELECT pj.passed, pj.job_id, NULL, NULL, NULL, NULL
+		FROM passed_jobs pj
+	`, pdb.TeamID(), string(passedJobsJSON))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	for rows.Next() {
-		var output algorithm.ResourceVersion
-		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID)
-		if err != nil {
-			return nil, err
-		}
+	defer rows.Close()
 
-		db.ResourceVersions = append(db.ResourceVersions, output)
-	}
+	// a version may have been both an input and an output of a build, or be
+	// named by more than one constraint
+	seen := map[algorithm.BuildOutput]bool{}
 
-	rows, err = pdb.conn.Query(`
-    SELECT j.name, j.id
-    FROM jobs j
-    WHERE j.pipeline_id = $1
-  `, pdb.ID)
-	if err != nil {
-		return nil, err
-	}
+	passedJobIDs := []int{}
 
 	for rows.Next() {
-		var name string
-		var id int
-		err := rows.Scan(&name, &id)
+		var passed string
+		var jobID int
+		var versionID, checkOrder, resourceID, buildID sql.NullInt64
+
+		err := rows.Scan(&passed, &jobID, &versionID, &checkOrder, &resourceID, &buildID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		db.JobIDs[name] = id
-	}
+		db.JobIDs[passed] = jobID
 
-	rows, err = pdb.conn.Query(`
-    SELECT r.name, r.id
-    FROM resources r
-    WHERE r.pipeline_id = $1
-  `, pdb.ID)
-	if err != nil {
-		return nil, err
-	}
+		if !versionID.Valid {
+			passedJobIDs = append(passedJobIDs, jobID)
+			continue
+		}
 
-	for rows.Next() {
-		var name string
-		var id int
-		err := rows.Scan(&name, &id)
-		if err != nil {
-			return nil, err
+		output := algorithm.BuildOutput{
+			ResourceVersion: algorithm.ResourceVersion{
+				VersionID:  int(versionID.Int64),
+				ResourceID: int(resourceID.Int64),
+				CheckOrder: int(checkOrder.Int64),
+			},
+			JobID:   jobID,
+			BuildID: int(buildID.Int64),
+		}
+
+		if seen[output] {
+			continue
 		}
 
-		db.ResourceIDs[name] = id
+		seen[output] = true
+
+		db.BuildOutputs = append(db.BuildOutputs, output)
 	}
 
-	pdb.versionsDB = db
+	return db, passedJobIDs, nil
+}
+
+// notifySchedulingNeeded wakes up the pipeline's schedulers. It is only sent
+// for new resource versions, saved outputs, finished builds, and config or
+// pause changes; the inputs saved while a build runs don't warrant
+// scheduling. Schedulers debounce the notifications, so a build saving many
+// outputs only wakes them once.
+func (pdb *pipelineDB) notifySchedulingNeeded() error {
+	return pdb.bus.Notify(pipelineSchedulingChannel(pdb.ID))
+}
 
-	return db, nil
+func (pdb *pipelineDB) SchedulingNotifier() (Notifier, error) {
+	// also fires whenever the connection to the bus is re-established, as
+	// notifications may have been missed in the meantime
+	return newConditionNotifier(pdb.bus, pipelineSchedulingChannel(pdb.ID), func() (bool, error) {
+		return true, nil
+	})
 }
 
 func (pdb *pipelineDB) GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error) {
@@ -1922,6 +2433,46 @@ func (pdb *pipelineDB) DeleteNextInputMapping(jobName string) error {
 	return nil
 }
 
+func (pdb *pipelineDB) SaveInputsExplanation(explanation algorithm.Explanation, jobName string) error {
+	explanationJSON, err := json.Marshal(explanation)
+	if err != nil {
+		return err
+	}
+
+	_, err = pdb.conn.Exec(`
+		UPDATE jobs
+		SET inputs_explanation = $1
+		WHERE name = $2 AND pipeline_id = $3
+	`, string(explanationJSON), jobName, pdb.ID)
+	return err
+}
+
+func (pdb *pipelineDB) GetInputsExplanation(jobName string) (algorithm.Explanation, bool, error) {
+	var explanationJSON sql.NullString
+	err := pdb.conn.QueryRow(`
+		SELECT inputs_explanation FROM jobs WHERE name = $1 AND pipeline_id = $2
+	`, jobName, pdb.ID).Scan(&explanationJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	if !explanationJSON.Valid {
+		return nil, false, nil
+	}
+
+	var explanation algorithm.Explanation
+	err = json.Unmarshal([]byte(explanationJSON.String), &explanation)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return explanation, true, nil
+}
+
 func (pdb *pipelineDB) saveJobInputMapping(table string, inputMapping algorithm.InputMapping, jobName string) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1939,7 +2490,7 @@ func (pdb *pipelineDB) saveJobInputMapping(table string, inputMapping algorithm.
 	case "next_build_inputs":
 		err = tx.QueryRow(`
 			UPDATE jobs
-			SET inputs_determined = true
+			SET inputs_determined = true, inputs_explanation = NULL
 			WHERE name = $1 AND pipeline_id = $2
 			RETURNING id
 			`, jobName, pdb.ID).Scan(&jobID)
@@ -2160,7 +2711,12 @@ func (pdb *pipelineDB) updatePausedJob(job string, pause bool) error {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return pdb.notifySchedulingNeeded()
 }
 
 func (pdb *pipelineDB) GetJobBuilds(jobName string, page Page) ([]Build, Pagination, error) {
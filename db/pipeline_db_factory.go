@@ -11,17 +11,22 @@ type pipelineDBFactory struct {
 	bus  *notificationsBus
 
 	lockFactory LockFactory
+
+	versionsDBCaches *VersionsDBCaches
 }
 
 func NewPipelineDBFactory(
 	sqldbConnection Conn,
 	bus *notificationsBus,
 	lockFactory LockFactory,
+	versionsDBCaches *VersionsDBCaches,
 ) *pipelineDBFactory {
 	return &pipelineDBFactory{
 		conn:        sqldbConnection,
 		bus:         bus,
 		lockFactory: lockFactory,
+
+		versionsDBCaches: versionsDBCaches,
 	}
 }
 
@@ -30,9 +35,12 @@ func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 		conn: pdbf.conn,
 		bus:  pdbf.bus,
 
-		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus, pdbf.lockFactory),
+		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus, pdbf.lockFactory, pdbf.versionsDBCaches),
 		lockFactory:  pdbf.lockFactory,
 
+		versionsDBCache:  pdbf.versionsDBCaches.cacheFor(pipeline.ID),
+		versionsDBCaches: pdbf.versionsDBCaches,
+
 		SavedPipeline: pipeline,
 	}
 }
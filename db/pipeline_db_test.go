@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
@@ -21,6 +22,7 @@ var _ = Describe("PipelineDB", func() {
 	var listener *pq.Listener
 
 	var pipelineDBFactory db.PipelineDBFactory
+	var sharingPipelineDBFactory db.PipelineDBFactory
 	var sqlDB *db.SQLDB
 	var teamDBFactory db.TeamDBFactory
 
@@ -38,9 +40,11 @@ var _ = Describe("PipelineDB", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		sharingPipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 	})
 
 	AfterEach(func() {
@@ -1035,6 +1039,230 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Context("when an input has passed constraints on a job in another pipeline", func() {
+			var (
+				downstreamPipelineDB db.PipelineDB
+
+				otherJob           db.SavedJob
+				downstreamResource db.SavedResource
+			)
+
+			BeforeEach(func() {
+				downstreamPipeline, _, err := teamDB.SaveConfigToBeDeprecated("downstream-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "same-resource-by-another-name",
+							Type: "some-type",
+							Source: atc.Source{
+								"source-config": "some-value",
+							},
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "deploy",
+							Plan: atc.PlanSequence{
+								{
+									Get:    "same-resource-by-another-name",
+									Passed: []string{"other-pipeline-name/a-job"},
+								},
+							},
+						},
+					},
+				}, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				downstreamPipelineDB = pipelineDBFactory.Build(downstreamPipeline)
+
+				err = downstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "same-resource-by-another-name",
+					Type:   "some-type",
+					Source: atc.Source{"source-config": "some-value"},
+				}, []atc.Version{{"version": "1"}, {"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var found bool
+				otherJob, found, err = otherPipelineDB.GetJob("a-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				downstreamResource, found, err = downstreamPipelineDB.GetResource("same-resource-by-another-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			passedOutput := func(version string, buildID int) algorithm.BuildOutput {
+				passedVersion, found, err := downstreamPipelineDB.GetVersionedResourceByVersion(atc.Version{"version": version}, "same-resource-by-another-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				return algorithm.BuildOutput{
+					ResourceVersion: algorithm.ResourceVersion{
+						VersionID:  passedVersion.ID,
+						ResourceID: downstreamResource.ID,
+						CheckOrder: passedVersion.CheckOrder,
+					},
+					JobID:   otherJob.ID,
+					BuildID: buildID,
+				}
+			}
+
+			vr := func(version string) db.VersionedResource {
+				return db.VersionedResource{
+					Resource:   "some-resource",
+					Type:       "some-type",
+					Version:    db.Version{"version": version},
+					PipelineID: otherPipelineDB.GetPipelineID(),
+				}
+			}
+
+			It("includes the job's outputs as versions of the same resource in this pipeline", func() {
+				otherBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveOutput(otherBuild.ID(), vr("1"), true)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versions.JobIDs).To(HaveKeyWithValue("other-pipeline-name/a-job", otherJob.ID))
+				Expect(versions.BuildOutputs).To(ConsistOf(passedOutput("1", otherBuild.ID())))
+			})
+
+			It("includes the job's inputs as versions of the same resource in this pipeline", func() {
+				otherBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveInput(otherBuild.ID(), db.BuildInput{
+					Name:              "some-input",
+					VersionedResource: vr("2"),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versions.BuildOutputs).To(ConsistOf(passedOutput("2", otherBuild.ID())))
+			})
+
+			It("does not include the versions of unsuccessful builds", func() {
+				otherBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveOutput(otherBuild.ID(), vr("1"), true)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherBuild.Finish(db.StatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versions.JobIDs).To(HaveKeyWithValue("other-pipeline-name/a-job", otherJob.ID))
+				Expect(versions.BuildOutputs).To(BeEmpty())
+			})
+
+			It("caches the versions of the other pipeline until a build of the job succeeds", func() {
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(downstreamPipelineDB.LoadVersionsDB()).To(BeIdenticalTo(versions))
+
+				otherBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveOutput(otherBuild.ID(), vr("1"), true)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(downstreamPipelineDB.LoadVersionsDB()).To(BeIdenticalTo(versions))
+
+				err = otherBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err = downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versions.BuildOutputs).To(ConsistOf(passedOutput("1", otherBuild.ID())))
+			})
+
+			It("notifies the pipeline's schedulers when a build of the job succeeds", func() {
+				notifier, err := downstreamPipelineDB.SchedulingNotifier()
+				Expect(err).NotTo(HaveOccurred())
+
+				defer notifier.Close()
+
+				// drain the initial notification
+				Eventually(notifier.Notify()).Should(Receive())
+
+				otherBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+
+			It("does not notify the pipeline's schedulers when a build of another job succeeds", func() {
+				notifier, err := downstreamPipelineDB.SchedulingNotifier()
+				Expect(err).NotTo(HaveOccurred())
+
+				defer notifier.Close()
+
+				Eventually(notifier.Notify()).Should(Receive())
+
+				otherBuild, err := otherPipelineDB.CreateJobBuild("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				Consistently(notifier.Notify()).ShouldNot(Receive())
+			})
+
+			It("refuses to rename the other pipeline", func() {
+				err := otherPipelineDB.UpdateName("renamed-pipeline")
+				Expect(err).To(Equal(db.PipelineReferencedError{
+					Pipeline:     "other-pipeline-name",
+					ReferencedBy: []string{"downstream-pipeline"},
+				}))
+
+				_, found, err := teamDB.GetPipelineByName("other-pipeline-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("refuses to destroy the other pipeline", func() {
+				err := otherPipelineDB.Destroy()
+				Expect(err).To(Equal(db.PipelineReferencedError{
+					Pipeline:     "other-pipeline-name",
+					ReferencedBy: []string{"downstream-pipeline"},
+				}))
+
+				_, found, err := teamDB.GetPipelineByName("other-pipeline-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("allows renaming the other pipeline once the constraint is removed", func() {
+				config := downstreamPipelineDB.Config()
+				config.Jobs[0].Plan[0].Passed = nil
+
+				_, _, err := teamDB.SaveConfigToBeDeprecated("downstream-pipeline", config, downstreamPipelineDB.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherPipelineDB.UpdateName("renamed-pipeline")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
 		Describe("GetVersionedResourceByVersion", func() {
 			var savedVersion2 db.SavedVersionedResource
 			BeforeEach(func() {
@@ -1295,6 +1523,112 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("SchedulingNotifier", func() {
+			var notifier db.Notifier
+
+			BeforeEach(func() {
+				var err error
+				notifier, err = pipelineDB.SchedulingNotifier()
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+
+			AfterEach(func() {
+				Expect(notifier.Close()).To(Succeed())
+			})
+
+			It("notifies when new versions are saved", func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   resourceName,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+
+			It("notifies when a resource is unpaused", func() {
+				err := pipelineDB.UnpauseResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+
+			It("does not notify when versions that already exist are saved", func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   resourceName,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+
+				err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   resourceName,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Consistently(notifier.Notify()).ShouldNot(Receive())
+			})
+
+			Context("when a running build saves its inputs and outputs", func() {
+				var build db.Build
+				var vr db.VersionedResource
+
+				BeforeEach(func() {
+					var err error
+					build, err = pipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					vr = db.VersionedResource{
+						Resource:   resourceName,
+						Type:       "some-type",
+						Version:    db.Version{"version": "1"},
+						PipelineID: pipelineDB.GetPipelineID(),
+					}
+				})
+
+				It("does not notify for its inputs", func() {
+					_, err := pipelineDB.SaveInput(build.ID(), db.BuildInput{
+						Name:              "some-input",
+						VersionedResource: vr,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Consistently(notifier.Notify()).ShouldNot(Receive())
+				})
+
+				It("notifies for its outputs", func() {
+					_, err := pipelineDB.SaveOutput(build.ID(), vr, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(notifier.Notify()).Should(Receive())
+				})
+			})
+
+			It("notifies when a build finishes", func() {
+				build, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+
+			It("does not notify for changes to other pipelines", func() {
+				err := otherPipelineDB.UnpauseResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				Consistently(notifier.Notify()).ShouldNot(Receive())
+			})
+		})
+
 		Describe("enabling and disabling versioned resources", func() {
 			It("returns an error if the resource or version is bogus", func() {
 				err := pipelineDB.EnableVersionedResource(42)
@@ -1500,6 +1834,15 @@ var _ = Describe("PipelineDB", func() {
 					Expect(versionsDB == cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be the same object")
 				})
 
+				It("shares the cached VersionsDB with pipeline DBs built by other factories", func() {
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+
+					cachedVersionsDB, err := sharingPipelineDBFactory.Build(savedPipeline).LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB == cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be the same object")
+				})
+
 				It("will not cache VersionsDB if a change occured", func() {
 					versionsDB, err := pipelineDB.LoadVersionsDB()
 					Expect(err).NotTo(HaveOccurred())
@@ -1584,6 +1927,100 @@ var _ = Describe("PipelineDB", func() {
 					Expect(versionsDB != cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be different objects")
 				})
 
+				It("adds the new versions without modifying the previously loaded VersionsDB", func() {
+					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB.ResourceVersions).To(HaveLen(1))
+
+					err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": "2"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					savedResource, _, err := pipelineDB.GetResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+
+					savedVR, found, err := pipelineDB.GetLatestVersionedResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					updatedVersionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updatedVersionsDB.ResourceVersions).To(ContainElement(algorithm.ResourceVersion{
+						VersionID:  savedVR.ID,
+						ResourceID: savedResource.ID,
+						CheckOrder: savedVR.CheckOrder,
+					}))
+
+					Expect(versionsDB.ResourceVersions).To(HaveLen(1))
+				})
+
+				It("omits versions disabled after loading", func() {
+					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					savedVR, found, err := pipelineDB.GetLatestVersionedResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB.ResourceVersions).To(HaveLen(1))
+
+					err = pipelineDB.DisableVersionedResource(savedVR.ID)
+					Expect(err).NotTo(HaveOccurred())
+
+					updatedVersionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updatedVersionsDB.ResourceVersions).To(BeEmpty())
+				})
+
+				It("includes changes committed after loading by transactions that began before", func() {
+					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					savedVR, found, err := pipelineDB.GetLatestVersionedResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					tx, err := dbConn.Begin()
+					Expect(err).NotTo(HaveOccurred())
+
+					defer tx.Rollback()
+
+					_, err = tx.Exec(`
+						UPDATE versioned_resources SET enabled = false WHERE id = $1
+					`, savedVR.ID)
+					Expect(err).NotTo(HaveOccurred())
+
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB.ResourceVersions).To(HaveLen(1))
+
+					Expect(tx.Commit()).To(Succeed())
+
+					updatedVersionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updatedVersionsDB.ResourceVersions).To(BeEmpty())
+				})
+
 				Context("when the versioned resources are added for a different pipeline", func() {
 					It("does not invalidate the cache for the original pipeline", func() {
 						err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
@@ -1708,6 +2145,379 @@ var _ = Describe("PipelineDB", func() {
 					Expect(returnedResource.CheckError).To(BeNil())
 				})
 			})
+
+			Context("when checks keep failing", func() {
+				It("counts the consecutive failures until a check succeeds", func() {
+					err := pipelineDB.SetResourceCheckError(resource, errors.New("on fire"))
+					Expect(err).NotTo(HaveOccurred())
+
+					err = pipelineDB.SetResourceCheckError(resource, errors.New("still on fire"))
+					Expect(err).NotTo(HaveOccurred())
+
+					returnedResource, _, err := pipelineDB.GetResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedResource.ConsecutiveCheckFailures).To(Equal(2))
+
+					err = pipelineDB.SetResourceCheckError(resource, nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					returnedResource, _, err = pipelineDB.GetResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedResource.ConsecutiveCheckFailures).To(BeZero())
+				})
+			})
+
+			Context("when the next check is scheduled", func() {
+				It("returns when it will be", func() {
+					nextCheckAt := time.Now().Add(time.Minute).Truncate(time.Second)
+
+					err := pipelineDB.SetResourceNextCheckAt(resource, nextCheckAt)
+					Expect(err).NotTo(HaveOccurred())
+
+					returnedResource, _, err := pipelineDB.GetResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedResource.NextCheckAt.Unix()).To(Equal(nextCheckAt.Unix()))
+				})
+			})
+		})
+
+		Describe("recording resource checks", func() {
+			var resource db.SavedResource
+			var checkStart time.Time
+
+			BeforeEach(func() {
+				var err error
+				resource, _, err = pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				checkStart = time.Now().Add(-time.Hour).Truncate(time.Second)
+
+				for i := 0; i < 3; i++ {
+					check := db.ResourceCheck{
+						StartTime:   checkStart.Add(time.Duration(i) * time.Minute),
+						EndTime:     checkStart.Add(time.Duration(i)*time.Minute + 5*time.Second),
+						NewVersions: i,
+						WorkerName:  "some-worker",
+					}
+
+					if i == 1 {
+						check.CheckError = errors.New("on fire")
+						check.NewVersions = 0
+					}
+
+					err = pipelineDB.SaveResourceCheck(resource, check)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("returns them newest first", func() {
+				checks, _, found, err := pipelineDB.GetResourceChecks("some-resource", db.Page{Limit: 10})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(checks).To(HaveLen(3))
+
+				Expect(checks[0].StartTime.Unix()).To(Equal(checkStart.Add(2 * time.Minute).Unix()))
+				Expect(checks[0].EndTime.Unix()).To(Equal(checkStart.Add(2*time.Minute + 5*time.Second).Unix()))
+				Expect(checks[0].NewVersions).To(Equal(2))
+				Expect(checks[0].WorkerName).To(Equal("some-worker"))
+				Expect(checks[0].Succeeded()).To(BeTrue())
+
+				Expect(checks[1].CheckError).To(Equal(errors.New("on fire")))
+				Expect(checks[1].Succeeded()).To(BeFalse())
+
+				Expect(checks[2].NewVersions).To(BeZero())
+			})
+
+			It("pages through them", func() {
+				checks, pagination, found, err := pipelineDB.GetResourceChecks("some-resource", db.Page{Limit: 2})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(checks).To(HaveLen(2))
+				Expect(pagination.Previous).To(BeNil())
+				Expect(pagination.Next).To(Equal(&db.Page{Since: checks[1].ID, Limit: 2}))
+
+				olderChecks, pagination, found, err := pipelineDB.GetResourceChecks("some-resource", *pagination.Next)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(olderChecks).To(HaveLen(1))
+				Expect(pagination.Previous).To(Equal(&db.Page{Until: olderChecks[0].ID, Limit: 2}))
+				Expect(pagination.Next).To(BeNil())
+
+				newerChecks, _, _, err := pipelineDB.GetResourceChecks("some-resource", *pagination.Previous)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newerChecks).To(Equal(checks))
+			})
+
+			It("removes checks that ended before the retention period", func() {
+				err := sqlDB.ReapExpiredResourceChecks(time.Hour - time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				checks, _, _, err := pipelineDB.GetResourceChecks("some-resource", db.Page{Limit: 10})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(checks).To(HaveLen(2))
+			})
+
+			Context("when the resource does not exist", func() {
+				It("returns not found", func() {
+					_, _, found, err := pipelineDB.GetResourceChecks("bogus-resource", db.Page{Limit: 10})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("SaveResourceVersions for a resource shared with other pipelines", func() {
+		var resourceConfig atc.ResourceConfig
+
+		BeforeEach(func() {
+			logger := lagertest.NewTestLogger("test")
+
+			savedResource, _, err := pipelineDB.GetResource("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, savedResource, "some-shared-hash", time.Minute, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+			lock.Release()
+
+			otherResource, _, err := otherPipelineDB.GetResource("some-other-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			lock, acquired, err = otherPipelineDB.AcquireResourceCheckingLock(logger, otherResource, "some-shared-hash", time.Minute, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+			lock.Release()
+
+			var found bool
+			resourceConfig, found = pipelineConfig.Resources.Lookup("some-resource")
+			Expect(found).To(BeTrue())
+		})
+
+		It("saves the versions for every resource with the same hash", func() {
+			err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			savedVR, found, err := otherPipelineDB.GetLatestVersionedResource("some-other-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedVR.Version).To(Equal(db.Version{"version": "1"}))
+		})
+
+		It("records the check and its error for every resource with the same hash", func() {
+			savedResource, _, err := pipelineDB.GetResource("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = pipelineDB.SaveResourceCheck(savedResource, db.ResourceCheck{
+				StartTime:  time.Now(),
+				EndTime:    time.Now(),
+				CheckError: errors.New("on fire"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherResource, _, err := otherPipelineDB.GetResource("some-other-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherResource.CheckError).To(Equal(errors.New("on fire")))
+			Expect(otherResource.ConsecutiveCheckFailures).To(Equal(1))
+
+			checks, _, found, err := otherPipelineDB.GetResourceChecks("some-other-resource", db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(checks).To(HaveLen(1))
+			Expect(checks[0].CheckError).To(Equal(errors.New("on fire")))
+
+			err = pipelineDB.SaveResourceCheck(savedResource, db.ResourceCheck{
+				StartTime: time.Now(),
+				EndTime:   time.Now(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherResource, _, err = otherPipelineDB.GetResource("some-other-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherResource.CheckError).To(BeNil())
+			Expect(otherResource.ConsecutiveCheckFailures).To(BeZero())
+		})
+
+		It("shares the check interval between the resources", func() {
+			otherResource, _, err := otherPipelineDB.GetResource("some-other-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, acquired, err := otherPipelineDB.AcquireResourceCheckingLock(lagertest.NewTestLogger("test"), otherResource, "some-shared-hash", time.Minute, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeFalse())
+		})
+
+		It("only lets one of the resources check at a time", func() {
+			logger := lagertest.NewTestLogger("test")
+
+			savedResource, _, err := pipelineDB.GetResource("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			otherResource, _, err := otherPipelineDB.GetResource("some-other-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			lock, acquired, err := pipelineDB.AcquireResourceCheckingLock(logger, savedResource, "some-shared-hash", time.Minute, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired, err = otherPipelineDB.AcquireResourceCheckingLock(logger, otherResource, "some-shared-hash", time.Minute, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeFalse())
+
+			err = lock.Release()
+			Expect(err).NotTo(HaveOccurred())
+
+			lock, acquired, err = otherPipelineDB.AcquireResourceCheckingLock(logger, otherResource, "some-shared-hash", time.Minute, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			err = lock.Release()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("stores a digest of the hash rather than the hash itself", func() {
+			var resourceHash string
+			err := dbConn.QueryRow(`
+				SELECT resource_hash
+				FROM resources
+				WHERE name = 'some-resource'
+					AND pipeline_id = $1
+			`, pipelineDB.GetPipelineID()).Scan(&resourceHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resourceHash).To(HaveLen(64))
+			Expect(resourceHash).NotTo(ContainSubstring("some-shared-hash"))
+
+			var checkHashes int
+			err = dbConn.QueryRow(`
+				SELECT COUNT(*)
+				FROM resource_config_checks
+				WHERE resource_hash = $1
+			`, resourceHash).Scan(&checkHashes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checkHashes).To(Equal(1))
+		})
+
+		Context("when a resource with the same hash is in another team", func() {
+			var teamPipelineDB db.PipelineDB
+
+			BeforeEach(func() {
+				otherTeam, err := sqlDB.CreateTeam(db.Team{Name: "some-other-team"})
+				Expect(err).NotTo(HaveOccurred())
+
+				savedPipeline, _, err := teamDBFactory.GetTeamDB(otherTeam.Name).SaveConfigToBeDeprecated("other-team-pipeline", otherPipelineConfig, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				teamPipelineDB = pipelineDBFactory.Build(savedPipeline)
+			})
+
+			It("does not share the check interval with it", func() {
+				teamResource, _, err := teamPipelineDB.GetResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				lock, acquired, err := teamPipelineDB.AcquireResourceCheckingLock(lagertest.NewTestLogger("test"), teamResource, "some-shared-hash", time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+				lock.Release()
+			})
+
+			It("does not save the versions for it", func() {
+				teamResource, _, err := teamPipelineDB.GetResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				lock, _, err := teamPipelineDB.AcquireResourceCheckingLock(lagertest.NewTestLogger("test"), teamResource, "some-shared-hash", time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+				lock.Release()
+
+				err = pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := teamPipelineDB.GetLatestVersionedResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a resource with the same hash has a type defined by its pipeline", func() {
+			var customTypePipelineDB db.PipelineDB
+
+			BeforeEach(func() {
+				customTypeConfig := otherPipelineConfig
+				customTypeConfig.ResourceTypes = atc.ResourceTypes{
+					{
+						Name: "some-type",
+						Type: "docker-image",
+						Source: atc.Source{
+							"repository": "some/custom-type",
+						},
+					},
+				}
+
+				savedPipeline, _, err := teamDB.SaveConfigToBeDeprecated("custom-type-pipeline", customTypeConfig, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				customTypePipelineDB = pipelineDBFactory.Build(savedPipeline)
+
+				customResource, _, err := customTypePipelineDB.GetResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				lock, acquired, err := customTypePipelineDB.AcquireResourceCheckingLock(lagertest.NewTestLogger("test"), customResource, "some-shared-hash", time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+				lock.Release()
+			})
+
+			It("does not save the versions for it", func() {
+				err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := customTypePipelineDB.GetLatestVersionedResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("does not share its versions with others", func() {
+				customConfig, found := customTypePipelineDB.Config().Resources.Lookup("some-other-resource")
+				Expect(found).To(BeTrue())
+
+				err := customTypePipelineDB.SaveResourceVersions(customConfig, []atc.Version{{"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err = otherPipelineDB.GetLatestVersionedResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the other pipeline is paused", func() {
+			BeforeEach(func() {
+				err := otherPipelineDB.Pause()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not save the versions for it", func() {
+				err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := otherPipelineDB.GetLatestVersionedResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the other resource is paused", func() {
+			BeforeEach(func() {
+				err := otherPipelineDB.PauseResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not save the versions for it", func() {
+				err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := otherPipelineDB.GetLatestVersionedResource("some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
 		})
 	})
 
@@ -17,6 +17,27 @@ type SavedResource struct {
 	PipelineName string
 	Config       atc.ResourceConfig
 	Resource
+
+	ConsecutiveCheckFailures int
+	NextCheckAt              time.Time
+}
+
+type ResourceCheck struct {
+	StartTime   time.Time
+	EndTime     time.Time
+	CheckError  error
+	NewVersions int
+	WorkerName  string
+}
+
+func (c ResourceCheck) Succeeded() bool {
+	return c.CheckError == nil
+}
+
+type SavedResourceCheck struct {
+	ID int
+
+	ResourceCheck
 }
 
 type SavedResourceType struct {
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Session struct {
+	ID        string
+	TeamName  string
+	Username  string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// SaveSession records a session issued by the team, or extends it when one
+// of its tokens is renewed.
+func (db *teamDB) SaveSession(sessionID string, username string, issuedAt time.Time, expiresAt time.Time) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE sessions s
+		SET expires_at = $3
+		FROM teams t
+		WHERE t.id = s.team_id
+		AND LOWER(t.name) = LOWER($1)
+		AND s.session_id = $2
+	`, db.teamName, sessionID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		var usernameValue sql.NullString
+		if username != "" {
+			usernameValue = sql.NullString{String: username, Valid: true}
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO sessions (team_id, session_id, username, issued_at, expires_at)
+			SELECT id, $2, $3, $4, $5
+			FROM teams
+			WHERE LOWER(name) = LOWER($1)
+		`, db.teamName, sessionID, usernameValue, issuedAt, expiresAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetSessions returns the team's unexpired sessions that have not been
+// revoked, oldest first. Sessions are listed under the team they were issued
+// by, even if they also grant access to other teams.
+func (db *teamDB) GetSessions() ([]Session, error) {
+	rows, err := db.conn.Query(`
+		SELECT s.session_id, t.name, s.username, s.issued_at, s.expires_at
+		FROM sessions s
+		JOIN teams t ON t.id = s.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+		AND s.expires_at > now()
+		ORDER BY s.issued_at ASC, s.session_id ASC
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	sessions := []Session{}
+	for rows.Next() {
+		var session Session
+		var username sql.NullString
+
+		err := rows.Scan(&session.ID, &session.TeamName, &username, &session.IssuedAt, &session.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		session.Username = username.String
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// ReapExpiredSessions forgets sessions whose tokens have all expired.
+func (db *SQLDB) ReapExpiredSessions() error {
+	_, err := db.conn.Exec(`
+		DELETE FROM sessions
+		WHERE expires_at < now()
+	`)
+	return err
+}
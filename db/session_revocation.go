@@ -0,0 +1,233 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+)
+
+const sessionRevocationsChannel = "session_revocations"
+
+// RevokeSession revokes the team's session with the given ID.
+func (db *teamDB) RevokeSession(sessionID string) error {
+	return db.revokeSessions(sql.NullString{String: sessionID, Valid: true}, sql.NullString{})
+}
+
+// RevokeAllSessions revokes every session issued to the team so far, along
+// with its personal API tokens. Service API tokens and client certificates
+// are not sessions, and are left alone.
+func (db *teamDB) RevokeAllSessions() error {
+	return db.revokeSessions(sql.NullString{}, sql.NullString{})
+}
+
+// RevokeUserSessions revokes every session issued to the team's user with the
+// given name so far, along with the user's personal API tokens.
+func (db *teamDB) RevokeUserSessions(username string) error {
+	return db.revokeSessions(sql.NullString{}, sql.NullString{String: username, Valid: true})
+}
+
+func (db *teamDB) revokeSessions(sessionID sql.NullString, username sql.NullString) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO session_revocations (team_id, session_id, username)
+		SELECT id, $2, $3
+		FROM teams
+		WHERE LOWER(name) = LOWER($1)
+	`, db.teamName, sessionID, username)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM sessions s
+		USING teams t
+		WHERE t.id = s.team_id
+		AND LOWER(t.name) = LOWER($1)
+		AND ($2::text IS NULL OR s.session_id = $2)
+		AND ($3::text IS NULL OR s.username = $3)
+	`, db.teamName, sessionID, username)
+	if err != nil {
+		return err
+	}
+
+	if !sessionID.Valid {
+		_, err = tx.Exec(`
+			DELETE FROM api_tokens a
+			USING teams t
+			WHERE t.id = a.team_id
+			AND LOWER(t.name) = LOWER($1)
+			AND a.kind = 'personal'
+			AND ($2::text IS NULL OR a.owner = $2)
+		`, db.teamName, username)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return db.bus.Notify(sessionRevocationsChannel)
+}
+
+// IsSessionRevoked reports whether the team's session with the given ID,
+// username and issue time has been revoked. The username may be empty for
+// sessions not issued to a user. Revocations are cached and reloaded whenever
+// any ATC records a new one.
+func (db *SQLDB) IsSessionRevoked(teamName string, sessionID string, username string, issuedAt time.Time) (bool, error) {
+	return db.sessionRevocations.isRevoked(teamName, sessionID, username, issuedAt)
+}
+
+// ReapExpiredSessionRevocations removes revocations older than the retention
+// period, by which time every session they could match has expired anyway.
+func (db *SQLDB) ReapExpiredSessionRevocations(retention time.Duration) error {
+	_, err := db.conn.Exec(`
+		DELETE FROM session_revocations
+		WHERE revoked_at < now() - ($1 || ' SECONDS')::INTERVAL
+	`, retention.Seconds())
+	return err
+}
+
+type sessionRevocationCache struct {
+	conn Conn
+	bus  NotificationsBus
+
+	notified chan bool
+	loaded   bool
+
+	// revoked session IDs and revoke-all times, keyed by lowercased team name,
+	// and the revoke-all times of each of the team's users
+	sessions    map[string]map[string]bool
+	cutoffs     map[string]time.Time
+	userCutoffs map[string]map[string]time.Time
+
+	lock sync.Mutex
+}
+
+func newSessionRevocationCache(conn Conn, bus NotificationsBus) *sessionRevocationCache {
+	return &sessionRevocationCache{
+		conn: conn,
+		bus:  bus,
+	}
+}
+
+func (cache *sessionRevocationCache) isRevoked(teamName string, sessionID string, username string, issuedAt time.Time) (bool, error) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.notified == nil {
+		notified, err := cache.bus.Listen(sessionRevocationsChannel)
+		if err != nil {
+			return false, err
+		}
+
+		cache.notified = notified
+	}
+
+	select {
+	case <-cache.notified:
+		// either a revocation was recorded or the connection to the bus was
+		// lost and some may have been missed; reload in both cases
+		cache.loaded = false
+	default:
+	}
+
+	if !cache.loaded {
+		err := cache.reload()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	team := strings.ToLower(teamName)
+
+	if sessionID != "" && cache.sessions[team][sessionID] {
+		return true, nil
+	}
+
+	// sessions issued in the same second as a revoke-all are revoked too, as
+	// issue times only have second precision
+	cutoff, found := cache.cutoffs[team]
+	if found && !issuedAt.After(cutoff) {
+		return true, nil
+	}
+
+	if username != "" {
+		cutoff, found := cache.userCutoffs[team][username]
+		if found && !issuedAt.After(cutoff) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (cache *sessionRevocationCache) reload() error {
+	rows, err := cache.conn.Query(`
+		SELECT t.name, s.session_id, s.username, s.revoked_at
+		FROM session_revocations s
+		JOIN teams t ON t.id = s.team_id
+	`)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	sessions := map[string]map[string]bool{}
+	cutoffs := map[string]time.Time{}
+	userCutoffs := map[string]map[string]time.Time{}
+
+	for rows.Next() {
+		var teamName string
+		var sessionID sql.NullString
+		var username sql.NullString
+		var revokedAt time.Time
+
+		err := rows.Scan(&teamName, &sessionID, &username, &revokedAt)
+		if err != nil {
+			return err
+		}
+
+		team := strings.ToLower(teamName)
+
+		if sessionID.Valid {
+			if sessions[team] == nil {
+				sessions[team] = map[string]bool{}
+			}
+
+			sessions[team][sessionID.String] = true
+		} else if username.Valid {
+			if userCutoffs[team] == nil {
+				userCutoffs[team] = map[string]time.Time{}
+			}
+
+			if revokedAt.After(userCutoffs[team][username.String]) {
+				userCutoffs[team][username.String] = revokedAt
+			}
+		} else if revokedAt.After(cutoffs[team]) {
+			cutoffs[team] = revokedAt
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	cache.sessions = sessions
+	cache.cutoffs = cutoffs
+	cache.userCutoffs = userCutoffs
+	cache.loaded = true
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Session revocations", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		database *db.SQLDB
+		teamDB   db.TeamDB
+
+		otherDBConn   db.Conn
+		otherListener *pq.Listener
+		otherDatabase *db.SQLDB
+
+		issuedAt time.Time
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+
+		_, err := database.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = database.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+
+		// another ATC, with its own connection and cache
+		otherDBConn = db.Wrap(postgresRunner.Open())
+		otherListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(otherListener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		otherBus := db.NewNotificationsBus(otherListener, otherDBConn)
+
+		otherDatabase = db.NewSQL(otherDBConn, otherBus, lockFactory, db.NewVersionsDBCaches(otherDBConn))
+
+		issuedAt = time.Now().Add(-time.Minute)
+	})
+
+	AfterEach(func() {
+		Expect(dbConn.Close()).To(Succeed())
+		Expect(listener.Close()).To(Succeed())
+		Expect(otherDBConn.Close()).To(Succeed())
+		Expect(otherListener.Close()).To(Succeed())
+	})
+
+	It("does not revoke sessions by default", func() {
+		revoked, err := database.IsSessionRevoked("some-team", "some-session", "", issuedAt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revoked).To(BeFalse())
+	})
+
+	Describe("RevokeSession", func() {
+		BeforeEach(func() {
+			err := teamDB.RevokeSession("some-session")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes the team's session", func() {
+			revoked, err := database.IsSessionRevoked("some-team", "some-session", "", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("matches the team name case-insensitively", func() {
+			revoked, err := database.IsSessionRevoked("Some-Team", "some-session", "", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("does not revoke the team's other sessions", func() {
+			revoked, err := database.IsSessionRevoked("some-team", "other-session", "", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("does not revoke another team's session with the same ID", func() {
+			revoked, err := database.IsSessionRevoked("other-team", "some-session", "", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+	})
+
+	Describe("RevokeAllSessions", func() {
+		BeforeEach(func() {
+			err := teamDB.RevokeAllSessions()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes sessions issued before now", func() {
+			revoked, err := database.IsSessionRevoked("some-team", "some-session", "", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("revokes sessions without an issue time", func() {
+			revoked, err := database.IsSessionRevoked("some-team", "", "", time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("does not revoke sessions issued afterwards", func() {
+			revoked, err := database.IsSessionRevoked("some-team", "some-session", "", time.Now().Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("does not revoke other teams' sessions", func() {
+			revoked, err := database.IsSessionRevoked("other-team", "some-session", "", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+	})
+
+	Describe("RevokeUserSessions", func() {
+		BeforeEach(func() {
+			err := teamDB.RevokeUserSessions("some-user")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes the user's sessions issued before now", func() {
+			revoked, err := database.IsSessionRevoked("some-team", "some-session", "some-user", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("does not revoke the user's sessions issued afterwards", func() {
+			revoked, err := database.IsSessionRevoked("some-team", "some-session", "some-user", time.Now().Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("does not revoke other users' sessions", func() {
+			revoked, err := database.IsSessionRevoked("some-team", "some-session", "other-user", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("does not revoke sessions not issued to a user", func() {
+			revoked, err := database.IsSessionRevoked("some-team", "some-session", "", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("does not revoke the sessions of another team's user with the same name", func() {
+			revoked, err := database.IsSessionRevoked("other-team", "some-session", "some-user", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+	})
+
+	Context("when another ATC has already cached the revocations", func() {
+		BeforeEach(func() {
+			revoked, err := otherDatabase.IsSessionRevoked("some-team", "some-session", "", issuedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("picks up new revocations", func() {
+			err := teamDB.RevokeSession("some-session")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() bool {
+				revoked, err := otherDatabase.IsSessionRevoked("some-team", "some-session", "", issuedAt)
+				Expect(err).NotTo(HaveOccurred())
+				return revoked
+			}, 5*time.Second).Should(BeTrue())
+		})
+	})
+
+	Describe("ReapExpiredSessionRevocations", func() {
+		BeforeEach(func() {
+			err := teamDB.RevokeSession("old-session")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE session_revocations SET revoked_at = now() - interval '2 days'`)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = teamDB.RevokeSession("new-session")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes revocations older than the retention period", func() {
+			err := database.ReapExpiredSessionRevocations(24 * time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			var sessionIDs []string
+			rows, err := dbConn.Query(`SELECT session_id FROM session_revocations`)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer rows.Close()
+
+			for rows.Next() {
+				var sessionID string
+				Expect(rows.Scan(&sessionID)).To(Succeed())
+				sessionIDs = append(sessionIDs, sessionID)
+			}
+
+			Expect(sessionIDs).To(ConsistOf("new-session"))
+		})
+	})
+})
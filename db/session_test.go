@@ -0,0 +1,167 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sessions", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		database    *db.SQLDB
+		teamDB      db.TeamDB
+		otherTeamDB db.TeamDB
+
+		issuedAt  time.Time
+		expiresAt time.Time
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+
+		_, err := database.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = database.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+
+		issuedAt = time.Now().Add(-time.Minute).Truncate(time.Second)
+		expiresAt = time.Now().Add(time.Hour).Truncate(time.Second)
+
+		err = teamDB.SaveSession("some-session", "some-user", issuedAt, expiresAt)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = teamDB.SaveSession("other-session", "", issuedAt.Add(time.Second), expiresAt)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = otherTeamDB.SaveSession("another-team-session", "", issuedAt, expiresAt)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(dbConn.Close()).To(Succeed())
+		Expect(listener.Close()).To(Succeed())
+	})
+
+	sessionIDs := func() []string {
+		sessions, err := teamDB.GetSessions()
+		Expect(err).NotTo(HaveOccurred())
+
+		ids := []string{}
+		for _, session := range sessions {
+			ids = append(ids, session.ID)
+		}
+
+		return ids
+	}
+
+	Describe("GetSessions", func() {
+		It("returns the team's sessions, oldest first", func() {
+			sessions, err := teamDB.GetSessions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessions).To(HaveLen(2))
+
+			Expect(sessions[0].ID).To(Equal("some-session"))
+			Expect(sessions[0].TeamName).To(Equal("some-team"))
+			Expect(sessions[0].Username).To(Equal("some-user"))
+			Expect(sessions[0].IssuedAt.Unix()).To(Equal(issuedAt.Unix()))
+			Expect(sessions[0].ExpiresAt.Unix()).To(Equal(expiresAt.Unix()))
+
+			Expect(sessions[1].ID).To(Equal("other-session"))
+			Expect(sessions[1].Username).To(BeEmpty())
+		})
+
+		It("does not return expired sessions", func() {
+			err := teamDB.SaveSession("expired-session", "", issuedAt, time.Now().Add(-time.Second))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sessionIDs()).To(Equal([]string{"some-session", "other-session"}))
+		})
+	})
+
+	Describe("SaveSession", func() {
+		It("extends renewed sessions", func() {
+			renewedExpiresAt := expiresAt.Add(time.Hour)
+
+			err := teamDB.SaveSession("some-session", "some-user", time.Now(), renewedExpiresAt)
+			Expect(err).NotTo(HaveOccurred())
+
+			sessions, err := teamDB.GetSessions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessions).To(HaveLen(2))
+			Expect(sessions[0].ID).To(Equal("some-session"))
+			Expect(sessions[0].IssuedAt.Unix()).To(Equal(issuedAt.Unix()))
+			Expect(sessions[0].ExpiresAt.Unix()).To(Equal(renewedExpiresAt.Unix()))
+		})
+	})
+
+	Describe("revoking sessions", func() {
+		It("forgets a revoked session", func() {
+			err := teamDB.RevokeSession("some-session")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sessionIDs()).To(Equal([]string{"other-session"}))
+		})
+
+		It("forgets the sessions of a user whose sessions are revoked", func() {
+			err := teamDB.RevokeUserSessions("some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sessionIDs()).To(Equal([]string{"other-session"}))
+		})
+
+		It("forgets every session when all are revoked", func() {
+			err := teamDB.RevokeAllSessions()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sessionIDs()).To(BeEmpty())
+
+			sessions, err := otherTeamDB.GetSessions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessions).To(HaveLen(1))
+		})
+	})
+
+	Describe("ReapExpiredSessions", func() {
+		It("removes expired sessions", func() {
+			err := teamDB.SaveSession("expired-session", "", issuedAt, time.Now().Add(-time.Second))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.ReapExpiredSessions()
+			Expect(err).NotTo(HaveOccurred())
+
+			var count int
+			err = dbConn.QueryRow(`SELECT COUNT(*) FROM sessions WHERE session_id = 'expired-session'`).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+
+			Expect(sessionIDs()).To(Equal([]string{"some-session", "other-session"}))
+		})
+	})
+})
@@ -11,18 +11,23 @@ type SQLDB struct {
 	bus         *notificationsBus
 
 	buildFactory *buildFactory
+
+	sessionRevocations *sessionRevocationCache
 }
 
 func NewSQL(
 	sqldbConnection Conn,
 	bus *notificationsBus,
 	lockFactory LockFactory,
+	versionsDBCaches *VersionsDBCaches,
 ) *SQLDB {
 	return &SQLDB{
 		conn:         sqldbConnection,
 		lockFactory:  lockFactory,
 		bus:          bus,
-		buildFactory: newBuildFactory(sqldbConnection, bus, lockFactory),
+		buildFactory: newBuildFactory(sqldbConnection, bus, lockFactory, versionsDBCaches),
+
+		sessionRevocations: newSessionRevocationCache(sqldbConnection, bus),
 	}
 }
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
 )
 
 const containerColumns = "worker_name, resource_id, check_type, check_source, build_id, plan_id, stage, handle, b.name as build_name, r.name as resource_name, p.id as pipeline_id, p.name as pipeline_name, j.name as job_name, step_name, type, working_directory, env_variables, attempts, process_user, ttl, EXTRACT(epoch FROM expires_at - NOW()), c.id, resource_type_version, c.team_id"
@@ -55,6 +56,51 @@ func (db *SQLDB) FindJobContainersFromUnsuccessfulBuilds() ([]SavedContainer, er
 	return scanRows(rows)
 }
 
+// ActiveTaskCounts counts the containers of running task steps on each
+// worker. A task step's container outlives the step, so steps that have
+// saved a finish-task or error event are skipped.
+func (db *SQLDB) ActiveTaskCounts() (map[string]int, error) {
+	rows, err := db.conn.Query(`
+		SELECT c.worker_name, COUNT(*)
+		FROM containers c
+		JOIN builds b ON b.id = c.build_id
+		WHERE c.type = $1
+		AND b.status = $2
+		AND NOT EXISTS (
+			SELECT 1
+			FROM build_events e
+			WHERE e.build_id = c.build_id
+			AND e.type IN ($3, $4)
+			AND e.payload::json -> 'origin' ->> 'id' = c.plan_id
+		)
+		GROUP BY c.worker_name
+	`,
+		string(ContainerTypeTask),
+		string(StatusStarted),
+		string(event.EventTypeFinishTask),
+		string(event.EventTypeError),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var workerName string
+		var count int
+		err := rows.Scan(&workerName, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		counts[workerName] = count
+	}
+
+	return counts, nil
+}
+
 func (db *SQLDB) FindContainerByIdentifier(id ContainerIdentifier) (SavedContainer, bool, error) {
 	conditions := []string{"(expires_at IS NULL OR expires_at > NOW())"}
 	params := []interface{}{}
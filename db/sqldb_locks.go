@@ -37,3 +37,96 @@ func (db *SQLDB) AcquireVolumeCreatingLock(logger lager.Logger, volumeID int) (L
 
 	return lock, true, nil
 }
+
+// AcquireResourceCheckSlot takes out one of the slots that a resource check
+// needs to run. At most globalLimit checks run at once across all ATCs, and
+// at most teamLimit checks run at once for each team. A limit of 0 means
+// there is no limit.
+func (db *SQLDB) AcquireResourceCheckSlot(logger lager.Logger, teamID int, globalLimit int, teamLimit int) (Lock, bool, error) {
+	slots := &slotLock{}
+
+	if teamLimit > 0 {
+		teamSlot, acquired, err := db.acquireSlot(logger.Session("team-resource-check-slot"), teamLimit, func(slot int) LockID {
+			return teamResourceCheckSlotLockID(teamID, slot)
+		})
+		if err != nil || !acquired {
+			return nil, false, err
+		}
+
+		slots.locks = append(slots.locks, teamSlot)
+	}
+
+	if globalLimit > 0 {
+		globalSlot, acquired, err := db.acquireSlot(logger.Session("resource-check-slot"), globalLimit, resourceCheckSlotLockID)
+		if err != nil || !acquired {
+			slots.Release()
+			return nil, false, err
+		}
+
+		slots.locks = append(slots.locks, globalSlot)
+	}
+
+	return slots, true, nil
+}
+
+func (db *SQLDB) acquireSlot(logger lager.Logger, limit int, slotLockID func(int) LockID) (Lock, bool, error) {
+	for slot := 0; slot < limit; slot++ {
+		lock := db.lockFactory.NewLock(logger, slotLockID(slot))
+
+		acquired, err := lock.Acquire()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if acquired {
+			return lock, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// slotLock holds the team and global slots taken out for a single check.
+type slotLock struct {
+	locks []Lock
+
+	afterRelease func() error
+}
+
+func (l *slotLock) Acquire() (bool, error) {
+	for i, lock := range l.locks {
+		acquired, err := lock.Acquire()
+		if err != nil || !acquired {
+			for _, acquiredLock := range l.locks[:i] {
+				acquiredLock.Release()
+			}
+
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (l *slotLock) Release() error {
+	var releaseErr error
+	for _, lock := range l.locks {
+		err := lock.Release()
+		if err != nil {
+			releaseErr = err
+		}
+	}
+
+	if l.afterRelease != nil {
+		err := l.afterRelease()
+		if err != nil {
+			return err
+		}
+	}
+
+	return releaseErr
+}
+
+func (l *slotLock) AfterRelease(afterReleaseFunc func() error) {
+	l.afterRelease = afterReleaseFunc
+}
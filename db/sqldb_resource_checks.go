@@ -0,0 +1,11 @@
+package db
+
+import "time"
+
+func (db *SQLDB) ReapExpiredResourceChecks(retention time.Duration) error {
+	_, err := db.conn.Exec(`
+		DELETE FROM resource_checks
+		WHERE end_time < now() - ($1 || ' SECONDS')::INTERVAL
+	`, retention.Seconds())
+	return err
+}
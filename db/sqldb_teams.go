@@ -11,7 +11,7 @@ import (
 
 func (db *SQLDB) GetTeams() ([]SavedTeam, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth FROM teams
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth FROM teams
 	`)
 	if err != nil {
 		return nil, err
@@ -106,14 +106,34 @@ func (db *SQLDB) CreateTeam(team Team) (SavedTeam, error) {
 		return SavedTeam{}, err
 	}
 
+	jsonEncodedOIDCAuth, err := json.Marshal(team.OIDCAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	jsonEncodedLDAPAuth, err := json.Marshal(team.LDAPAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	jsonEncodedGitLabAuth, err := json.Marshal(team.GitLabAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	jsonEncodedCertAuth, err := json.Marshal(team.CertAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
 	savedTeam, err := scanTeam(db.conn.QueryRow(`
 	INSERT INTO teams (
-    name, basic_auth, github_auth, uaa_auth, genericoauth_auth
+    name, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
 	) VALUES (
-		$1, $2, $3, $4, $5
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
 	)
-	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
-	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth)))
+	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
+	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth), string(jsonEncodedOIDCAuth), string(jsonEncodedLDAPAuth), string(jsonEncodedGitLabAuth), string(jsonEncodedCertAuth)))
 	if err != nil {
 		return SavedTeam{}, err
 	}
@@ -130,7 +150,7 @@ func (db *SQLDB) CreateTeam(team Team) (SavedTeam, error) {
 }
 
 func scanTeam(rows scannable) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, genericOAuth, oidcAuth, ldapAuth, gitLabAuth, certAuth sql.NullString
 	var savedTeam SavedTeam
 
 	err := rows.Scan(
@@ -141,6 +161,10 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&oidcAuth,
+		&ldapAuth,
+		&gitLabAuth,
+		&certAuth,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -174,6 +198,34 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		}
 	}
 
+	if oidcAuth.Valid {
+		err = json.Unmarshal([]byte(oidcAuth.String), &savedTeam.OIDCAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if ldapAuth.Valid {
+		err = json.Unmarshal([]byte(ldapAuth.String), &savedTeam.LDAPAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if gitLabAuth.Valid {
+		err = json.Unmarshal([]byte(gitLabAuth.String), &savedTeam.GitLabAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if certAuth.Valid {
+		err = json.Unmarshal([]byte(certAuth.String), &savedTeam.CertAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
@@ -129,7 +129,8 @@ func (db *SQLDB) GetVolumes() ([]SavedVolume, error) {
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.last_used_at
 		FROM volumes v
 		` + volumeJoins + `
 		WHERE (v.expires_at IS NULL OR v.expires_at > NOW())
@@ -189,7 +190,8 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.last_used_at
 		FROM volumes v` + volumeJoins
 
 	statement += "WHERE " + strings.Join(conditions, " AND ")
@@ -227,7 +229,8 @@ func (db *SQLDB) GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.last_used_at
 		FROM volumes v ` + volumeJoins + `
 			INNER JOIN image_resource_versions i
 				ON i.version = v.resource_version
@@ -292,6 +295,15 @@ func (db *SQLDB) SetVolumeTTL(handle string, ttl time.Duration) error {
 	return err
 }
 
+func (db *SQLDB) MarkVolumeAsUsed(handle string) error {
+	_, err := db.conn.Exec(`
+		UPDATE volumes
+		SET last_used_at = NOW()
+		WHERE handle = $1
+	`, handle)
+	return err
+}
+
 func (db *SQLDB) GetVolumeTTL(handle string) (time.Duration, bool, error) {
 	var ttl time.Duration
 
@@ -353,6 +365,7 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			&volume.SizeInBytes,
 			&volume.ContainerTTL,
 			&teamID,
+			&volume.LastUsedAt,
 		)
 		if err != nil {
 			return []SavedVolume{}, err
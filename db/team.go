@@ -14,10 +14,14 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth"`
+	OIDCAuth     *OIDCAuth     `json:"oidc_auth"`
+	LDAPAuth     *LDAPAuth     `json:"ldap_auth"`
+	GitLabAuth   *GitLabAuth   `json:"gitlab_auth"`
+	CertAuth     *CertAuth     `json:"cert_auth"`
 }
 
 func (t Team) IsAuthConfigured() bool {
-	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil
+	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil || t.OIDCAuth != nil || t.LDAPAuth != nil || t.GitLabAuth != nil || t.CertAuth != nil
 }
 
 type BasicAuth struct {
@@ -58,6 +62,16 @@ type GitHubTeam struct {
 	TeamName         string `json:"team_name"`
 }
 
+type GitLabAuth struct {
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Groups       []string `json:"groups"`
+	Users        []string `json:"users"`
+	AuthURL      string   `json:"auth_url"`
+	TokenURL     string   `json:"token_url"`
+	APIURL       string   `json:"api_url"`
+}
+
 type SavedTeam struct {
 	ID int
 	Team
@@ -82,3 +96,41 @@ type GenericOAuth struct {
 	DisplayName   string            `json:"display_name"`
 	Scope         string            `json:"scope"`
 }
+
+type OIDCAuth struct {
+	DisplayName  string   `json:"display_name"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Scopes       []string `json:"scopes"`
+	Users        []string `json:"users"`
+	EmailDomains []string `json:"email_domains"`
+	Groups       []string `json:"groups"`
+	GroupsClaim  string   `json:"groups_claim"`
+}
+
+type LDAPAuth struct {
+	DisplayName          string   `json:"display_name"`
+	Host                 string   `json:"host"`
+	TLS                  bool     `json:"tls"`
+	StartTLS             bool     `json:"start_tls"`
+	InsecureSkipVerify   bool     `json:"insecure_skip_verify"`
+	CACert               string   `json:"ca_cert"`
+	BindDN               string   `json:"bind_dn"`
+	BindPassword         string   `json:"bind_password"`
+	UserSearchBaseDN     string   `json:"user_search_base_dn"`
+	UserSearchFilter     string   `json:"user_search_filter"`
+	UsernameAttribute    string   `json:"username_attribute"`
+	GroupSearchBaseDN    string   `json:"group_search_base_dn"`
+	GroupSearchFilter    string   `json:"group_search_filter"`
+	GroupMemberAttribute string   `json:"group_member_attribute"`
+	GroupNameAttribute   string   `json:"group_name_attribute"`
+	Groups               []string `json:"groups"`
+}
+
+// CertAuth lets clients log in with a verified client certificate whose
+// subject common name or one of whose subject alternative names is listed.
+type CertAuth struct {
+	CommonNames []string `json:"common_names"`
+	SANs        []string `json:"sans"`
+}
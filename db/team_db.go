@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -27,6 +28,10 @@ type TeamDB interface {
 	UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error)
 	UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error)
 	UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, error)
+	UpdateOIDCAuth(oidcAuth *OIDCAuth) (SavedTeam, error)
+	UpdateLDAPAuth(ldapAuth *LDAPAuth) (SavedTeam, error)
+	UpdateGitLabAuth(gitLabAuth *GitLabAuth) (SavedTeam, error)
+	UpdateCertAuth(certAuth *CertAuth) (SavedTeam, error)
 
 	GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error)
 	SaveConfigToBeDeprecated(string, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
@@ -39,12 +44,35 @@ type TeamDB interface {
 	FindContainersByDescriptors(id Container) ([]SavedContainer, error)
 
 	GetVolumes() ([]SavedVolume, error)
+
+	SetLockCapacity(lockName string, capacity int) error
+	GetLockCapacities() (map[string]int, error)
+	GetLockHolders() ([]TeamLockHolder, error)
+	ReleaseLock(lockName string, buildID int) (bool, error)
+
+	CreateAPIToken(name string, token string, kind string, owner string, scopes []string, expiresAt time.Time) (APIToken, error)
+	GetAPITokens() ([]APIToken, error)
+	RevokeAPIToken(name string) (bool, error)
+
+	SaveSession(sessionID string, username string, issuedAt time.Time, expiresAt time.Time) error
+	GetSessions() ([]Session, error)
+	RevokeSession(sessionID string) error
+	RevokeAllSessions() error
+	RevokeUserSessions(username string) error
+
+	CreateLocalUser(username string, password string) (LocalUser, error)
+	GetLocalUsers() ([]LocalUser, error)
+	GetLocalUser(username string) (LocalUser, bool, error)
+	SetLocalUserPassword(username string, password string) (bool, error)
+	SetLocalUserEnabled(username string, enabled bool) (bool, error)
+	DeleteLocalUser(username string) (bool, error)
 }
 
 type teamDB struct {
 	teamName string
 
 	conn         Conn
+	bus          *notificationsBus
 	buildFactory *buildFactory
 }
 
@@ -419,7 +447,22 @@ func (db *teamDB) SaveConfigToBeDeprecated(
 		}
 	}
 
-	return savedPipeline, created, tx.Commit()
+	err = db.savePassedJobs(tx, pipelineName, config, savedPipeline.ID)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	err = db.bus.Notify(pipelineSchedulingChannel(savedPipeline.ID))
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	return savedPipeline, created, nil
 }
 
 func (db *teamDB) saveJob(tx Tx, job atc.JobConfig, pipelineID int) error {
@@ -465,6 +508,31 @@ func (db *teamDB) registerSerialGroup(tx Tx, jobName, serialGroup string, pipeli
 	return swallowUniqueViolation(err)
 }
 
+// savePassedJobs records the jobs in other pipelines the config's passed
+// constraints refer to, so that those pipelines can find the pipelines
+// downstream of them.
+func (db *teamDB) savePassedJobs(tx Tx, pipelineName string, config atc.Config, pipelineID int) error {
+	_, err := tx.Exec(`
+		DELETE FROM pipeline_passed_jobs
+		WHERE pipeline_id = $1
+	`, pipelineID)
+	if err != nil {
+		return err
+	}
+
+	for _, passed := range config.PassedJobsInOtherPipelines(pipelineName) {
+		_, err := tx.Exec(`
+			INSERT INTO pipeline_passed_jobs (pipeline_id, passed_pipeline_name, passed_job_name)
+			VALUES ($1, $2, $3)
+		`, pipelineID, passed.Pipeline, passed.Job)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (db *teamDB) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int) error {
 	configPayload, err := json.Marshal(resource)
 	if err != nil {
@@ -521,7 +589,7 @@ func (db *teamDB) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelin
 
 func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 	query := `
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
 		FROM teams
 		WHERE LOWER(name) = LOWER($1)
 	`
@@ -539,7 +607,7 @@ func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 }
 
 func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, genericOAuth, oidcAuth, ldapAuth, gitLabAuth, certAuth sql.NullString
 	var savedTeam SavedTeam
 
 	tx, err := db.conn.Begin()
@@ -556,6 +624,10 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&oidcAuth,
+		&ldapAuth,
+		&gitLabAuth,
+		&certAuth,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -593,6 +665,34 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		}
 	}
 
+	if oidcAuth.Valid {
+		err = json.Unmarshal([]byte(oidcAuth.String), &savedTeam.OIDCAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if ldapAuth.Valid {
+		err = json.Unmarshal([]byte(ldapAuth.String), &savedTeam.LDAPAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if gitLabAuth.Valid {
+		err = json.Unmarshal([]byte(gitLabAuth.String), &savedTeam.GitLabAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if certAuth.Valid {
+		err = json.Unmarshal([]byte(certAuth.String), &savedTeam.CertAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
@@ -606,7 +706,7 @@ func (db *teamDB) UpdateBasicAuth(basicAuth *BasicAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET basic_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
 	`
 
 	params := []interface{}{encryptedBasicAuth, db.teamName}
@@ -628,7 +728,7 @@ func (db *teamDB) UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET github_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
 	`
 	params := []interface{}{string(jsonEncodedGitHubAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -644,7 +744,7 @@ func (db *teamDB) UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET uaa_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
 	`
 	params := []interface{}{string(jsonEncodedUAAAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -660,12 +760,80 @@ func (db *teamDB) UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, err
 		UPDATE teams
 		SET genericoauth_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
 	`
 	params := []interface{}{string(jsonEncodedGenericOAuth), db.teamName}
 	return db.queryTeam(query, params)
 }
 
+func (db *teamDB) UpdateOIDCAuth(oidcAuth *OIDCAuth) (SavedTeam, error) {
+	jsonEncodedOIDCAuth, err := json.Marshal(oidcAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET oidc_auth = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
+	`
+	params := []interface{}{string(jsonEncodedOIDCAuth), db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateLDAPAuth(ldapAuth *LDAPAuth) (SavedTeam, error) {
+	jsonEncodedLDAPAuth, err := json.Marshal(ldapAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET ldap_auth = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
+	`
+	params := []interface{}{string(jsonEncodedLDAPAuth), db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateGitLabAuth(gitLabAuth *GitLabAuth) (SavedTeam, error) {
+	var auth *GitLabAuth
+	if gitLabAuth != nil && gitLabAuth.ClientID != "" && gitLabAuth.ClientSecret != "" {
+		auth = gitLabAuth
+	}
+	jsonEncodedGitLabAuth, err := json.Marshal(auth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET gitlab_auth = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
+	`
+	params := []interface{}{string(jsonEncodedGitLabAuth), db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateCertAuth(certAuth *CertAuth) (SavedTeam, error) {
+	jsonEncodedCertAuth, err := json.Marshal(certAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET cert_auth = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, ldap_auth, gitlab_auth, cert_auth
+	`
+	params := []interface{}{string(jsonEncodedCertAuth), db.teamName}
+	return db.queryTeam(query, params)
+}
+
 func (db *teamDB) CreateOneOffBuild() (Build, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
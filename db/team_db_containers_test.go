@@ -45,8 +45,9 @@ var _ = Describe("TeamDbContainers", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 
 		team := db.Team{Name: "team-name"}
 		savedTeam, err := database.CreateTeam(team)
@@ -75,7 +76,7 @@ var _ = Describe("TeamDbContainers", func() {
 		}, 10*time.Minute)
 		Expect(err).NotTo(HaveOccurred())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 
 		config := atc.Config{
 			Jobs: atc.JobConfigs{
@@ -10,13 +10,17 @@ type teamDBFactory struct {
 	conn        Conn
 	bus         *notificationsBus
 	lockFactory LockFactory
+
+	versionsDBCaches *VersionsDBCaches
 }
 
-func NewTeamDBFactory(conn Conn, bus *notificationsBus, lockFactory LockFactory) TeamDBFactory {
+func NewTeamDBFactory(conn Conn, bus *notificationsBus, lockFactory LockFactory, versionsDBCaches *VersionsDBCaches) TeamDBFactory {
 	return &teamDBFactory{
 		conn:        conn,
 		bus:         bus,
 		lockFactory: lockFactory,
+
+		versionsDBCaches: versionsDBCaches,
 	}
 }
 
@@ -24,6 +28,7 @@ func (f *teamDBFactory) GetTeamDB(teamName string) TeamDB {
 	return &teamDB{
 		teamName:     teamName,
 		conn:         f.conn,
-		buildFactory: newBuildFactory(f.conn, f.bus, f.lockFactory),
+		bus:          f.bus,
+		buildFactory: newBuildFactory(f.conn, f.bus, f.lockFactory, f.versionsDBCaches),
 	}
 }
@@ -44,8 +44,9 @@ var _ = Describe("TeamDB", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		database = db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 
 		team := db.Team{Name: "TEAM-name"}
 		var err error
@@ -55,7 +56,7 @@ var _ = Describe("TeamDB", func() {
 		teamDB = teamDBFactory.GetTeamDB("team-NAME")
 		nonExistentTeamDB = teamDBFactory.GetTeamDB("non-existent-name")
 
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 
 		team = db.Team{Name: "other-team-name"}
 		otherSavedTeam, err = database.CreateTeam(team)
@@ -446,6 +447,99 @@ var _ = Describe("TeamDB", func() {
 				Expect(savedTeam.GenericOAuth).To(Equal(genericOAuth))
 			})
 		})
+
+		Describe("UpdateOIDCAuth", func() {
+			It("saves oidc auth info to the existing team", func() {
+				oidcAuth := &db.OIDCAuth{
+					DisplayName:  "Single Sign-On",
+					Issuer:       "https://issuer.example.com",
+					ClientID:     "some-client-id",
+					ClientSecret: "some-client-secret",
+					Scopes:       []string{"some-scope"},
+					Users:        []string{"some-user"},
+					EmailDomains: []string{"example.com"},
+					Groups:       []string{"some-group"},
+					GroupsClaim:  "roles",
+				}
+
+				savedTeam, err := teamDB.UpdateOIDCAuth(oidcAuth)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.OIDCAuth).To(Equal(oidcAuth))
+			})
+		})
+
+		Describe("UpdateGitLabAuth", func() {
+			It("saves gitlab auth team info to the existing team", func() {
+				gitLabAuth := &db.GitLabAuth{
+					ClientID:     "some-client-id",
+					ClientSecret: "some-client-secret",
+					Groups:       []string{"some-group", "some-parent/some-subgroup"},
+					Users:        []string{"some-user"},
+					APIURL:       "https://gitlab.example.com/api/v4/",
+				}
+
+				savedTeam, err := teamDB.UpdateGitLabAuth(gitLabAuth)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.GitLabAuth).To(Equal(gitLabAuth))
+			})
+
+			It("nulls gitlab auth when has a blank clientSecret", func() {
+				savedTeam, err := teamDB.UpdateGitLabAuth(&db.GitLabAuth{
+					ClientID: "some-client-id",
+					Users:    []string{"some-user"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.GitLabAuth).To(BeNil())
+			})
+		})
+
+		Describe("UpdateLDAPAuth", func() {
+			It("saves ldap auth info to the existing team", func() {
+				ldapAuth := &db.LDAPAuth{
+					DisplayName:       "LDAP",
+					Host:              "ldap.example.com:636",
+					TLS:               true,
+					BindDN:            "cn=concourse,dc=example,dc=com",
+					BindPassword:      "some-password",
+					UserSearchBaseDN:  "ou=people,dc=example,dc=com",
+					UserSearchFilter:  "(objectClass=person)",
+					UsernameAttribute: "uid",
+					GroupSearchBaseDN: "ou=groups,dc=example,dc=com",
+					Groups:            []string{"developers"},
+				}
+
+				savedTeam, err := teamDB.UpdateLDAPAuth(ldapAuth)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.LDAPAuth).To(Equal(ldapAuth))
+			})
+		})
+
+		Describe("UpdateCertAuth", func() {
+			It("saves cert auth info to the existing team", func() {
+				certAuth := &db.CertAuth{
+					CommonNames: []string{"build-bot"},
+					SANs:        []string{"bots.example.com"},
+				}
+
+				savedTeam, err := teamDB.UpdateCertAuth(certAuth)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.CertAuth).To(Equal(certAuth))
+
+				savedTeam, found, err := teamDB.GetTeam()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(savedTeam.CertAuth).To(Equal(certAuth))
+			})
+
+			It("nulls out cert auth when it is removed", func() {
+				_, err := teamDB.UpdateCertAuth(&db.CertAuth{CommonNames: []string{"build-bot"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				savedTeam, err := teamDB.UpdateCertAuth(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.CertAuth).To(BeNil())
+			})
+		})
 	})
 
 	Describe("GetTeam", func() {
@@ -33,7 +33,8 @@ func (db *teamDB) GetVolumes() ([]SavedVolume, error) {
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.last_used_at
 		FROM volumes v
 		LEFT JOIN containers c
 			ON v.container_id = c.id
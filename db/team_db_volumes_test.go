@@ -32,7 +32,8 @@ var _ = Describe("TeamDB Volumes", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		sqlDB := db.NewSQL(dbConn, bus, lockFactory)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		sqlDB := db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
 		database = sqlDB
 
 		_, err := database.CreateTeam(db.Team{Name: "some-team"})
@@ -41,7 +42,7 @@ var _ = Describe("TeamDB Volumes", func() {
 		_, err = database.CreateTeam(db.Team{Name: "other-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
 
@@ -0,0 +1,372 @@
+package db
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// DefaultTeamLockCapacity is the capacity of locks that have not been
+// configured on their team.
+const DefaultTeamLockCapacity = 1
+
+type TeamLockHolder struct {
+	LockName     string
+	BuildID      int
+	BuildName    string
+	JobName      string
+	PipelineName string
+	AcquiredAt   time.Time
+}
+
+func (pdb *pipelineDB) AcquireTeamLocks(logger lager.Logger, buildID int, locks []string) (bool, error) {
+	if len(locks) == 0 {
+		return true, nil
+	}
+
+	// always take the advisory locks in the same order so that two builds
+	// acquiring overlapping sets of locks cannot deadlock
+	sorted := make([]string, len(locks))
+	copy(sorted, locks)
+	sort.Strings(sorted)
+
+	for _, teamLock := range sorted {
+		lock := pdb.lockFactory.NewLock(
+			logger.Session("lock", lager.Data{
+				"team-lock": teamLock,
+			}),
+			teamLockLockID(pdb.TeamID, teamLock),
+		)
+
+		acquired, err := lock.Acquire()
+		if err != nil {
+			return false, err
+		}
+
+		if !acquired {
+			return false, nil
+		}
+
+		defer lock.Release()
+	}
+
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	for _, teamLock := range sorted {
+		holders, held, err := teamLockHolderCount(tx, pdb.TeamID, teamLock, buildID)
+		if err != nil {
+			return false, err
+		}
+
+		if held {
+			continue
+		}
+
+		capacity, err := teamLockCapacity(tx, pdb.TeamID, teamLock)
+		if err != nil {
+			return false, err
+		}
+
+		if holders >= capacity {
+			logger.Debug("team-lock-at-capacity", lager.Data{
+				"team-lock": teamLock,
+				"holders":   holders,
+				"capacity":  capacity,
+			})
+
+			return false, nil
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO team_lock_holders (team_id, name, build_id)
+			VALUES ($1, $2, $3)
+		`, pdb.TeamID, teamLock, buildID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SetLockCapacity configures how many builds across the team's pipelines may
+// hold the lock at once.
+func (db *teamDB) SetLockCapacity(lockName string, capacity int) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var teamID int
+	err = tx.QueryRow(`
+		SELECT id
+		FROM teams
+		WHERE LOWER(name) = LOWER($1)
+	`, db.teamName).Scan(&teamID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE team_locks
+		SET capacity = $3
+		WHERE team_id = $1
+		AND name = $2
+	`, teamID, lockName, capacity)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		_, err = tx.Exec(`
+			INSERT INTO team_locks (team_id, name, capacity)
+			VALUES ($1, $2, $3)
+		`, teamID, lockName, capacity)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	// raising the capacity may let waiting builds acquire the lock
+	return notifyTeamPipelinesScheduling(db.conn, db.bus, teamID)
+}
+
+// GetLockCapacities returns the capacity of every lock configured on the
+// team by name. Other locks have the DefaultTeamLockCapacity.
+func (db *teamDB) GetLockCapacities() (map[string]int, error) {
+	rows, err := db.conn.Query(`
+		SELECT l.name, l.capacity
+		FROM team_locks l
+		JOIN teams t ON t.id = l.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	capacities := map[string]int{}
+	for rows.Next() {
+		var name string
+		var capacity int
+		err := rows.Scan(&name, &capacity)
+		if err != nil {
+			return nil, err
+		}
+
+		capacities[name] = capacity
+	}
+
+	return capacities, nil
+}
+
+func (db *teamDB) GetLockHolders() ([]TeamLockHolder, error) {
+	rows, err := db.conn.Query(`
+		SELECT h.name, b.id, b.name, j.name, p.name, h.acquired_at
+		FROM team_lock_holders h
+		JOIN teams t ON t.id = h.team_id
+		JOIN builds b ON b.id = h.build_id
+		JOIN jobs j ON j.id = b.job_id
+		JOIN pipelines p ON p.id = j.pipeline_id
+		WHERE LOWER(t.name) = LOWER($1)
+		AND b.completed = false
+		ORDER BY h.name ASC, h.acquired_at ASC, b.id ASC
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	holders := []TeamLockHolder{}
+	for rows.Next() {
+		var holder TeamLockHolder
+		err := rows.Scan(
+			&holder.LockName,
+			&holder.BuildID,
+			&holder.BuildName,
+			&holder.JobName,
+			&holder.PipelineName,
+			&holder.AcquiredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		holders = append(holders, holder)
+	}
+
+	return holders, nil
+}
+
+func (db *teamDB) ReleaseLock(lockName string, buildID int) (bool, error) {
+	var teamID int
+	err := db.conn.QueryRow(`
+		DELETE FROM team_lock_holders h
+		USING teams t
+		WHERE t.id = h.team_id
+		AND LOWER(t.name) = LOWER($1)
+		AND h.name = $2
+		AND h.build_id = $3
+		RETURNING h.team_id
+	`, db.teamName, lockName, buildID).Scan(&teamID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	err = notifyTeamPipelinesScheduling(db.conn, db.bus, teamID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func teamLockStatuses(conn Conn, teamID int, buildID int, locks []string) (map[string]BuildPreparationStatus, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	statuses := map[string]BuildPreparationStatus{}
+	for _, teamLock := range locks {
+		holders, held, err := teamLockHolderCount(tx, teamID, teamLock, buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		capacity, err := teamLockCapacity(tx, teamID, teamLock)
+		if err != nil {
+			return nil, err
+		}
+
+		if held || holders < capacity {
+			statuses[teamLock] = BuildPreparationStatusNotBlocking
+		} else {
+			statuses[teamLock] = BuildPreparationStatusBlocking
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func teamLockCapacity(tx Tx, teamID int, lockName string) (int, error) {
+	var capacity int
+	err := tx.QueryRow(`
+		SELECT capacity
+		FROM team_locks
+		WHERE team_id = $1
+		AND name = $2
+	`, teamID, lockName).Scan(&capacity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return DefaultTeamLockCapacity, nil
+		}
+
+		return 0, err
+	}
+
+	return capacity, nil
+}
+
+// teamLockHolderCount returns the number of running builds other than the
+// given build holding the lock, and whether the given build holds it itself.
+// Holders whose builds have completed are ignored in case they were not
+// cleaned up.
+func teamLockHolderCount(tx Tx, teamID int, lockName string, buildID int) (int, bool, error) {
+	rows, err := tx.Query(`
+		SELECT h.build_id
+		FROM team_lock_holders h
+		JOIN builds b ON b.id = h.build_id
+		WHERE h.team_id = $1
+		AND h.name = $2
+		AND b.completed = false
+	`, teamID, lockName)
+	if err != nil {
+		return 0, false, err
+	}
+
+	defer rows.Close()
+
+	var holders int
+	var held bool
+	for rows.Next() {
+		var holderID int
+		err := rows.Scan(&holderID)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if holderID == buildID {
+			held = true
+		} else {
+			holders++
+		}
+	}
+
+	return holders, held, nil
+}
+
+func notifyTeamPipelinesScheduling(conn Conn, bus *notificationsBus, teamID int) error {
+	rows, err := conn.Query(`
+		SELECT id
+		FROM pipelines
+		WHERE team_id = $1
+	`, teamID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	pipelineIDs := []int{}
+	for rows.Next() {
+		var pipelineID int
+		err := rows.Scan(&pipelineID)
+		if err != nil {
+			return err
+		}
+
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+
+	for _, pipelineID := range pipelineIDs {
+		err := bus.Notify(pipelineSchedulingChannel(pipelineID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
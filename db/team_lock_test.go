@@ -0,0 +1,262 @@
+package db_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team locks", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		teamDB      db.TeamDB
+		otherTeamDB db.TeamDB
+
+		pipelineDB        db.PipelineDB
+		otherPipelineDB   db.PipelineDB
+		otherTeamPipeline db.PipelineDB
+
+		logger *lagertest.TestLogger
+	)
+
+	config := atc.Config{
+		Jobs: atc.JobConfigs{
+			{Name: "some-job"},
+		},
+	}
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		versionsDBCaches := db.NewVersionsDBCaches(dbConn)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, versionsDBCaches)
+		database := db.NewSQL(dbConn, bus, lockFactory, versionsDBCaches)
+
+		_, err := database.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = database.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+
+		savedPipeline, _, err := teamDB.SaveConfigToBeDeprecated("some-pipeline", config, 0, db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+		pipelineDB = pipelineDBFactory.Build(savedPipeline)
+
+		otherSavedPipeline, _, err := teamDB.SaveConfigToBeDeprecated("other-pipeline", config, 0, db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+		otherPipelineDB = pipelineDBFactory.Build(otherSavedPipeline)
+
+		otherTeamSavedPipeline, _, err := otherTeamDB.SaveConfigToBeDeprecated("some-pipeline", config, 0, db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+		otherTeamPipeline = pipelineDBFactory.Build(otherTeamSavedPipeline)
+
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("SetLockCapacity", func() {
+		It("configures the capacity of the team's lock", func() {
+			err := teamDB.SetLockCapacity("deploy", 3)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(teamDB.GetLockCapacities()).To(Equal(map[string]int{"deploy": 3}))
+			Expect(otherTeamDB.GetLockCapacities()).To(BeEmpty())
+		})
+
+		It("updates the capacity if it was already configured", func() {
+			err := teamDB.SetLockCapacity("deploy", 3)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = teamDB.SetLockCapacity("deploy", 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(teamDB.GetLockCapacities()).To(Equal(map[string]int{"deploy": 1}))
+		})
+	})
+
+	Describe("AcquireTeamLocks", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("acquires no locks trivially", func() {
+			acquired, err := pipelineDB.AcquireTeamLocks(logger, build.ID(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+		})
+
+		Context("when the build has acquired a lock", func() {
+			BeforeEach(func() {
+				acquired, err := pipelineDB.AcquireTeamLocks(logger, build.ID(), []string{"deploy"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("can acquire it again", func() {
+				acquired, err := pipelineDB.AcquireTeamLocks(logger, build.ID(), []string{"deploy"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("prevents builds in other pipelines of the team from acquiring it", func() {
+				otherBuild, err := otherPipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				acquired, err := otherPipelineDB.AcquireTeamLocks(logger, otherBuild.ID(), []string{"deploy"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+			})
+
+			It("does not acquire any of the locks when one of them is held", func() {
+				otherBuild, err := otherPipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				acquired, err := otherPipelineDB.AcquireTeamLocks(logger, otherBuild.ID(), []string{"smoke-env", "deploy"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+
+				holders, err := teamDB.GetLockHolders()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holders).To(HaveLen(1))
+				Expect(holders[0].LockName).To(Equal("deploy"))
+				Expect(holders[0].BuildID).To(Equal(build.ID()))
+			})
+
+			Context("when the team has raised the lock's capacity", func() {
+				BeforeEach(func() {
+					err := teamDB.SetLockCapacity("deploy", 2)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("allows other builds to acquire it while below capacity", func() {
+					otherBuild, err := otherPipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					acquired, err := otherPipelineDB.AcquireTeamLocks(logger, otherBuild.ID(), []string{"deploy"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+
+					thirdBuild, err := otherPipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					acquired, err = otherPipelineDB.AcquireTeamLocks(logger, thirdBuild.ID(), []string{"deploy"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(acquired).To(BeFalse())
+				})
+
+				It("does not raise the capacity of the lock in other teams", func() {
+					otherBuild, err := otherTeamPipeline.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					acquired, err := otherTeamPipeline.AcquireTeamLocks(logger, otherBuild.ID(), []string{"deploy"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+
+					thirdBuild, err := otherTeamPipeline.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					acquired, err = otherTeamPipeline.AcquireTeamLocks(logger, thirdBuild.ID(), []string{"deploy"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(acquired).To(BeFalse())
+				})
+			})
+
+			It("does not affect locks of the same name in other teams", func() {
+				otherBuild, err := otherTeamPipeline.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				acquired, err := otherTeamPipeline.AcquireTeamLocks(logger, otherBuild.ID(), []string{"deploy"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("is returned as a holder of the team's lock", func() {
+				holders, err := teamDB.GetLockHolders()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holders).To(HaveLen(1))
+				Expect(holders[0].LockName).To(Equal("deploy"))
+				Expect(holders[0].BuildID).To(Equal(build.ID()))
+				Expect(holders[0].BuildName).To(Equal(build.Name()))
+				Expect(holders[0].JobName).To(Equal("some-job"))
+				Expect(holders[0].PipelineName).To(Equal("some-pipeline"))
+
+				holders, err = otherTeamDB.GetLockHolders()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holders).To(BeEmpty())
+			})
+
+			Context("when the build finishes", func() {
+				BeforeEach(func() {
+					err := build.Finish(db.StatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("releases the lock", func() {
+					holders, err := teamDB.GetLockHolders()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(holders).To(BeEmpty())
+
+					otherBuild, err := otherPipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					acquired, err := otherPipelineDB.AcquireTeamLocks(logger, otherBuild.ID(), []string{"deploy"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+				})
+			})
+
+			Context("when the lock is force-released", func() {
+				It("releases the lock", func() {
+					released, err := teamDB.ReleaseLock("deploy", build.ID())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(released).To(BeTrue())
+
+					holders, err := teamDB.GetLockHolders()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(holders).To(BeEmpty())
+				})
+
+				It("returns false when released through another team", func() {
+					released, err := otherTeamDB.ReleaseLock("deploy", build.ID())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(released).To(BeFalse())
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,576 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/db/algorithm"
+)
+
+func pipelineSchedulingChannel(pipelineID int) string {
+	return fmt.Sprintf("pipeline_scheduling_%d", pipelineID)
+}
+
+type VersionsDBCaches struct {
+	conn Conn
+
+	caches  map[int]*versionsDBCache
+	cachesL sync.Mutex
+}
+
+// NewVersionsDBCaches constructs the cache of every pipeline's versions DB.
+// Only one should exist per process so that every pipeline DB shares the
+// same cache of its pipeline.
+func NewVersionsDBCaches(conn Conn) *VersionsDBCaches {
+	return &VersionsDBCaches{
+		conn: conn,
+
+		caches: map[int]*versionsDBCache{},
+	}
+}
+
+func (caches *VersionsDBCaches) cacheFor(pipelineID int) *versionsDBCache {
+	caches.cachesL.Lock()
+	defer caches.cachesL.Unlock()
+
+	cache, found := caches.caches[pipelineID]
+	if !found {
+		cache = &versionsDBCache{
+			conn:       caches.conn,
+			pipelineID: pipelineID,
+		}
+
+		caches.caches[pipelineID] = cache
+	}
+
+	return cache
+}
+
+func (caches *VersionsDBCaches) remove(pipelineID int) {
+	caches.cachesL.Lock()
+	delete(caches.caches, pipelineID)
+	caches.cachesL.Unlock()
+}
+
+// versionsDBCache keeps a pipeline's versions DB up to date by reading the
+// rows written since it was last loaded. Every row of versioned_resources,
+// builds, build_inputs and build_outputs is stamped with the ID of the
+// transaction that last changed it, so the rows to read are the ones stamped
+// by any transaction that wasn't yet committed when the cache was loaded,
+// regardless of when they commit or which IDs they were given.
+type versionsDBCache struct {
+	conn       Conn
+	pipelineID int
+
+	versionsDB    *algorithm.VersionsDB
+	configVersion ConfigVersion
+
+	// oldest transaction still running when the versions DB was loaded;
+	// everything it and any later transaction wrote may not have been seen
+	txid int64
+
+	enabled map[int]bool
+
+	// the versions DB extended with jobs in other pipelines, the one it was
+	// extended from, and the jobs it was extended with; it's reloaded when the
+	// latter changes or builds of those jobs have changed since passedJobsTxid
+	passedJobsVersionsDB *algorithm.VersionsDB
+	passedJobsFrom       *algorithm.VersionsDB
+	passedJobIDs         []int
+	passedJobsTxid       int64
+
+	lock sync.Mutex
+}
+
+// LoadWithPassedJobs loads the versions DB and extends it with the given
+// function, which also returns the jobs of other pipelines it extended it
+// with. The result is cached until the versions DB or the builds of those
+// jobs change.
+func (cache *versionsDBCache) LoadWithPassedJobs(
+	configVersion ConfigVersion,
+	loadPassedJobs func(*algorithm.VersionsDB) (*algorithm.VersionsDB, []int, error),
+) (*algorithm.VersionsDB, error) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	txid, err := cache.oldestRunningTxid()
+	if err != nil {
+		return nil, err
+	}
+
+	versionsDB, err := cache.load(configVersion, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache.passedJobsVersionsDB != nil && cache.passedJobsFrom == versionsDB {
+		changed, err := cache.passedJobsChanged()
+		if err != nil {
+			return nil, err
+		}
+
+		if !changed {
+			return cache.passedJobsVersionsDB, nil
+		}
+	}
+
+	passedJobsVersionsDB, passedJobIDs, err := loadPassedJobs(versionsDB)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.passedJobsVersionsDB = passedJobsVersionsDB
+	cache.passedJobsFrom = versionsDB
+	cache.passedJobIDs = passedJobIDs
+	cache.passedJobsTxid = txid
+
+	return passedJobsVersionsDB, nil
+}
+
+func (cache *versionsDBCache) load(configVersion ConfigVersion, txid int64) (*algorithm.VersionsDB, error) {
+	if cache.versionsDB == nil || configVersion > cache.configVersion {
+		return cache.reload(configVersion, txid)
+	}
+
+	return cache.update(txid)
+}
+
+func (cache *versionsDBCache) oldestRunningTxid() (int64, error) {
+	var txid int64
+	err := cache.conn.QueryRow(`
+		SELECT txid_snapshot_xmin(txid_current_snapshot())
+	`).Scan(&txid)
+
+	return txid, err
+}
+
+func (cache *versionsDBCache) reload(configVersion ConfigVersion, txid int64) (*algorithm.VersionsDB, error) {
+	db := &algorithm.VersionsDB{
+		BuildOutputs:     []algorithm.BuildOutput{},
+		BuildInputs:      []algorithm.BuildInput{},
+		ResourceVersions: []algorithm.ResourceVersion{},
+		DisabledVersions: []algorithm.ResourceVersion{},
+		JobIDs:           map[string]int{},
+		ResourceIDs:      map[string]int{},
+		CachedAt:         time.Now(),
+	}
+
+	versions, err := cache.loadVersions(0)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := map[int]bool{}
+
+	for _, v := range versions {
+		if v.enabled {
+			db.ResourceVersions = append(db.ResourceVersions, v.ResourceVersion)
+		} else {
+			db.DisabledVersions = append(db.DisabledVersions, v.ResourceVersion)
+		}
+
+		enabled[v.VersionID] = v.enabled
+	}
+
+	db.BuildOutputs, err = cache.loadBuildOutputs(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	db.BuildInputs, err = cache.loadBuildInputs(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cache.conn.Query(`
+    SELECT j.name, j.id
+    FROM jobs j
+    WHERE j.pipeline_id = $1
+  `, cache.pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var id int
+		err := rows.Scan(&name, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.JobIDs[name] = id
+	}
+
+	rows, err = cache.conn.Query(`
+    SELECT r.name, r.id
+    FROM resources r
+    WHERE r.pipeline_id = $1
+  `, cache.pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var id int
+		err := rows.Scan(&name, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.ResourceIDs[name] = id
+	}
+
+	cache.versionsDB = db
+	cache.configVersion = configVersion
+	cache.txid = txid
+	cache.enabled = enabled
+
+	return db, nil
+}
+
+func (cache *versionsDBCache) update(txid int64) (*algorithm.VersionsDB, error) {
+	versions, err := cache.loadVersions(cache.txid)
+	if err != nil {
+		return nil, err
+	}
+
+	changedCheckOrders := map[int]int{}
+	newVersions := []versionRow{}
+
+	for _, v := range versions {
+		wasEnabled, found := cache.enabled[v.VersionID]
+		if !found {
+			newVersions = append(newVersions, v)
+			continue
+		}
+
+		if wasEnabled != v.enabled {
+			// enabling or disabling a version affects every build that used it;
+			// not worth tracking down incrementally
+			return cache.reload(cache.configVersion, txid)
+		}
+
+		changedCheckOrders[v.VersionID] = v.CheckOrder
+	}
+
+	buildIDs, err := cache.loadChangedBuildIDs(cache.txid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 && len(buildIDs) == 0 {
+		cache.txid = txid
+		return cache.versionsDB, nil
+	}
+
+	outputs, err := cache.loadBuildOutputs(buildIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := cache.loadBuildInputs(buildIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	changedBuilds := map[int]bool{}
+	for _, buildID := range buildIDs {
+		changedBuilds[buildID] = true
+	}
+
+	old := cache.versionsDB
+
+	db := &algorithm.VersionsDB{
+		BuildOutputs:     []algorithm.BuildOutput{},
+		BuildInputs:      []algorithm.BuildInput{},
+		ResourceVersions: []algorithm.ResourceVersion{},
+		DisabledVersions: []algorithm.ResourceVersion{},
+		JobIDs:           old.JobIDs,
+		ResourceIDs:      old.ResourceIDs,
+		CachedAt:         time.Now(),
+	}
+
+	for _, v := range old.ResourceVersions {
+		if checkOrder, changed := changedCheckOrders[v.VersionID]; changed {
+			v.CheckOrder = checkOrder
+		}
+
+		db.ResourceVersions = append(db.ResourceVersions, v)
+	}
+
+	for _, v := range old.DisabledVersions {
+		if checkOrder, changed := changedCheckOrders[v.VersionID]; changed {
+			v.CheckOrder = checkOrder
+		}
+
+		db.DisabledVersions = append(db.DisabledVersions, v)
+	}
+
+	for _, v := range newVersions {
+		if v.enabled {
+			db.ResourceVersions = append(db.ResourceVersions, v.ResourceVersion)
+		} else {
+			db.DisabledVersions = append(db.DisabledVersions, v.ResourceVersion)
+		}
+
+		cache.enabled[v.VersionID] = v.enabled
+	}
+
+	for _, output := range old.BuildOutputs {
+		if changedBuilds[output.BuildID] {
+			continue
+		}
+
+		if checkOrder, changed := changedCheckOrders[output.VersionID]; changed {
+			output.CheckOrder = checkOrder
+		}
+
+		db.BuildOutputs = append(db.BuildOutputs, output)
+	}
+
+	db.BuildOutputs = append(db.BuildOutputs, outputs...)
+
+	for _, input := range old.BuildInputs {
+		if changedBuilds[input.BuildID] {
+			continue
+		}
+
+		if checkOrder, changed := changedCheckOrders[input.VersionID]; changed {
+			input.CheckOrder = checkOrder
+		}
+
+		db.BuildInputs = append(db.BuildInputs, input)
+	}
+
+	db.BuildInputs = append(db.BuildInputs, inputs...)
+
+	cache.versionsDB = db
+	cache.txid = txid
+
+	return db, nil
+}
+
+// passedJobsChanged determines whether builds of the jobs of other pipelines
+// the versions DB was extended with have changed status, or the inputs and
+// outputs of their successful builds have changed, since it was extended.
+func (cache *versionsDBCache) passedJobsChanged() (bool, error) {
+	if len(cache.passedJobIDs) == 0 {
+		return false, nil
+	}
+
+	jobIDsJSON, err := json.Marshal(cache.passedJobIDs)
+	if err != nil {
+		return false, err
+	}
+
+	var changed bool
+	err = cache.conn.QueryRow(`
+		WITH passed_builds AS (
+			SELECT b.id, b.status, b.txid
+			FROM builds b
+			WHERE b.job_id IN (SELECT value::text::int FROM json_array_elements($1::json))
+		)
+		SELECT EXISTS (
+			SELECT 1
+			FROM passed_builds b
+			WHERE b.txid >= $2
+			UNION ALL
+			SELECT 1
+			FROM build_outputs o
+			JOIN passed_builds b ON b.id = o.build_id
+			JOIN versioned_resources v ON v.id = o.versioned_resource_id
+			WHERE b.status = 'succeeded'
+			AND (o.txid >= $2 OR v.txid >= $2)
+			UNION ALL
+			SELECT 1
+			FROM build_inputs i
+			JOIN passed_builds b ON b.id = i.build_id
+			JOIN versioned_resources v ON v.id = i.versioned_resource_id
+			WHERE b.status = 'succeeded'
+			AND (i.txid >= $2 OR v.txid >= $2)
+		)
+	`, string(jobIDsJSON), cache.passedJobsTxid).Scan(&changed)
+
+	return changed, err
+}
+
+type versionRow struct {
+	algorithm.ResourceVersion
+
+	enabled bool
+}
+
+func (cache *versionsDBCache) loadVersions(sinceTxid int64) ([]versionRow, error) {
+	rows, err := cache.conn.Query(`
+    SELECT v.id, v.check_order, r.id, v.enabled
+    FROM versioned_resources v, resources r
+    WHERE r.id = v.resource_id
+		AND r.pipeline_id = $1
+		AND v.txid >= $2
+  `, cache.pipelineID, sinceTxid)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := []versionRow{}
+
+	for rows.Next() {
+		var v versionRow
+		err := rows.Scan(&v.VersionID, &v.CheckOrder, &v.ResourceID, &v.enabled)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, v)
+	}
+
+	return versions, nil
+}
+
+// loadChangedBuildIDs loads the builds of the pipeline whose status, inputs or
+// outputs have changed since the given transaction.
+func (cache *versionsDBCache) loadChangedBuildIDs(sinceTxid int64) ([]int, error) {
+	rows, err := cache.conn.Query(`
+		SELECT b.id
+		FROM builds b
+		JOIN jobs j ON j.id = b.job_id
+		WHERE j.pipeline_id = $1
+		AND b.txid >= $2
+		UNION
+		SELECT o.build_id
+		FROM build_outputs o
+		JOIN builds b ON b.id = o.build_id
+		JOIN jobs j ON j.id = b.job_id
+		WHERE j.pipeline_id = $1
+		AND o.txid >= $2
+		UNION
+		SELECT i.build_id
+		FROM build_inputs i
+		JOIN builds b ON b.id = i.build_id
+		JOIN jobs j ON j.id = b.job_id
+		WHERE j.pipeline_id = $1
+		AND i.txid >= $2
+	`, cache.pipelineID, sinceTxid)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	buildIDs := []int{}
+
+	for rows.Next() {
+		var buildID int
+		err := rows.Scan(&buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		buildIDs = append(buildIDs, buildID)
+	}
+
+	return buildIDs, nil
+}
+
+// buildIDsJSON marshals the builds to load the inputs or outputs of, with nil
+// meaning all of them.
+func buildIDsJSON(buildIDs []int) (*string, error) {
+	if buildIDs == nil {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(buildIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	buildIDsJSON := string(payload)
+	return &buildIDsJSON, nil
+}
+
+func (cache *versionsDBCache) loadBuildOutputs(buildIDs []int) ([]algorithm.BuildOutput, error) {
+	buildIDsJSON, err := buildIDsJSON(buildIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cache.conn.Query(`
+    SELECT v.id, v.check_order, r.id, o.build_id, j.id
+    FROM build_outputs o, builds b, versioned_resources v, jobs j, resources r
+    WHERE v.id = o.versioned_resource_id
+    AND b.id = o.build_id
+    AND j.id = b.job_id
+    AND r.id = v.resource_id
+    AND v.enabled
+		AND b.status = 'succeeded'
+		AND r.pipeline_id = $1
+		AND ($2::json IS NULL OR b.id IN (SELECT value::text::int FROM json_array_elements($2::json)))
+  `, cache.pipelineID, buildIDsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	outputs := []algorithm.BuildOutput{}
+
+	for rows.Next() {
+		var output algorithm.BuildOutput
+		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
+func (cache *versionsDBCache) loadBuildInputs(buildIDs []int) ([]algorithm.BuildInput, error) {
+	buildIDsJSON, err := buildIDsJSON(buildIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cache.conn.Query(`
+    SELECT v.id, v.check_order, r.id, i.build_id, i.name, j.id
+    FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
+    WHERE v.id = i.versioned_resource_id
+    AND b.id = i.build_id
+    AND j.id = b.job_id
+    AND r.id = v.resource_id
+    AND v.enabled
+		AND r.pipeline_id = $1
+		AND ($2::json IS NULL OR b.id IN (SELECT value::text::int FROM json_array_elements($2::json)))
+  `, cache.pipelineID, buildIDsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	inputs := []algorithm.BuildInput{}
+
+	for rows.Next() {
+		var input algorithm.BuildInput
+		err := rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
@@ -115,6 +115,7 @@ func (i ImportIdentifier) String() string {
 type SavedVolume struct {
 	Volume
 
-	ID        int
-	ExpiresIn time.Duration
+	ID         int
+	ExpiresIn  time.Duration
+	LastUsedAt time.Time
 }
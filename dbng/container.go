@@ -0,0 +1,188 @@
+package dbng
+
+import (
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type ContainerState string
+
+const (
+	ContainerStateCreating   = ContainerState("creating")
+	ContainerStateCreated    = ContainerState("created")
+	ContainerStateDestroying = ContainerState("destroying")
+)
+
+var ErrContainerStateChanged = errors.New("container-state-changed-in-db")
+
+//go:generate counterfeiter . CreatingContainer
+
+type CreatingContainer interface {
+	ID() int
+	Handle() string
+	Worker() *Worker
+
+	Created() (CreatedContainer, error)
+	Destroying() (DestroyingContainer, error)
+}
+
+//go:generate counterfeiter . CreatedContainer
+
+type CreatedContainer interface {
+	ID() int
+	Handle() string
+	Worker() *Worker
+
+	Destroying() (DestroyingContainer, error)
+}
+
+//go:generate counterfeiter . DestroyingContainer
+
+type DestroyingContainer interface {
+	ID() int
+	Handle() string
+	Worker() *Worker
+
+	// Destroy deletes the container, marking its volumes as destroying. It
+	// returns false if the container was already deleted, e.g. by another
+	// ATC.
+	Destroy() (bool, error)
+}
+
+type container struct {
+	id     int
+	handle string
+	worker *Worker
+
+	conn Conn
+}
+
+func (c *container) ID() int         { return c.id }
+func (c *container) Handle() string  { return c.handle }
+func (c *container) Worker() *Worker { return c.worker }
+
+type creatingContainer struct {
+	container
+}
+
+func (c *creatingContainer) Created() (CreatedContainer, error) {
+	err := c.transition(ContainerStateCreated, ContainerStateCreating)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdContainer{c.container}, nil
+}
+
+func (c *creatingContainer) Destroying() (DestroyingContainer, error) {
+	err := c.transition(ContainerStateDestroying, ContainerStateCreating)
+	if err != nil {
+		return nil, err
+	}
+
+	return &destroyingContainer{c.container}, nil
+}
+
+type createdContainer struct {
+	container
+}
+
+func (c *createdContainer) Destroying() (DestroyingContainer, error) {
+	err := c.transition(ContainerStateDestroying, ContainerStateCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &destroyingContainer{c.container}, nil
+}
+
+type destroyingContainer struct {
+	container
+}
+
+func (c *destroyingContainer) Destroy() (bool, error) {
+	tx, err := c.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	// resource caches and imported volumes outlive the containers they are
+	// mounted in
+	_, err = psql.Update("volumes").
+		Set("state", string(VolumeStateDestroying)).
+		Where(sq.Eq{
+			"container_id":  c.id,
+			"resource_hash": nil,
+			"path":          nil,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := psql.Delete("containers").
+		Where(sq.Eq{
+			"id":    c.id,
+			"state": string(ContainerStateDestroying),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// transition moves the container to the given state, failing with
+// ErrContainerStateChanged if it is no longer in the expected state. This is
+// what keeps two ATCs from acting on the same container.
+func (c *container) transition(to ContainerState, from ContainerState) error {
+	tx, err := c.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := psql.Update("containers").
+		Set("state", string(to)).
+		Where(sq.Eq{
+			"id":    c.id,
+			"state": string(from),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrContainerStateChanged
+	}
+
+	return tx.Commit()
+}
@@ -1,16 +1,27 @@
 package dbng
 
 import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
 	"github.com/nu7hatch/gouuid"
 )
 
-type ContainerFactory struct {
+//go:generate counterfeiter . ContainerFactory
+
+type ContainerFactory interface {
+	FindOrCreateBuildContainer(worker *Worker, build *Build, planID atc.PlanID, meta ContainerMetadata) (CreatingContainer, error)
+
+	FindOrphanedContainers() ([]CreatingContainer, []CreatedContainer, []DestroyingContainer, error)
+}
+
+type containerFactory struct {
 	conn Conn
 }
 
-func NewContainerFactory(conn Conn) *ContainerFactory {
-	return &ContainerFactory{
+func NewContainerFactory(conn Conn) ContainerFactory {
+	return &containerFactory{
 		conn: conn,
 	}
 }
@@ -20,31 +31,142 @@ type ContainerMetadata struct {
 	Name string
 }
 
-func (factory *ContainerFactory) FindOrCreateBuildContainer(
+func (factory *containerFactory) FindOrCreateBuildContainer(
 	worker *Worker,
 	build *Build,
 	planID atc.PlanID,
 	meta ContainerMetadata,
-) error {
+) (CreatingContainer, error) {
 	return factory.createPlanContainer(worker, build, planID, meta)
 }
 
-func (factory *ContainerFactory) createPlanContainer(
+// FindOrphanedContainers returns the containers that are no longer needed,
+// grouped by state. A build's containers are no longer needed once it has
+// finished, unless it is the latest finished build of its job and did not
+// succeed, in which case they are kept around for hijacking. Containers that
+// are already being destroyed are returned so that an interrupted
+// collection can be finished. Containers on workers that cannot be reached
+// are left alone.
+func (factory *containerFactory) FindOrphanedContainers() ([]CreatingContainer, []CreatedContainer, []DestroyingContainer, error) {
+	tx, err := factory.conn.Begin()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := psql.Select(
+		"c.id",
+		"c.handle",
+		"c.state",
+		"w.name",
+		"w.addr",
+		"w.baggageclaim_url",
+		"w.state",
+	).
+		From("containers c").
+		Join("workers w ON w.name = c.worker_name").
+		LeftJoin("builds b ON b.id = c.build_id").
+		Where(sq.NotEq{"w.addr": nil}).
+		// Squirrel does not have default support for subqueries in where clauses.
+		Where(`(
+			c.state = 'destroying'
+			OR (
+				c.build_id IS NOT NULL
+				AND (
+					b.id IS NULL
+					OR (
+						b.status NOT IN ('pending', 'started')
+						AND NOT (
+							b.job_id IS NOT NULL
+							AND b.status != 'succeeded'
+							AND b.id = (
+								SELECT MAX(lb.id)
+								FROM builds lb
+								WHERE lb.job_id = b.job_id
+								AND lb.status NOT IN ('pending', 'started')
+							)
+						)
+					)
+				)
+			)
+		)`).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	defer rows.Close()
+
+	creatingContainers := []CreatingContainer{}
+	createdContainers := []CreatedContainer{}
+	destroyingContainers := []DestroyingContainer{}
+
+	for rows.Next() {
+		var (
+			c        container
+			state    string
+			addr     sql.NullString
+			bcURLStr sql.NullString
+
+			workerName  string
+			workerState string
+		)
+
+		err := rows.Scan(&c.id, &c.handle, &state, &workerName, &addr, &bcURLStr, &workerState)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		c.conn = factory.conn
+		c.worker = &Worker{
+			Name:  workerName,
+			State: WorkerState(workerState),
+		}
+
+		if addr.Valid {
+			c.worker.GardenAddr = &addr.String
+		}
+
+		if bcURLStr.Valid {
+			c.worker.BaggageclaimURL = &bcURLStr.String
+		}
+
+		switch ContainerState(state) {
+		case ContainerStateCreating:
+			creatingContainers = append(creatingContainers, &creatingContainer{c})
+		case ContainerStateCreated:
+			createdContainers = append(createdContainers, &createdContainer{c})
+		case ContainerStateDestroying:
+			destroyingContainers = append(destroyingContainers, &destroyingContainer{c})
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return creatingContainers, createdContainers, destroyingContainers, nil
+}
+
+func (factory *containerFactory) createPlanContainer(
 	worker *Worker,
 	build *Build,
 	planID atc.PlanID,
 	meta ContainerMetadata,
-) error {
+) (CreatingContainer, error) {
 	tx, err := factory.conn.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer tx.Rollback()
 
 	handle, err := uuid.NewV4()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var containerID int
@@ -57,6 +179,7 @@ func (factory *ContainerFactory) createPlanContainer(
 			"type",
 			"step_name",
 			"handle",
+			"state",
 		).
 		Values(
 			worker.Name,
@@ -65,6 +188,7 @@ func (factory *ContainerFactory) createPlanContainer(
 			meta.Type,
 			meta.Name,
 			handle.String(),
+			string(ContainerStateCreating),
 		).
 		Suffix("RETURNING id").
 		RunWith(tx).
@@ -72,13 +196,20 @@ func (factory *ContainerFactory) createPlanContainer(
 		Scan(&containerID)
 	if err != nil {
 		// TODO: explicitly handle fkey constraint
-		return err
+		return nil, err
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &creatingContainer{
+		container{
+			id:     containerID,
+			handle: handle.String(),
+			worker: worker,
+			conn:   factory.conn,
+		},
+	}, nil
 }
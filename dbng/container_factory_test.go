@@ -0,0 +1,229 @@
+package dbng_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerFactory", func() {
+	var (
+		build *dbng.Build
+	)
+
+	saveStatus := func(build *dbng.Build, status dbng.BuildStatus) {
+		tx, err := dbConn.Begin()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveStatus(tx, status)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = tx.Commit()
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	orphanedHandles := func() []string {
+		creating, created, destroying, err := containerFactory.FindOrphanedContainers()
+		Expect(err).NotTo(HaveOccurred())
+
+		handles := []string{}
+		for _, c := range creating {
+			handles = append(handles, c.Handle())
+		}
+		for _, c := range created {
+			handles = append(handles, c.Handle())
+		}
+		for _, c := range destroying {
+			handles = append(handles, c.Handle())
+		}
+
+		return handles
+	}
+
+	BeforeEach(func() {
+		var err error
+		build, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("FindOrCreateBuildContainer", func() {
+		It("creates a container in the creating state", func() {
+			container, err := containerFactory.FindOrCreateBuildContainer(defaultWorker, build, atc.PlanID("some-plan"), dbng.ContainerMetadata{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(container.Handle()).NotTo(BeEmpty())
+			Expect(container.Worker().Name).To(Equal(defaultWorker.Name))
+
+			var state string
+			err = psql.Select("state").From("containers").Where("id = ?", container.ID()).RunWith(dbConn).QueryRow().Scan(&state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state).To(Equal("creating"))
+		})
+	})
+
+	Describe("state transitions", func() {
+		var creatingContainer dbng.CreatingContainer
+
+		BeforeEach(func() {
+			var err error
+			creatingContainer, err = containerFactory.FindOrCreateBuildContainer(defaultWorker, build, atc.PlanID("some-plan"), dbng.ContainerMetadata{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("can be created and then destroyed", func() {
+			createdContainer, err := creatingContainer.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			destroyingContainer, err := createdContainer.Destroying()
+			Expect(err).NotTo(HaveOccurred())
+
+			destroyed, err := destroyingContainer.Destroy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(destroyed).To(BeTrue())
+
+			destroyed, err = destroyingContainer.Destroy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(destroyed).To(BeFalse())
+		})
+
+		Context("when the container has already been marked as destroying", func() {
+			BeforeEach(func() {
+				_, err := creatingContainer.Destroying()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("cannot be marked as created", func() {
+				_, err := creatingContainer.Created()
+				Expect(err).To(Equal(dbng.ErrContainerStateChanged))
+			})
+
+			It("cannot be marked as destroying again", func() {
+				_, err := creatingContainer.Destroying()
+				Expect(err).To(Equal(dbng.ErrContainerStateChanged))
+			})
+		})
+
+		Context("when the container has volumes", func() {
+			var creatingVolume dbng.CreatingVolume
+
+			BeforeEach(func() {
+				var err error
+				creatingVolume, err = volumeFactory.CreateContainerVolume(0, defaultWorker, creatingContainer)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = creatingVolume.Created()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("marks them as destroying when the container is destroyed", func() {
+				destroyingContainer, err := creatingContainer.Destroying()
+				Expect(err).NotTo(HaveOccurred())
+
+				destroyingVolumes, err := volumeFactory.FindDestroyingVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destroyingVolumes).To(BeEmpty())
+
+				_, err = destroyingContainer.Destroy()
+				Expect(err).NotTo(HaveOccurred())
+
+				destroyingVolumes, err = volumeFactory.FindDestroyingVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destroyingVolumes).To(HaveLen(1))
+				Expect(destroyingVolumes[0].Handle()).To(Equal(creatingVolume.Handle()))
+			})
+		})
+	})
+
+	Describe("FindOrphanedContainers", func() {
+		Context("with a one-off build", func() {
+			var container dbng.CreatingContainer
+
+			BeforeEach(func() {
+				var err error
+				container, err = containerFactory.FindOrCreateBuildContainer(defaultWorker, build, atc.PlanID("some-plan"), dbng.ContainerMetadata{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not return the containers of a running build", func() {
+				saveStatus(build, dbng.BuildStatusStarted)
+				Expect(orphanedHandles()).To(BeEmpty())
+			})
+
+			It("returns the containers of a finished build", func() {
+				saveStatus(build, dbng.BuildStatusFailed)
+				Expect(orphanedHandles()).To(ConsistOf(container.Handle()))
+			})
+
+			It("returns containers that are being destroyed", func() {
+				_, err := container.Destroying()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, destroying, err := containerFactory.FindOrphanedContainers()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destroying).To(HaveLen(1))
+				Expect(destroying[0].Handle()).To(Equal(container.Handle()))
+			})
+		})
+
+		Context("with job builds", func() {
+			var (
+				firstBuild      *dbng.Build
+				secondBuild     *dbng.Build
+				firstContainer  dbng.CreatingContainer
+				secondContainer dbng.CreatingContainer
+			)
+
+			BeforeEach(func() {
+				pipeline, _, err := defaultTeam.SavePipeline("some-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "some-job"},
+					},
+				}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				firstBuild, err = pipeline.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				secondBuild, err = pipeline.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				firstContainer, err = containerFactory.FindOrCreateBuildContainer(defaultWorker, firstBuild, atc.PlanID("some-plan"), dbng.ContainerMetadata{})
+				Expect(err).NotTo(HaveOccurred())
+
+				secondContainer, err = containerFactory.FindOrCreateBuildContainer(defaultWorker, secondBuild, atc.PlanID("some-plan"), dbng.ContainerMetadata{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps the containers of the latest finished build if it failed", func() {
+				saveStatus(firstBuild, dbng.BuildStatusFailed)
+				saveStatus(secondBuild, dbng.BuildStatusFailed)
+
+				Expect(orphanedHandles()).To(ConsistOf(firstContainer.Handle()))
+			})
+
+			It("returns the containers of the latest finished build if it succeeded", func() {
+				saveStatus(firstBuild, dbng.BuildStatusFailed)
+				saveStatus(secondBuild, dbng.BuildStatusSucceeded)
+
+				Expect(orphanedHandles()).To(ConsistOf(firstContainer.Handle(), secondContainer.Handle()))
+			})
+		})
+
+		Context("when the worker is stalled", func() {
+			BeforeEach(func() {
+				_, err := containerFactory.FindOrCreateBuildContainer(defaultWorker, build, atc.PlanID("some-plan"), dbng.ContainerMetadata{})
+				Expect(err).NotTo(HaveOccurred())
+
+				saveStatus(build, dbng.BuildStatusSucceeded)
+
+				_, err = psql.Update("workers").Set("addr", nil).Where("name = ?", defaultWorker.Name).RunWith(dbConn).Exec()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("leaves its containers alone", func() {
+				Expect(orphanedHandles()).To(BeEmpty())
+			})
+		})
+	})
+})
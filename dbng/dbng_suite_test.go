@@ -31,7 +31,8 @@ var (
 
 	workerFactory    dbng.WorkerFactory
 	teamFactory      dbng.TeamFactory
-	containerFactory *dbng.ContainerFactory
+	containerFactory dbng.ContainerFactory
+	volumeFactory    dbng.VolumeFactory
 
 	defaultWorker *dbng.Worker
 	defaultTeam   dbng.Team
@@ -56,6 +57,7 @@ var _ = BeforeEach(func() {
 	workerFactory = dbng.NewWorkerFactory(dbConn)
 	teamFactory = dbng.NewTeamFactory(dbConn)
 	containerFactory = dbng.NewContainerFactory(dbConn)
+	volumeFactory = dbng.NewVolumeFactory(dbConn)
 
 	baseResourceType := atc.WorkerResourceType{
 		Type:    "some-base-resource-type",
@@ -0,0 +1,124 @@
+// This file was generated by counterfeiter
+package dbngfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+)
+
+type FakeContainerFactory struct {
+	FindOrCreateBuildContainerStub        func(worker *dbng.Worker, build *dbng.Build, planID atc.PlanID, meta dbng.ContainerMetadata) (dbng.CreatingContainer, error)
+	findOrCreateBuildContainerMutex       sync.RWMutex
+	findOrCreateBuildContainerArgsForCall []struct {
+		worker *dbng.Worker
+		build  *dbng.Build
+		planID atc.PlanID
+		meta   dbng.ContainerMetadata
+	}
+	findOrCreateBuildContainerReturns struct {
+		result1 dbng.CreatingContainer
+		result2 error
+	}
+	FindOrphanedContainersStub        func() ([]dbng.CreatingContainer, []dbng.CreatedContainer, []dbng.DestroyingContainer, error)
+	findOrphanedContainersMutex       sync.RWMutex
+	findOrphanedContainersArgsForCall []struct{}
+	findOrphanedContainersReturns     struct {
+		result1 []dbng.CreatingContainer
+		result2 []dbng.CreatedContainer
+		result3 []dbng.DestroyingContainer
+		result4 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeContainerFactory) FindOrCreateBuildContainer(worker *dbng.Worker, build *dbng.Build, planID atc.PlanID, meta dbng.ContainerMetadata) (dbng.CreatingContainer, error) {
+	fake.findOrCreateBuildContainerMutex.Lock()
+	fake.findOrCreateBuildContainerArgsForCall = append(fake.findOrCreateBuildContainerArgsForCall, struct {
+		worker *dbng.Worker
+		build  *dbng.Build
+		planID atc.PlanID
+		meta   dbng.ContainerMetadata
+	}{worker, build, planID, meta})
+	fake.recordInvocation("FindOrCreateBuildContainer", []interface{}{worker, build, planID, meta})
+	fake.findOrCreateBuildContainerMutex.Unlock()
+	if fake.FindOrCreateBuildContainerStub != nil {
+		return fake.FindOrCreateBuildContainerStub(worker, build, planID, meta)
+	} else {
+		return fake.findOrCreateBuildContainerReturns.result1, fake.findOrCreateBuildContainerReturns.result2
+	}
+}
+
+func (fake *FakeContainerFactory) FindOrCreateBuildContainerCallCount() int {
+	fake.findOrCreateBuildContainerMutex.RLock()
+	defer fake.findOrCreateBuildContainerMutex.RUnlock()
+	return len(fake.findOrCreateBuildContainerArgsForCall)
+}
+
+func (fake *FakeContainerFactory) FindOrCreateBuildContainerArgsForCall(i int) (*dbng.Worker, *dbng.Build, atc.PlanID, dbng.ContainerMetadata) {
+	fake.findOrCreateBuildContainerMutex.RLock()
+	defer fake.findOrCreateBuildContainerMutex.RUnlock()
+	return fake.findOrCreateBuildContainerArgsForCall[i].worker, fake.findOrCreateBuildContainerArgsForCall[i].build, fake.findOrCreateBuildContainerArgsForCall[i].planID, fake.findOrCreateBuildContainerArgsForCall[i].meta
+}
+
+func (fake *FakeContainerFactory) FindOrCreateBuildContainerReturns(result1 dbng.CreatingContainer, result2 error) {
+	fake.FindOrCreateBuildContainerStub = nil
+	fake.findOrCreateBuildContainerReturns = struct {
+		result1 dbng.CreatingContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerFactory) FindOrphanedContainers() ([]dbng.CreatingContainer, []dbng.CreatedContainer, []dbng.DestroyingContainer, error) {
+	fake.findOrphanedContainersMutex.Lock()
+	fake.findOrphanedContainersArgsForCall = append(fake.findOrphanedContainersArgsForCall, struct{}{})
+	fake.recordInvocation("FindOrphanedContainers", []interface{}{})
+	fake.findOrphanedContainersMutex.Unlock()
+	if fake.FindOrphanedContainersStub != nil {
+		return fake.FindOrphanedContainersStub()
+	} else {
+		return fake.findOrphanedContainersReturns.result1, fake.findOrphanedContainersReturns.result2, fake.findOrphanedContainersReturns.result3, fake.findOrphanedContainersReturns.result4
+	}
+}
+
+func (fake *FakeContainerFactory) FindOrphanedContainersCallCount() int {
+	fake.findOrphanedContainersMutex.RLock()
+	defer fake.findOrphanedContainersMutex.RUnlock()
+	return len(fake.findOrphanedContainersArgsForCall)
+}
+
+func (fake *FakeContainerFactory) FindOrphanedContainersReturns(result1 []dbng.CreatingContainer, result2 []dbng.CreatedContainer, result3 []dbng.DestroyingContainer, result4 error) {
+	fake.FindOrphanedContainersStub = nil
+	fake.findOrphanedContainersReturns = struct {
+		result1 []dbng.CreatingContainer
+		result2 []dbng.CreatedContainer
+		result3 []dbng.DestroyingContainer
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeContainerFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findOrCreateBuildContainerMutex.RLock()
+	defer fake.findOrCreateBuildContainerMutex.RUnlock()
+	fake.findOrphanedContainersMutex.RLock()
+	defer fake.findOrphanedContainersMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeContainerFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ dbng.ContainerFactory = new(FakeContainerFactory)
@@ -0,0 +1,134 @@
+// This file was generated by counterfeiter
+package dbngfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/dbng"
+)
+
+type FakeCreatedVolume struct {
+	HandleStub        func() string
+	handleMutex       sync.RWMutex
+	handleArgsForCall []struct{}
+	handleReturns     struct {
+		result1 string
+	}
+	WorkerStub        func() *dbng.Worker
+	workerMutex       sync.RWMutex
+	workerArgsForCall []struct{}
+	workerReturns     struct {
+		result1 *dbng.Worker
+	}
+	DestroyingStub        func() (dbng.DestroyingVolume, error)
+	destroyingMutex       sync.RWMutex
+	destroyingArgsForCall []struct{}
+	destroyingReturns     struct {
+		result1 dbng.DestroyingVolume
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCreatedVolume) Handle() string {
+	fake.handleMutex.Lock()
+	fake.handleArgsForCall = append(fake.handleArgsForCall, struct{}{})
+	fake.recordInvocation("Handle", []interface{}{})
+	fake.handleMutex.Unlock()
+	if fake.HandleStub != nil {
+		return fake.HandleStub()
+	} else {
+		return fake.handleReturns.result1
+	}
+}
+
+func (fake *FakeCreatedVolume) HandleCallCount() int {
+	fake.handleMutex.RLock()
+	defer fake.handleMutex.RUnlock()
+	return len(fake.handleArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) HandleReturns(result1 string) {
+	fake.HandleStub = nil
+	fake.handleReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) Worker() *dbng.Worker {
+	fake.workerMutex.Lock()
+	fake.workerArgsForCall = append(fake.workerArgsForCall, struct{}{})
+	fake.recordInvocation("Worker", []interface{}{})
+	fake.workerMutex.Unlock()
+	if fake.WorkerStub != nil {
+		return fake.WorkerStub()
+	} else {
+		return fake.workerReturns.result1
+	}
+}
+
+func (fake *FakeCreatedVolume) WorkerCallCount() int {
+	fake.workerMutex.RLock()
+	defer fake.workerMutex.RUnlock()
+	return len(fake.workerArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) WorkerReturns(result1 *dbng.Worker) {
+	fake.WorkerStub = nil
+	fake.workerReturns = struct {
+		result1 *dbng.Worker
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) Destroying() (dbng.DestroyingVolume, error) {
+	fake.destroyingMutex.Lock()
+	fake.destroyingArgsForCall = append(fake.destroyingArgsForCall, struct{}{})
+	fake.recordInvocation("Destroying", []interface{}{})
+	fake.destroyingMutex.Unlock()
+	if fake.DestroyingStub != nil {
+		return fake.DestroyingStub()
+	} else {
+		return fake.destroyingReturns.result1, fake.destroyingReturns.result2
+	}
+}
+
+func (fake *FakeCreatedVolume) DestroyingCallCount() int {
+	fake.destroyingMutex.RLock()
+	defer fake.destroyingMutex.RUnlock()
+	return len(fake.destroyingArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) DestroyingReturns(result1 dbng.DestroyingVolume, result2 error) {
+	fake.DestroyingStub = nil
+	fake.destroyingReturns = struct {
+		result1 dbng.DestroyingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.handleMutex.RLock()
+	defer fake.handleMutex.RUnlock()
+	fake.workerMutex.RLock()
+	defer fake.workerMutex.RUnlock()
+	fake.destroyingMutex.RLock()
+	defer fake.destroyingMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCreatedVolume) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ dbng.CreatedVolume = new(FakeCreatedVolume)
@@ -0,0 +1,202 @@
+// This file was generated by counterfeiter
+package dbngfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/dbng"
+)
+
+type FakeCreatingContainer struct {
+	IDStub        func() int
+	idMutex       sync.RWMutex
+	idArgsForCall []struct{}
+	idReturns     struct {
+		result1 int
+	}
+	HandleStub        func() string
+	handleMutex       sync.RWMutex
+	handleArgsForCall []struct{}
+	handleReturns     struct {
+		result1 string
+	}
+	WorkerStub        func() *dbng.Worker
+	workerMutex       sync.RWMutex
+	workerArgsForCall []struct{}
+	workerReturns     struct {
+		result1 *dbng.Worker
+	}
+	CreatedStub        func() (dbng.CreatedContainer, error)
+	createdMutex       sync.RWMutex
+	createdArgsForCall []struct{}
+	createdReturns     struct {
+		result1 dbng.CreatedContainer
+		result2 error
+	}
+	DestroyingStub        func() (dbng.DestroyingContainer, error)
+	destroyingMutex       sync.RWMutex
+	destroyingArgsForCall []struct{}
+	destroyingReturns     struct {
+		result1 dbng.DestroyingContainer
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCreatingContainer) ID() int {
+	fake.idMutex.Lock()
+	fake.idArgsForCall = append(fake.idArgsForCall, struct{}{})
+	fake.recordInvocation("ID", []interface{}{})
+	fake.idMutex.Unlock()
+	if fake.IDStub != nil {
+		return fake.IDStub()
+	} else {
+		return fake.idReturns.result1
+	}
+}
+
+func (fake *FakeCreatingContainer) IDCallCount() int {
+	fake.idMutex.RLock()
+	defer fake.idMutex.RUnlock()
+	return len(fake.idArgsForCall)
+}
+
+func (fake *FakeCreatingContainer) IDReturns(result1 int) {
+	fake.IDStub = nil
+	fake.idReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeCreatingContainer) Handle() string {
+	fake.handleMutex.Lock()
+	fake.handleArgsForCall = append(fake.handleArgsForCall, struct{}{})
+	fake.recordInvocation("Handle", []interface{}{})
+	fake.handleMutex.Unlock()
+	if fake.HandleStub != nil {
+		return fake.HandleStub()
+	} else {
+		return fake.handleReturns.result1
+	}
+}
+
+func (fake *FakeCreatingContainer) HandleCallCount() int {
+	fake.handleMutex.RLock()
+	defer fake.handleMutex.RUnlock()
+	return len(fake.handleArgsForCall)
+}
+
+func (fake *FakeCreatingContainer) HandleReturns(result1 string) {
+	fake.HandleStub = nil
+	fake.handleReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCreatingContainer) Worker() *dbng.Worker {
+	fake.workerMutex.Lock()
+	fake.workerArgsForCall = append(fake.workerArgsForCall, struct{}{})
+	fake.recordInvocation("Worker", []interface{}{})
+	fake.workerMutex.Unlock()
+	if fake.WorkerStub != nil {
+		return fake.WorkerStub()
+	} else {
+		return fake.workerReturns.result1
+	}
+}
+
+func (fake *FakeCreatingContainer) WorkerCallCount() int {
+	fake.workerMutex.RLock()
+	defer fake.workerMutex.RUnlock()
+	return len(fake.workerArgsForCall)
+}
+
+func (fake *FakeCreatingContainer) WorkerReturns(result1 *dbng.Worker) {
+	fake.WorkerStub = nil
+	fake.workerReturns = struct {
+		result1 *dbng.Worker
+	}{result1}
+}
+
+func (fake *FakeCreatingContainer) Created() (dbng.CreatedContainer, error) {
+	fake.createdMutex.Lock()
+	fake.createdArgsForCall = append(fake.createdArgsForCall, struct{}{})
+	fake.recordInvocation("Created", []interface{}{})
+	fake.createdMutex.Unlock()
+	if fake.CreatedStub != nil {
+		return fake.CreatedStub()
+	} else {
+		return fake.createdReturns.result1, fake.createdReturns.result2
+	}
+}
+
+func (fake *FakeCreatingContainer) CreatedCallCount() int {
+	fake.createdMutex.RLock()
+	defer fake.createdMutex.RUnlock()
+	return len(fake.createdArgsForCall)
+}
+
+func (fake *FakeCreatingContainer) CreatedReturns(result1 dbng.CreatedContainer, result2 error) {
+	fake.CreatedStub = nil
+	fake.createdReturns = struct {
+		result1 dbng.CreatedContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCreatingContainer) Destroying() (dbng.DestroyingContainer, error) {
+	fake.destroyingMutex.Lock()
+	fake.destroyingArgsForCall = append(fake.destroyingArgsForCall, struct{}{})
+	fake.recordInvocation("Destroying", []interface{}{})
+	fake.destroyingMutex.Unlock()
+	if fake.DestroyingStub != nil {
+		return fake.DestroyingStub()
+	} else {
+		return fake.destroyingReturns.result1, fake.destroyingReturns.result2
+	}
+}
+
+func (fake *FakeCreatingContainer) DestroyingCallCount() int {
+	fake.destroyingMutex.RLock()
+	defer fake.destroyingMutex.RUnlock()
+	return len(fake.destroyingArgsForCall)
+}
+
+func (fake *FakeCreatingContainer) DestroyingReturns(result1 dbng.DestroyingContainer, result2 error) {
+	fake.DestroyingStub = nil
+	fake.destroyingReturns = struct {
+		result1 dbng.DestroyingContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCreatingContainer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.idMutex.RLock()
+	defer fake.idMutex.RUnlock()
+	fake.handleMutex.RLock()
+	defer fake.handleMutex.RUnlock()
+	fake.workerMutex.RLock()
+	defer fake.workerMutex.RUnlock()
+	fake.createdMutex.RLock()
+	defer fake.createdMutex.RUnlock()
+	fake.destroyingMutex.RLock()
+	defer fake.destroyingMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCreatingContainer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ dbng.CreatingContainer = new(FakeCreatingContainer)
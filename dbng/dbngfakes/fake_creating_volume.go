@@ -0,0 +1,134 @@
+// This file was generated by counterfeiter
+package dbngfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/dbng"
+)
+
+type FakeCreatingVolume struct {
+	HandleStub        func() string
+	handleMutex       sync.RWMutex
+	handleArgsForCall []struct{}
+	handleReturns     struct {
+		result1 string
+	}
+	WorkerStub        func() *dbng.Worker
+	workerMutex       sync.RWMutex
+	workerArgsForCall []struct{}
+	workerReturns     struct {
+		result1 *dbng.Worker
+	}
+	CreatedStub        func() (dbng.CreatedVolume, error)
+	createdMutex       sync.RWMutex
+	createdArgsForCall []struct{}
+	createdReturns     struct {
+		result1 dbng.CreatedVolume
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCreatingVolume) Handle() string {
+	fake.handleMutex.Lock()
+	fake.handleArgsForCall = append(fake.handleArgsForCall, struct{}{})
+	fake.recordInvocation("Handle", []interface{}{})
+	fake.handleMutex.Unlock()
+	if fake.HandleStub != nil {
+		return fake.HandleStub()
+	} else {
+		return fake.handleReturns.result1
+	}
+}
+
+func (fake *FakeCreatingVolume) HandleCallCount() int {
+	fake.handleMutex.RLock()
+	defer fake.handleMutex.RUnlock()
+	return len(fake.handleArgsForCall)
+}
+
+func (fake *FakeCreatingVolume) HandleReturns(result1 string) {
+	fake.HandleStub = nil
+	fake.handleReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCreatingVolume) Worker() *dbng.Worker {
+	fake.workerMutex.Lock()
+	fake.workerArgsForCall = append(fake.workerArgsForCall, struct{}{})
+	fake.recordInvocation("Worker", []interface{}{})
+	fake.workerMutex.Unlock()
+	if fake.WorkerStub != nil {
+		return fake.WorkerStub()
+	} else {
+		return fake.workerReturns.result1
+	}
+}
+
+func (fake *FakeCreatingVolume) WorkerCallCount() int {
+	fake.workerMutex.RLock()
+	defer fake.workerMutex.RUnlock()
+	return len(fake.workerArgsForCall)
+}
+
+func (fake *FakeCreatingVolume) WorkerReturns(result1 *dbng.Worker) {
+	fake.WorkerStub = nil
+	fake.workerReturns = struct {
+		result1 *dbng.Worker
+	}{result1}
+}
+
+func (fake *FakeCreatingVolume) Created() (dbng.CreatedVolume, error) {
+	fake.createdMutex.Lock()
+	fake.createdArgsForCall = append(fake.createdArgsForCall, struct{}{})
+	fake.recordInvocation("Created", []interface{}{})
+	fake.createdMutex.Unlock()
+	if fake.CreatedStub != nil {
+		return fake.CreatedStub()
+	} else {
+		return fake.createdReturns.result1, fake.createdReturns.result2
+	}
+}
+
+func (fake *FakeCreatingVolume) CreatedCallCount() int {
+	fake.createdMutex.RLock()
+	defer fake.createdMutex.RUnlock()
+	return len(fake.createdArgsForCall)
+}
+
+func (fake *FakeCreatingVolume) CreatedReturns(result1 dbng.CreatedVolume, result2 error) {
+	fake.CreatedStub = nil
+	fake.createdReturns = struct {
+		result1 dbng.CreatedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCreatingVolume) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.handleMutex.RLock()
+	defer fake.handleMutex.RUnlock()
+	fake.workerMutex.RLock()
+	defer fake.workerMutex.RUnlock()
+	fake.createdMutex.RLock()
+	defer fake.createdMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCreatingVolume) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ dbng.CreatingVolume = new(FakeCreatingVolume)
@@ -0,0 +1,117 @@
+// This file was generated by counterfeiter
+package dbngfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/dbng"
+)
+
+type FakeVolumeFactory struct {
+	CreateContainerVolumeStub        func(teamID int, worker *dbng.Worker, container dbng.CreatingContainer) (dbng.CreatingVolume, error)
+	createContainerVolumeMutex       sync.RWMutex
+	createContainerVolumeArgsForCall []struct {
+		teamID    int
+		worker    *dbng.Worker
+		container dbng.CreatingContainer
+	}
+	createContainerVolumeReturns struct {
+		result1 dbng.CreatingVolume
+		result2 error
+	}
+	FindDestroyingVolumesStub        func() ([]dbng.DestroyingVolume, error)
+	findDestroyingVolumesMutex       sync.RWMutex
+	findDestroyingVolumesArgsForCall []struct{}
+	findDestroyingVolumesReturns     struct {
+		result1 []dbng.DestroyingVolume
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeVolumeFactory) CreateContainerVolume(teamID int, worker *dbng.Worker, container dbng.CreatingContainer) (dbng.CreatingVolume, error) {
+	fake.createContainerVolumeMutex.Lock()
+	fake.createContainerVolumeArgsForCall = append(fake.createContainerVolumeArgsForCall, struct {
+		teamID    int
+		worker    *dbng.Worker
+		container dbng.CreatingContainer
+	}{teamID, worker, container})
+	fake.recordInvocation("CreateContainerVolume", []interface{}{teamID, worker, container})
+	fake.createContainerVolumeMutex.Unlock()
+	if fake.CreateContainerVolumeStub != nil {
+		return fake.CreateContainerVolumeStub(teamID, worker, container)
+	} else {
+		return fake.createContainerVolumeReturns.result1, fake.createContainerVolumeReturns.result2
+	}
+}
+
+func (fake *FakeVolumeFactory) CreateContainerVolumeCallCount() int {
+	fake.createContainerVolumeMutex.RLock()
+	defer fake.createContainerVolumeMutex.RUnlock()
+	return len(fake.createContainerVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) CreateContainerVolumeArgsForCall(i int) (int, *dbng.Worker, dbng.CreatingContainer) {
+	fake.createContainerVolumeMutex.RLock()
+	defer fake.createContainerVolumeMutex.RUnlock()
+	return fake.createContainerVolumeArgsForCall[i].teamID, fake.createContainerVolumeArgsForCall[i].worker, fake.createContainerVolumeArgsForCall[i].container
+}
+
+func (fake *FakeVolumeFactory) CreateContainerVolumeReturns(result1 dbng.CreatingVolume, result2 error) {
+	fake.CreateContainerVolumeStub = nil
+	fake.createContainerVolumeReturns = struct {
+		result1 dbng.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) FindDestroyingVolumes() ([]dbng.DestroyingVolume, error) {
+	fake.findDestroyingVolumesMutex.Lock()
+	fake.findDestroyingVolumesArgsForCall = append(fake.findDestroyingVolumesArgsForCall, struct{}{})
+	fake.recordInvocation("FindDestroyingVolumes", []interface{}{})
+	fake.findDestroyingVolumesMutex.Unlock()
+	if fake.FindDestroyingVolumesStub != nil {
+		return fake.FindDestroyingVolumesStub()
+	} else {
+		return fake.findDestroyingVolumesReturns.result1, fake.findDestroyingVolumesReturns.result2
+	}
+}
+
+func (fake *FakeVolumeFactory) FindDestroyingVolumesCallCount() int {
+	fake.findDestroyingVolumesMutex.RLock()
+	defer fake.findDestroyingVolumesMutex.RUnlock()
+	return len(fake.findDestroyingVolumesArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) FindDestroyingVolumesReturns(result1 []dbng.DestroyingVolume, result2 error) {
+	fake.FindDestroyingVolumesStub = nil
+	fake.findDestroyingVolumesReturns = struct {
+		result1 []dbng.DestroyingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createContainerVolumeMutex.RLock()
+	defer fake.createContainerVolumeMutex.RUnlock()
+	fake.findDestroyingVolumesMutex.RLock()
+	defer fake.findDestroyingVolumesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeVolumeFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ dbng.VolumeFactory = new(FakeVolumeFactory)
@@ -218,6 +218,11 @@ func (t *team) SavePipeline(
 		}
 	}
 
+	err = t.savePassedJobs(tx, pipelineName, config, savedPipeline.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, false, err
@@ -321,6 +326,31 @@ func (t *team) registerSerialGroup(tx Tx, jobName, serialGroup string, pipelineI
 	return swallowUniqueViolation(err)
 }
 
+// savePassedJobs records the jobs in other pipelines the config's passed
+// constraints refer to, so that those pipelines can find the pipelines
+// downstream of them.
+func (t *team) savePassedJobs(tx Tx, pipelineName string, config atc.Config, pipelineID int) error {
+	_, err := tx.Exec(`
+		DELETE FROM pipeline_passed_jobs
+		WHERE pipeline_id = $1
+	`, pipelineID)
+	if err != nil {
+		return err
+	}
+
+	for _, passed := range config.PassedJobsInOtherPipelines(pipelineName) {
+		_, err := tx.Exec(`
+			INSERT INTO pipeline_passed_jobs (pipeline_id, passed_pipeline_name, passed_job_name)
+			VALUES ($1, $2, $3)
+		`, pipelineID, passed.Pipeline, passed.Job)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int) error {
 	configPayload, err := json.Marshal(resource)
 	if err != nil {
@@ -0,0 +1,156 @@
+package dbng
+
+import (
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type VolumeState string
+
+const (
+	VolumeStateCreating   = VolumeState("creating")
+	VolumeStateCreated    = VolumeState("created")
+	VolumeStateDestroying = VolumeState("destroying")
+)
+
+var ErrVolumeStateChanged = errors.New("volume-state-changed-in-db")
+
+//go:generate counterfeiter . CreatingVolume
+
+type CreatingVolume interface {
+	Handle() string
+	Worker() *Worker
+
+	Created() (CreatedVolume, error)
+}
+
+//go:generate counterfeiter . CreatedVolume
+
+type CreatedVolume interface {
+	Handle() string
+	Worker() *Worker
+
+	Destroying() (DestroyingVolume, error)
+}
+
+//go:generate counterfeiter . DestroyingVolume
+
+type DestroyingVolume interface {
+	Handle() string
+	Worker() *Worker
+
+	// Destroy deletes the volume. It returns false if the volume was already
+	// deleted, e.g. by another ATC.
+	Destroy() (bool, error)
+}
+
+type volume struct {
+	handle string
+	worker *Worker
+
+	conn Conn
+}
+
+func (v *volume) Handle() string  { return v.handle }
+func (v *volume) Worker() *Worker { return v.worker }
+
+type creatingVolume struct {
+	volume
+}
+
+func (v *creatingVolume) Created() (CreatedVolume, error) {
+	err := v.transition(VolumeStateCreated, VolumeStateCreating)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdVolume{v.volume}, nil
+}
+
+type createdVolume struct {
+	volume
+}
+
+func (v *createdVolume) Destroying() (DestroyingVolume, error) {
+	err := v.transition(VolumeStateDestroying, VolumeStateCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &destroyingVolume{v.volume}, nil
+}
+
+type destroyingVolume struct {
+	volume
+}
+
+func (v *destroyingVolume) Destroy() (bool, error) {
+	tx, err := v.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := psql.Delete("volumes").
+		Where(sq.Eq{
+			"handle": v.handle,
+			"state":  string(VolumeStateDestroying),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// transition moves the volume to the given state, failing with
+// ErrVolumeStateChanged if it is no longer in the expected state.
+func (v *volume) transition(to VolumeState, from VolumeState) error {
+	tx, err := v.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := psql.Update("volumes").
+		Set("state", string(to)).
+		Where(sq.Eq{
+			"handle": v.handle,
+			"state":  string(from),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrVolumeStateChanged
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,154 @@
+package dbng
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/nu7hatch/gouuid"
+)
+
+//go:generate counterfeiter . VolumeFactory
+
+type VolumeFactory interface {
+	CreateContainerVolume(teamID int, worker *Worker, container CreatingContainer) (CreatingVolume, error)
+
+	FindDestroyingVolumes() ([]DestroyingVolume, error)
+}
+
+type volumeFactory struct {
+	conn Conn
+}
+
+func NewVolumeFactory(conn Conn) VolumeFactory {
+	return &volumeFactory{
+		conn: conn,
+	}
+}
+
+// CreateContainerVolume creates a volume for use by the given container. It
+// lives until the container is destroyed.
+func (factory *volumeFactory) CreateContainerVolume(teamID int, worker *Worker, container CreatingContainer) (CreatingVolume, error) {
+	tx, err := factory.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	handle, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	var teamIDValue sql.NullInt64
+	if teamID != 0 {
+		teamIDValue = sql.NullInt64{Int64: int64(teamID), Valid: true}
+	}
+
+	_, err = psql.Insert("volumes").
+		Columns(
+			"handle",
+			"worker_name",
+			"team_id",
+			"container_id",
+			"ttl",
+			"state",
+		).
+		Values(
+			handle.String(),
+			worker.Name,
+			teamIDValue,
+			container.ID(),
+			0,
+			string(VolumeStateCreating),
+		).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &creatingVolume{
+		volume{
+			handle: handle.String(),
+			worker: worker,
+			conn:   factory.conn,
+		},
+	}, nil
+}
+
+// FindDestroyingVolumes returns the volumes that are being destroyed on
+// workers that can be reached.
+func (factory *volumeFactory) FindDestroyingVolumes() ([]DestroyingVolume, error) {
+	tx, err := factory.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := psql.Select(
+		"v.handle",
+		"w.name",
+		"w.addr",
+		"w.baggageclaim_url",
+		"w.state",
+	).
+		From("volumes v").
+		Join("workers w ON w.name = v.worker_name").
+		Where(sq.Eq{"v.state": string(VolumeStateDestroying)}).
+		Where(sq.NotEq{"w.baggageclaim_url": nil}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	destroyingVolumes := []DestroyingVolume{}
+
+	for rows.Next() {
+		var (
+			v        volume
+			addr     sql.NullString
+			bcURLStr sql.NullString
+
+			workerName  string
+			workerState string
+		)
+
+		err := rows.Scan(&v.handle, &workerName, &addr, &bcURLStr, &workerState)
+		if err != nil {
+			return nil, err
+		}
+
+		v.conn = factory.conn
+		v.worker = &Worker{
+			Name:  workerName,
+			State: WorkerState(workerState),
+		}
+
+		if addr.Valid {
+			v.worker.GardenAddr = &addr.String
+		}
+
+		if bcURLStr.Valid {
+			v.worker.BaggageclaimURL = &bcURLStr.String
+		}
+
+		destroyingVolumes = append(destroyingVolumes, &destroyingVolume{v})
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return destroyingVolumes, nil
+}
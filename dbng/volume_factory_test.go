@@ -0,0 +1,109 @@
+package dbng_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VolumeFactory", func() {
+	var (
+		container dbng.CreatingContainer
+	)
+
+	BeforeEach(func() {
+		build, err := defaultTeam.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		container, err = containerFactory.FindOrCreateBuildContainer(defaultWorker, build, atc.PlanID("some-plan"), dbng.ContainerMetadata{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("CreateContainerVolume", func() {
+		It("creates a volume in the creating state", func() {
+			volume, err := volumeFactory.CreateContainerVolume(0, defaultWorker, container)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volume.Handle()).NotTo(BeEmpty())
+			Expect(volume.Worker().Name).To(Equal(defaultWorker.Name))
+
+			var state string
+			err = psql.Select("state").From("volumes").Where("handle = ?", volume.Handle()).RunWith(dbConn).QueryRow().Scan(&state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state).To(Equal("creating"))
+		})
+	})
+
+	Describe("state transitions", func() {
+		var createdVolume dbng.CreatedVolume
+
+		BeforeEach(func() {
+			creatingVolume, err := volumeFactory.CreateContainerVolume(0, defaultWorker, container)
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, err = creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("can be destroyed", func() {
+			destroyingVolume, err := createdVolume.Destroying()
+			Expect(err).NotTo(HaveOccurred())
+
+			destroyed, err := destroyingVolume.Destroy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(destroyed).To(BeTrue())
+
+			destroyed, err = destroyingVolume.Destroy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(destroyed).To(BeFalse())
+		})
+
+		It("cannot be marked as destroying twice", func() {
+			_, err := createdVolume.Destroying()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = createdVolume.Destroying()
+			Expect(err).To(Equal(dbng.ErrVolumeStateChanged))
+		})
+	})
+
+	Describe("FindDestroyingVolumes", func() {
+		var destroyingVolume dbng.DestroyingVolume
+
+		BeforeEach(func() {
+			creatingVolume, err := volumeFactory.CreateContainerVolume(0, defaultWorker, container)
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = volumeFactory.CreateContainerVolume(0, defaultWorker, container)
+			Expect(err).NotTo(HaveOccurred())
+
+			destroyingVolume, err = createdVolume.Destroying()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns only the volumes being destroyed", func() {
+			destroyingVolumes, err := volumeFactory.FindDestroyingVolumes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(destroyingVolumes).To(HaveLen(1))
+			Expect(destroyingVolumes[0].Handle()).To(Equal(destroyingVolume.Handle()))
+			Expect(*destroyingVolumes[0].Worker().BaggageclaimURL).To(Equal("5.6.7.8:7878"))
+		})
+
+		Context("when the worker's baggageclaim cannot be reached", func() {
+			BeforeEach(func() {
+				_, err := psql.Update("workers").Set("baggageclaim_url", nil).Where("name = ?", defaultWorker.Name).RunWith(dbConn).Exec()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not return its volumes", func() {
+				destroyingVolumes, err := volumeFactory.FindDestroyingVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destroyingVolumes).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -39,6 +39,9 @@ type Worker struct {
 	TeamID           int
 	StartTime        int64
 
+	DiskCapacityInBytes int64
+	DiskUsageInBytes    int64
+
 	TeamName  string
 	ExpiresIn time.Duration
 }
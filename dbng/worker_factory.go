@@ -56,6 +56,8 @@ func (f *workerFactory) GetWorker(name string) (*Worker, bool, error) {
 		w.https_proxy_url,
 		w.no_proxy,
 		w.active_containers,
+		w.disk_capacity_in_bytes,
+		w.disk_usage_in_bytes,
 		w.resource_types,
 		w.platform,
 		w.tags,
@@ -95,6 +97,8 @@ var workersQuery = psql.Select(`
 		w.https_proxy_url,
 		w.no_proxy,
 		w.active_containers,
+		w.disk_capacity_in_bytes,
+		w.disk_usage_in_bytes,
 		w.resource_types,
 		w.platform,
 		w.tags,
@@ -160,6 +164,8 @@ func scanWorker(row scannable) (*Worker, error) {
 		noProxy       sql.NullString
 
 		activeContainers int
+		diskCapacity     int64
+		diskUsage        int64
 		resourceTypes    []byte
 		platform         sql.NullString
 		tags             []byte
@@ -179,6 +185,8 @@ func scanWorker(row scannable) (*Worker, error) {
 		&httpsProxyURL,
 		&noProxy,
 		&activeContainers,
+		&diskCapacity,
+		&diskUsage,
 		&resourceTypes,
 		&platform,
 		&tags,
@@ -207,8 +215,10 @@ func scanWorker(row scannable) (*Worker, error) {
 		BaggageclaimURL: bcURL,
 		State:           WorkerState(state),
 
-		ActiveContainers: activeContainers,
-		StartTime:        startTime,
+		ActiveContainers:    activeContainers,
+		DiskCapacityInBytes: diskCapacity,
+		DiskUsageInBytes:    diskUsage,
+		StartTime:           startTime,
 	}
 
 	if expiresIn != nil {
@@ -467,6 +477,8 @@ func (f *workerFactory) HeartbeatWorker(worker atc.Worker, ttl time.Duration) (*
 		workerName       string
 		workerStateStr   string
 		activeContainers int
+		diskCapacity     int64
+		diskUsage        int64
 		expiresAt        time.Time
 		addrStr          sql.NullString
 		bcURLStr         sql.NullString
@@ -477,12 +489,14 @@ func (f *workerFactory) HeartbeatWorker(worker atc.Worker, ttl time.Duration) (*
 		Set("addr", sq.Expr("("+addrSql+")")).
 		Set("baggageclaim_url", sq.Expr("("+bcSql+")")).
 		Set("active_containers", worker.ActiveContainers).
+		Set("disk_capacity_in_bytes", worker.DiskCapacityInBytes).
+		Set("disk_usage_in_bytes", worker.DiskUsageInBytes).
 		Set("state", sq.Expr("("+cSql+")")).
 		Where(sq.Eq{"name": worker.Name}).
-		Suffix("RETURNING name, addr, baggageclaim_url, state, expires, active_containers").
+		Suffix("RETURNING name, addr, baggageclaim_url, state, expires, active_containers, disk_capacity_in_bytes, disk_usage_in_bytes").
 		RunWith(tx).
 		QueryRow().
-		Scan(&workerName, &addrStr, &bcURLStr, &workerStateStr, &expiresAt, &activeContainers)
+		Scan(&workerName, &addrStr, &bcURLStr, &workerStateStr, &expiresAt, &activeContainers, &diskCapacity, &diskUsage)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrWorkerNotPresent
@@ -512,6 +526,9 @@ func (f *workerFactory) HeartbeatWorker(worker atc.Worker, ttl time.Duration) (*
 		State:            WorkerState(workerStateStr),
 		ExpiresIn:        expiresAt.Sub(now),
 		ActiveContainers: activeContainers,
+
+		DiskCapacityInBytes: diskCapacity,
+		DiskUsageInBytes:    diskUsage,
 	}, nil
 }
 
@@ -814,6 +831,8 @@ func saveWorker(tx Tx, worker atc.Worker, team *team, ttl time.Duration) (*Worke
 					"addr",
 					"expires",
 					"active_containers",
+					"disk_capacity_in_bytes",
+					"disk_usage_in_bytes",
 					"resource_types",
 					"tags",
 					"platform",
@@ -830,6 +849,8 @@ func saveWorker(tx Tx, worker atc.Worker, team *team, ttl time.Duration) (*Worke
 					worker.GardenAddr,
 					sq.Expr(expires),
 					worker.ActiveContainers,
+					worker.DiskCapacityInBytes,
+					worker.DiskUsageInBytes,
 					resourceTypes,
 					tags,
 					worker.Platform,
@@ -861,6 +882,8 @@ func saveWorker(tx Tx, worker atc.Worker, team *team, ttl time.Duration) (*Worke
 			Set("addr", worker.GardenAddr).
 			Set("expires", sq.Expr(expires)).
 			Set("active_containers", worker.ActiveContainers).
+			Set("disk_capacity_in_bytes", worker.DiskCapacityInBytes).
+			Set("disk_usage_in_bytes", worker.DiskUsageInBytes).
 			Set("resource_types", resourceTypes).
 			Set("tags", tags).
 			Set("platform", worker.Platform).
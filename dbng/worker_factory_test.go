@@ -342,7 +342,7 @@ var _ = Describe("WorkerFactory", func() {
 				err = tx.Commit()
 				Expect(err).NotTo(HaveOccurred())
 
-				err = containerFactory.FindOrCreateBuildContainer(dbWorker, dbBuild, atc.PlanID(4), dbng.ContainerMetadata{})
+				_, err = containerFactory.FindOrCreateBuildContainer(dbWorker, dbBuild, atc.PlanID(4), dbng.ContainerMetadata{})
 				Expect(err).NotTo(HaveOccurred())
 
 				_, found, err := workerFactory.GetWorker(atcWorker.Name)
@@ -499,7 +499,7 @@ var _ = Describe("WorkerFactory", func() {
 				err = tx.Commit()
 				Expect(err).NotTo(HaveOccurred())
 
-				err = containerFactory.FindOrCreateBuildContainer(dbWorker, dbBuild, atc.PlanID(4), dbng.ContainerMetadata{})
+				_, err = containerFactory.FindOrCreateBuildContainer(dbWorker, dbBuild, atc.PlanID(4), dbng.ContainerMetadata{})
 				Expect(err).NotTo(HaveOccurred())
 
 				_, found, err := workerFactory.GetWorker(atcWorker.Name)
@@ -781,6 +781,23 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(*foundWorker.BaggageclaimURL).To(Equal("some-bc-url"))
 			})
 
+			It("updates the disk capacity and usage", func() {
+				atcWorker.DiskCapacityInBytes = 1000
+				atcWorker.DiskUsageInBytes = 900
+
+				foundWorker, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(foundWorker.DiskCapacityInBytes).To(Equal(int64(1000)))
+				Expect(foundWorker.DiskUsageInBytes).To(Equal(int64(900)))
+
+				savedWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(savedWorker.DiskCapacityInBytes).To(Equal(int64(1000)))
+				Expect(savedWorker.DiskUsageInBytes).To(Equal(int64(900)))
+			})
+
 			Context("when the current state is landing", func() {
 				BeforeEach(func() {
 					atcWorker.State = string(dbng.WorkerStateLanding)
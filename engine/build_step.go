@@ -107,12 +107,14 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 
 	clock := clock.NewClock()
 
-	return build.factory.Task(
+	delegate := build.delegate.ExecutionDelegate(logger, *plan.Task, event.OriginID(plan.ID))
+
+	step := build.factory.Task(
 		logger,
 		exec.SourceName(plan.Task.Name),
 		workerID,
 		workerMetadata,
-		build.delegate.ExecutionDelegate(logger, *plan.Task, event.OriginID(plan.ID)),
+		delegate,
 		exec.Privileged(plan.Task.Privileged),
 		plan.Task.Tags,
 		build.teamID,
@@ -125,6 +127,12 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		build.containerSuccessTTL,
 		build.containerFailureTTL,
 	)
+
+	if !plan.Task.RetryOnWorkerLoss {
+		return step
+	}
+
+	return build.retryOnWorkerLoss(step, delegate)
 }
 
 func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
@@ -141,13 +149,15 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		"get",
 	)
 
-	return build.factory.Get(
+	delegate := build.delegate.InputDelegate(logger, *plan.Get, event.OriginID(plan.ID))
+
+	step := build.factory.Get(
 		logger,
 		build.stepMetadata,
 		exec.SourceName(plan.Get.Name),
 		workerID,
 		workerMetadata,
-		build.delegate.InputDelegate(logger, *plan.Get, event.OriginID(plan.ID)),
+		delegate,
 		atc.ResourceConfig{
 			Name:   plan.Get.Resource,
 			Type:   plan.Get.Type,
@@ -161,6 +171,8 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		build.containerSuccessTTL,
 		build.containerFailureTTL,
 	)
+
+	return build.retryOnWorkerLoss(step, delegate)
 }
 
 func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
@@ -212,13 +224,15 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		"get",
 	)
 
-	return build.factory.DependentGet(
+	delegate := build.delegate.InputDelegate(logger, getPlan, event.OriginID(plan.ID))
+
+	step := build.factory.DependentGet(
 		logger,
 		build.stepMetadata,
 		exec.SourceName(getPlan.Name),
 		workerID,
 		workerMetadata,
-		build.delegate.InputDelegate(logger, getPlan, event.OriginID(plan.ID)),
+		delegate,
 		atc.ResourceConfig{
 			Name:   getPlan.Resource,
 			Type:   getPlan.Type,
@@ -231,6 +245,8 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		build.containerSuccessTTL,
 		build.containerFailureTTL,
 	)
+
+	return build.retryOnWorkerLoss(step, delegate)
 }
 
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
@@ -247,3 +263,17 @@ func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.
 
 	return step
 }
+
+// retryOnWorkerLoss wraps a step that is safe to run more than once so that
+// it is run again on another worker if its worker goes away.
+func (build *execBuild) retryOnWorkerLoss(step exec.StepFactory, delegate exec.WorkerLossDelegate) exec.StepFactory {
+	if build.workerLossAttempts <= 1 {
+		return step
+	}
+
+	return exec.RetryOnWorkerLoss{
+		Step:        step,
+		MaxAttempts: build.workerLossAttempts,
+		Delegate:    delegate,
+	}
+}
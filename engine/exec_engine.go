@@ -29,6 +29,8 @@ type execEngine struct {
 	delegateFactory BuildDelegateFactory
 	teamDBFactory   db.TeamDBFactory
 	externalURL     string
+
+	workerLossAttempts int
 }
 
 func NewExecEngine(
@@ -36,12 +38,15 @@ func NewExecEngine(
 	delegateFactory BuildDelegateFactory,
 	teamDBFactory db.TeamDBFactory,
 	externalURL string,
+	workerLossAttempts int,
 ) Engine {
 	return &execEngine{
 		factory:         factory,
 		delegateFactory: delegateFactory,
 		teamDBFactory:   teamDBFactory,
 		externalURL:     externalURL,
+
+		workerLossAttempts: workerLossAttempts,
 	}
 }
 
@@ -66,6 +71,8 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 
 		containerSuccessTTL: successTTL,
 		containerFailureTTL: failureTTL,
+
+		workerLossAttempts: engine.workerLossAttempts,
 	}, nil
 }
 
@@ -96,6 +103,8 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 
 		containerSuccessTTL: successTTL,
 		containerFailureTTL: failureTTL,
+
+		workerLossAttempts: engine.workerLossAttempts,
 	}, nil
 }
 
@@ -174,6 +183,8 @@ type execBuild struct {
 
 	containerSuccessTTL time.Duration
 	containerFailureTTL time.Duration
+
+	workerLossAttempts int
 }
 
 func (build *execBuild) Metadata() string {
@@ -178,6 +178,33 @@ func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus
 	}
 }
 
+func (delegate *delegate) saveWaitingForWorker(logger lager.Logger, spec worker.WorkerSpec, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.WaitingForWorker{
+		Time:         time.Now().Unix(),
+		Origin:       origin,
+		Platform:     spec.Platform,
+		Tags:         spec.Tags,
+		TeamID:       spec.TeamID,
+		ResourceType: spec.ResourceType,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-worker-event", err)
+	}
+}
+
+func (delegate *delegate) saveRetryingAfterWorkerLoss(logger lager.Logger, errVal error, attempt int, maxAttempts int, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.RetryingAfterWorkerLoss{
+		Time:        time.Now().Unix(),
+		Origin:      origin,
+		Message:     errVal.Error(),
+		Attempt:     attempt,
+		MaxAttempts: maxAttempts,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-retrying-after-worker-loss-event", err)
+	}
+}
+
 func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	err := delegate.build.Finish(db.Status(status))
 	if err != nil {
@@ -336,6 +363,22 @@ func (input *inputDelegate) ImageVersionDetermined(identifier worker.VolumeIdent
 	return input.delegate.build.SaveImageResourceVersion(atc.PlanID(input.id), *identifier.ResourceCache)
 }
 
+func (input *inputDelegate) WaitingForWorker(spec worker.WorkerSpec) {
+	input.delegate.saveWaitingForWorker(input.logger, spec, event.Origin{
+		ID: input.id,
+	})
+
+	input.logger.Info("waiting-for-worker")
+}
+
+func (input *inputDelegate) RetryingAfterWorkerLoss(err error, attempt int, maxAttempts int) {
+	input.delegate.saveRetryingAfterWorkerLoss(input.logger, err, attempt, maxAttempts, event.Origin{
+		ID: input.id,
+	})
+
+	input.logger.Info("retrying-after-worker-loss", lager.Data{"error": err.Error(), "attempt": attempt})
+}
+
 func (input *inputDelegate) Stdout() io.Writer {
 	return input.delegate.eventWriter(event.Origin{
 		Source: event.OriginSourceStdout,
@@ -384,6 +427,22 @@ func (output *outputDelegate) ImageVersionDetermined(identifier worker.VolumeIde
 	return output.delegate.build.SaveImageResourceVersion(atc.PlanID(output.id), *identifier.ResourceCache)
 }
 
+func (output *outputDelegate) WaitingForWorker(spec worker.WorkerSpec) {
+	output.delegate.saveWaitingForWorker(output.logger, spec, event.Origin{
+		ID: output.id,
+	})
+
+	output.logger.Info("waiting-for-worker")
+}
+
+func (output *outputDelegate) RetryingAfterWorkerLoss(err error, attempt int, maxAttempts int) {
+	output.delegate.saveRetryingAfterWorkerLoss(output.logger, err, attempt, maxAttempts, event.Origin{
+		ID: output.id,
+	})
+
+	output.logger.Info("retrying-after-worker-loss", lager.Data{"error": err.Error(), "attempt": attempt})
+}
+
 func (output *outputDelegate) Stdout() io.Writer {
 	return output.delegate.eventWriter(event.Origin{
 		Source: event.OriginSourceStdout,
@@ -442,6 +501,22 @@ func (execution *executionDelegate) ImageVersionDetermined(identifier worker.Vol
 	return execution.delegate.build.SaveImageResourceVersion(atc.PlanID(execution.id), *identifier.ResourceCache)
 }
 
+func (execution *executionDelegate) WaitingForWorker(spec worker.WorkerSpec) {
+	execution.delegate.saveWaitingForWorker(execution.logger, spec, event.Origin{
+		ID: execution.id,
+	})
+
+	execution.logger.Info("waiting-for-worker")
+}
+
+func (execution *executionDelegate) RetryingAfterWorkerLoss(err error, attempt int, maxAttempts int) {
+	execution.delegate.saveRetryingAfterWorkerLoss(execution.logger, err, attempt, maxAttempts, event.Origin{
+		ID: execution.id,
+	})
+
+	execution.logger.Info("retrying-after-worker-loss", lager.Data{"error": err.Error(), "attempt": attempt})
+}
+
 func (execution *executionDelegate) Stdout() io.Writer {
 	return execution.delegate.eventWriter(event.Origin{
 		Source: event.OriginSourceStdout,
@@ -462,6 +462,52 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("WaitingForWorker", func() {
+			JustBeforeEach(func() {
+				inputDelegate.WaitingForWorker(worker.WorkerSpec{
+					Platform:     "some-platform",
+					Tags:         []string{"some", "tags"},
+					TeamID:       17,
+					ResourceType: "some-type",
+				})
+			})
+
+			It("saves a waiting-for-worker event with the worker spec", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.WaitingForWorker{}))
+
+				waitingEvent := savedEvent.(event.WaitingForWorker)
+				Expect(waitingEvent.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(waitingEvent.Origin).To(Equal(event.Origin{ID: originID}))
+				Expect(waitingEvent.Platform).To(Equal("some-platform"))
+				Expect(waitingEvent.Tags).To(Equal([]string{"some", "tags"}))
+				Expect(waitingEvent.TeamID).To(Equal(17))
+				Expect(waitingEvent.ResourceType).To(Equal("some-type"))
+			})
+		})
+
+		Describe("RetryingAfterWorkerLoss", func() {
+			JustBeforeEach(func() {
+				inputDelegate.RetryingAfterWorkerLoss(errors.New("worker went away"), 2, 3)
+			})
+
+			It("saves a retrying-after-worker-loss event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.RetryingAfterWorkerLoss{}))
+
+				retryingEvent := savedEvent.(event.RetryingAfterWorkerLoss)
+				Expect(retryingEvent.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(retryingEvent.Origin).To(Equal(event.Origin{ID: originID}))
+				Expect(retryingEvent.Message).To(Equal("worker went away"))
+				Expect(retryingEvent.Attempt).To(Equal(2))
+				Expect(retryingEvent.MaxAttempts).To(Equal(3))
+			})
+		})
+
 		Describe("Stdout", func() {
 			var writer io.Writer
 
@@ -753,6 +799,52 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("WaitingForWorker", func() {
+			JustBeforeEach(func() {
+				executionDelegate.WaitingForWorker(worker.WorkerSpec{
+					Platform:     "some-platform",
+					Tags:         []string{"some", "tags"},
+					TeamID:       17,
+					ResourceType: "some-type",
+				})
+			})
+
+			It("saves a waiting-for-worker event with the worker spec", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.WaitingForWorker{}))
+
+				waitingEvent := savedEvent.(event.WaitingForWorker)
+				Expect(waitingEvent.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(waitingEvent.Origin).To(Equal(event.Origin{ID: originID}))
+				Expect(waitingEvent.Platform).To(Equal("some-platform"))
+				Expect(waitingEvent.Tags).To(Equal([]string{"some", "tags"}))
+				Expect(waitingEvent.TeamID).To(Equal(17))
+				Expect(waitingEvent.ResourceType).To(Equal("some-type"))
+			})
+		})
+
+		Describe("RetryingAfterWorkerLoss", func() {
+			JustBeforeEach(func() {
+				executionDelegate.RetryingAfterWorkerLoss(errors.New("worker went away"), 2, 3)
+			})
+
+			It("saves a retrying-after-worker-loss event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.RetryingAfterWorkerLoss{}))
+
+				retryingEvent := savedEvent.(event.RetryingAfterWorkerLoss)
+				Expect(retryingEvent.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(retryingEvent.Origin).To(Equal(event.Origin{ID: originID}))
+				Expect(retryingEvent.Message).To(Equal("worker went away"))
+				Expect(retryingEvent.Attempt).To(Equal(2))
+				Expect(retryingEvent.MaxAttempts).To(Equal(3))
+			})
+		})
+
 		Describe("Stdout", func() {
 			var writer io.Writer
 
@@ -41,6 +41,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
 			"http://example.com",
+			1,
 		)
 
 		fakeDelegate = new(enginefakes.FakeBuildDelegate)
@@ -47,6 +47,7 @@ var _ = Describe("ExecEngine", func() {
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
 			"http://example.com",
+			1,
 		)
 	})
 
@@ -889,6 +890,106 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 
+			Context("when steps are retried on worker loss", func() {
+				BeforeEach(func() {
+					fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
+					fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
+					execEngine = engine.NewExecEngine(
+						fakeFactory,
+						fakeDelegateFactory,
+						fakeTeamDBFactory,
+						"http://example.com",
+						3,
+					)
+				})
+
+				Context("with a get whose worker is lost", func() {
+					BeforeEach(func() {
+						plan = planFactory.NewPlan(atc.GetPlan{
+							Name:     "some-input",
+							Resource: "some-input-resource",
+							Type:     "get",
+						})
+
+						runs := 0
+						inputStep.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+							runs++
+							if runs == 1 {
+								return worker.ErrMissingWorker
+							}
+
+							return nil
+						}
+					})
+
+					It("runs the get again", func() {
+						build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+
+						Expect(inputStepFactory.UsingCallCount()).To(Equal(2))
+						Expect(inputStep.RunCallCount()).To(Equal(2))
+					})
+
+					It("tells the delegate about the retry", func() {
+						build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+
+						Expect(fakeInputDelegate.RetryingAfterWorkerLossCallCount()).To(Equal(1))
+						retryErr, attempt, maxAttempts := fakeInputDelegate.RetryingAfterWorkerLossArgsForCall(0)
+						Expect(retryErr).To(Equal(worker.ErrMissingWorker))
+						Expect(attempt).To(Equal(2))
+						Expect(maxAttempts).To(Equal(3))
+					})
+				})
+
+				Context("with a task whose worker is lost", func() {
+					var taskPlan atc.TaskPlan
+
+					BeforeEach(func() {
+						taskPlan = atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-input/build.yml",
+						}
+
+						taskStep.RunReturns(worker.ErrMissingWorker)
+					})
+
+					JustBeforeEach(func() {
+						plan = planFactory.NewPlan(taskPlan)
+					})
+
+					It("does not run the task again", func() {
+						build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+
+						Expect(taskStep.RunCallCount()).To(Equal(1))
+						Expect(fakeExecutionDelegate.RetryingAfterWorkerLossCallCount()).To(BeZero())
+					})
+
+					Context("when the task is marked to retry on worker loss", func() {
+						BeforeEach(func() {
+							taskPlan.RetryOnWorkerLoss = true
+						})
+
+						It("runs the task until the attempts run out", func() {
+							build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+
+							Expect(taskStep.RunCallCount()).To(Equal(3))
+							Expect(fakeExecutionDelegate.RetryingAfterWorkerLossCallCount()).To(Equal(2))
+						})
+					})
+				})
+			})
+
 			Context("that contains outputs", func() {
 				var (
 					plan             atc.Plan
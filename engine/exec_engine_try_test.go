@@ -42,6 +42,7 @@ var _ = Describe("Exec Engine with Try", func() {
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
 			"http://example.com",
+			1,
 		)
 
 		fakeDelegate = new(enginefakes.FakeBuildDelegate)
@@ -155,3 +155,26 @@ type InitializePut struct {
 
 func (InitializePut) EventType() atc.EventType  { return EventTypeInitializePut }
 func (InitializePut) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForWorker struct {
+	Time         int64    `json:"time"`
+	Origin       Origin   `json:"origin"`
+	Platform     string   `json:"platform,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	TeamID       int      `json:"team_id,omitempty"`
+	ResourceType string   `json:"resource_type,omitempty"`
+}
+
+func (WaitingForWorker) EventType() atc.EventType  { return EventTypeWaitingForWorker }
+func (WaitingForWorker) Version() atc.EventVersion { return "1.0" }
+
+type RetryingAfterWorkerLoss struct {
+	Time        int64  `json:"time"`
+	Origin      Origin `json:"origin"`
+	Message     string `json:"message"`
+	Attempt     int    `json:"attempt"`
+	MaxAttempts int    `json:"max_attempts"`
+}
+
+func (RetryingAfterWorkerLoss) EventType() atc.EventType  { return EventTypeRetryingAfterWorkerLoss }
+func (RetryingAfterWorkerLoss) Version() atc.EventVersion { return "1.0" }
@@ -40,6 +40,8 @@ func init() {
 	registerEvent(FinishGet{})
 	registerEvent(InitializePut{})
 	registerEvent(FinishPut{})
+	registerEvent(WaitingForWorker{})
+	registerEvent(RetryingAfterWorkerLoss{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
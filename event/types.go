@@ -30,6 +30,12 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// step waiting for a compatible worker to become available
+	EventTypeWaitingForWorker atc.EventType = "waiting-for-worker"
+
+	// step is being re-run after its worker was lost
+	EventTypeRetryingAfterWorkerLoss atc.EventType = "retrying-after-worker-loss"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
@@ -32,6 +32,18 @@ type FakeGetDelegate struct {
 	imageVersionDeterminedReturns struct {
 		result1 error
 	}
+	WaitingForWorkerStub        func(worker.WorkerSpec)
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct {
+		arg1 worker.WorkerSpec
+	}
+	RetryingAfterWorkerLossStub        func(error, int, int)
+	retryingAfterWorkerLossMutex       sync.RWMutex
+	retryingAfterWorkerLossArgsForCall []struct {
+		arg1 error
+		arg2 int
+		arg3 int
+	}
 	StdoutStub        func() io.Writer
 	stdoutMutex       sync.RWMutex
 	stdoutArgsForCall []struct{}
@@ -146,6 +158,56 @@ func (fake *FakeGetDelegate) ImageVersionDeterminedReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeGetDelegate) WaitingForWorker(arg1 worker.WorkerSpec) {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
+		arg1 worker.WorkerSpec
+	}{arg1})
+	fake.recordInvocation("WaitingForWorker", []interface{}{arg1})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub(arg1)
+	}
+}
+
+func (fake *FakeGetDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeGetDelegate) WaitingForWorkerArgsForCall(i int) worker.WorkerSpec {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return fake.waitingForWorkerArgsForCall[i].arg1
+}
+
+func (fake *FakeGetDelegate) RetryingAfterWorkerLoss(arg1 error, arg2 int, arg3 int) {
+	fake.retryingAfterWorkerLossMutex.Lock()
+	fake.retryingAfterWorkerLossArgsForCall = append(fake.retryingAfterWorkerLossArgsForCall, struct {
+		arg1 error
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RetryingAfterWorkerLoss", []interface{}{arg1, arg2, arg3})
+	fake.retryingAfterWorkerLossMutex.Unlock()
+	if fake.RetryingAfterWorkerLossStub != nil {
+		fake.RetryingAfterWorkerLossStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeGetDelegate) RetryingAfterWorkerLossCallCount() int {
+	fake.retryingAfterWorkerLossMutex.RLock()
+	defer fake.retryingAfterWorkerLossMutex.RUnlock()
+	return len(fake.retryingAfterWorkerLossArgsForCall)
+}
+
+func (fake *FakeGetDelegate) RetryingAfterWorkerLossArgsForCall(i int) (error, int, int) {
+	fake.retryingAfterWorkerLossMutex.RLock()
+	defer fake.retryingAfterWorkerLossMutex.RUnlock()
+	return fake.retryingAfterWorkerLossArgsForCall[i].arg1, fake.retryingAfterWorkerLossArgsForCall[i].arg2, fake.retryingAfterWorkerLossArgsForCall[i].arg3
+}
+
 func (fake *FakeGetDelegate) Stdout() io.Writer {
 	fake.stdoutMutex.Lock()
 	fake.stdoutArgsForCall = append(fake.stdoutArgsForCall, struct{}{})
@@ -207,6 +269,10 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.failedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	fake.retryingAfterWorkerLossMutex.RLock()
+	defer fake.retryingAfterWorkerLossMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
 	fake.stderrMutex.RLock()
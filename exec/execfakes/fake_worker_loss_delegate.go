@@ -0,0 +1,68 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeWorkerLossDelegate struct {
+	RetryingAfterWorkerLossStub        func(error, int, int)
+	retryingAfterWorkerLossMutex       sync.RWMutex
+	retryingAfterWorkerLossArgsForCall []struct {
+		arg1 error
+		arg2 int
+		arg3 int
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWorkerLossDelegate) RetryingAfterWorkerLoss(arg1 error, arg2 int, arg3 int) {
+	fake.retryingAfterWorkerLossMutex.Lock()
+	fake.retryingAfterWorkerLossArgsForCall = append(fake.retryingAfterWorkerLossArgsForCall, struct {
+		arg1 error
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RetryingAfterWorkerLoss", []interface{}{arg1, arg2, arg3})
+	fake.retryingAfterWorkerLossMutex.Unlock()
+	if fake.RetryingAfterWorkerLossStub != nil {
+		fake.RetryingAfterWorkerLossStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeWorkerLossDelegate) RetryingAfterWorkerLossCallCount() int {
+	fake.retryingAfterWorkerLossMutex.RLock()
+	defer fake.retryingAfterWorkerLossMutex.RUnlock()
+	return len(fake.retryingAfterWorkerLossArgsForCall)
+}
+
+func (fake *FakeWorkerLossDelegate) RetryingAfterWorkerLossArgsForCall(i int) (error, int, int) {
+	fake.retryingAfterWorkerLossMutex.RLock()
+	defer fake.retryingAfterWorkerLossMutex.RUnlock()
+	return fake.retryingAfterWorkerLossArgsForCall[i].arg1, fake.retryingAfterWorkerLossArgsForCall[i].arg2, fake.retryingAfterWorkerLossArgsForCall[i].arg3
+}
+
+func (fake *FakeWorkerLossDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.retryingAfterWorkerLossMutex.RLock()
+	defer fake.retryingAfterWorkerLossMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeWorkerLossDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.WorkerLossDelegate = new(FakeWorkerLossDelegate)
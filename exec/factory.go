@@ -105,6 +105,8 @@ type TaskDelegate interface {
 	Failed(error)
 
 	ImageVersionDetermined(worker.VolumeIdentifier) error
+	WaitingForWorker(worker.WorkerSpec)
+	RetryingAfterWorkerLoss(error, int, int)
 
 	Stdout() io.Writer
 	Stderr() io.Writer
@@ -119,6 +121,8 @@ type ResourceDelegate interface {
 	Failed(error)
 
 	ImageVersionDetermined(worker.VolumeIdentifier) error
+	WaitingForWorker(worker.WorkerSpec)
+	RetryingAfterWorkerLoss(error, int, int)
 
 	Stdout() io.Writer
 	Stderr() io.Writer
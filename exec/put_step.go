@@ -103,6 +103,7 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	trackedResource, missingNames, err := step.tracker.InitWithSources(
 		step.logger,
+		signals,
 		step.stepMetadata,
 		runSession,
 		resource.ResourceType(step.resourceConfig.Type),
@@ -161,7 +161,7 @@ var _ = Describe("GardenFactory", func() {
 				It("initializes the resource with the correct type, session, and sources", func() {
 					Expect(fakeTracker.InitWithSourcesCallCount()).To(Equal(1))
 
-					_, sm, sid, typ, tags, actualTeamID, sources, actualResourceTypes, delegate := fakeTracker.InitWithSourcesArgsForCall(0)
+					_, _, sm, sid, typ, tags, actualTeamID, sources, actualResourceTypes, delegate := fakeTracker.InitWithSourcesArgsForCall(0)
 					Expect(sm).To(Equal(stepMetadata))
 					Expect(sid).To(Equal(resource.Session{
 						ID: worker.Identifier{
@@ -288,7 +288,7 @@ var _ = Describe("GardenFactory", func() {
 					BeforeEach(func() {
 						callCountDuringInit = make(chan int, 1)
 
-						fakeTracker.InitWithSourcesStub = func(lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, int, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, []string, error) {
+						fakeTracker.InitWithSourcesStub = func(lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, int, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, []string, error) {
 							callCountDuringInit <- putDelegate.InitializingCallCount()
 							return fakeResource, []string{"some-source", "some-other-source"}, nil
 						}
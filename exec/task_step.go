@@ -158,8 +158,11 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.logger.Session("found-container"),
 		runContainerID,
 	)
+	if err != nil {
+		return err
+	}
 
-	if err == nil && found {
+	if found {
 		exitStatusProp, err := step.container.Property(taskExitStatusPropertyName)
 		if err == nil {
 			step.logger.Info("already-exited", lager.Data{"status": exitStatusProp})
@@ -205,13 +208,27 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			workerSpec.ResourceType = config.ImageResource.Type
 		}
 
-		compatibleWorkers, err := step.workerPool.AllSatisfying(workerSpec, step.resourceTypes)
+		chosenWorker, err := step.workerPool.ChooseWorker(
+			step.logger,
+			signals,
+			step.delegate,
+			workerSpec,
+			step.resourceTypes,
+			worker.PlacementSpec{
+				Task:           true,
+				VolumeLocality: step.inputsLocality(config.Inputs),
+			},
+		)
+		if err == worker.ErrInterrupted {
+			return ErrInterrupted
+		}
+
 		if err != nil {
 			return err
 		}
 
 		var inputsToStream []inputPair
-		step.container, inputsToStream, err = step.createContainer(compatibleWorkers, config, signals)
+		step.container, inputsToStream, err = step.createContainer(chosenWorker, config, signals)
 
 		if err != nil {
 			return err
@@ -296,8 +313,8 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	}
 }
 
-func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config atc.TaskConfig, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
-	chosenWorker, inputMounts, inputsToStream, err := step.chooseWorkerWithMostVolumes(compatibleWorkers, config.Inputs)
+func (step *TaskStep) createContainer(chosenWorker worker.Worker, config atc.TaskConfig, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
+	inputMounts, inputsToStream, err := step.inputsOn(config.Inputs, chosenWorker)
 	if err != nil {
 		return nil, []inputPair{}, err
 	}
@@ -508,33 +525,19 @@ func (step *TaskStep) Release() {
 	}
 }
 
-func (step *TaskStep) chooseWorkerWithMostVolumes(compatibleWorkers []worker.Worker, inputs []atc.TaskInputConfig) (worker.Worker, []worker.VolumeMount, []inputPair, error) {
-	inputMounts := []worker.VolumeMount{}
-	inputsToStream := []inputPair{}
-
-	var chosenWorker worker.Worker
-	for _, w := range compatibleWorkers {
-		mounts, toStream, err := step.inputsOn(inputs, w)
+func (step *TaskStep) inputsLocality(inputs []atc.TaskInputConfig) worker.VolumeLocality {
+	return func(w worker.Worker) (int, error) {
+		mounts, _, err := step.inputsOn(inputs, w)
 		if err != nil {
-			return nil, nil, nil, err
+			return 0, err
 		}
 
-		if len(mounts) >= len(inputMounts) {
-			for _, mount := range inputMounts {
-				mount.Volume.Release(nil)
-			}
-
-			inputMounts = mounts
-			inputsToStream = toStream
-			chosenWorker = w
-		} else {
-			for _, mount := range mounts {
-				mount.Volume.Release(nil)
-			}
+		for _, mount := range mounts {
+			mount.Volume.Release(nil)
 		}
-	}
 
-	return chosenWorker, inputMounts, inputsToStream, nil
+		return len(mounts), nil
+	}
 }
 
 type inputPair struct {
@@ -142,9 +142,22 @@ var _ = Describe("GardenFactory", func() {
 			process = ifrit.Invoke(step)
 		})
 
+		Context("when looking up the container fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeWorkerClient.FindContainerForIdentifierReturns(nil, false, disaster)
+			})
+
+			It("exits with the error without starting over in a new container", func() {
+				Expect(<-process.Wait()).To(Equal(disaster))
+				Expect(fakeWorkerClient.ChooseWorkerCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when the container does not yet exist", func() {
 			BeforeEach(func() {
-				fakeWorkerClient.FindContainerForIdentifierReturns(nil, false, errors.New("nope"))
+				fakeWorkerClient.FindContainerForIdentifierReturns(nil, false, nil)
 			})
 
 			Context("when getting the config works", func() {
@@ -172,7 +185,7 @@ var _ = Describe("GardenFactory", func() {
 					disaster := errors.New("nope")
 
 					BeforeEach(func() {
-						fakeWorkerClient.AllSatisfyingReturns(nil, disaster)
+						fakeWorkerClient.ChooseWorkerReturns(nil, disaster)
 					})
 
 					It("exits with the error", func() {
@@ -180,12 +193,22 @@ var _ = Describe("GardenFactory", func() {
 					})
 				})
 
+				Context("when waiting for a worker is interrupted", func() {
+					BeforeEach(func() {
+						fakeWorkerClient.ChooseWorkerReturns(nil, worker.ErrInterrupted)
+					})
+
+					It("exits with ErrInterrupted", func() {
+						Expect(<-process.Wait()).To(Equal(ErrInterrupted))
+					})
+				})
+
 				Context("when a single worker can be located", func() {
 					var fakeWorker *wfakes.FakeWorker
 
 					BeforeEach(func() {
 						fakeWorker = new(wfakes.FakeWorker)
-						fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{fakeWorker}, nil)
+						fakeWorkerClient.ChooseWorkerReturns(fakeWorker, nil)
 					})
 
 					Context("when creating the task's container works", func() {
@@ -221,8 +244,10 @@ var _ = Describe("GardenFactory", func() {
 						})
 
 						It("found the worker with the right spec", func() {
-							Expect(fakeWorkerClient.AllSatisfyingCallCount()).To(Equal(1))
-							spec, actualResourceTypes := fakeWorkerClient.AllSatisfyingArgsForCall(0)
+							Expect(fakeWorkerClient.ChooseWorkerCallCount()).To(Equal(1))
+							_, _, delegate, spec, actualResourceTypes, placement := fakeWorkerClient.ChooseWorkerArgsForCall(0)
+							Expect(delegate).To(Equal(taskDelegate))
+							Expect(placement.Task).To(BeTrue())
 							Expect(spec.Platform).To(Equal("some-platform"))
 							Expect(spec.TeamID).To(Equal(teamID))
 							Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{
@@ -1960,7 +1985,7 @@ var _ = Describe("GardenFactory", func() {
 						fakeWorker2 = new(wfakes.FakeWorker)
 						fakeWorker3 = new(wfakes.FakeWorker)
 
-						fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{fakeWorker, fakeWorker2, fakeWorker3}, nil)
+						fakeWorkerClient.ChooseWorkerReturns(fakeWorker2, nil)
 					})
 
 					Context("when the configuration has inputs", func() {
@@ -2045,16 +2070,39 @@ var _ = Describe("GardenFactory", func() {
 									fakeWorker2.CreateContainerReturns(nil, errors.New("fall out of method here"))
 								})
 
-								It("picks the worker that has the most", func() {
+								It("chooses the worker with a locality counting the inputs present on each worker", func() {
+									Eventually(process.Wait()).Should(Receive())
+
+									Expect(fakeWorkerClient.ChooseWorkerCallCount()).To(Equal(1))
+									_, _, _, _, _, placement := fakeWorkerClient.ChooseWorkerArgsForCall(0)
+									Expect(placement.VolumeLocality).NotTo(BeNil())
+
+									Expect(placement.VolumeLocality(fakeWorker)).To(Equal(1))
+									Expect(placement.VolumeLocality(fakeWorker2)).To(Equal(2))
+									Expect(placement.VolumeLocality(fakeWorker3)).To(Equal(1))
+								})
+
+								It("creates the container on the chosen worker", func() {
 									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(0))
 									Expect(fakeWorker2.CreateContainerCallCount()).To(Equal(1))
 									Expect(fakeWorker3.CreateContainerCallCount()).To(Equal(0))
 								})
 
-								It("releases the volumes on the unused workers", func() {
+								It("releases the volumes counted for the locality", func() {
+									Eventually(process.Wait()).Should(Receive())
+
+									_, _, _, _, _, placement := fakeWorkerClient.ChooseWorkerArgsForCall(0)
+
+									_, err := placement.VolumeLocality(fakeWorker)
+									Expect(err).NotTo(HaveOccurred())
 									Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
+
+									_, err = placement.VolumeLocality(fakeWorker3)
+									Expect(err).NotTo(HaveOccurred())
 									Expect(inputVolume3.ReleaseCallCount()).To(Equal(1))
+								})
 
+								It("releases the volumes mounted on the chosen worker", func() {
 									Expect(inputVolume2.ReleaseCallCount()).To(Equal(1))
 									Expect(otherInputVolume.ReleaseCallCount()).To(Equal(1))
 								})
@@ -0,0 +1,113 @@
+package exec
+
+import (
+	"net"
+	"net/url"
+	"os"
+
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/transport"
+)
+
+//go:generate counterfeiter . WorkerLossDelegate
+
+// WorkerLossDelegate is used to record that a step is being re-run after the
+// worker it was running on was lost. It is given the error, the attempt about
+// to be made, and the maximum number of attempts.
+type WorkerLossDelegate interface {
+	RetryingAfterWorkerLoss(error, int, int)
+}
+
+// IsWorkerLossError returns true if the error indicates that the worker a step
+// was running on went away, i.e. it stalled, was deleted, or could no longer
+// be dialed, rather than the step itself failing. Other network errors, such
+// as a stream being cut short, are not enough to tell that the worker is gone.
+func IsWorkerLossError(err error) bool {
+	switch e := err.(type) {
+	case *url.Error:
+		return IsWorkerLossError(e.Err)
+	case transport.ErrMissingWorker, transport.ErrWorkerStalled:
+		return true
+	case *net.OpError:
+		return e.Op == "dial"
+	}
+
+	switch err {
+	case worker.ErrMissingWorker, worker.ErrDesiredWorkerNotRunning:
+		return true
+	}
+
+	return false
+}
+
+// RetryOnWorkerLoss constructs a Step that will re-run the step from scratch
+// if it errors because its worker was lost, up to MaxAttempts times in total.
+// It must only wrap steps that are safe to run more than once.
+type RetryOnWorkerLoss struct {
+	Step        StepFactory
+	MaxAttempts int
+	Delegate    WorkerLossDelegate
+}
+
+// Using constructs a *RetryOnWorkerLossStep.
+func (stepFactory RetryOnWorkerLoss) Using(prev Step, repo *SourceRepository) Step {
+	return &RetryOnWorkerLossStep{
+		step:        stepFactory.Step,
+		maxAttempts: stepFactory.MaxAttempts,
+		delegate:    stepFactory.Delegate,
+
+		prev: prev,
+		repo: repo,
+	}
+}
+
+// RetryOnWorkerLossStep is a step that re-runs its step when its worker is
+// lost.
+type RetryOnWorkerLossStep struct {
+	step        StepFactory
+	maxAttempts int
+	delegate    WorkerLossDelegate
+
+	prev Step
+	repo *SourceRepository
+
+	attempts []Step
+}
+
+// Run runs the step, constructing and running it again each time it errors
+// due to worker loss, until it either completes or the attempts run out.
+func (step *RetryOnWorkerLossStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	for attempt := 1; ; attempt++ {
+		attemptStep := step.step.Using(step.prev, step.repo)
+		step.attempts = append(step.attempts, attemptStep)
+
+		err := attemptStep.Run(signals, make(chan struct{}))
+		if err == nil || err == ErrInterrupted {
+			return err
+		}
+
+		if attempt >= step.maxAttempts || !IsWorkerLossError(err) {
+			return err
+		}
+
+		step.delegate.RetryingAfterWorkerLoss(err, attempt+1, step.maxAttempts)
+	}
+}
+
+// Release releases each attempt.
+func (step *RetryOnWorkerLossStep) Release() {
+	for _, attempt := range step.attempts {
+		attempt.Release()
+	}
+}
+
+// Result delegates to the latest attempt.
+func (step *RetryOnWorkerLossStep) Result(x interface{}) bool {
+	if len(step.attempts) == 0 {
+		return false
+	}
+
+	return step.attempts[len(step.attempts)-1].Result(x)
+}
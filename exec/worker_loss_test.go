@@ -0,0 +1,204 @@
+package exec_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/url"
+
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/transport"
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsWorkerLossError", func() {
+	It("is true for errors caused by the worker going away", func() {
+		Expect(IsWorkerLossError(worker.ErrMissingWorker)).To(BeTrue())
+		Expect(IsWorkerLossError(worker.ErrDesiredWorkerNotRunning)).To(BeTrue())
+		Expect(IsWorkerLossError(transport.ErrMissingWorker{WorkerName: "some-worker"})).To(BeTrue())
+		Expect(IsWorkerLossError(transport.ErrWorkerStalled{WorkerName: "some-worker"})).To(BeTrue())
+		Expect(IsWorkerLossError(&net.OpError{Op: "dial", Err: errors.New("connection refused")})).To(BeTrue())
+	})
+
+	It("looks inside url errors", func() {
+		Expect(IsWorkerLossError(&url.Error{
+			Op:  "Get",
+			URL: "http://some-worker",
+			Err: transport.ErrWorkerStalled{WorkerName: "some-worker"},
+		})).To(BeTrue())
+
+		Expect(IsWorkerLossError(&url.Error{
+			Op:  "Get",
+			URL: "http://some-worker",
+			Err: errors.New("nope"),
+		})).To(BeFalse())
+	})
+
+	It("is false for other errors", func() {
+		Expect(IsWorkerLossError(errors.New("nope"))).To(BeFalse())
+		Expect(IsWorkerLossError(ErrInterrupted)).To(BeFalse())
+	})
+
+	It("is false for errors that do not show that the worker is gone", func() {
+		Expect(IsWorkerLossError(io.ErrUnexpectedEOF)).To(BeFalse())
+		Expect(IsWorkerLossError(&net.OpError{Op: "read", Err: errors.New("connection reset by peer")})).To(BeFalse())
+	})
+})
+
+var _ = Describe("RetryOnWorkerLoss Step", func() {
+	var (
+		fakeStepFactory *execfakes.FakeStepFactory
+		attempt1Step    *execfakes.FakeStep
+		attempt2Step    *execfakes.FakeStep
+		attempt3Step    *execfakes.FakeStep
+		fakeDelegate    *execfakes.FakeWorkerLossDelegate
+
+		repo *SourceRepository
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		attempt1Step = new(execfakes.FakeStep)
+		attempt2Step = new(execfakes.FakeStep)
+		attempt3Step = new(execfakes.FakeStep)
+
+		attempts := []Step{attempt1Step, attempt2Step, attempt3Step}
+
+		fakeStepFactory = new(execfakes.FakeStepFactory)
+		fakeStepFactory.UsingStub = func(Step, *SourceRepository) Step {
+			return attempts[fakeStepFactory.UsingCallCount()-1]
+		}
+
+		fakeDelegate = new(execfakes.FakeWorkerLossDelegate)
+
+		repo = NewSourceRepository()
+
+		step = RetryOnWorkerLoss{
+			Step:        fakeStepFactory,
+			MaxAttempts: 3,
+			Delegate:    fakeDelegate,
+		}.Using(nil, repo)
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Invoke(step)
+	})
+
+	Context("when the first attempt succeeds", func() {
+		It("only runs it once", func() {
+			Expect(<-process.Wait()).ToNot(HaveOccurred())
+
+			Expect(fakeStepFactory.UsingCallCount()).To(Equal(1))
+			Expect(attempt1Step.RunCallCount()).To(Equal(1))
+			Expect(fakeDelegate.RetryingAfterWorkerLossCallCount()).To(BeZero())
+		})
+
+		It("constructs it with the source repository", func() {
+			<-process.Wait()
+
+			_, actualRepo := fakeStepFactory.UsingArgsForCall(0)
+			Expect(actualRepo).To(Equal(repo))
+		})
+	})
+
+	Context("when the first attempt loses its worker", func() {
+		BeforeEach(func() {
+			attempt1Step.RunReturns(worker.ErrMissingWorker)
+		})
+
+		It("runs a new attempt", func() {
+			Expect(<-process.Wait()).ToNot(HaveOccurred())
+
+			Expect(fakeStepFactory.UsingCallCount()).To(Equal(2))
+			Expect(attempt1Step.RunCallCount()).To(Equal(1))
+			Expect(attempt2Step.RunCallCount()).To(Equal(1))
+		})
+
+		It("tells the delegate about the retry", func() {
+			<-process.Wait()
+
+			Expect(fakeDelegate.RetryingAfterWorkerLossCallCount()).To(Equal(1))
+			err, attempt, maxAttempts := fakeDelegate.RetryingAfterWorkerLossArgsForCall(0)
+			Expect(err).To(Equal(worker.ErrMissingWorker))
+			Expect(attempt).To(Equal(2))
+			Expect(maxAttempts).To(Equal(3))
+		})
+
+		Describe("Result", func() {
+			It("delegates to the latest attempt", func() {
+				<-process.Wait()
+
+				attempt2Step.ResultReturns(true)
+
+				var foo interface{}
+				destination := &foo
+				Expect(step.Result(destination)).To(BeTrue())
+
+				Expect(attempt1Step.ResultCallCount()).To(BeZero())
+				Expect(attempt2Step.ResultCallCount()).To(Equal(1))
+				Expect(attempt2Step.ResultArgsForCall(0)).To(Equal(destination))
+			})
+		})
+
+		Describe("Release", func() {
+			It("releases every attempt", func() {
+				<-process.Wait()
+
+				step.Release()
+
+				Expect(attempt1Step.ReleaseCallCount()).To(Equal(1))
+				Expect(attempt2Step.ReleaseCallCount()).To(Equal(1))
+				Expect(attempt3Step.ReleaseCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when every attempt loses its worker", func() {
+		BeforeEach(func() {
+			attempt1Step.RunReturns(worker.ErrMissingWorker)
+			attempt2Step.RunReturns(worker.ErrDesiredWorkerNotRunning)
+			attempt3Step.RunReturns(transport.ErrWorkerStalled{WorkerName: "some-worker"})
+		})
+
+		It("returns the last error", func() {
+			Expect(<-process.Wait()).To(Equal(transport.ErrWorkerStalled{WorkerName: "some-worker"}))
+
+			Expect(fakeStepFactory.UsingCallCount()).To(Equal(3))
+			Expect(fakeDelegate.RetryingAfterWorkerLossCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when an attempt errors for another reason", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			attempt1Step.RunReturns(disaster)
+		})
+
+		It("returns the error without retrying", func() {
+			Expect(<-process.Wait()).To(Equal(disaster))
+
+			Expect(fakeStepFactory.UsingCallCount()).To(Equal(1))
+			Expect(fakeDelegate.RetryingAfterWorkerLossCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when an attempt is interrupted", func() {
+		BeforeEach(func() {
+			attempt1Step.RunReturns(ErrInterrupted)
+		})
+
+		It("returns ErrInterrupted without retrying", func() {
+			Expect(<-process.Wait()).To(Equal(ErrInterrupted))
+
+			Expect(fakeStepFactory.UsingCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package dbgc
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 )
 
@@ -10,6 +12,9 @@ type ReaperDB interface {
 	ReapExpiredContainers() error
 	ReapExpiredVolumes() error
 	ReapExpiredWorkers() error
+	ReapExpiredResourceChecks(retention time.Duration) error
+	ReapExpiredSessionRevocations(retention time.Duration) error
+	ReapExpiredSessions() error
 }
 
 type DBGarbageCollector interface {
@@ -19,15 +24,23 @@ type DBGarbageCollector interface {
 type dbGarbageCollector struct {
 	logger lager.Logger
 	db     ReaperDB
+
+	resourceCheckRetention     time.Duration
+	sessionRevocationRetention time.Duration
 }
 
 func NewDBGarbageCollector(
 	logger lager.Logger,
 	db ReaperDB,
+	resourceCheckRetention time.Duration,
+	sessionRevocationRetention time.Duration,
 ) DBGarbageCollector {
 	return &dbGarbageCollector{
 		logger: logger,
 		db:     db,
+
+		resourceCheckRetention:     resourceCheckRetention,
+		sessionRevocationRetention: sessionRevocationRetention,
 	}
 }
 
@@ -44,5 +57,25 @@ func (c *dbGarbageCollector) Run() error {
 		return err
 	}
 
+	if c.resourceCheckRetention > 0 {
+		err = c.db.ReapExpiredResourceChecks(c.resourceCheckRetention)
+		if err != nil {
+			c.logger.Error("failed-to-reap-expired-resource-checks", err)
+			return err
+		}
+	}
+
+	err = c.db.ReapExpiredSessionRevocations(c.sessionRevocationRetention)
+	if err != nil {
+		c.logger.Error("failed-to-reap-expired-session-revocations", err)
+		return err
+	}
+
+	err = c.db.ReapExpiredSessions()
+	if err != nil {
+		c.logger.Error("failed-to-reap-expired-sessions", err)
+		return err
+	}
+
 	return nil
 }
@@ -1,6 +1,9 @@
 package dbgc_test
 
 import (
+	"errors"
+	"time"
+
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc/gc/dbgc"
 	"github.com/concourse/atc/gc/dbgc/dbgcfakes"
@@ -16,7 +19,7 @@ var _ = Describe("DbGarbageCollector", func() {
 	BeforeEach(func() {
 		logger := lagertest.NewTestLogger("dbgc")
 		fakeDB = new(dbgcfakes.FakeReaperDB)
-		dbGarbageCollector = dbgc.NewDBGarbageCollector(logger, fakeDB)
+		dbGarbageCollector = dbgc.NewDBGarbageCollector(logger, fakeDB, 24*time.Hour, 12*time.Hour)
 	})
 
 	Describe("Run", func() {
@@ -27,5 +30,73 @@ var _ = Describe("DbGarbageCollector", func() {
 			Expect(fakeDB.ReapExpiredContainersCallCount()).To(Equal(1))
 			Expect(fakeDB.ReapExpiredVolumesCallCount()).To(Equal(1))
 		})
+
+		It("reaps resource checks older than the retention period", func() {
+			err := dbGarbageCollector.Run()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeDB.ReapExpiredResourceChecksCallCount()).To(Equal(1))
+			Expect(fakeDB.ReapExpiredResourceChecksArgsForCall(0)).To(Equal(24 * time.Hour))
+		})
+
+		Context("when reaping resource checks fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDB.ReapExpiredResourceChecksReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(dbGarbageCollector.Run()).To(Equal(disaster))
+			})
+		})
+
+		It("reaps session revocations older than the longest session", func() {
+			err := dbGarbageCollector.Run()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeDB.ReapExpiredSessionRevocationsCallCount()).To(Equal(1))
+			Expect(fakeDB.ReapExpiredSessionRevocationsArgsForCall(0)).To(Equal(12 * time.Hour))
+		})
+
+		Context("when reaping session revocations fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDB.ReapExpiredSessionRevocationsReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(dbGarbageCollector.Run()).To(Equal(disaster))
+			})
+		})
+
+		It("reaps expired sessions", func() {
+			err := dbGarbageCollector.Run()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeDB.ReapExpiredSessionsCallCount()).To(Equal(1))
+		})
+
+		Context("when reaping sessions fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDB.ReapExpiredSessionsReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(dbGarbageCollector.Run()).To(Equal(disaster))
+			})
+		})
+
+		Context("when there is no retention period", func() {
+			BeforeEach(func() {
+				dbGarbageCollector = dbgc.NewDBGarbageCollector(lagertest.NewTestLogger("dbgc"), fakeDB, 0, 12*time.Hour)
+			})
+
+			It("keeps all resource checks", func() {
+				err := dbGarbageCollector.Run()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeDB.ReapExpiredResourceChecksCallCount()).To(BeZero())
+			})
+		})
 	})
 })
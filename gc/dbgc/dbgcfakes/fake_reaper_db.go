@@ -3,6 +3,7 @@ package dbgcfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/gc/dbgc"
 )
@@ -26,6 +27,28 @@ type FakeReaperDB struct {
 	reapExpiredWorkersReturns     struct {
 		result1 error
 	}
+	ReapExpiredResourceChecksStub        func(retention time.Duration) error
+	reapExpiredResourceChecksMutex       sync.RWMutex
+	reapExpiredResourceChecksArgsForCall []struct {
+		retention time.Duration
+	}
+	reapExpiredResourceChecksReturns struct {
+		result1 error
+	}
+	ReapExpiredSessionRevocationsStub        func(retention time.Duration) error
+	reapExpiredSessionRevocationsMutex       sync.RWMutex
+	reapExpiredSessionRevocationsArgsForCall []struct {
+		retention time.Duration
+	}
+	reapExpiredSessionRevocationsReturns struct {
+		result1 error
+	}
+	ReapExpiredSessionsStub        func() error
+	reapExpiredSessionsMutex       sync.RWMutex
+	reapExpiredSessionsArgsForCall []struct{}
+	reapExpiredSessionsReturns     struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -105,6 +128,97 @@ func (fake *FakeReaperDB) ReapExpiredWorkersReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeReaperDB) ReapExpiredResourceChecks(retention time.Duration) error {
+	fake.reapExpiredResourceChecksMutex.Lock()
+	fake.reapExpiredResourceChecksArgsForCall = append(fake.reapExpiredResourceChecksArgsForCall, struct {
+		retention time.Duration
+	}{retention})
+	fake.recordInvocation("ReapExpiredResourceChecks", []interface{}{retention})
+	fake.reapExpiredResourceChecksMutex.Unlock()
+	if fake.ReapExpiredResourceChecksStub != nil {
+		return fake.ReapExpiredResourceChecksStub(retention)
+	} else {
+		return fake.reapExpiredResourceChecksReturns.result1
+	}
+}
+
+func (fake *FakeReaperDB) ReapExpiredResourceChecksCallCount() int {
+	fake.reapExpiredResourceChecksMutex.RLock()
+	defer fake.reapExpiredResourceChecksMutex.RUnlock()
+	return len(fake.reapExpiredResourceChecksArgsForCall)
+}
+
+func (fake *FakeReaperDB) ReapExpiredResourceChecksArgsForCall(i int) time.Duration {
+	fake.reapExpiredResourceChecksMutex.RLock()
+	defer fake.reapExpiredResourceChecksMutex.RUnlock()
+	return fake.reapExpiredResourceChecksArgsForCall[i].retention
+}
+
+func (fake *FakeReaperDB) ReapExpiredResourceChecksReturns(result1 error) {
+	fake.ReapExpiredResourceChecksStub = nil
+	fake.reapExpiredResourceChecksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReaperDB) ReapExpiredSessionRevocations(retention time.Duration) error {
+	fake.reapExpiredSessionRevocationsMutex.Lock()
+	fake.reapExpiredSessionRevocationsArgsForCall = append(fake.reapExpiredSessionRevocationsArgsForCall, struct {
+		retention time.Duration
+	}{retention})
+	fake.recordInvocation("ReapExpiredSessionRevocations", []interface{}{retention})
+	fake.reapExpiredSessionRevocationsMutex.Unlock()
+	if fake.ReapExpiredSessionRevocationsStub != nil {
+		return fake.ReapExpiredSessionRevocationsStub(retention)
+	} else {
+		return fake.reapExpiredSessionRevocationsReturns.result1
+	}
+}
+
+func (fake *FakeReaperDB) ReapExpiredSessionRevocationsCallCount() int {
+	fake.reapExpiredSessionRevocationsMutex.RLock()
+	defer fake.reapExpiredSessionRevocationsMutex.RUnlock()
+	return len(fake.reapExpiredSessionRevocationsArgsForCall)
+}
+
+func (fake *FakeReaperDB) ReapExpiredSessionRevocationsArgsForCall(i int) time.Duration {
+	fake.reapExpiredSessionRevocationsMutex.RLock()
+	defer fake.reapExpiredSessionRevocationsMutex.RUnlock()
+	return fake.reapExpiredSessionRevocationsArgsForCall[i].retention
+}
+
+func (fake *FakeReaperDB) ReapExpiredSessionRevocationsReturns(result1 error) {
+	fake.ReapExpiredSessionRevocationsStub = nil
+	fake.reapExpiredSessionRevocationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReaperDB) ReapExpiredSessions() error {
+	fake.reapExpiredSessionsMutex.Lock()
+	fake.reapExpiredSessionsArgsForCall = append(fake.reapExpiredSessionsArgsForCall, struct{}{})
+	fake.recordInvocation("ReapExpiredSessions", []interface{}{})
+	fake.reapExpiredSessionsMutex.Unlock()
+	if fake.ReapExpiredSessionsStub != nil {
+		return fake.ReapExpiredSessionsStub()
+	} else {
+		return fake.reapExpiredSessionsReturns.result1
+	}
+}
+
+func (fake *FakeReaperDB) ReapExpiredSessionsCallCount() int {
+	fake.reapExpiredSessionsMutex.RLock()
+	defer fake.reapExpiredSessionsMutex.RUnlock()
+	return len(fake.reapExpiredSessionsArgsForCall)
+}
+
+func (fake *FakeReaperDB) ReapExpiredSessionsReturns(result1 error) {
+	fake.ReapExpiredSessionsStub = nil
+	fake.reapExpiredSessionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReaperDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -114,6 +228,12 @@ func (fake *FakeReaperDB) Invocations() map[string][][]interface{} {
 	defer fake.reapExpiredVolumesMutex.RUnlock()
 	fake.reapExpiredWorkersMutex.RLock()
 	defer fake.reapExpiredWorkersMutex.RUnlock()
+	fake.reapExpiredResourceChecksMutex.RLock()
+	defer fake.reapExpiredResourceChecksMutex.RUnlock()
+	fake.reapExpiredSessionRevocationsMutex.RLock()
+	defer fake.reapExpiredSessionRevocationsMutex.RUnlock()
+	fake.reapExpiredSessionsMutex.RLock()
+	defer fake.reapExpiredSessionsMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 
@@ -34,6 +35,7 @@ type baggageCollector struct {
 	pipelineDBFactory                   db.PipelineDBFactory
 	oldResourceGracePeriod              time.Duration
 	oneOffBuildImageResourceGracePeriod time.Duration
+	diskHighWatermark                   float64
 }
 
 func (bc *baggageCollector) Run() error {
@@ -48,6 +50,14 @@ func (bc *baggageCollector) Run() error {
 	if err != nil {
 		return err
 	}
+
+	if bc.diskHighWatermark > 0 {
+		err = bc.relieveDiskPressure()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -262,6 +272,129 @@ func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error
 	return nil
 }
 
+// relieveDiskPressure destroys the least-recently-used resource caches on
+// each worker whose disk usage is above the high watermark, until its usage
+// is estimated to be back under it. Caches with copies still in use are left
+// alone.
+func (bc *baggageCollector) relieveDiskPressure() error {
+	logger := bc.logger.Session("relieve-disk-pressure")
+
+	workers, err := bc.workerClient.RunningWorkers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	var workersUnderPressure []worker.Worker
+	for _, w := range workers {
+		if worker.AboveDiskWatermark(w, bc.diskHighWatermark) {
+			workersUnderPressure = append(workersUnderPressure, w)
+		}
+	}
+
+	if len(workersUnderPressure) == 0 {
+		return nil
+	}
+
+	savedVolumes, err := bc.db.GetVolumes()
+	if err != nil {
+		logger.Error("could-not-get-volume-data", err)
+		return err
+	}
+
+	parentHandles := map[string]bool{}
+	for _, savedVolume := range savedVolumes {
+		if savedVolume.Volume.Identifier.COW != nil {
+			parentHandles[savedVolume.Volume.Identifier.COW.ParentVolumeHandle] = true
+		}
+	}
+
+	for _, w := range workersUnderPressure {
+		candidates := []db.SavedVolume{}
+		for _, savedVolume := range savedVolumes {
+			if savedVolume.WorkerName != w.Name() ||
+				savedVolume.Volume.Identifier.ResourceCache == nil ||
+				savedVolume.ContainerTTL != nil ||
+				parentHandles[savedVolume.Handle] {
+				continue
+			}
+
+			candidates = append(candidates, savedVolume)
+		}
+
+		sort.Sort(sortByLastUsed(candidates))
+
+		bc.evictVolumes(logger.Session("worker", lager.Data{"worker-name": w.Name()}), w, candidates)
+	}
+
+	return nil
+}
+
+func (bc *baggageCollector) evictVolumes(logger lager.Logger, volumeWorker worker.Worker, candidates []db.SavedVolume) {
+	target := int64(float64(volumeWorker.DiskCapacityInBytes()) * bc.diskHighWatermark)
+	usage := volumeWorker.DiskUsageInBytes()
+
+	var evicted int
+	var evictedBytes int64
+
+	for _, candidate := range candidates {
+		if usage <= target {
+			break
+		}
+
+		vLogger := logger.Session("volume", lager.Data{
+			"handle":       candidate.Handle,
+			"last-used-at": candidate.LastUsedAt,
+			"size":         candidate.SizeInBytes,
+		})
+
+		volume, found, err := volumeWorker.LookupVolume(vLogger, candidate.Handle)
+		if err != nil {
+			vLogger.Error("failed-to-lookup-volume", err)
+			continue
+		}
+
+		if found {
+			err = volume.Destroy()
+			volume.Release(nil)
+
+			if err != nil {
+				vLogger.Error("failed-to-destroy-volume", err)
+				continue
+			}
+		}
+
+		err = bc.db.ReapVolume(candidate.Handle)
+		if err != nil {
+			vLogger.Error("failed-to-delete-volume-from-database", err)
+		}
+
+		vLogger.Info("evicted")
+
+		usage -= candidate.SizeInBytes
+		evicted++
+		evictedBytes += candidate.SizeInBytes
+	}
+
+	if evicted > 0 {
+		metric.VolumesEvicted{
+			WorkerName:   volumeWorker.Name(),
+			Volumes:      evicted,
+			BytesEvicted: evictedBytes,
+		}.Emit(logger)
+	}
+
+	if usage > target {
+		logger.Info("still-above-high-watermark", lager.Data{
+			"estimated-usage": usage,
+			"target":          target,
+		})
+	}
+}
+
+// NewBaggageCollector constructs a BaggageCollector. If diskHighWatermark,
+// a fraction between 0 and 1, is non-zero, least-recently-used resource
+// caches are evicted from workers whose disk usage is above it.
 func NewBaggageCollector(
 	logger lager.Logger,
 	workerClient worker.Client,
@@ -269,6 +402,7 @@ func NewBaggageCollector(
 	pipelineDBFactory db.PipelineDBFactory,
 	oldResourceGracePeriod time.Duration,
 	oneOffBuildImageResourceGracePeriod time.Duration,
+	diskHighWatermark float64,
 ) BaggageCollector {
 	return &baggageCollector{
 		logger:                              logger,
@@ -277,6 +411,7 @@ func NewBaggageCollector(
 		pipelineDBFactory:                   pipelineDBFactory,
 		oldResourceGracePeriod:              oldResourceGracePeriod,
 		oneOffBuildImageResourceGracePeriod: oneOffBuildImageResourceGracePeriod,
+		diskHighWatermark:                   diskHighWatermark,
 	}
 }
 
@@ -285,3 +420,9 @@ type sortByHandle []db.SavedVolume
 func (s sortByHandle) Len() int           { return len(s) }
 func (s sortByHandle) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 func (s sortByHandle) Less(i, j int) bool { return s[i].Volume.Handle < s[j].Volume.Handle }
+
+type sortByLastUsed []db.SavedVolume
+
+func (s sortByLastUsed) Len() int           { return len(s) }
+func (s sortByLastUsed) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortByLastUsed) Less(i, j int) bool { return s[i].LastUsedAt.Before(s[j].LastUsedAt) }
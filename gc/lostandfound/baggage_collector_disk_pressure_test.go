@@ -0,0 +1,221 @@
+package lostandfound_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc/lostandfound"
+	"github.com/concourse/atc/gc/lostandfound/lostandfoundfakes"
+	"github.com/concourse/atc/worker"
+	wfakes "github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volumes are evicted under disk pressure", func() {
+	var (
+		fakeWorkerClient       *wfakes.FakeClient
+		fakeWorker             *wfakes.FakeWorker
+		fakeBaggageCollectorDB *lostandfoundfakes.FakeBaggageCollectorDB
+		fakePipelineDBFactory  *dbfakes.FakePipelineDBFactory
+
+		oldResourceGracePeriod = 4 * time.Minute
+		diskHighWatermark      float64
+
+		lastUsed time.Time
+
+		volumes      map[string]*wfakes.FakeVolume
+		lookedUp     []string
+		savedVolumes []db.SavedVolume
+
+		baggageCollector lostandfound.BaggageCollector
+		runErr           error
+	)
+
+	cacheVolume := func(handle string, workerName string, lastUsedAt time.Time, size int64) db.SavedVolume {
+		return db.SavedVolume{
+			Volume: db.Volume{
+				Handle:      handle,
+				WorkerName:  workerName,
+				TTL:         oldResourceGracePeriod,
+				SizeInBytes: size,
+				Identifier: db.VolumeIdentifier{
+					ResourceCache: &db.ResourceCacheIdentifier{
+						ResourceVersion: atc.Version{"some": handle},
+						ResourceHash:    "some-hash",
+					},
+				},
+			},
+			LastUsedAt: lastUsedAt,
+		}
+	}
+
+	BeforeEach(func() {
+		fakeWorkerClient = new(wfakes.FakeClient)
+		fakeBaggageCollectorDB = new(lostandfoundfakes.FakeBaggageCollectorDB)
+		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
+
+		fakeWorker = new(wfakes.FakeWorker)
+		fakeWorker.NameReturns("some-worker")
+		fakeWorker.DiskCapacityInBytesReturns(1000)
+		fakeWorker.DiskUsageInBytesReturns(950)
+
+		fakeWorkerClient.RunningWorkersReturns([]worker.Worker{fakeWorker}, nil)
+
+		diskHighWatermark = 0.9
+
+		lastUsed = time.Now()
+
+		volumes = map[string]*wfakes.FakeVolume{}
+		lookedUp = []string{}
+		fakeWorker.LookupVolumeStub = func(_ lager.Logger, handle string) (worker.Volume, bool, error) {
+			lookedUp = append(lookedUp, handle)
+
+			volume, found := volumes[handle]
+			if !found {
+				return nil, false, nil
+			}
+
+			return volume, true, nil
+		}
+
+		for _, handle := range []string{"older-cache", "old-cache", "newest-cache", "parent-cache"} {
+			volumes[handle] = new(wfakes.FakeVolume)
+		}
+
+		savedVolumes = []db.SavedVolume{
+			cacheVolume("newest-cache", "some-worker", lastUsed.Add(time.Hour), 100),
+			cacheVolume("old-cache", "some-worker", lastUsed, 30),
+			cacheVolume("older-cache", "some-worker", lastUsed.Add(-time.Hour), 30),
+			cacheVolume("parent-cache", "some-worker", lastUsed.Add(-3*time.Hour), 500),
+			cacheVolume("other-worker-cache", "other-worker", lastUsed.Add(-2*time.Hour), 500),
+			{
+				Volume: db.Volume{
+					Handle:     "copy-of-parent",
+					WorkerName: "some-worker",
+					TTL:        oldResourceGracePeriod,
+					Identifier: db.VolumeIdentifier{
+						COW: &db.COWIdentifier{
+							ParentVolumeHandle: "parent-cache",
+						},
+					},
+				},
+				LastUsedAt: lastUsed.Add(-4 * time.Hour),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeBaggageCollectorDB.GetVolumesReturns(savedVolumes, nil)
+
+		baggageCollector = lostandfound.NewBaggageCollector(
+			lagertest.NewTestLogger("test"),
+			fakeWorkerClient,
+			fakeBaggageCollectorDB,
+			fakePipelineDBFactory,
+			oldResourceGracePeriod,
+			5*time.Hour,
+			diskHighWatermark,
+		)
+
+		runErr = baggageCollector.Run()
+	})
+
+	It("succeeds", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+	})
+
+	It("destroys the least-recently-used caches until usage is under the watermark", func() {
+		Expect(lookedUp).To(Equal([]string{"older-cache", "old-cache"}))
+
+		Expect(volumes["older-cache"].DestroyCallCount()).To(Equal(1))
+		Expect(volumes["older-cache"].ReleaseCallCount()).To(Equal(1))
+		Expect(volumes["old-cache"].DestroyCallCount()).To(Equal(1))
+		Expect(volumes["newest-cache"].DestroyCallCount()).To(BeZero())
+	})
+
+	It("does not destroy caches with copies of them still around", func() {
+		Expect(volumes["parent-cache"].DestroyCallCount()).To(BeZero())
+	})
+
+	It("removes the evicted volumes from the database", func() {
+		Expect(fakeBaggageCollectorDB.ReapVolumeCallCount()).To(Equal(2))
+		Expect(fakeBaggageCollectorDB.ReapVolumeArgsForCall(0)).To(Equal("older-cache"))
+		Expect(fakeBaggageCollectorDB.ReapVolumeArgsForCall(1)).To(Equal("old-cache"))
+	})
+
+	Context("when a volume can no longer be found on the worker", func() {
+		BeforeEach(func() {
+			delete(volumes, "older-cache")
+		})
+
+		It("removes it from the database and counts it as evicted", func() {
+			Expect(lookedUp).To(Equal([]string{"older-cache", "old-cache"}))
+			Expect(fakeBaggageCollectorDB.ReapVolumeCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when destroying a volume fails", func() {
+		BeforeEach(func() {
+			volumes["older-cache"].DestroyReturns(errors.New("nope"))
+		})
+
+		It("keeps it in the database and moves on to the next volume", func() {
+			Expect(lookedUp).To(Equal([]string{"older-cache", "old-cache", "newest-cache"}))
+			Expect(volumes["older-cache"].ReleaseCallCount()).To(Equal(1))
+			Expect(fakeBaggageCollectorDB.ReapVolumeCallCount()).To(Equal(2))
+			Expect(fakeBaggageCollectorDB.ReapVolumeArgsForCall(0)).To(Equal("old-cache"))
+			Expect(fakeBaggageCollectorDB.ReapVolumeArgsForCall(1)).To(Equal("newest-cache"))
+		})
+	})
+
+	Context("when the worker is under the watermark", func() {
+		BeforeEach(func() {
+			fakeWorker.DiskUsageInBytesReturns(800)
+		})
+
+		It("does not evict anything", func() {
+			Expect(lookedUp).To(BeEmpty())
+			Expect(fakeBaggageCollectorDB.ReapVolumeCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the worker does not report its disk capacity", func() {
+		BeforeEach(func() {
+			fakeWorker.DiskCapacityInBytesReturns(0)
+		})
+
+		It("does not evict anything", func() {
+			Expect(lookedUp).To(BeEmpty())
+		})
+	})
+
+	Context("when there is no watermark", func() {
+		BeforeEach(func() {
+			diskHighWatermark = 0
+		})
+
+		It("does not evict anything", func() {
+			Expect(lookedUp).To(BeEmpty())
+			Expect(fakeBaggageCollectorDB.ReapVolumeCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the workers fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeWorkerClient.RunningWorkersReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
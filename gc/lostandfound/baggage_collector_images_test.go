@@ -79,6 +79,7 @@ var _ = Describe("Baggage-collecting image resource volumes", func() {
 				fakePipelineDBFactory,
 				expectedOldVersionTTL,
 				expectedOneOffTTL,
+				0,
 			)
 
 			savedPipeline = db.SavedPipeline{
@@ -276,6 +277,7 @@ var _ = Describe("Baggage-collecting image resource volumes", func() {
 				fakePipelineDBFactory,
 				expectedOldVersionTTL,
 				expectedOneOffTTL,
+				0,
 			)
 
 			savedPipeline = db.SavedPipeline{
@@ -71,6 +71,7 @@ var _ = Describe("Baggage-collecting image resource volumes created by one-off b
 			fakePipelineDBFactory,
 			expectedOldVersionTTL,
 			expectedOneOffTTL,
+			0,
 		)
 
 		savedPipeline = db.SavedPipeline{
@@ -71,6 +71,7 @@ var _ = Describe("Baggage Collector", func() {
 				fakePipelineDBFactory,
 				expectedOldResourceGracePeriod,
 				expectedOneOffTTL,
+				0,
 			)
 
 			fakeWorker.FindResourceTypeByPathStub = func(path string) (atc.WorkerResourceType, bool) {
@@ -51,6 +51,7 @@ var _ = Describe("Volumes are reaped", func() {
 			fakePipelineDBFactory,
 			expectedOldResourceGracePeriod,
 			expectedOneOffTTL,
+			0,
 		)
 
 		returnedSavedVolume = db.SavedVolume{
@@ -0,0 +1,146 @@
+package gcng
+
+import (
+	"code.cloudfoundry.org/garden"
+	gclient "code.cloudfoundry.org/garden/client"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/transport"
+	"github.com/concourse/retryhttp"
+)
+
+//go:generate counterfeiter . GardenClientFactory
+
+type GardenClientFactory interface {
+	NewClient(apiURL string, workerName string) garden.Client
+}
+
+type gardenClientFactory struct {
+	db                  transport.TransportDB
+	logger              lager.Logger
+	retryBackOffFactory retryhttp.BackOffFactory
+}
+
+func NewGardenClientFactory(
+	db transport.TransportDB,
+	logger lager.Logger,
+	retryBackOffFactory retryhttp.BackOffFactory,
+) GardenClientFactory {
+	return &gardenClientFactory{
+		db:                  db,
+		logger:              logger,
+		retryBackOffFactory: retryBackOffFactory,
+	}
+}
+
+func (f *gardenClientFactory) NewClient(apiURL string, workerName string) garden.Client {
+	gcf := worker.NewGardenConnectionFactory(
+		f.db,
+		f.logger.Session("garden-connection"),
+		workerName,
+		&apiURL,
+		f.retryBackOffFactory,
+	)
+
+	return gclient.New(gcf.BuildConnection())
+}
+
+type ContainerCollector interface {
+	Run() error
+}
+
+type containerCollector struct {
+	logger              lager.Logger
+	containerFactory    dbng.ContainerFactory
+	gardenClientFactory GardenClientFactory
+}
+
+func NewContainerCollector(
+	logger lager.Logger,
+	containerFactory dbng.ContainerFactory,
+	gardenClientFactory GardenClientFactory,
+) ContainerCollector {
+	return &containerCollector{
+		logger:              logger,
+		containerFactory:    containerFactory,
+		gardenClientFactory: gardenClientFactory,
+	}
+}
+
+func (cc *containerCollector) Run() error {
+	logger := cc.logger.Session("collect")
+
+	creatingContainers, createdContainers, destroyingContainers, err := cc.containerFactory.FindOrphanedContainers()
+	if err != nil {
+		logger.Error("failed-to-find-orphaned-containers", err)
+		return err
+	}
+
+	for _, creatingContainer := range creatingContainers {
+		cLog := logger.Session("mark-creating-as-destroying", lager.Data{
+			"handle": creatingContainer.Handle(),
+			"worker": creatingContainer.Worker().Name,
+		})
+
+		destroyingContainer, err := creatingContainer.Destroying()
+		if err != nil {
+			cLog.Error("failed-to-transition", err)
+			continue
+		}
+
+		destroyingContainers = append(destroyingContainers, destroyingContainer)
+	}
+
+	for _, createdContainer := range createdContainers {
+		cLog := logger.Session("mark-created-as-destroying", lager.Data{
+			"handle": createdContainer.Handle(),
+			"worker": createdContainer.Worker().Name,
+		})
+
+		destroyingContainer, err := createdContainer.Destroying()
+		if err != nil {
+			cLog.Error("failed-to-transition", err)
+			continue
+		}
+
+		destroyingContainers = append(destroyingContainers, destroyingContainer)
+	}
+
+	for _, destroyingContainer := range destroyingContainers {
+		cLog := logger.Session("destroy", lager.Data{
+			"handle": destroyingContainer.Handle(),
+			"worker": destroyingContainer.Worker().Name,
+		})
+
+		gardenClient := cc.gardenClientFactory.NewClient(
+			*destroyingContainer.Worker().GardenAddr,
+			destroyingContainer.Worker().Name,
+		)
+
+		err := gardenClient.Destroy(destroyingContainer.Handle())
+		if err != nil {
+			if _, ok := err.(garden.ContainerNotFoundError); !ok {
+				cLog.Error("failed-to-destroy-garden-container", err)
+				continue
+			}
+
+			cLog.Info("garden-container-not-found")
+		}
+
+		destroyed, err := destroyingContainer.Destroy()
+		if err != nil {
+			cLog.Error("failed-to-delete-container-from-db", err)
+			continue
+		}
+
+		if !destroyed {
+			cLog.Info("container-already-deleted-from-db")
+			continue
+		}
+
+		cLog.Debug("destroyed")
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package gcng_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/garden"
+	gfakes "code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/gcng"
+	"github.com/concourse/atc/gcng/gcngfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerCollector", func() {
+	var (
+		containerCollector gcng.ContainerCollector
+
+		fakeContainerFactory    *dbngfakes.FakeContainerFactory
+		fakeGardenClientFactory *gcngfakes.FakeGardenClientFactory
+		fakeGardenClient        *gfakes.FakeClient
+
+		worker *dbng.Worker
+
+		creatingContainer           *dbngfakes.FakeCreatingContainer
+		createdContainer            *dbngfakes.FakeCreatedContainer
+		destroyingContainer         *dbngfakes.FakeDestroyingContainer
+		creatingDestroyingContainer *dbngfakes.FakeDestroyingContainer
+		createdDestroyingContainer  *dbngfakes.FakeDestroyingContainer
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("container-collector")
+
+		fakeContainerFactory = new(dbngfakes.FakeContainerFactory)
+		fakeGardenClientFactory = new(gcngfakes.FakeGardenClientFactory)
+		fakeGardenClient = new(gfakes.FakeClient)
+		fakeGardenClientFactory.NewClientReturns(fakeGardenClient)
+
+		gardenAddr := "1.2.3.4:7777"
+		worker = &dbng.Worker{
+			Name:       "some-worker",
+			GardenAddr: &gardenAddr,
+		}
+
+		creatingDestroyingContainer = new(dbngfakes.FakeDestroyingContainer)
+		creatingDestroyingContainer.HandleReturns("creating-handle")
+		creatingDestroyingContainer.WorkerReturns(worker)
+		creatingDestroyingContainer.DestroyReturns(true, nil)
+
+		creatingContainer = new(dbngfakes.FakeCreatingContainer)
+		creatingContainer.HandleReturns("creating-handle")
+		creatingContainer.WorkerReturns(worker)
+		creatingContainer.DestroyingReturns(creatingDestroyingContainer, nil)
+
+		createdDestroyingContainer = new(dbngfakes.FakeDestroyingContainer)
+		createdDestroyingContainer.HandleReturns("created-handle")
+		createdDestroyingContainer.WorkerReturns(worker)
+		createdDestroyingContainer.DestroyReturns(true, nil)
+
+		createdContainer = new(dbngfakes.FakeCreatedContainer)
+		createdContainer.HandleReturns("created-handle")
+		createdContainer.WorkerReturns(worker)
+		createdContainer.DestroyingReturns(createdDestroyingContainer, nil)
+
+		destroyingContainer = new(dbngfakes.FakeDestroyingContainer)
+		destroyingContainer.HandleReturns("destroying-handle")
+		destroyingContainer.WorkerReturns(worker)
+		destroyingContainer.DestroyReturns(true, nil)
+
+		fakeContainerFactory.FindOrphanedContainersReturns(
+			[]dbng.CreatingContainer{creatingContainer},
+			[]dbng.CreatedContainer{createdContainer},
+			[]dbng.DestroyingContainer{destroyingContainer},
+			nil,
+		)
+
+		containerCollector = gcng.NewContainerCollector(
+			logger,
+			fakeContainerFactory,
+			fakeGardenClientFactory,
+		)
+	})
+
+	JustBeforeEach(func() {
+		runErr = containerCollector.Run()
+	})
+
+	It("succeeds", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+	})
+
+	It("marks the creating and created containers as destroying", func() {
+		Expect(creatingContainer.DestroyingCallCount()).To(Equal(1))
+		Expect(createdContainer.DestroyingCallCount()).To(Equal(1))
+	})
+
+	It("destroys every orphaned container in garden", func() {
+		Expect(fakeGardenClientFactory.NewClientCallCount()).To(Equal(3))
+		apiURL, workerName := fakeGardenClientFactory.NewClientArgsForCall(0)
+		Expect(apiURL).To(Equal("1.2.3.4:7777"))
+		Expect(workerName).To(Equal("some-worker"))
+
+		Expect(fakeGardenClient.DestroyCallCount()).To(Equal(3))
+
+		handles := []string{}
+		for i := 0; i < fakeGardenClient.DestroyCallCount(); i++ {
+			handles = append(handles, fakeGardenClient.DestroyArgsForCall(i))
+		}
+
+		Expect(handles).To(ConsistOf("creating-handle", "created-handle", "destroying-handle"))
+	})
+
+	It("deletes every orphaned container from the database", func() {
+		Expect(creatingDestroyingContainer.DestroyCallCount()).To(Equal(1))
+		Expect(createdDestroyingContainer.DestroyCallCount()).To(Equal(1))
+		Expect(destroyingContainer.DestroyCallCount()).To(Equal(1))
+	})
+
+	Context("when another ATC has already changed the state of a container", func() {
+		BeforeEach(func() {
+			createdContainer.DestroyingReturns(nil, dbng.ErrContainerStateChanged)
+		})
+
+		It("leaves it alone", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeGardenClient.DestroyCallCount()).To(Equal(2))
+			Expect(createdDestroyingContainer.DestroyCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the container cannot be found in garden", func() {
+		BeforeEach(func() {
+			fakeGardenClient.DestroyReturns(garden.ContainerNotFoundError{Handle: "some-handle"})
+		})
+
+		It("still deletes it from the database", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(destroyingContainer.DestroyCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when destroying the container in garden fails", func() {
+		BeforeEach(func() {
+			fakeGardenClient.DestroyReturns(errors.New("nope"))
+		})
+
+		It("keeps it in the database so it is retried", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(creatingDestroyingContainer.DestroyCallCount()).To(BeZero())
+			Expect(createdDestroyingContainer.DestroyCallCount()).To(BeZero())
+			Expect(destroyingContainer.DestroyCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when finding the orphaned containers fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeContainerFactory.FindOrphanedContainersReturns(nil, nil, nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+package gcng
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/worker/transport"
+	bclient "github.com/concourse/baggageclaim/client"
+)
+
+//go:generate counterfeiter . BaggageclaimClientFactory
+
+type BaggageclaimClientFactory interface {
+	NewClient(apiURL string, workerName string) bclient.Client
+}
+
+type baggageclaimClientFactory struct {
+	db transport.TransportDB
+}
+
+func NewBaggageclaimClientFactory(db transport.TransportDB) BaggageclaimClientFactory {
+	return &baggageclaimClientFactory{
+		db: db,
+	}
+}
+
+func (f *baggageclaimClientFactory) NewClient(apiURL string, workerName string) bclient.Client {
+	return bclient.New(apiURL, transport.NewBaggageclaimRoundTripper(
+		workerName,
+		&apiURL,
+		f.db,
+		&http.Transport{DisableKeepAlives: true},
+	))
+}
+
+type VolumeCollector interface {
+	Run() error
+}
+
+type volumeCollector struct {
+	logger                    lager.Logger
+	volumeFactory             dbng.VolumeFactory
+	baggageclaimClientFactory BaggageclaimClientFactory
+}
+
+func NewVolumeCollector(
+	logger lager.Logger,
+	volumeFactory dbng.VolumeFactory,
+	baggageclaimClientFactory BaggageclaimClientFactory,
+) VolumeCollector {
+	return &volumeCollector{
+		logger:                    logger,
+		volumeFactory:             volumeFactory,
+		baggageclaimClientFactory: baggageclaimClientFactory,
+	}
+}
+
+func (vc *volumeCollector) Run() error {
+	logger := vc.logger.Session("collect")
+
+	destroyingVolumes, err := vc.volumeFactory.FindDestroyingVolumes()
+	if err != nil {
+		logger.Error("failed-to-find-destroying-volumes", err)
+		return err
+	}
+
+	for _, destroyingVolume := range destroyingVolumes {
+		vLog := logger.Session("destroy", lager.Data{
+			"handle": destroyingVolume.Handle(),
+			"worker": destroyingVolume.Worker().Name,
+		})
+
+		baggageclaimClient := vc.baggageclaimClientFactory.NewClient(
+			*destroyingVolume.Worker().BaggageclaimURL,
+			destroyingVolume.Worker().Name,
+		)
+
+		volume, found, err := baggageclaimClient.LookupVolume(vLog, destroyingVolume.Handle())
+		if err != nil {
+			vLog.Error("failed-to-lookup-volume", err)
+			continue
+		}
+
+		if found {
+			err = volume.Destroy()
+			if err != nil {
+				vLog.Error("failed-to-destroy-volume", err)
+				continue
+			}
+		}
+
+		destroyed, err := destroyingVolume.Destroy()
+		if err != nil {
+			vLog.Error("failed-to-delete-volume-from-db", err)
+			continue
+		}
+
+		if !destroyed {
+			vLog.Info("volume-already-deleted-from-db")
+			continue
+		}
+
+		vLog.Debug("destroyed")
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package gcng_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/gcng"
+	"github.com/concourse/atc/gcng/gcngfakes"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VolumeCollector", func() {
+	var (
+		volumeCollector gcng.VolumeCollector
+
+		fakeVolumeFactory             *dbngfakes.FakeVolumeFactory
+		fakeBaggageclaimClientFactory *gcngfakes.FakeBaggageclaimClientFactory
+		fakeBaggageclaimClient        *baggageclaimfakes.FakeClient
+		fakeBaggageclaimVolume        *baggageclaimfakes.FakeVolume
+
+		destroyingVolume *dbngfakes.FakeDestroyingVolume
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("volume-collector")
+
+		fakeVolumeFactory = new(dbngfakes.FakeVolumeFactory)
+		fakeBaggageclaimClientFactory = new(gcngfakes.FakeBaggageclaimClientFactory)
+		fakeBaggageclaimClient = new(baggageclaimfakes.FakeClient)
+		fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+
+		fakeBaggageclaimClientFactory.NewClientReturns(fakeBaggageclaimClient)
+		fakeBaggageclaimClient.LookupVolumeReturns(fakeBaggageclaimVolume, true, nil)
+
+		baggageclaimURL := "http://1.2.3.4:7788"
+		destroyingVolume = new(dbngfakes.FakeDestroyingVolume)
+		destroyingVolume.HandleReturns("some-handle")
+		destroyingVolume.WorkerReturns(&dbng.Worker{
+			Name:            "some-worker",
+			BaggageclaimURL: &baggageclaimURL,
+		})
+		destroyingVolume.DestroyReturns(true, nil)
+
+		fakeVolumeFactory.FindDestroyingVolumesReturns([]dbng.DestroyingVolume{destroyingVolume}, nil)
+
+		volumeCollector = gcng.NewVolumeCollector(
+			logger,
+			fakeVolumeFactory,
+			fakeBaggageclaimClientFactory,
+		)
+	})
+
+	JustBeforeEach(func() {
+		runErr = volumeCollector.Run()
+	})
+
+	It("succeeds", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+	})
+
+	It("destroys the volume in baggageclaim", func() {
+		Expect(fakeBaggageclaimClientFactory.NewClientCallCount()).To(Equal(1))
+		apiURL, workerName := fakeBaggageclaimClientFactory.NewClientArgsForCall(0)
+		Expect(apiURL).To(Equal("http://1.2.3.4:7788"))
+		Expect(workerName).To(Equal("some-worker"))
+
+		Expect(fakeBaggageclaimClient.LookupVolumeCallCount()).To(Equal(1))
+		_, handle := fakeBaggageclaimClient.LookupVolumeArgsForCall(0)
+		Expect(handle).To(Equal("some-handle"))
+
+		Expect(fakeBaggageclaimVolume.DestroyCallCount()).To(Equal(1))
+	})
+
+	It("deletes the volume from the database", func() {
+		Expect(destroyingVolume.DestroyCallCount()).To(Equal(1))
+	})
+
+	Context("when the volume cannot be found in baggageclaim", func() {
+		BeforeEach(func() {
+			fakeBaggageclaimClient.LookupVolumeReturns(nil, false, nil)
+		})
+
+		It("still deletes it from the database", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(destroyingVolume.DestroyCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when looking up the volume fails", func() {
+		BeforeEach(func() {
+			fakeBaggageclaimClient.LookupVolumeReturns(nil, false, errors.New("nope"))
+		})
+
+		It("keeps it in the database so it is retried", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(destroyingVolume.DestroyCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when destroying the volume in baggageclaim fails", func() {
+		BeforeEach(func() {
+			fakeBaggageclaimVolume.DestroyReturns(errors.New("nope"))
+		})
+
+		It("keeps it in the database so it is retried", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(destroyingVolume.DestroyCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when finding the destroying volumes fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeVolumeFactory.FindDestroyingVolumesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
@@ -27,6 +27,11 @@ type JobOutput struct {
 	Resource string `json:"resource"`
 }
 
+type JobInputsExplanation struct {
+	Inputs      []BuildInput                `json:"inputs"`
+	Explanation map[string]InputExplanation `json:"explanation"`
+}
+
 type BuildInput struct {
 	Name     string   `json:"name"`
 	Resource string   `json:"resource"`
@@ -0,0 +1,11 @@
+package atc
+
+type LocalUser struct {
+	Username  string `json:"username"`
+	Enabled   bool   `json:"enabled"`
+	CreatedAt int64  `json:"created_at,omitempty"`
+
+	// Password is only accepted when creating a user or changing its
+	// password, and is never returned.
+	Password string `json:"password,omitempty"`
+}
@@ -14,6 +14,8 @@ var TrackedContainers = &Gauge{}
 var TrackedVolumes = &Gauge{}
 var DatabaseQueries = Meter(0)
 var DatabaseConnections = &Gauge{}
+var StepsWaitingForWorker = &Gauge{}
+var ResourceChecksQueued = &Gauge{}
 
 type SchedulingFullDuration struct {
 	PipelineName string
@@ -137,6 +139,88 @@ func (event WorkerContainers) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerDiskUsage struct {
+	WorkerName      string
+	CapacityInBytes int64
+	UsageInBytes    int64
+}
+
+func (event WorkerDiskUsage) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("worker-disk-usage", lager.Data{
+			"worker":   event.WorkerName,
+			"capacity": event.CapacityInBytes,
+			"usage":    event.UsageInBytes,
+		}),
+		goryman.Event{
+			Service: "worker disk usage (bytes)",
+			Metric:  event.UsageInBytes,
+			State:   "ok",
+			Attributes: map[string]string{
+				"worker":   event.WorkerName,
+				"capacity": strconv.FormatInt(event.CapacityInBytes, 10),
+			},
+		},
+	)
+}
+
+type VolumesEvicted struct {
+	WorkerName   string
+	Volumes      int
+	BytesEvicted int64
+}
+
+func (event VolumesEvicted) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("volumes-evicted", lager.Data{
+			"worker":  event.WorkerName,
+			"volumes": event.Volumes,
+			"bytes":   event.BytesEvicted,
+		}),
+		goryman.Event{
+			Service: "volumes evicted under disk pressure",
+			Metric:  event.Volumes,
+			State:   "ok",
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+				"bytes":  strconv.FormatInt(event.BytesEvicted, 10),
+			},
+		},
+	)
+}
+
+type ResourceCheckQueueWait struct {
+	TeamID   int
+	Duration time.Duration
+}
+
+func (event ResourceCheckQueueWait) Emit(logger lager.Logger) {
+	state := "ok"
+
+	if event.Duration > time.Minute {
+		state = "warning"
+	}
+
+	if event.Duration > 5*time.Minute {
+		state = "critical"
+	}
+
+	emit(
+		logger.Session("resource-check-queue-wait", lager.Data{
+			"team-id":  event.TeamID,
+			"duration": event.Duration.String(),
+		}),
+		goryman.Event{
+			Service: "resource check queue wait (ms)",
+			Metric:  ms(event.Duration),
+			State:   state,
+			Attributes: map[string]string{
+				"team_id": strconv.Itoa(event.TeamID),
+			},
+		},
+	)
+}
+
 type BuildStarted struct {
 	PipelineName string
 	JobName      string
@@ -237,3 +321,29 @@ func (event HTTPResponseTime) Emit(logger lager.Logger) {
 		},
 	)
 }
+
+type RequestThrottled struct {
+	Route   string
+	Class   string
+	Limiter string
+}
+
+func (event RequestThrottled) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("request-throttled", lager.Data{
+			"route":   event.Route,
+			"class":   event.Class,
+			"limiter": event.Limiter,
+		}),
+		goryman.Event{
+			Service: "http requests throttled",
+			Metric:  1,
+			State:   "warning",
+			Attributes: map[string]string{
+				"route":   event.Route,
+				"class":   event.Class,
+				"limiter": event.Limiter,
+			},
+		},
+	)
+}
@@ -19,6 +19,8 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 		trackedVolumes := TrackedVolumes.Max()
 		databaseQueries := DatabaseQueries.Delta()
 		databaseConnections := DatabaseConnections.Max()
+		stepsWaitingForWorker := StepsWaitingForWorker.Max()
+		resourceChecksQueued := ResourceChecksQueued.Max()
 
 		emit(
 			tLog.Session("tracked-containers", lager.Data{
@@ -64,6 +66,28 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			},
 		)
 
+		emit(
+			tLog.Session("steps-waiting-for-worker", lager.Data{
+				"count": stepsWaitingForWorker,
+			}),
+			goryman.Event{
+				Service: "steps waiting for worker",
+				Metric:  stepsWaitingForWorker,
+				State:   "ok",
+			},
+		)
+
+		emit(
+			tLog.Session("resource-checks-queued", lager.Data{
+				"count": resourceChecksQueued,
+			}),
+			goryman.Event{
+				Service: "resource checks queued",
+				Metric:  resourceChecksQueued,
+				State:   "ok",
+			},
+		)
+
 		var memStats runtime.MemStats
 		runtime.ReadMemStats(&memStats)
 
@@ -24,25 +24,34 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	tracker  resource.Tracker
-	interval time.Duration
-	engine   engine.Engine
+	tracker         resource.Tracker
+	interval        time.Duration
+	checkJitter     time.Duration
+	maxCheckBackoff time.Duration
+	checkLimiter    radar.CheckLimiter
+	engine          engine.Engine
 }
 
 func NewRadarSchedulerFactory(
 	tracker resource.Tracker,
 	interval time.Duration,
+	checkJitter time.Duration,
+	maxCheckBackoff time.Duration,
+	checkLimiter radar.CheckLimiter,
 	engine engine.Engine,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		tracker:  tracker,
-		interval: interval,
-		engine:   engine,
+		tracker:         tracker,
+		interval:        interval,
+		checkJitter:     checkJitter,
+		maxCheckBackoff: maxCheckBackoff,
+		checkLimiter:    checkLimiter,
+		engine:          engine,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
+	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, rsf.checkJitter, rsf.maxCheckBackoff, rsf.checkLimiter, pipelineDB, clock.NewClock(), externalURL)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, externalURL string) scheduler.BuildScheduler {
@@ -50,6 +59,9 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 		clock.NewClock(),
 		rsf.tracker,
 		rsf.interval,
+		rsf.checkJitter,
+		rsf.maxCheckBackoff,
+		rsf.checkLimiter,
 		pipelineDB,
 		externalURL,
 	)
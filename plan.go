@@ -105,6 +105,8 @@ type TaskPlan struct {
 	Privileged bool `json:"privileged"`
 	Tags       Tags `json:"tags,omitempty"`
 
+	RetryOnWorkerLoss bool `json:"retry_on_worker_loss,omitempty"`
+
 	ConfigPath string      `json:"config_path,omitempty"`
 	Config     *TaskConfig `json:"config,omitempty"`
 
@@ -0,0 +1,229 @@
+package radar
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
+)
+
+//go:generate counterfeiter . CheckLimiter
+
+type CheckLimiter interface {
+	// Acquire blocks until there is a free slot for a check to run in, or
+	// until a signal is received, in which case it returns false. The
+	// returned lock must be released once the check is done.
+	Acquire(logger lager.Logger, signals <-chan os.Signal, teamID int) (db.Lock, bool)
+}
+
+//go:generate counterfeiter . CheckSlotDB
+
+type CheckSlotDB interface {
+	AcquireResourceCheckSlot(logger lager.Logger, teamID int, globalLimit int, teamLimit int) (db.Lock, bool, error)
+}
+
+// NewCheckLimiter returns a CheckLimiter that allows at most globalLimit
+// checks, and at most teamLimit checks per team, to run at once across all
+// ATCs. Checks waiting for a slot are queued per team, and the teams take
+// turns as slots free up so that one team with many resources does not
+// starve the others. Slots freed by other ATCs are noticed every
+// pollInterval. A limit of 0 means there is no limit.
+func NewCheckLimiter(
+	clock clock.Clock,
+	slotDB CheckSlotDB,
+	globalLimit int,
+	teamLimit int,
+	pollInterval time.Duration,
+) CheckLimiter {
+	if globalLimit == 0 && teamLimit == 0 {
+		return unlimitedCheckLimiter{}
+	}
+
+	return &checkLimiter{
+		clock:        clock,
+		slotDB:       slotDB,
+		globalLimit:  globalLimit,
+		teamLimit:    teamLimit,
+		pollInterval: pollInterval,
+
+		queues: map[int][]chan db.Lock{},
+	}
+}
+
+type checkLimiter struct {
+	clock        clock.Clock
+	slotDB       CheckSlotDB
+	globalLimit  int
+	teamLimit    int
+	pollInterval time.Duration
+
+	mutex sync.Mutex
+
+	// teams with waiting checks, in the order they will be served
+	teams  []int
+	queues map[int][]chan db.Lock
+}
+
+func (limiter *checkLimiter) Acquire(logger lager.Logger, signals <-chan os.Signal, teamID int) (db.Lock, bool) {
+	logger = logger.Session("acquire-check-slot")
+
+	metric.ResourceChecksQueued.Inc()
+	defer metric.ResourceChecksQueued.Dec()
+
+	enqueuedAt := limiter.clock.Now()
+
+	granted := limiter.enqueue(teamID)
+	limiter.dispatch(logger)
+
+	for {
+		timer := limiter.clock.NewTimer(limiter.pollInterval)
+
+		select {
+		case slot := <-granted:
+			timer.Stop()
+
+			metric.ResourceCheckQueueWait{
+				TeamID:   teamID,
+				Duration: limiter.clock.Since(enqueuedAt),
+			}.Emit(logger)
+
+			slot.AfterRelease(func() error {
+				limiter.dispatch(logger)
+				return nil
+			})
+
+			return slot, true
+
+		case <-signals:
+			timer.Stop()
+			limiter.cancel(logger, teamID, granted)
+			return nil, false
+
+		case <-timer.C():
+			limiter.dispatch(logger)
+		}
+	}
+}
+
+// cancel takes a check that is no longer waiting out of its team's queue. If
+// it was handed a slot in the meantime, the slot goes to the next check.
+func (limiter *checkLimiter) cancel(logger lager.Logger, teamID int, granted chan db.Lock) {
+	limiter.mutex.Lock()
+
+	queue := limiter.queues[teamID]
+	for i, waiting := range queue {
+		if waiting != granted {
+			continue
+		}
+
+		if len(queue) == 1 {
+			delete(limiter.queues, teamID)
+
+			for j, id := range limiter.teams {
+				if id == teamID {
+					limiter.teams = append(limiter.teams[:j], limiter.teams[j+1:]...)
+					break
+				}
+			}
+		} else {
+			limiter.queues[teamID] = append(queue[:i:i], queue[i+1:]...)
+		}
+
+		limiter.mutex.Unlock()
+		return
+	}
+
+	limiter.mutex.Unlock()
+
+	slot := <-granted
+
+	err := slot.Release()
+	if err != nil {
+		logger.Error("failed-to-release-check-slot", err)
+	}
+
+	limiter.dispatch(logger)
+}
+
+func (limiter *checkLimiter) enqueue(teamID int) chan db.Lock {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	granted := make(chan db.Lock, 1)
+
+	if len(limiter.queues[teamID]) == 0 {
+		limiter.teams = append(limiter.teams, teamID)
+	}
+
+	limiter.queues[teamID] = append(limiter.queues[teamID], granted)
+
+	return granted
+}
+
+// dispatch hands out as many slots as can be acquired, taking the first
+// waiting check of each team in turn. A team that was given a slot goes to
+// the back of the line.
+func (limiter *checkLimiter) dispatch(logger lager.Logger) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	for {
+		grantedAny := false
+
+		for _, teamID := range append([]int{}, limiter.teams...) {
+			slot, acquired, err := limiter.slotDB.AcquireResourceCheckSlot(logger, teamID, limiter.globalLimit, limiter.teamLimit)
+			if err != nil {
+				logger.Error("failed-to-acquire-check-slot", err)
+				return
+			}
+
+			if !acquired {
+				continue
+			}
+
+			limiter.dequeue(teamID) <- slot
+			grantedAny = true
+		}
+
+		if !grantedAny {
+			return
+		}
+	}
+}
+
+func (limiter *checkLimiter) dequeue(teamID int) chan db.Lock {
+	queue := limiter.queues[teamID]
+	granted := queue[0]
+
+	for i, id := range limiter.teams {
+		if id == teamID {
+			limiter.teams = append(limiter.teams[:i], limiter.teams[i+1:]...)
+			break
+		}
+	}
+
+	if len(queue) == 1 {
+		delete(limiter.queues, teamID)
+	} else {
+		limiter.queues[teamID] = queue[1:]
+		limiter.teams = append(limiter.teams, teamID)
+	}
+
+	return granted
+}
+
+type unlimitedCheckLimiter struct{}
+
+func (unlimitedCheckLimiter) Acquire(lager.Logger, <-chan os.Signal, int) (db.Lock, bool) {
+	return noopLock{}, true
+}
+
+type noopLock struct{}
+
+func (noopLock) Acquire() (bool, error)    { return true, nil }
+func (noopLock) Release() error            { return nil }
+func (noopLock) AfterRelease(func() error) {}
@@ -0,0 +1,228 @@
+package radar_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/radar"
+	"github.com/concourse/atc/radar/radarfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckLimiter", func() {
+	var (
+		fakeClock       *fakeclock.FakeClock
+		fakeCheckSlotDB *radarfakes.FakeCheckSlotDB
+		pollInterval    time.Duration
+
+		logger *lagertest.TestLogger
+
+		freeSlots  int
+		slotsMutex *sync.Mutex
+
+		limiter CheckLimiter
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		fakeCheckSlotDB = new(radarfakes.FakeCheckSlotDB)
+		pollInterval = time.Second
+
+		logger = lagertest.NewTestLogger("test")
+
+		freeSlots = 0
+		slotsMutex = &sync.Mutex{}
+
+		fakeCheckSlotDB.AcquireResourceCheckSlotStub = func(lager.Logger, int, int, int) (db.Lock, bool, error) {
+			slotsMutex.Lock()
+			defer slotsMutex.Unlock()
+
+			if freeSlots == 0 {
+				return nil, false, nil
+			}
+
+			freeSlots--
+
+			var afterRelease func() error
+
+			slot := new(dbfakes.FakeLock)
+			slot.AfterReleaseStub = func(f func() error) {
+				afterRelease = f
+			}
+			slot.ReleaseStub = func() error {
+				slotsMutex.Lock()
+				freeSlots++
+				slotsMutex.Unlock()
+
+				return afterRelease()
+			}
+
+			return slot, true, nil
+		}
+
+		limiter = NewCheckLimiter(fakeClock, fakeCheckSlotDB, 10, 2, pollInterval)
+	})
+
+	setFreeSlots := func(slots int) {
+		slotsMutex.Lock()
+		freeSlots = slots
+		slotsMutex.Unlock()
+	}
+
+	acquireAsync := func(name string, teamID int, acquired chan<- string, slots chan<- db.Lock) {
+		go func() {
+			defer GinkgoRecover()
+
+			slot, ok := limiter.Acquire(logger, nil, teamID)
+			Expect(ok).To(BeTrue())
+
+			acquired <- name
+			slots <- slot
+		}()
+	}
+
+	Context("when there is a free slot", func() {
+		BeforeEach(func() {
+			setFreeSlots(1)
+		})
+
+		It("takes it for the team with the configured limits", func() {
+			slot, ok := limiter.Acquire(logger, nil, 42)
+			Expect(ok).To(BeTrue())
+			Expect(slot).NotTo(BeNil())
+
+			Expect(fakeCheckSlotDB.AcquireResourceCheckSlotCallCount()).To(Equal(1))
+
+			_, teamID, globalLimit, teamLimit := fakeCheckSlotDB.AcquireResourceCheckSlotArgsForCall(0)
+			Expect(teamID).To(Equal(42))
+			Expect(globalLimit).To(Equal(10))
+			Expect(teamLimit).To(Equal(2))
+		})
+	})
+
+	Context("when there are no free slots", func() {
+		var acquired chan string
+		var slots chan db.Lock
+
+		BeforeEach(func() {
+			acquired = make(chan string, 3)
+			slots = make(chan db.Lock, 3)
+		})
+
+		It("waits until a slot frees up", func() {
+			acquireAsync("some-check", 42, acquired, slots)
+
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+			Consistently(acquired).ShouldNot(Receive())
+
+			setFreeSlots(1)
+			fakeClock.Increment(pollInterval)
+
+			Eventually(acquired).Should(Receive(Equal("some-check")))
+		})
+
+		It("takes turns between the teams as slots are released", func() {
+			acquireAsync("team-1-first-check", 1, acquired, slots)
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+			acquireAsync("team-1-second-check", 1, acquired, slots)
+			Eventually(fakeClock.WatcherCount).Should(Equal(2))
+
+			acquireAsync("team-2-check", 2, acquired, slots)
+			Eventually(fakeClock.WatcherCount).Should(Equal(3))
+
+			setFreeSlots(1)
+			fakeClock.Increment(pollInterval)
+
+			Eventually(acquired).Should(Receive(Equal("team-1-first-check")))
+			Consistently(acquired).ShouldNot(Receive())
+
+			var slot db.Lock
+			Expect(slots).To(Receive(&slot))
+			Expect(slot.Release()).To(Succeed())
+
+			Eventually(acquired).Should(Receive(Equal("team-2-check")))
+			Consistently(acquired).ShouldNot(Receive())
+
+			Expect(slots).To(Receive(&slot))
+			Expect(slot.Release()).To(Succeed())
+
+			Eventually(acquired).Should(Receive(Equal("team-1-second-check")))
+		})
+	})
+
+	Context("when a signal is received while waiting", func() {
+		It("gives up its place in the queue", func() {
+			signals := make(chan os.Signal, 1)
+			interrupted := make(chan bool, 1)
+
+			go func() {
+				defer GinkgoRecover()
+
+				_, ok := limiter.Acquire(logger, signals, 42)
+				interrupted <- !ok
+			}()
+
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+			signals <- os.Interrupt
+			Eventually(interrupted).Should(Receive(BeTrue()))
+
+			acquired := make(chan string, 1)
+			slots := make(chan db.Lock, 1)
+
+			acquireAsync("some-check", 42, acquired, slots)
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+			setFreeSlots(1)
+			fakeClock.Increment(pollInterval)
+
+			Eventually(acquired).Should(Receive(Equal("some-check")))
+		})
+	})
+
+	Context("when acquiring a slot fails", func() {
+		BeforeEach(func() {
+			fakeCheckSlotDB.AcquireResourceCheckSlotStub = nil
+			fakeCheckSlotDB.AcquireResourceCheckSlotReturns(nil, false, errors.New("nope"))
+		})
+
+		It("keeps waiting and tries again", func() {
+			acquired := make(chan string, 1)
+			slots := make(chan db.Lock, 1)
+
+			acquireAsync("some-check", 42, acquired, slots)
+
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+			Expect(fakeCheckSlotDB.AcquireResourceCheckSlotCallCount()).To(Equal(1))
+
+			fakeCheckSlotDB.AcquireResourceCheckSlotReturns(new(dbfakes.FakeLock), true, nil)
+			fakeClock.Increment(pollInterval)
+
+			Eventually(acquired).Should(Receive(Equal("some-check")))
+		})
+	})
+
+	Context("when there are no limits", func() {
+		BeforeEach(func() {
+			limiter = NewCheckLimiter(fakeClock, fakeCheckSlotDB, 0, 0, pollInterval)
+		})
+
+		It("does not wait for a slot", func() {
+			slot, ok := limiter.Acquire(logger, nil, 42)
+			Expect(ok).To(BeTrue())
+			Expect(slot.Release()).To(Succeed())
+
+			Expect(fakeCheckSlotDB.AcquireResourceCheckSlotCallCount()).To(BeZero())
+		})
+	})
+})
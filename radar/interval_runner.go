@@ -1,6 +1,7 @@
 package radar
 
 import (
+	"math/rand"
 	"os"
 	"time"
 
@@ -13,6 +14,7 @@ type IntervalRunner struct {
 	clock   clock.Clock
 	name    string
 	scanner Scanner
+	jitter  time.Duration
 }
 
 func NewIntervalRunner(
@@ -20,17 +22,24 @@ func NewIntervalRunner(
 	clock clock.Clock,
 	name string,
 	scanner Scanner,
+	jitter time.Duration,
 ) *IntervalRunner {
 	return &IntervalRunner{
 		logger:  logger,
 		clock:   clock,
 		name:    name,
 		scanner: scanner,
+		jitter:  jitter,
 	}
 }
+
 func (r *IntervalRunner) RunFunc(signals <-chan os.Signal, ready chan<- struct{}) error {
-	// do an immediate initial check
+	// do an initial check straight away, or within the jitter so that the
+	// runners started together when the ATC comes up do not all check at once
 	var interval time.Duration = 0
+	if r.jitter > 0 {
+		interval = time.Duration(rand.Int63n(int64(r.jitter)))
+	}
 
 	close(ready)
 
@@ -44,11 +53,16 @@ func (r *IntervalRunner) RunFunc(signals <-chan os.Signal, ready chan<- struct{}
 
 		case <-timer.C():
 			var err error
-			interval, err = r.scanner.Run(r.logger, r.name)
+			interval, err = r.scanner.Run(r.logger, signals, r.name)
 			if err != nil {
 				if err == ErrFailedToAcquireLease {
 					break
 				}
+
+				if err == ErrInterrupted {
+					return nil
+				}
+
 				return err
 			}
 		}
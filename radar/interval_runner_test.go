@@ -21,6 +21,7 @@ var _ = Describe("IntervalRunner", func() {
 
 		fakeClock *fakeclock.FakeClock
 		interval  time.Duration
+		jitter    time.Duration
 		times     chan time.Time
 
 		intervalRunner *IntervalRunner
@@ -42,17 +43,19 @@ var _ = Describe("IntervalRunner", func() {
 		fakeScanner = &radarfakes.FakeScanner{}
 		times = make(chan time.Time, 100)
 		interval = 1 * time.Minute
-		fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+		jitter = 0
+		fakeScanner.RunStub = func(lager.Logger, <-chan os.Signal, string) (time.Duration, error) {
 			times <- fakeClock.Now()
 			return interval, nil
 		}
 
-		logger := lagertest.NewTestLogger("test")
-		intervalRunner = NewIntervalRunner(logger, fakeClock, "some-resource", fakeScanner)
 	})
 
 	Describe("RunFunc", func() {
 		JustBeforeEach(func() {
+			logger := lagertest.NewTestLogger("test")
+			intervalRunner = NewIntervalRunner(logger, fakeClock, "some-resource", fakeScanner, jitter)
+
 			go func() {
 				errCh <- intervalRunner.RunFunc(signalCh, readyCh)
 			}()
@@ -80,9 +83,26 @@ var _ = Describe("IntervalRunner", func() {
 				Expect(<-times).To(Equal(epoch.Add(interval)))
 			})
 
+			Context("when jitter is configured", func() {
+				BeforeEach(func() {
+					jitter = 10 * time.Second
+				})
+
+				It("runs the initial scan within the jitter", func() {
+					Consistently(times).ShouldNot(Receive())
+
+					fakeClock.WaitForWatcherAndIncrement(jitter)
+
+					var scannedAt time.Time
+					Eventually(times).Should(Receive(&scannedAt))
+					Expect(scannedAt).To(BeTemporally(">", epoch))
+					Expect(scannedAt).To(BeTemporally("<=", epoch.Add(jitter)))
+				})
+			})
+
 			Context("when Run takes a while", func() {
 				BeforeEach(func() {
-					fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+					fakeScanner.RunStub = func(lager.Logger, <-chan os.Signal, string) (time.Duration, error) {
 						times <- fakeClock.Now()
 						fakeClock.Increment(interval / 2)
 						return interval, nil
@@ -102,7 +122,7 @@ var _ = Describe("IntervalRunner", func() {
 		Context("when scanner.Run() returns an error", func() {
 			var disaster = errors.New("failed")
 			BeforeEach(func() {
-				fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+				fakeScanner.RunStub = func(lager.Logger, <-chan os.Signal, string) (time.Duration, error) {
 					times <- fakeClock.Now()
 					return interval, disaster
 				}
@@ -113,9 +133,25 @@ var _ = Describe("IntervalRunner", func() {
 			})
 		})
 
+		Context("when scanner.Run() is interrupted", func() {
+			BeforeEach(func() {
+				fakeScanner.RunStub = func(_ lager.Logger, signals <-chan os.Signal, _ string) (time.Duration, error) {
+					<-signals
+					return interval, ErrInterrupted
+				}
+			})
+
+			It("passes the signals along and exits", func() {
+				Eventually(fakeScanner.RunCallCount).Should(Equal(1))
+
+				signalCh <- os.Interrupt
+				Expect(<-errCh).NotTo(HaveOccurred())
+			})
+		})
+
 		Context("when scanner.Run() returns ErrFailedToAcquireLease error", func() {
 			BeforeEach(func() {
-				fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+				fakeScanner.RunStub = func(lager.Logger, <-chan os.Signal, string) (time.Duration, error) {
 					times <- fakeClock.Now()
 					return interval, ErrFailedToAcquireLease
 				}
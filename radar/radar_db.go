@@ -30,6 +30,8 @@ type RadarDB interface {
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
 	SaveResourceTypeVersion(atc.ResourceType, atc.Version) error
 	SetResourceCheckError(resource db.SavedResource, err error) error
-	AcquireResourceCheckingLock(logger lager.Logger, resource db.SavedResource, interval time.Duration, immediate bool) (db.Lock, bool, error)
+	SetResourceNextCheckAt(resource db.SavedResource, nextCheckAt time.Time) error
+	SaveResourceCheck(resource db.SavedResource, check db.ResourceCheck) error
+	AcquireResourceCheckingLock(logger lager.Logger, resource db.SavedResource, resourceHash string, interval time.Duration, immediate bool) (db.Lock, bool, error)
 	AcquireResourceTypeCheckingLock(logger lager.Logger, resourceType db.SavedResourceType, interval time.Duration, immediate bool) (db.Lock, bool, error)
 }
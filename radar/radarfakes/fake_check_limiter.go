@@ -0,0 +1,85 @@
+// This file was generated by counterfeiter
+package radarfakes
+
+import (
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/radar"
+)
+
+type FakeCheckLimiter struct {
+	AcquireStub        func(logger lager.Logger, signals <-chan os.Signal, teamID int) (db.Lock, bool)
+	acquireMutex       sync.RWMutex
+	acquireArgsForCall []struct {
+		logger  lager.Logger
+		signals <-chan os.Signal
+		teamID  int
+	}
+	acquireReturns struct {
+		result1 db.Lock
+		result2 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCheckLimiter) Acquire(logger lager.Logger, signals <-chan os.Signal, teamID int) (db.Lock, bool) {
+	fake.acquireMutex.Lock()
+	fake.acquireArgsForCall = append(fake.acquireArgsForCall, struct {
+		logger  lager.Logger
+		signals <-chan os.Signal
+		teamID  int
+	}{logger, signals, teamID})
+	fake.recordInvocation("Acquire", []interface{}{logger, signals, teamID})
+	fake.acquireMutex.Unlock()
+	if fake.AcquireStub != nil {
+		return fake.AcquireStub(logger, signals, teamID)
+	} else {
+		return fake.acquireReturns.result1, fake.acquireReturns.result2
+	}
+}
+
+func (fake *FakeCheckLimiter) AcquireCallCount() int {
+	fake.acquireMutex.RLock()
+	defer fake.acquireMutex.RUnlock()
+	return len(fake.acquireArgsForCall)
+}
+
+func (fake *FakeCheckLimiter) AcquireArgsForCall(i int) (lager.Logger, <-chan os.Signal, int) {
+	fake.acquireMutex.RLock()
+	defer fake.acquireMutex.RUnlock()
+	return fake.acquireArgsForCall[i].logger, fake.acquireArgsForCall[i].signals, fake.acquireArgsForCall[i].teamID
+}
+
+func (fake *FakeCheckLimiter) AcquireReturns(result1 db.Lock, result2 bool) {
+	fake.AcquireStub = nil
+	fake.acquireReturns = struct {
+		result1 db.Lock
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeCheckLimiter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.acquireMutex.RLock()
+	defer fake.acquireMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCheckLimiter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ radar.CheckLimiter = new(FakeCheckLimiter)
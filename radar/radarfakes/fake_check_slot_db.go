@@ -0,0 +1,88 @@
+// This file was generated by counterfeiter
+package radarfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/radar"
+)
+
+type FakeCheckSlotDB struct {
+	AcquireResourceCheckSlotStub        func(logger lager.Logger, teamID int, globalLimit int, teamLimit int) (db.Lock, bool, error)
+	acquireResourceCheckSlotMutex       sync.RWMutex
+	acquireResourceCheckSlotArgsForCall []struct {
+		logger      lager.Logger
+		teamID      int
+		globalLimit int
+		teamLimit   int
+	}
+	acquireResourceCheckSlotReturns struct {
+		result1 db.Lock
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCheckSlotDB) AcquireResourceCheckSlot(logger lager.Logger, teamID int, globalLimit int, teamLimit int) (db.Lock, bool, error) {
+	fake.acquireResourceCheckSlotMutex.Lock()
+	fake.acquireResourceCheckSlotArgsForCall = append(fake.acquireResourceCheckSlotArgsForCall, struct {
+		logger      lager.Logger
+		teamID      int
+		globalLimit int
+		teamLimit   int
+	}{logger, teamID, globalLimit, teamLimit})
+	fake.recordInvocation("AcquireResourceCheckSlot", []interface{}{logger, teamID, globalLimit, teamLimit})
+	fake.acquireResourceCheckSlotMutex.Unlock()
+	if fake.AcquireResourceCheckSlotStub != nil {
+		return fake.AcquireResourceCheckSlotStub(logger, teamID, globalLimit, teamLimit)
+	} else {
+		return fake.acquireResourceCheckSlotReturns.result1, fake.acquireResourceCheckSlotReturns.result2, fake.acquireResourceCheckSlotReturns.result3
+	}
+}
+
+func (fake *FakeCheckSlotDB) AcquireResourceCheckSlotCallCount() int {
+	fake.acquireResourceCheckSlotMutex.RLock()
+	defer fake.acquireResourceCheckSlotMutex.RUnlock()
+	return len(fake.acquireResourceCheckSlotArgsForCall)
+}
+
+func (fake *FakeCheckSlotDB) AcquireResourceCheckSlotArgsForCall(i int) (lager.Logger, int, int, int) {
+	fake.acquireResourceCheckSlotMutex.RLock()
+	defer fake.acquireResourceCheckSlotMutex.RUnlock()
+	return fake.acquireResourceCheckSlotArgsForCall[i].logger, fake.acquireResourceCheckSlotArgsForCall[i].teamID, fake.acquireResourceCheckSlotArgsForCall[i].globalLimit, fake.acquireResourceCheckSlotArgsForCall[i].teamLimit
+}
+
+func (fake *FakeCheckSlotDB) AcquireResourceCheckSlotReturns(result1 db.Lock, result2 bool, result3 error) {
+	fake.AcquireResourceCheckSlotStub = nil
+	fake.acquireResourceCheckSlotReturns = struct {
+		result1 db.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCheckSlotDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.acquireResourceCheckSlotMutex.RLock()
+	defer fake.acquireResourceCheckSlotMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCheckSlotDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ radar.CheckSlotDB = new(FakeCheckSlotDB)
@@ -131,13 +131,32 @@ type FakeRadarDB struct {
 	setResourceCheckErrorReturns struct {
 		result1 error
 	}
-	AcquireResourceCheckingLockStub        func(logger lager.Logger, resource db.SavedResource, interval time.Duration, immediate bool) (db.Lock, bool, error)
+	SetResourceNextCheckAtStub        func(resource db.SavedResource, nextCheckAt time.Time) error
+	setResourceNextCheckAtMutex       sync.RWMutex
+	setResourceNextCheckAtArgsForCall []struct {
+		resource    db.SavedResource
+		nextCheckAt time.Time
+	}
+	setResourceNextCheckAtReturns struct {
+		result1 error
+	}
+	SaveResourceCheckStub        func(resource db.SavedResource, check db.ResourceCheck) error
+	saveResourceCheckMutex       sync.RWMutex
+	saveResourceCheckArgsForCall []struct {
+		resource db.SavedResource
+		check    db.ResourceCheck
+	}
+	saveResourceCheckReturns struct {
+		result1 error
+	}
+	AcquireResourceCheckingLockStub        func(logger lager.Logger, resource db.SavedResource, resourceHash string, interval time.Duration, immediate bool) (db.Lock, bool, error)
 	acquireResourceCheckingLockMutex       sync.RWMutex
 	acquireResourceCheckingLockArgsForCall []struct {
-		logger    lager.Logger
-		resource  db.SavedResource
-		interval  time.Duration
-		immediate bool
+		logger       lager.Logger
+		resource     db.SavedResource
+		resourceHash string
+		interval     time.Duration
+		immediate    bool
 	}
 	acquireResourceCheckingLockReturns struct {
 		result1 db.Lock
@@ -624,18 +643,87 @@ func (fake *FakeRadarDB) SetResourceCheckErrorReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeRadarDB) AcquireResourceCheckingLock(logger lager.Logger, resource db.SavedResource, interval time.Duration, immediate bool) (db.Lock, bool, error) {
+func (fake *FakeRadarDB) SetResourceNextCheckAt(resource db.SavedResource, nextCheckAt time.Time) error {
+	fake.setResourceNextCheckAtMutex.Lock()
+	fake.setResourceNextCheckAtArgsForCall = append(fake.setResourceNextCheckAtArgsForCall, struct {
+		resource    db.SavedResource
+		nextCheckAt time.Time
+	}{resource, nextCheckAt})
+	fake.recordInvocation("SetResourceNextCheckAt", []interface{}{resource, nextCheckAt})
+	fake.setResourceNextCheckAtMutex.Unlock()
+	if fake.SetResourceNextCheckAtStub != nil {
+		return fake.SetResourceNextCheckAtStub(resource, nextCheckAt)
+	} else {
+		return fake.setResourceNextCheckAtReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) SetResourceNextCheckAtCallCount() int {
+	fake.setResourceNextCheckAtMutex.RLock()
+	defer fake.setResourceNextCheckAtMutex.RUnlock()
+	return len(fake.setResourceNextCheckAtArgsForCall)
+}
+
+func (fake *FakeRadarDB) SetResourceNextCheckAtArgsForCall(i int) (db.SavedResource, time.Time) {
+	fake.setResourceNextCheckAtMutex.RLock()
+	defer fake.setResourceNextCheckAtMutex.RUnlock()
+	return fake.setResourceNextCheckAtArgsForCall[i].resource, fake.setResourceNextCheckAtArgsForCall[i].nextCheckAt
+}
+
+func (fake *FakeRadarDB) SetResourceNextCheckAtReturns(result1 error) {
+	fake.SetResourceNextCheckAtStub = nil
+	fake.setResourceNextCheckAtReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRadarDB) SaveResourceCheck(resource db.SavedResource, check db.ResourceCheck) error {
+	fake.saveResourceCheckMutex.Lock()
+	fake.saveResourceCheckArgsForCall = append(fake.saveResourceCheckArgsForCall, struct {
+		resource db.SavedResource
+		check    db.ResourceCheck
+	}{resource, check})
+	fake.recordInvocation("SaveResourceCheck", []interface{}{resource, check})
+	fake.saveResourceCheckMutex.Unlock()
+	if fake.SaveResourceCheckStub != nil {
+		return fake.SaveResourceCheckStub(resource, check)
+	} else {
+		return fake.saveResourceCheckReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) SaveResourceCheckCallCount() int {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return len(fake.saveResourceCheckArgsForCall)
+}
+
+func (fake *FakeRadarDB) SaveResourceCheckArgsForCall(i int) (db.SavedResource, db.ResourceCheck) {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return fake.saveResourceCheckArgsForCall[i].resource, fake.saveResourceCheckArgsForCall[i].check
+}
+
+func (fake *FakeRadarDB) SaveResourceCheckReturns(result1 error) {
+	fake.SaveResourceCheckStub = nil
+	fake.saveResourceCheckReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRadarDB) AcquireResourceCheckingLock(logger lager.Logger, resource db.SavedResource, resourceHash string, interval time.Duration, immediate bool) (db.Lock, bool, error) {
 	fake.acquireResourceCheckingLockMutex.Lock()
 	fake.acquireResourceCheckingLockArgsForCall = append(fake.acquireResourceCheckingLockArgsForCall, struct {
-		logger    lager.Logger
-		resource  db.SavedResource
-		interval  time.Duration
-		immediate bool
-	}{logger, resource, interval, immediate})
-	fake.recordInvocation("AcquireResourceCheckingLock", []interface{}{logger, resource, interval, immediate})
+		logger       lager.Logger
+		resource     db.SavedResource
+		resourceHash string
+		interval     time.Duration
+		immediate    bool
+	}{logger, resource, resourceHash, interval, immediate})
+	fake.recordInvocation("AcquireResourceCheckingLock", []interface{}{logger, resource, resourceHash, interval, immediate})
 	fake.acquireResourceCheckingLockMutex.Unlock()
 	if fake.AcquireResourceCheckingLockStub != nil {
-		return fake.AcquireResourceCheckingLockStub(logger, resource, interval, immediate)
+		return fake.AcquireResourceCheckingLockStub(logger, resource, resourceHash, interval, immediate)
 	} else {
 		return fake.acquireResourceCheckingLockReturns.result1, fake.acquireResourceCheckingLockReturns.result2, fake.acquireResourceCheckingLockReturns.result3
 	}
@@ -647,10 +735,10 @@ func (fake *FakeRadarDB) AcquireResourceCheckingLockCallCount() int {
 	return len(fake.acquireResourceCheckingLockArgsForCall)
 }
 
-func (fake *FakeRadarDB) AcquireResourceCheckingLockArgsForCall(i int) (lager.Logger, db.SavedResource, time.Duration, bool) {
+func (fake *FakeRadarDB) AcquireResourceCheckingLockArgsForCall(i int) (lager.Logger, db.SavedResource, string, time.Duration, bool) {
 	fake.acquireResourceCheckingLockMutex.RLock()
 	defer fake.acquireResourceCheckingLockMutex.RUnlock()
-	return fake.acquireResourceCheckingLockArgsForCall[i].logger, fake.acquireResourceCheckingLockArgsForCall[i].resource, fake.acquireResourceCheckingLockArgsForCall[i].interval, fake.acquireResourceCheckingLockArgsForCall[i].immediate
+	return fake.acquireResourceCheckingLockArgsForCall[i].logger, fake.acquireResourceCheckingLockArgsForCall[i].resource, fake.acquireResourceCheckingLockArgsForCall[i].resourceHash, fake.acquireResourceCheckingLockArgsForCall[i].interval, fake.acquireResourceCheckingLockArgsForCall[i].immediate
 }
 
 func (fake *FakeRadarDB) AcquireResourceCheckingLockReturns(result1 db.Lock, result2 bool, result3 error) {
@@ -733,6 +821,10 @@ func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	defer fake.saveResourceTypeVersionMutex.RUnlock()
 	fake.setResourceCheckErrorMutex.RLock()
 	defer fake.setResourceCheckErrorMutex.RUnlock()
+	fake.setResourceNextCheckAtMutex.RLock()
+	defer fake.setResourceNextCheckAtMutex.RUnlock()
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
 	fake.acquireResourceCheckingLockMutex.RLock()
 	defer fake.acquireResourceCheckingLockMutex.RUnlock()
 	fake.acquireResourceTypeCheckingLockMutex.RLock()
@@ -2,6 +2,8 @@ package radar
 
 import (
 	"errors"
+	"math/rand"
+	"os"
 	"reflect"
 	"time"
 
@@ -17,6 +19,9 @@ type resourceScanner struct {
 	clock           clock.Clock
 	tracker         resource.Tracker
 	defaultInterval time.Duration
+	checkJitter     time.Duration
+	maxCheckBackoff time.Duration
+	checkLimiter    CheckLimiter
 	db              RadarDB
 	externalURL     string
 }
@@ -25,6 +30,9 @@ func NewResourceScanner(
 	clock clock.Clock,
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	checkJitter time.Duration,
+	maxCheckBackoff time.Duration,
+	checkLimiter CheckLimiter,
 	db RadarDB,
 	externalURL string,
 ) Scanner {
@@ -32,6 +40,9 @@ func NewResourceScanner(
 		clock:           clock,
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
+		checkJitter:     checkJitter,
+		maxCheckBackoff: maxCheckBackoff,
+		checkLimiter:    checkLimiter,
 		db:              db,
 		externalURL:     externalURL,
 	}
@@ -39,7 +50,11 @@ func NewResourceScanner(
 
 var ErrFailedToAcquireLease = errors.New("failed-to-acquire-lock")
 
-func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (time.Duration, error) {
+// ErrInterrupted is returned by Run when it is signalled while waiting for a
+// check slot.
+var ErrInterrupted = errors.New("interrupted")
+
+func (scanner *resourceScanner) Run(logger lager.Logger, signals <-chan os.Signal, resourceName string) (time.Duration, error) {
 	savedResource, found, err := scanner.db.GetResource(resourceName)
 	if err != nil {
 		return 0, err
@@ -59,11 +74,37 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 		return 0, err
 	}
 
+	// the check interval is shared with other pipelines, so a paused
+	// pipeline or resource must not take its turn
+	paused, err := scanner.isPaused(logger, savedResource)
+	if err != nil {
+		return interval, err
+	}
+
+	if paused {
+		return scanner.scheduleNextCheck(logger, savedResource, interval, savedResource.ConsecutiveCheckFailures), nil
+	}
+
+	// the slot is taken before the lock so that the lock is not held, and
+	// the interval not used up, while the check waits its turn
+	slot, acquired := scanner.checkLimiter.Acquire(logger, signals, scanner.db.TeamID())
+	if !acquired {
+		return interval, ErrInterrupted
+	}
+
+	defer slot.Release()
+
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource": resourceName,
 	})
 
-	lock, acquired, err := scanner.db.AcquireResourceCheckingLock(logger, savedResource, interval, false)
+	lock, acquired, err := scanner.db.AcquireResourceCheckingLock(
+		logger,
+		savedResource,
+		resource.GenerateResourceHash(savedResource.Config.Source, savedResource.Config.Type),
+		interval,
+		false,
+	)
 
 	if err != nil {
 		lockLogger.Error("failed-to-get-lock", err, lager.Data{
@@ -74,7 +115,7 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 
 	if !acquired {
 		lockLogger.Debug("did-not-get-lock")
-		return interval, ErrFailedToAcquireLease
+		return scanner.scheduleNextCheck(logger, savedResource, interval, savedResource.ConsecutiveCheckFailures), ErrFailedToAcquireLease
 	}
 
 	defer lock.Release()
@@ -85,9 +126,16 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 		return interval, err
 	}
 
-	err = swallowErrResourceScriptFailed(
-		scanner.scan(logger.Session("tick"), savedResource, atc.Version(vr.Version)),
-	)
+	scanErr := scanner.scan(logger.Session("tick"), savedResource, atc.Version(vr.Version))
+
+	failures := 0
+	if scanErr != nil {
+		failures = savedResource.ConsecutiveCheckFailures + 1
+	}
+
+	interval = scanner.scheduleNextCheck(logger, savedResource, interval, failures)
+
+	err = swallowErrResourceScriptFailed(scanErr)
 	if err != nil {
 		return interval, err
 	}
@@ -123,8 +171,18 @@ func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceNam
 	}
 
 	for {
-		lock, acquired, err := scanner.db.AcquireResourceCheckingLock(logger, savedResource, interval, true)
+		slot, _ := scanner.checkLimiter.Acquire(logger, nil, scanner.db.TeamID())
+
+		lock, acquired, err := scanner.db.AcquireResourceCheckingLock(
+			logger,
+			savedResource,
+			resource.GenerateResourceHash(savedResource.Config.Source, savedResource.Config.Type),
+			interval,
+			true,
+		)
 		if err != nil {
+			slot.Release()
+
 			lockLogger.Error("failed-to-get-lock", err, lager.Data{
 				"resource": resourceName,
 			})
@@ -133,11 +191,15 @@ func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceNam
 		}
 
 		if !acquired {
+			// do not hold on to a slot while waiting for the lock
+			slot.Release()
+
 			lockLogger.Debug("did-not-get-lock")
 			scanner.clock.Sleep(time.Second)
 			continue
 		}
 
+		defer slot.Release()
 		defer lock.Release()
 
 		break
@@ -163,19 +225,12 @@ func (scanner *resourceScanner) scan(
 	savedResource db.SavedResource,
 	fromVersion atc.Version,
 ) error {
-	pipelinePaused, err := scanner.db.IsPaused()
+	paused, err := scanner.isPaused(logger, savedResource)
 	if err != nil {
-		logger.Error("failed-to-check-if-pipeline-paused", err)
 		return err
 	}
 
-	if pipelinePaused {
-		logger.Debug("pipeline-paused")
-		return nil
-	}
-
-	if savedResource.Paused {
-		logger.Debug("resource-paused")
+	if paused {
 		return nil
 	}
 
@@ -217,6 +272,10 @@ func (scanner *resourceScanner) scan(
 		return errPipelineRemoved
 	}
 
+	check := db.ResourceCheck{
+		StartTime: scanner.clock.Now(),
+	}
+
 	res, err := scanner.tracker.Init(
 		logger,
 		resource.TrackerMetadata{
@@ -233,6 +292,11 @@ func (scanner *resourceScanner) scan(
 	)
 	if err != nil {
 		logger.Error("failed-to-initialize-new-resource", err)
+
+		check.EndTime = scanner.clock.Now()
+		check.CheckError = err
+		scanner.saveCheck(logger, savedResource, check)
+
 		return err
 	}
 
@@ -244,12 +308,13 @@ func (scanner *resourceScanner) scan(
 
 	newVersions, err := res.Check(savedResource.Config.Source, fromVersion)
 
-	setErr := scanner.db.SetResourceCheckError(savedResource, err)
-	if setErr != nil {
-		logger.Error("failed-to-set-check-error", err)
-	}
+	check.EndTime = scanner.clock.Now()
+	check.CheckError = err
+	check.WorkerName = res.WorkerName()
 
 	if err != nil {
+		scanner.saveCheck(logger, savedResource, check)
+
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
 			return rErr
@@ -261,6 +326,7 @@ func (scanner *resourceScanner) scan(
 
 	if len(newVersions) == 0 || reflect.DeepEqual(newVersions, []atc.Version{fromVersion}) {
 		logger.Debug("no-new-versions")
+		scanner.saveCheck(logger, savedResource, check)
 		return nil
 	}
 
@@ -269,6 +335,13 @@ func (scanner *resourceScanner) scan(
 		"total":    len(newVersions),
 	})
 
+	check.NewVersions = len(newVersions)
+	if reflect.DeepEqual(newVersions[0], fromVersion) {
+		check.NewVersions--
+	}
+
+	scanner.saveCheck(logger, savedResource, check)
+
 	err = scanner.db.SaveResourceVersions(savedResource.Config, newVersions)
 	if err != nil {
 		logger.Error("failed-to-save-versions", err, lager.Data{
@@ -279,6 +352,35 @@ func (scanner *resourceScanner) scan(
 	return nil
 }
 
+// saveCheck records the check, which also sets or clears the check error of
+// the resource and of every resource it was checked for.
+func (scanner *resourceScanner) saveCheck(logger lager.Logger, savedResource db.SavedResource, check db.ResourceCheck) {
+	err := scanner.db.SaveResourceCheck(savedResource, check)
+	if err != nil {
+		logger.Error("failed-to-save-check", err)
+	}
+}
+
+func (scanner *resourceScanner) isPaused(logger lager.Logger, savedResource db.SavedResource) (bool, error) {
+	pipelinePaused, err := scanner.db.IsPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-paused", err)
+		return false, err
+	}
+
+	if pipelinePaused {
+		logger.Debug("pipeline-paused")
+		return true, nil
+	}
+
+	if savedResource.Paused {
+		logger.Debug("resource-paused")
+		return true, nil
+	}
+
+	return false, nil
+}
+
 func swallowErrResourceScriptFailed(err error) error {
 	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
 		return nil
@@ -300,4 +402,42 @@ func (scanner *resourceScanner) checkInterval(resourceConfig atc.ResourceConfig)
 	return interval, nil
 }
 
+// scheduleNextCheck determines how long to wait before checking the resource
+// again and records when that will be.
+func (scanner *resourceScanner) scheduleNextCheck(
+	logger lager.Logger,
+	savedResource db.SavedResource,
+	interval time.Duration,
+	consecutiveFailures int,
+) time.Duration {
+	next := scanner.nextCheckInterval(interval, consecutiveFailures)
+
+	err := scanner.db.SetResourceNextCheckAt(savedResource, scanner.clock.Now().Add(next))
+	if err != nil {
+		logger.Error("failed-to-set-next-check", err)
+	}
+
+	return next
+}
+
+// nextCheckInterval doubles the interval for every consecutive failed check,
+// up to the maximum backoff, and adds a random jitter so that resources with
+// the same interval are not all checked at once.
+func (scanner *resourceScanner) nextCheckInterval(interval time.Duration, consecutiveFailures int) time.Duration {
+	next := interval
+	for i := 0; i < consecutiveFailures && next < scanner.maxCheckBackoff; i++ {
+		next *= 2
+
+		if next > scanner.maxCheckBackoff {
+			next = scanner.maxCheckBackoff
+		}
+	}
+
+	if scanner.checkJitter > 0 {
+		next += time.Duration(rand.Int63n(int64(scanner.checkJitter)))
+	}
+
+	return next
+}
+
 var errPipelineRemoved = errors.New("pipeline removed")
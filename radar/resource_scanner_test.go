@@ -2,6 +2,7 @@ package radar_test
 
 import (
 	"errors"
+	"os"
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
@@ -29,6 +30,9 @@ var _ = Describe("ResourceScanner", func() {
 		fakeClock   *fakeclock.FakeClock
 		interval    time.Duration
 
+		fakeCheckLimiter *radarfakes.FakeCheckLimiter
+		fakeCheckSlot    *dbfakes.FakeLock
+
 		scanner Scanner
 
 		resourceConfig atc.ResourceConfig
@@ -45,11 +49,18 @@ var _ = Describe("ResourceScanner", func() {
 		fakeClock = fakeclock.NewFakeClock(epoch)
 		interval = 1 * time.Minute
 
+		fakeCheckSlot = new(dbfakes.FakeLock)
+		fakeCheckLimiter = new(radarfakes.FakeCheckLimiter)
+		fakeCheckLimiter.AcquireReturns(fakeCheckSlot, true)
+
 		fakeRadarDB.GetPipelineIDReturns(42)
 		scanner = NewResourceScanner(
 			fakeClock,
 			fakeTracker,
 			interval,
+			0,
+			10*time.Minute,
+			fakeCheckLimiter,
 			fakeRadarDB,
 			"https://www.example.com",
 		)
@@ -97,6 +108,7 @@ var _ = Describe("ResourceScanner", func() {
 	Describe("Run", func() {
 		var (
 			fakeResource   *rfakes.FakeResource
+			signals        chan os.Signal
 			actualInterval time.Duration
 			runErr         error
 		)
@@ -104,10 +116,45 @@ var _ = Describe("ResourceScanner", func() {
 		BeforeEach(func() {
 			fakeResource = new(rfakes.FakeResource)
 			fakeTracker.InitReturns(fakeResource, nil)
+
+			signals = make(chan os.Signal)
 		})
 
 		JustBeforeEach(func() {
-			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), "some-resource")
+			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), signals, "some-resource")
+		})
+
+		Context("when the resource is checked", func() {
+			var slotsTakenBeforeLock int
+
+			BeforeEach(func() {
+				fakeRadarDB.AcquireResourceCheckingLockStub = func(lager.Logger, db.SavedResource, string, time.Duration, bool) (db.Lock, bool, error) {
+					slotsTakenBeforeLock = fakeCheckLimiter.AcquireCallCount()
+					return fakeLease, true, nil
+				}
+			})
+
+			It("takes a check slot for the team before the lock", func() {
+				Expect(slotsTakenBeforeLock).To(Equal(1))
+
+				_, slotSignals, teamID := fakeCheckLimiter.AcquireArgsForCall(0)
+				Expect(slotSignals).To(Equal((<-chan os.Signal)(signals)))
+				Expect(teamID).To(Equal(123))
+
+				Expect(fakeCheckSlot.ReleaseCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when interrupted while waiting for a check slot", func() {
+			BeforeEach(func() {
+				fakeCheckLimiter.AcquireReturns(nil, false)
+			})
+
+			It("returns ErrInterrupted without taking the lock", func() {
+				Expect(runErr).To(Equal(ErrInterrupted))
+				Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(BeZero())
+				Expect(fakeResource.CheckCallCount()).To(BeZero())
+			})
 		})
 
 		Context("when the lock cannot be acquired", func() {
@@ -123,6 +170,17 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(runErr).To(Equal(ErrFailedToAcquireLease))
 				Expect(actualInterval).To(Equal(interval))
 			})
+
+			Context("when the last checks of the resource failed", func() {
+				BeforeEach(func() {
+					savedResource.ConsecutiveCheckFailures = 1
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+				})
+
+				It("keeps backing off", func() {
+					Expect(actualInterval).To(Equal(2 * interval))
+				})
+			})
 		})
 
 		Context("when the lock can be acquired", func() {
@@ -179,7 +237,7 @@ var _ = Describe("ResourceScanner", func() {
 				It("leases for the configured interval", func() {
 					Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(Equal(1))
 
-					_, resource, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
+					_, resource, _, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
 					Expect(resource.Name).To(Equal("some-resource"))
 					Expect(leaseInterval).To(Equal(10 * time.Millisecond))
 					Expect(immediate).To(BeFalse())
@@ -214,7 +272,7 @@ var _ = Describe("ResourceScanner", func() {
 			It("grabs a periodic resource checking lock before checking, breaks lock after done", func() {
 				Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(Equal(1))
 
-				_, resource, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
+				_, resource, _, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
 				Expect(resource.Name).To(Equal("some-resource"))
 				Expect(leaseInterval).To(Equal(interval))
 				Expect(immediate).To(BeFalse())
@@ -222,6 +280,14 @@ var _ = Describe("ResourceScanner", func() {
 				Eventually(fakeLease.BreakCallCount).Should(Equal(1))
 			})
 
+			It("shares the lock with resources of the same type and source", func() {
+				_, _, resourceHash, _, _ := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
+				Expect(resourceHash).To(Equal(resource.GenerateResourceHash(
+					atc.Source{"uri": "http://example.com"},
+					"git",
+				)))
+			})
+
 			It("releases after checking", func() {
 				Eventually(fakeResource.ReleaseCallCount).Should(Equal(1))
 			})
@@ -336,6 +402,78 @@ var _ = Describe("ResourceScanner", func() {
 				It("returns no error", func() {
 					Expect(runErr).NotTo(HaveOccurred())
 				})
+
+				It("backs off", func() {
+					Expect(actualInterval).To(Equal(2 * interval))
+				})
+
+				It("records when the next check will be", func() {
+					Expect(fakeRadarDB.SetResourceNextCheckAtCallCount()).To(Equal(1))
+
+					resource, nextCheckAt := fakeRadarDB.SetResourceNextCheckAtArgsForCall(0)
+					Expect(resource).To(Equal(savedResource))
+					Expect(nextCheckAt).To(Equal(epoch.Add(2 * interval)))
+				})
+
+				Context("when the previous checks failed too", func() {
+					BeforeEach(func() {
+						savedResource.ConsecutiveCheckFailures = 2
+						fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+					})
+
+					It("backs off exponentially", func() {
+						Expect(actualInterval).To(Equal(8 * interval))
+					})
+				})
+
+				Context("when the checks have been failing for a long time", func() {
+					BeforeEach(func() {
+						savedResource.ConsecutiveCheckFailures = 20
+						fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+					})
+
+					It("backs off no further than the maximum", func() {
+						Expect(actualInterval).To(Equal(10 * time.Minute))
+					})
+				})
+			})
+
+			Context("when the check succeeds after failing", func() {
+				BeforeEach(func() {
+					savedResource.ConsecutiveCheckFailures = 3
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+				})
+
+				It("resets the backoff", func() {
+					Expect(actualInterval).To(Equal(interval))
+				})
+
+				It("records when the next check will be", func() {
+					Expect(fakeRadarDB.SetResourceNextCheckAtCallCount()).To(Equal(1))
+
+					_, nextCheckAt := fakeRadarDB.SetResourceNextCheckAtArgsForCall(0)
+					Expect(nextCheckAt).To(Equal(epoch.Add(interval)))
+				})
+			})
+
+			Context("when jitter is configured", func() {
+				BeforeEach(func() {
+					scanner = NewResourceScanner(
+						fakeClock,
+						fakeTracker,
+						interval,
+						10*time.Second,
+						10*time.Minute,
+						fakeCheckLimiter,
+						fakeRadarDB,
+						"https://www.example.com",
+					)
+				})
+
+				It("adds up to that much to the interval", func() {
+					Expect(actualInterval).To(BeNumerically(">=", interval))
+					Expect(actualInterval).To(BeNumerically("<", interval+10*time.Second))
+				})
 			})
 
 			Context("when the pipeline is paused", func() {
@@ -347,6 +485,10 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(fakeResource.CheckCallCount()).To(BeZero())
 				})
 
+				It("does not take the lock away from other pipelines", func() {
+					Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(BeZero())
+				})
+
 				It("returns the default interval", func() {
 					Expect(actualInterval).To(Equal(interval))
 				})
@@ -370,6 +512,10 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(fakeResource.CheckCallCount()).To(BeZero())
 				})
 
+				It("does not take the lock away from other pipelines", func() {
+					Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(BeZero())
+				})
+
 				It("returns the default interval", func() {
 					Expect(actualInterval).To(Equal(interval))
 				})
@@ -474,7 +620,7 @@ var _ = Describe("ResourceScanner", func() {
 			It("grabs an immediate resource checking lock before checking, breaks lock after done", func() {
 				Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(Equal(1))
 
-				_, resource, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
+				_, resource, _, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
 				Expect(resource.Name).To(Equal("some-resource"))
 				Expect(leaseInterval).To(Equal(interval))
 				Expect(immediate).To(BeTrue())
@@ -491,7 +637,7 @@ var _ = Describe("ResourceScanner", func() {
 				It("leases for the configured interval", func() {
 					Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(Equal(1))
 
-					_, resource, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
+					_, resource, _, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
 					Expect(resource.Name).To(Equal("some-resource"))
 					Expect(leaseInterval).To(Equal(10 * time.Millisecond))
 					Expect(immediate).To(BeTrue())
@@ -525,7 +671,7 @@ var _ = Describe("ResourceScanner", func() {
 					results <- true
 					close(results)
 
-					fakeRadarDB.AcquireResourceCheckingLockStub = func(logger lager.Logger, resource db.SavedResource, interval time.Duration, immediate bool) (db.Lock, bool, error) {
+					fakeRadarDB.AcquireResourceCheckingLockStub = func(logger lager.Logger, resource db.SavedResource, resourceHash string, interval time.Duration, immediate bool) (db.Lock, bool, error) {
 						if <-results {
 							return fakeLease, true, nil
 						} else {
@@ -539,35 +685,88 @@ var _ = Describe("ResourceScanner", func() {
 				It("retries every second until it is", func() {
 					Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(Equal(3))
 
-					_, resource, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
+					_, resource, _, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
 					Expect(resource.Name).To(Equal("some-resource"))
 					Expect(leaseInterval).To(Equal(interval))
 					Expect(immediate).To(BeTrue())
 
-					_, resource, leaseInterval, immediate = fakeRadarDB.AcquireResourceCheckingLockArgsForCall(1)
+					_, resource, _, leaseInterval, immediate = fakeRadarDB.AcquireResourceCheckingLockArgsForCall(1)
 					Expect(resource.Name).To(Equal("some-resource"))
 					Expect(leaseInterval).To(Equal(interval))
 					Expect(immediate).To(BeTrue())
 
-					_, resource, leaseInterval, immediate = fakeRadarDB.AcquireResourceCheckingLockArgsForCall(2)
+					_, resource, _, leaseInterval, immediate = fakeRadarDB.AcquireResourceCheckingLockArgsForCall(2)
 					Expect(resource.Name).To(Equal("some-resource"))
 					Expect(leaseInterval).To(Equal(interval))
 					Expect(immediate).To(BeTrue())
 
 					Expect(fakeLease.BreakCallCount()).To(Equal(1))
 				})
+
+				It("gives up its check slot while it waits", func() {
+					Expect(fakeCheckLimiter.AcquireCallCount()).To(Equal(3))
+					Expect(fakeCheckSlot.ReleaseCallCount()).To(Equal(3))
+				})
+			})
+
+			It("waits for a check slot for the team before checking", func() {
+				Expect(fakeCheckLimiter.AcquireCallCount()).To(Equal(1))
+
+				_, _, teamID := fakeCheckLimiter.AcquireArgsForCall(0)
+				Expect(teamID).To(Equal(123))
+
+				Expect(fakeCheckSlot.ReleaseCallCount()).To(Equal(1))
+			})
+
+			Context("when initializing the resource fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeTracker.InitReturns(nil, disaster)
+				})
+
+				It("records the failed check", func() {
+					Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
+
+					_, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
+					Expect(check.CheckError).To(Equal(disaster))
+					Expect(check.WorkerName).To(BeEmpty())
+				})
 			})
 
 			It("releases the resource", func() {
 				Expect(fakeResource.ReleaseCallCount()).To(Equal(1))
 			})
 
-			It("clears the resource's check error", func() {
-				Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+			It("clears the resource's check error by recording a successful check", func() {
+				Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(BeZero())
+				Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
 
-				savedResourceArg, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+				savedResourceArg, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
 				Expect(savedResourceArg).To(Equal(savedResource))
-				Expect(err).To(BeNil())
+				Expect(check.CheckError).To(BeNil())
+			})
+
+			Context("when the check runs on a worker", func() {
+				BeforeEach(func() {
+					fakeResource.WorkerNameReturns("some-worker")
+					fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+						fakeClock.Increment(5 * time.Second)
+						return nil, nil
+					}
+				})
+
+				It("records the check", func() {
+					Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
+
+					savedResourceArg, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
+					Expect(savedResourceArg).To(Equal(savedResource))
+					Expect(check).To(Equal(db.ResourceCheck{
+						StartTime:  epoch,
+						EndTime:    epoch.Add(5 * time.Second),
+						WorkerName: "some-worker",
+					}))
+				})
 			})
 
 			Context("when there is no current version", func() {
@@ -623,6 +822,30 @@ var _ = Describe("ResourceScanner", func() {
 					It("does not save it", func() {
 						Expect(fakeRadarDB.SaveResourceVersionsCallCount()).To(Equal(0))
 					})
+
+					It("records a check with no new versions", func() {
+						Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
+
+						_, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
+						Expect(check.NewVersions).To(BeZero())
+					})
+				})
+
+				Context("when the check returns the latest version and newer ones", func() {
+					BeforeEach(func() {
+						fakeResource.CheckReturns([]atc.Version{
+							atc.Version(latestVersion),
+							{"version": "2"},
+							{"version": "3"},
+						}, nil)
+					})
+
+					It("records the number of new versions", func() {
+						Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
+
+						_, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
+						Expect(check.NewVersions).To(Equal(2))
+					})
 				})
 			})
 
@@ -676,6 +899,14 @@ var _ = Describe("ResourceScanner", func() {
 					}))
 
 				})
+
+				It("records the number of new versions", func() {
+					Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
+
+					_, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
+					Expect(check.NewVersions).To(Equal(3))
+					Expect(check.CheckError).To(BeNil())
+				})
 			})
 
 			Context("when checking fails internally", func() {
@@ -689,12 +920,13 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(scanErr).To(Equal(disaster))
 				})
 
-				It("sets the resource's check error", func() {
-					Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+				It("records the failed check, which sets the resource's check error", func() {
+					Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
 
-					savedResourceArg, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+					savedResourceArg, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
 					Expect(savedResourceArg).To(Equal(savedResource))
-					Expect(err).To(Equal(disaster))
+					Expect(check.CheckError).To(Equal(disaster))
+					Expect(check.Succeeded()).To(BeFalse())
 				})
 			})
 
@@ -709,12 +941,12 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(scanErr).NotTo(HaveOccurred())
 				})
 
-				It("sets the resource's check error", func() {
-					Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+				It("records the failed check, which sets the resource's check error", func() {
+					Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
 
-					savedResourceArg, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+					savedResourceArg, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
 					Expect(savedResourceArg).To(Equal(savedResource))
-					Expect(err).To(Equal(scriptFail))
+					Expect(check.CheckError).To(Equal(scriptFail))
 				})
 			})
 		})
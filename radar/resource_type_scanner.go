@@ -1,6 +1,7 @@
 package radar
 
 import (
+	"os"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -31,7 +32,7 @@ func NewResourceTypeScanner(
 	}
 }
 
-func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeName string) (time.Duration, error) {
+func (scanner *resourceTypeScanner) Run(logger lager.Logger, signals <-chan os.Signal, resourceTypeName string) (time.Duration, error) {
 	pipelinePaused, err := scanner.db.IsPaused()
 	if err != nil {
 		logger.Error("failed-to-check-if-pipeline-paused", err)
@@ -92,7 +92,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 		})
 
 		JustBeforeEach(func() {
-			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), "some-resource-type")
+			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), nil, "some-resource-type")
 		})
 
 		Context("when the lock cannot be acquired", func() {
@@ -1,6 +1,7 @@
 package radar
 
 import (
+	"os"
 	"time"
 
 	"github.com/concourse/atc"
@@ -14,7 +15,7 @@ import (
 //go:generate counterfeiter . Scanner
 
 type Scanner interface {
-	Run(lager.Logger, string) (time.Duration, error)
+	Run(lager.Logger, <-chan os.Signal, string) (time.Duration, error)
 	Scan(lager.Logger, string) error
 	ScanFromVersion(lager.Logger, string, atc.Version) error
 }
@@ -26,6 +27,7 @@ type ScanRunnerFactory interface {
 
 type scanRunnerFactory struct {
 	clock               clock.Clock
+	checkJitter         time.Duration
 	resourceScanner     Scanner
 	resourceTypeScanner Scanner
 }
@@ -33,6 +35,9 @@ type scanRunnerFactory struct {
 func NewScanRunnerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	checkJitter time.Duration,
+	maxCheckBackoff time.Duration,
+	checkLimiter CheckLimiter,
 	db RadarDB,
 	clock clock.Clock,
 	externalURL string,
@@ -41,6 +46,9 @@ func NewScanRunnerFactory(
 		clock,
 		tracker,
 		defaultInterval,
+		checkJitter,
+		maxCheckBackoff,
+		checkLimiter,
 		db,
 		externalURL,
 	)
@@ -53,17 +61,18 @@ func NewScanRunnerFactory(
 
 	return &scanRunnerFactory{
 		clock:               clock,
+		checkJitter:         checkJitter,
 		resourceScanner:     resourceScanner,
 		resourceTypeScanner: resourceTypeScanner,
 	}
 }
 
 func (sf *scanRunnerFactory) ScanResourceRunner(logger lager.Logger, name string) ifrit.Runner {
-	intervalRunner := NewIntervalRunner(logger, sf.clock, name, sf.resourceScanner)
+	intervalRunner := NewIntervalRunner(logger, sf.clock, name, sf.resourceScanner, sf.checkJitter)
 	return ifrit.RunFunc(intervalRunner.RunFunc)
 }
 
 func (sf *scanRunnerFactory) ScanResourceTypeRunner(logger lager.Logger, name string) ifrit.Runner {
-	intervalRunner := NewIntervalRunner(logger, sf.clock, name, sf.resourceTypeScanner)
+	intervalRunner := NewIntervalRunner(logger, sf.clock, name, sf.resourceTypeScanner, sf.checkJitter)
 	return ifrit.RunFunc(intervalRunner.RunFunc)
 }
@@ -14,21 +14,30 @@ type ScannerFactory interface {
 type scannerFactory struct {
 	tracker         resource.Tracker
 	defaultInterval time.Duration
+	checkJitter     time.Duration
+	maxCheckBackoff time.Duration
+	checkLimiter    CheckLimiter
 	externalURL     string
 }
 
 func NewScannerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	checkJitter time.Duration,
+	maxCheckBackoff time.Duration,
+	checkLimiter CheckLimiter,
 	externalURL string,
 ) ScannerFactory {
 	return &scannerFactory{
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
+		checkJitter:     checkJitter,
+		maxCheckBackoff: maxCheckBackoff,
+		checkLimiter:    checkLimiter,
 		externalURL:     externalURL,
 	}
 }
 
 func (f *scannerFactory) NewResourceScanner(db RadarDB) Scanner {
-	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.externalURL)
+	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, f.checkJitter, f.maxCheckBackoff, f.checkLimiter, db, f.externalURL)
 }
@@ -10,4 +10,19 @@ type Resource struct {
 
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
+
+	ConsecutiveCheckFailures int   `json:"consecutive_check_failures,omitempty"`
+	NextCheckAt              int64 `json:"next_check_at,omitempty"`
+}
+
+type ResourceCheck struct {
+	ID        int   `json:"id"`
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+
+	Succeeded  bool   `json:"succeeded"`
+	CheckError string `json:"check_error,omitempty"`
+
+	NewVersions int    `json:"new_versions"`
+	WorkerName  string `json:"worker_name,omitempty"`
 }
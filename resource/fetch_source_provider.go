@@ -21,13 +21,14 @@ type FetchSourceProviderFactory interface {
 		cacheIdentifier CacheIdentifier,
 		resourceOptions ResourceOptions,
 		containerCreator FetchContainerCreator,
+		imageFetchingDelegate worker.ImageFetchingDelegate,
 	) FetchSourceProvider
 }
 
 //go:generate counterfeiter . FetchSourceProvider
 
 type FetchSourceProvider interface {
-	Get() (FetchSource, error)
+	Get(signals <-chan os.Signal) (FetchSource, error)
 }
 
 //go:generate counterfeiter . FetchSource
@@ -59,33 +60,36 @@ func (f *fetchSourceProviderFactory) NewFetchSourceProvider(
 	cacheIdentifier CacheIdentifier,
 	resourceOptions ResourceOptions,
 	containerCreator FetchContainerCreator,
+	imageFetchingDelegate worker.ImageFetchingDelegate,
 ) FetchSourceProvider {
 	return &fetchSourceProvider{
-		logger:           logger,
-		session:          session,
-		tags:             tags,
-		teamID:           teamID,
-		resourceTypes:    resourceTypes,
-		cacheIdentifier:  cacheIdentifier,
-		resourceOptions:  resourceOptions,
-		containerCreator: containerCreator,
-		workerClient:     f.workerClient,
+		logger:                logger,
+		session:               session,
+		tags:                  tags,
+		teamID:                teamID,
+		resourceTypes:         resourceTypes,
+		cacheIdentifier:       cacheIdentifier,
+		resourceOptions:       resourceOptions,
+		containerCreator:      containerCreator,
+		imageFetchingDelegate: imageFetchingDelegate,
+		workerClient:          f.workerClient,
 	}
 }
 
 type fetchSourceProvider struct {
-	logger           lager.Logger
-	session          Session
-	tags             atc.Tags
-	teamID           int
-	resourceTypes    atc.ResourceTypes
-	cacheIdentifier  CacheIdentifier
-	resourceOptions  ResourceOptions
-	workerClient     worker.Client
-	containerCreator FetchContainerCreator
+	logger                lager.Logger
+	session               Session
+	tags                  atc.Tags
+	teamID                int
+	resourceTypes         atc.ResourceTypes
+	cacheIdentifier       CacheIdentifier
+	resourceOptions       ResourceOptions
+	workerClient          worker.Client
+	containerCreator      FetchContainerCreator
+	imageFetchingDelegate worker.ImageFetchingDelegate
 }
 
-func (f *fetchSourceProvider) Get() (FetchSource, error) {
+func (f *fetchSourceProvider) Get(signals <-chan os.Signal) (FetchSource, error) {
 	container, found, err := f.workerClient.FindContainerForIdentifier(f.logger, f.session.ID)
 	if err != nil {
 		f.logger.Error("failed-to-look-for-existing-container", err)
@@ -102,7 +106,16 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		TeamID:       f.teamID,
 	}
 
-	chosenWorker, err := f.workerClient.Satisfying(resourceSpec, f.resourceTypes)
+	chosenWorker, err := f.workerClient.ChooseWorker(
+		f.logger,
+		signals,
+		f.imageFetchingDelegate,
+		resourceSpec,
+		f.resourceTypes,
+		worker.PlacementSpec{
+			VolumeLocality: f.cacheLocality,
+		},
+	)
 	if err != nil {
 		f.logger.Error("no-workers-satisfying-spec", err)
 		return nil, err
@@ -132,3 +145,18 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		f.resourceOptions,
 	), nil
 }
+
+func (f *fetchSourceProvider) cacheLocality(w worker.Worker) (int, error) {
+	cachedVolume, found, err := f.cacheIdentifier.FindOn(f.logger, w)
+	if err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, nil
+	}
+
+	cachedVolume.Release(nil)
+
+	return 1, nil
+}
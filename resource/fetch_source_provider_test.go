@@ -2,6 +2,7 @@ package resource_test
 
 import (
 	"errors"
+	"os"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -17,9 +18,10 @@ import (
 
 var _ = Describe("FetchSourceProvider", func() {
 	var (
-		fakeWorkerClient     *workerfakes.FakeClient
-		fakeContainerCreator *resourcefakes.FakeFetchContainerCreator
-		fetchSourceProvider  FetchSourceProvider
+		fakeWorkerClient          *workerfakes.FakeClient
+		fakeContainerCreator      *resourcefakes.FakeFetchContainerCreator
+		fakeImageFetchingDelegate *workerfakes.FakeImageFetchingDelegate
+		fetchSourceProvider       FetchSourceProvider
 
 		logger          lager.Logger
 		resourceOptions *resourcefakes.FakeResourceOptions
@@ -27,10 +29,12 @@ var _ = Describe("FetchSourceProvider", func() {
 		tags            atc.Tags
 		resourceTypes   atc.ResourceTypes
 		teamID          = 3
+		signals         <-chan os.Signal
 	)
 
 	BeforeEach(func() {
 		fakeWorkerClient = new(workerfakes.FakeClient)
+		signals = make(chan os.Signal)
 		fetchSourceProviderFactory := NewFetchSourceProviderFactory(fakeWorkerClient)
 		logger = lagertest.NewTestLogger("test")
 		session := Session{}
@@ -44,6 +48,7 @@ var _ = Describe("FetchSourceProvider", func() {
 		resourceOptions = new(resourcefakes.FakeResourceOptions)
 		resourceOptions.ResourceTypeReturns("some-resource-type")
 		fakeContainerCreator = new(resourcefakes.FakeFetchContainerCreator)
+		fakeImageFetchingDelegate = new(workerfakes.FakeImageFetchingDelegate)
 
 		fetchSourceProvider = fetchSourceProviderFactory.NewFetchSourceProvider(
 			logger,
@@ -54,6 +59,7 @@ var _ = Describe("FetchSourceProvider", func() {
 			cacheID,
 			resourceOptions,
 			fakeContainerCreator,
+			fakeImageFetchingDelegate,
 		)
 	})
 
@@ -67,7 +73,7 @@ var _ = Describe("FetchSourceProvider", func() {
 			})
 
 			It("returns container based source", func() {
-				source, err := fetchSourceProvider.Get()
+				source, err := fetchSourceProvider.Get(signals)
 				Expect(err).NotTo(HaveOccurred())
 
 				expectedSource := NewContainerFetchSource(logger, fakeContainer, resourceOptions)
@@ -81,10 +87,12 @@ var _ = Describe("FetchSourceProvider", func() {
 			})
 
 			It("tries to find satisfying worker", func() {
-				_, err := fetchSourceProvider.Get()
+				_, err := fetchSourceProvider.Get(signals)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(fakeWorkerClient.SatisfyingCallCount()).To(Equal(1))
-				resourceSpec, actualResourceTypes := fakeWorkerClient.SatisfyingArgsForCall(0)
+				Expect(fakeWorkerClient.ChooseWorkerCallCount()).To(Equal(1))
+				_, actualSignals, delegate, resourceSpec, actualResourceTypes, _ := fakeWorkerClient.ChooseWorkerArgsForCall(0)
+				Expect(actualSignals).To(Equal(signals))
+				Expect(delegate).To(Equal(fakeImageFetchingDelegate))
 				Expect(resourceSpec).To(Equal(worker.WorkerSpec{
 					ResourceType: "some-resource-type",
 					Tags:         tags,
@@ -93,12 +101,33 @@ var _ = Describe("FetchSourceProvider", func() {
 				Expect(actualResourceTypes).To(Equal(resourceTypes))
 			})
 
+			It("prefers workers that have the cache", func() {
+				_, err := fetchSourceProvider.Get(signals)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, _, _, _, placement := fakeWorkerClient.ChooseWorkerArgsForCall(0)
+				Expect(placement.Task).To(BeFalse())
+
+				fakeWorker := new(workerfakes.FakeWorker)
+				fakeVolume := new(workerfakes.FakeVolume)
+
+				cacheID.FindOnReturns(fakeVolume, true, nil)
+				Expect(placement.VolumeLocality(fakeWorker)).To(Equal(1))
+				Expect(fakeVolume.ReleaseCallCount()).To(Equal(1))
+
+				_, actualWorker := cacheID.FindOnArgsForCall(0)
+				Expect(actualWorker).To(Equal(fakeWorker))
+
+				cacheID.FindOnReturns(nil, false, nil)
+				Expect(placement.VolumeLocality(fakeWorker)).To(Equal(0))
+			})
+
 			Context("when worker is found for resource types", func() {
 				var fakeWorker *workerfakes.FakeWorker
 
 				BeforeEach(func() {
 					fakeWorker = new(workerfakes.FakeWorker)
-					fakeWorkerClient.SatisfyingReturns(fakeWorker, nil)
+					fakeWorkerClient.ChooseWorkerReturns(fakeWorker, nil)
 				})
 
 				Context("when volume is found on worker", func() {
@@ -110,7 +139,7 @@ var _ = Describe("FetchSourceProvider", func() {
 					})
 
 					It("returns volume based source", func() {
-						source, err := fetchSourceProvider.Get()
+						source, err := fetchSourceProvider.Get(signals)
 						Expect(err).NotTo(HaveOccurred())
 
 						expectedSource := NewVolumeFetchSource(logger, fakeVolume, fakeWorker, resourceOptions, fakeContainerCreator)
@@ -124,7 +153,7 @@ var _ = Describe("FetchSourceProvider", func() {
 					})
 
 					It("returns empty source", func() {
-						source, err := fetchSourceProvider.Get()
+						source, err := fetchSourceProvider.Get(signals)
 						Expect(err).NotTo(HaveOccurred())
 
 						expectedSource := NewEmptyFetchSource(logger, fakeWorker, cacheID, fakeContainerCreator, resourceOptions)
@@ -138,11 +167,11 @@ var _ = Describe("FetchSourceProvider", func() {
 
 				BeforeEach(func() {
 					workerNotFoundErr = errors.New("not-found")
-					fakeWorkerClient.SatisfyingReturns(nil, workerNotFoundErr)
+					fakeWorkerClient.ChooseWorkerReturns(nil, workerNotFoundErr)
 				})
 
 				It("returns an error", func() {
-					_, err := fetchSourceProvider.Get()
+					_, err := fetchSourceProvider.Get(signals)
 					Expect(err).To(HaveOccurred())
 					Expect(err).To(Equal(workerNotFoundErr))
 				})
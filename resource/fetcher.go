@@ -99,6 +99,7 @@ func (f *fetcher) Fetch(
 		cacheIdentifier,
 		resourceOptions,
 		containerCreator,
+		imageFetchingDelegate,
 	)
 
 	ticker := f.clock.NewTicker(GetResourceLeaseInterval)
@@ -135,7 +136,7 @@ func (f *fetcher) fetchWithLease(
 	signals <-chan os.Signal,
 	ready chan<- struct{},
 ) (FetchSource, error) {
-	source, err := sourceProvider.Get()
+	source, err := sourceProvider.Get(signals)
 	if err != nil {
 		return nil, err
 	}
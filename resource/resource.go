@@ -17,6 +17,8 @@ type Resource interface {
 	Put(IOConfig, atc.Source, atc.Params, ArtifactSource, <-chan os.Signal, chan<- struct{}) (VersionedSource, error)
 	Check(atc.Source, atc.Version) ([]atc.Version, error)
 
+	WorkerName() string
+
 	Release(*time.Duration)
 }
 
@@ -59,6 +61,10 @@ func NewResource(container worker.Container) Resource {
 	}
 }
 
+func (resource *resource) WorkerName() string {
+	return resource.container.WorkerName()
+}
+
 func (resource *resource) Release(finalTTL *time.Duration) {
 	resource.container.Release(finalTTL)
 }
@@ -2,16 +2,19 @@
 package resourcefakes
 
 import (
+	"os"
 	"sync"
 
 	"github.com/concourse/atc/resource"
 )
 
 type FakeFetchSourceProvider struct {
-	GetStub        func() (resource.FetchSource, error)
+	GetStub        func(signals <-chan os.Signal) (resource.FetchSource, error)
 	getMutex       sync.RWMutex
-	getArgsForCall []struct{}
-	getReturns     struct {
+	getArgsForCall []struct {
+		signals <-chan os.Signal
+	}
+	getReturns struct {
 		result1 resource.FetchSource
 		result2 error
 	}
@@ -19,13 +22,15 @@ type FakeFetchSourceProvider struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFetchSourceProvider) Get() (resource.FetchSource, error) {
+func (fake *FakeFetchSourceProvider) Get(signals <-chan os.Signal) (resource.FetchSource, error) {
 	fake.getMutex.Lock()
-	fake.getArgsForCall = append(fake.getArgsForCall, struct{}{})
-	fake.recordInvocation("Get", []interface{}{})
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		signals <-chan os.Signal
+	}{signals})
+	fake.recordInvocation("Get", []interface{}{signals})
 	fake.getMutex.Unlock()
 	if fake.GetStub != nil {
-		return fake.GetStub()
+		return fake.GetStub(signals)
 	} else {
 		return fake.getReturns.result1, fake.getReturns.result2
 	}
@@ -37,6 +42,12 @@ func (fake *FakeFetchSourceProvider) GetCallCount() int {
 	return len(fake.getArgsForCall)
 }
 
+func (fake *FakeFetchSourceProvider) GetArgsForCall(i int) <-chan os.Signal {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].signals
+}
+
 func (fake *FakeFetchSourceProvider) GetReturns(result1 resource.FetchSource, result2 error) {
 	fake.GetStub = nil
 	fake.getReturns = struct {
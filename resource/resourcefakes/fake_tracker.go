@@ -2,6 +2,7 @@
 package resourcefakes
 
 import (
+	"os"
 	"sync"
 
 	"code.cloudfoundry.org/lager"
@@ -27,18 +28,19 @@ type FakeTracker struct {
 		result1 resource.Resource
 		result2 error
 	}
-	InitWithSourcesStub        func(lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, int, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, []string, error)
+	InitWithSourcesStub        func(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 resource.Metadata, arg4 resource.Session, arg5 resource.ResourceType, arg6 atc.Tags, arg7 int, arg8 map[string]resource.ArtifactSource, arg9 atc.ResourceTypes, arg10 worker.ImageFetchingDelegate) (resource.Resource, []string, error)
 	initWithSourcesMutex       sync.RWMutex
 	initWithSourcesArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 resource.Metadata
-		arg3 resource.Session
-		arg4 resource.ResourceType
-		arg5 atc.Tags
-		arg6 int
-		arg7 map[string]resource.ArtifactSource
-		arg8 atc.ResourceTypes
-		arg9 worker.ImageFetchingDelegate
+		arg1  lager.Logger
+		arg2  <-chan os.Signal
+		arg3  resource.Metadata
+		arg4  resource.Session
+		arg5  resource.ResourceType
+		arg6  atc.Tags
+		arg7  int
+		arg8  map[string]resource.ArtifactSource
+		arg9  atc.ResourceTypes
+		arg10 worker.ImageFetchingDelegate
 	}
 	initWithSourcesReturns struct {
 		result1 resource.Resource
@@ -90,23 +92,24 @@ func (fake *FakeTracker) InitReturns(result1 resource.Resource, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeTracker) InitWithSources(arg1 lager.Logger, arg2 resource.Metadata, arg3 resource.Session, arg4 resource.ResourceType, arg5 atc.Tags, arg6 int, arg7 map[string]resource.ArtifactSource, arg8 atc.ResourceTypes, arg9 worker.ImageFetchingDelegate) (resource.Resource, []string, error) {
+func (fake *FakeTracker) InitWithSources(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 resource.Metadata, arg4 resource.Session, arg5 resource.ResourceType, arg6 atc.Tags, arg7 int, arg8 map[string]resource.ArtifactSource, arg9 atc.ResourceTypes, arg10 worker.ImageFetchingDelegate) (resource.Resource, []string, error) {
 	fake.initWithSourcesMutex.Lock()
 	fake.initWithSourcesArgsForCall = append(fake.initWithSourcesArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 resource.Metadata
-		arg3 resource.Session
-		arg4 resource.ResourceType
-		arg5 atc.Tags
-		arg6 int
-		arg7 map[string]resource.ArtifactSource
-		arg8 atc.ResourceTypes
-		arg9 worker.ImageFetchingDelegate
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
-	fake.recordInvocation("InitWithSources", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
+		arg1  lager.Logger
+		arg2  <-chan os.Signal
+		arg3  resource.Metadata
+		arg4  resource.Session
+		arg5  resource.ResourceType
+		arg6  atc.Tags
+		arg7  int
+		arg8  map[string]resource.ArtifactSource
+		arg9  atc.ResourceTypes
+		arg10 worker.ImageFetchingDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10})
+	fake.recordInvocation("InitWithSources", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10})
 	fake.initWithSourcesMutex.Unlock()
 	if fake.InitWithSourcesStub != nil {
-		return fake.InitWithSourcesStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+		return fake.InitWithSourcesStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
 	} else {
 		return fake.initWithSourcesReturns.result1, fake.initWithSourcesReturns.result2, fake.initWithSourcesReturns.result3
 	}
@@ -118,10 +121,10 @@ func (fake *FakeTracker) InitWithSourcesCallCount() int {
 	return len(fake.initWithSourcesArgsForCall)
 }
 
-func (fake *FakeTracker) InitWithSourcesArgsForCall(i int) (lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, int, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) {
+func (fake *FakeTracker) InitWithSourcesArgsForCall(i int) (lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, int, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) {
 	fake.initWithSourcesMutex.RLock()
 	defer fake.initWithSourcesMutex.RUnlock()
-	return fake.initWithSourcesArgsForCall[i].arg1, fake.initWithSourcesArgsForCall[i].arg2, fake.initWithSourcesArgsForCall[i].arg3, fake.initWithSourcesArgsForCall[i].arg4, fake.initWithSourcesArgsForCall[i].arg5, fake.initWithSourcesArgsForCall[i].arg6, fake.initWithSourcesArgsForCall[i].arg7, fake.initWithSourcesArgsForCall[i].arg8, fake.initWithSourcesArgsForCall[i].arg9
+	return fake.initWithSourcesArgsForCall[i].arg1, fake.initWithSourcesArgsForCall[i].arg2, fake.initWithSourcesArgsForCall[i].arg3, fake.initWithSourcesArgsForCall[i].arg4, fake.initWithSourcesArgsForCall[i].arg5, fake.initWithSourcesArgsForCall[i].arg6, fake.initWithSourcesArgsForCall[i].arg7, fake.initWithSourcesArgsForCall[i].arg8, fake.initWithSourcesArgsForCall[i].arg9, fake.initWithSourcesArgsForCall[i].arg10
 }
 
 func (fake *FakeTracker) InitWithSourcesReturns(result1 resource.Resource, result2 []string, result3 error) {
@@ -1,6 +1,8 @@
 package resource
 
 import (
+	"os"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/worker"
@@ -18,7 +20,7 @@ type Session struct {
 
 type Tracker interface {
 	Init(lager.Logger, Metadata, Session, ResourceType, atc.Tags, int, atc.ResourceTypes, worker.ImageFetchingDelegate) (Resource, error)
-	InitWithSources(lager.Logger, Metadata, Session, ResourceType, atc.Tags, int, map[string]ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (Resource, []string, error)
+	InitWithSources(lager.Logger, <-chan os.Signal, Metadata, Session, ResourceType, atc.Tags, int, map[string]ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (Resource, []string, error)
 }
 
 //go:generate counterfeiter . Cache
@@ -57,6 +59,7 @@ func (factory *trackerFactory) TrackerFor(client worker.Client) Tracker {
 
 func (tracker *tracker) InitWithSources(
 	logger lager.Logger,
+	signals <-chan os.Signal,
 	metadata Metadata,
 	session Session,
 	typ ResourceType,
@@ -100,49 +103,34 @@ func (tracker *tracker) InitWithSources(
 		Env:       metadata.Env(),
 	}
 
-	compatibleWorkers, err := tracker.workerClient.AllSatisfying(resourceSpec.WorkerSpec(), resourceTypes)
+	chosenWorker, err := tracker.workerClient.ChooseWorker(
+		logger,
+		signals,
+		imageFetchingDelegate,
+		resourceSpec.WorkerSpec(),
+		resourceTypes,
+		worker.PlacementSpec{
+			VolumeLocality: func(w worker.Worker) (int, error) {
+				mounts, _, err := sourcesOn(w, sources)
+				if err != nil {
+					return 0, err
+				}
+
+				for _, mount := range mounts {
+					mount.Volume.Release(nil)
+				}
+
+				return len(mounts), nil
+			},
+		},
+	)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// find the worker with the most volumes
-	mounts := []worker.VolumeMount{}
-	missingSources := []string{}
-	var chosenWorker worker.Worker
-
-	for _, w := range compatibleWorkers {
-		candidateMounts := []worker.VolumeMount{}
-		missing := []string{}
-
-		for name, source := range sources {
-			ourVolume, found, err := source.VolumeOn(w)
-			if err != nil {
-				return nil, nil, err
-			}
-
-			if found {
-				candidateMounts = append(candidateMounts, worker.VolumeMount{
-					Volume:    ourVolume,
-					MountPath: ResourcesDir("put/" + name),
-				})
-			} else {
-				missing = append(missing, name)
-			}
-		}
-
-		if len(candidateMounts) >= len(mounts) {
-			for _, mount := range mounts {
-				mount.Volume.Release(nil)
-			}
-
-			mounts = candidateMounts
-			missingSources = missing
-			chosenWorker = w
-		} else {
-			for _, mount := range candidateMounts {
-				mount.Volume.Release(nil)
-			}
-		}
+	mounts, missingSources, err := sourcesOn(chosenWorker, sources)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	resourceSpec.Inputs = mounts
@@ -151,7 +139,7 @@ func (tracker *tracker) InitWithSources(
 
 	container, err = chosenWorker.CreateContainer(
 		logger,
-		nil,
+		signals,
 		imageFetchingDelegate,
 		session.ID,
 		session.Metadata,
@@ -226,3 +214,30 @@ func (tracker *tracker) Init(
 
 	return NewResource(container), nil
 }
+
+func sourcesOn(w worker.Worker, sources map[string]ArtifactSource) ([]worker.VolumeMount, []string, error) {
+	mounts := []worker.VolumeMount{}
+	missing := []string{}
+
+	for name, source := range sources {
+		ourVolume, found, err := source.VolumeOn(w)
+		if err != nil {
+			for _, mount := range mounts {
+				mount.Volume.Release(nil)
+			}
+
+			return nil, nil, err
+		}
+
+		if found {
+			mounts = append(mounts, worker.VolumeMount{
+				Volume:    ourVolume,
+				MountPath: ResourcesDir("put/" + name),
+			})
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	return mounts, missing, nil
+}
@@ -3,6 +3,7 @@ package resource_test
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -160,6 +161,7 @@ var _ = Describe("Tracker", func() {
 	Describe("InitWithSources", func() {
 		var (
 			logger       *lagertest.TestLogger
+			signals      <-chan os.Signal
 			metadata     Metadata = testMetadata{"a=1", "b=2"}
 			inputSources map[string]ArtifactSource
 			delegate     worker.ImageFetchingDelegate
@@ -178,6 +180,7 @@ var _ = Describe("Tracker", func() {
 
 		BeforeEach(func() {
 			logger = lagertest.NewTestLogger("test")
+			signals = make(chan os.Signal)
 			initType = "type1"
 			delegate = new(wfakes.FakeImageFetchingDelegate)
 
@@ -195,6 +198,7 @@ var _ = Describe("Tracker", func() {
 		JustBeforeEach(func() {
 			initResource, missingSources, initErr = tracker.InitWithSources(
 				logger,
+				signals,
 				metadata,
 				session,
 				initType,
@@ -216,7 +220,7 @@ var _ = Describe("Tracker", func() {
 
 				BeforeEach(func() {
 					satisfyingWorker = new(wfakes.FakeWorker)
-					workerClient.AllSatisfyingReturns([]worker.Worker{satisfyingWorker}, nil)
+					workerClient.ChooseWorkerReturns(satisfyingWorker, nil)
 
 					satisfyingWorker.CreateContainerReturns(fakeContainer, nil)
 				})
@@ -242,8 +246,10 @@ var _ = Describe("Tracker", func() {
 					})
 
 					It("chose the worker satisfying the resource type and tags", func() {
-						Expect(workerClient.AllSatisfyingCallCount()).To(Equal(1))
-						actualSpec, actualCustomTypes := workerClient.AllSatisfyingArgsForCall(0)
+						Expect(workerClient.ChooseWorkerCallCount()).To(Equal(1))
+						_, actualSignals, actualDelegate, actualSpec, actualCustomTypes, placement := workerClient.ChooseWorkerArgsForCall(0)
+						Expect(actualSignals).To(Equal(signals))
+						Expect(actualDelegate).To(Equal(delegate))
 						Expect(actualSpec).To(Equal(
 							worker.WorkerSpec{
 								ResourceType: "type1",
@@ -252,6 +258,8 @@ var _ = Describe("Tracker", func() {
 							},
 						))
 						Expect(actualCustomTypes).To(Equal(customTypes))
+						Expect(placement.Task).To(BeFalse())
+						Expect(placement.VolumeLocality).NotTo(BeNil())
 					})
 
 					It("looked for the sources on the correct worker", func() {
@@ -270,8 +278,9 @@ var _ = Describe("Tracker", func() {
 
 					It("creates the container with the cache volume", func() {
 						Expect(satisfyingWorker.CreateContainerCallCount()).To(Equal(1))
-						_, _, _, id, containerMetadata, spec, actualCustomTypes := satisfyingWorker.CreateContainerArgsForCall(0)
+						_, actualSignals, _, id, containerMetadata, spec, actualCustomTypes := satisfyingWorker.CreateContainerArgsForCall(0)
 
+						Expect(actualSignals).To(Equal(signals))
 						Expect(id).To(Equal(session.ID))
 						Expect(containerMetadata).To(Equal(session.Metadata))
 
@@ -331,8 +340,9 @@ var _ = Describe("Tracker", func() {
 
 					It("creates a container with no volumes", func() {
 						Expect(satisfyingWorker.CreateContainerCallCount()).To(Equal(1))
-						_, _, _, id, containerMetadata, spec, actualCustomTypes := satisfyingWorker.CreateContainerArgsForCall(0)
+						_, actualSignals, _, id, containerMetadata, spec, actualCustomTypes := satisfyingWorker.CreateContainerArgsForCall(0)
 
+						Expect(actualSignals).To(Equal(signals))
 						Expect(id).To(Equal(session.ID))
 						Expect(containerMetadata).To(Equal(session.Metadata))
 
@@ -377,6 +387,11 @@ var _ = Describe("Tracker", func() {
 					satisfyingWorker1 *wfakes.FakeWorker
 					satisfyingWorker2 *wfakes.FakeWorker
 					satisfyingWorker3 *wfakes.FakeWorker
+
+					inputVolume      *wfakes.FakeVolume
+					inputVolume2     *wfakes.FakeVolume
+					inputVolume3     *wfakes.FakeVolume
+					otherInputVolume *wfakes.FakeVolume
 				)
 
 				BeforeEach(func() {
@@ -384,82 +399,86 @@ var _ = Describe("Tracker", func() {
 					satisfyingWorker2 = new(wfakes.FakeWorker)
 					satisfyingWorker3 = new(wfakes.FakeWorker)
 
-					workerClient.AllSatisfyingReturns([]worker.Worker{
-						satisfyingWorker1,
-						satisfyingWorker2,
-						satisfyingWorker3,
-					}, nil)
+					workerClient.ChooseWorkerReturns(satisfyingWorker2, nil)
 
-					satisfyingWorker1.CreateContainerReturns(fakeContainer, nil)
 					satisfyingWorker2.CreateContainerReturns(fakeContainer, nil)
-					satisfyingWorker3.CreateContainerReturns(fakeContainer, nil)
-				})
 
-				Context("and some workers have more matching input volumes than others", func() {
-					var inputVolume *wfakes.FakeVolume
-					var inputVolume2 *wfakes.FakeVolume
-					var inputVolume3 *wfakes.FakeVolume
-					var otherInputVolume *wfakes.FakeVolume
+					inputVolume = new(wfakes.FakeVolume)
+					inputVolume.HandleReturns("input-volume-1")
 
-					BeforeEach(func() {
-						inputVolume = new(wfakes.FakeVolume)
-						inputVolume.HandleReturns("input-volume-1")
+					inputVolume2 = new(wfakes.FakeVolume)
+					inputVolume2.HandleReturns("input-volume-2")
 
-						inputVolume2 = new(wfakes.FakeVolume)
-						inputVolume2.HandleReturns("input-volume-2")
+					inputVolume3 = new(wfakes.FakeVolume)
+					inputVolume3.HandleReturns("input-volume-3")
 
-						inputVolume3 = new(wfakes.FakeVolume)
-						inputVolume3.HandleReturns("input-volume-3")
-
-						otherInputVolume = new(wfakes.FakeVolume)
-						otherInputVolume.HandleReturns("other-input-volume")
-
-						inputSource1.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
-							if w == satisfyingWorker1 {
-								return inputVolume, true, nil
-							} else if w == satisfyingWorker2 {
-								return inputVolume2, true, nil
-							} else if w == satisfyingWorker3 {
-								return inputVolume3, true, nil
-							} else {
-								return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
-							}
-						}
+					otherInputVolume = new(wfakes.FakeVolume)
+					otherInputVolume.HandleReturns("other-input-volume")
 
-						inputSource2.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
-							if w == satisfyingWorker1 {
-								return nil, false, nil
-							} else if w == satisfyingWorker2 {
-								return otherInputVolume, true, nil
-							} else if w == satisfyingWorker3 {
-								return nil, false, nil
-							} else {
-								return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
-							}
+					inputSource1.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
+						if w == satisfyingWorker1 {
+							return inputVolume, true, nil
+						} else if w == satisfyingWorker2 {
+							return inputVolume2, true, nil
+						} else if w == satisfyingWorker3 {
+							return inputVolume3, true, nil
+						} else {
+							return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
 						}
-						inputSource3.VolumeOnReturns(nil, false, nil)
+					}
+
+					inputSource2.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
+						if w == satisfyingWorker1 {
+							return nil, false, nil
+						} else if w == satisfyingWorker2 {
+							return otherInputVolume, true, nil
+						} else if w == satisfyingWorker3 {
+							return nil, false, nil
+						} else {
+							return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
+						}
+					}
 
-						satisfyingWorker1.CreateContainerReturns(nil, errors.New("fall out of method here"))
-						satisfyingWorker2.CreateContainerReturns(nil, errors.New("fall out of method here"))
-						satisfyingWorker3.CreateContainerReturns(nil, errors.New("fall out of method here"))
-					})
+					inputSource3.VolumeOnReturns(nil, false, nil)
+				})
 
-					It("picks the worker that has the most", func() {
-						Expect(satisfyingWorker1.CreateContainerCallCount()).To(Equal(0))
-						Expect(satisfyingWorker2.CreateContainerCallCount()).To(Equal(1))
-						Expect(satisfyingWorker3.CreateContainerCallCount()).To(Equal(0))
-					})
+				It("gives the placement strategy the number of inputs present on each worker", func() {
+					Expect(workerClient.ChooseWorkerCallCount()).To(Equal(1))
+					_, _, _, _, _, placement := workerClient.ChooseWorkerArgsForCall(0)
 
-					It("releases the volumes on the unused workers", func() {
-						Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
-						Expect(inputVolume3.ReleaseCallCount()).To(Equal(1))
+					Expect(placement.VolumeLocality(satisfyingWorker1)).To(Equal(1))
+					Expect(placement.VolumeLocality(satisfyingWorker2)).To(Equal(2))
+					Expect(placement.VolumeLocality(satisfyingWorker3)).To(Equal(1))
+				})
 
-						// We don't expect these to be released because we are
-						// causing an error in the create container step, which
-						// happens before they are released.
-						Expect(inputVolume2.ReleaseCallCount()).To(Equal(0))
-						Expect(otherInputVolume.ReleaseCallCount()).To(Equal(0))
-					})
+				It("releases the volumes it finds while measuring locality", func() {
+					_, _, _, _, _, placement := workerClient.ChooseWorkerArgsForCall(0)
+
+					_, err := placement.VolumeLocality(satisfyingWorker1)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
+
+					_, err = placement.VolumeLocality(satisfyingWorker3)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(inputVolume3.ReleaseCallCount()).To(Equal(1))
+				})
+
+				It("creates the container on the chosen worker with its volumes", func() {
+					Expect(satisfyingWorker1.CreateContainerCallCount()).To(Equal(0))
+					Expect(satisfyingWorker2.CreateContainerCallCount()).To(Equal(1))
+					Expect(satisfyingWorker3.CreateContainerCallCount()).To(Equal(0))
+
+					_, _, _, _, _, spec, _ := satisfyingWorker2.CreateContainerArgsForCall(0)
+					Expect(spec.Inputs).To(ConsistOf([]worker.VolumeMount{
+						{
+							Volume:    inputVolume2,
+							MountPath: "/tmp/build/put/source-1-name",
+						},
+						{
+							Volume:    otherInputVolume,
+							MountPath: "/tmp/build/put/source-2-name",
+						},
+					}))
 				})
 			})
 
@@ -467,7 +486,7 @@ var _ = Describe("Tracker", func() {
 				disaster := errors.New("nope")
 
 				BeforeEach(func() {
-					workerClient.AllSatisfyingReturns(nil, disaster)
+					workerClient.ChooseWorkerReturns(nil, disaster)
 				})
 
 				It("returns the error and no resource", func() {
@@ -492,7 +511,7 @@ var _ = Describe("Tracker", func() {
 			})
 
 			It("does not create a container", func() {
-				Expect(workerClient.SatisfyingCallCount()).To(BeZero())
+				Expect(workerClient.ChooseWorkerCallCount()).To(BeZero())
 				Expect(workerClient.CreateContainerCallCount()).To(BeZero())
 			})
 		})
@@ -511,7 +530,7 @@ var _ = Describe("Tracker", func() {
 			})
 
 			It("does not create a container", func() {
-				Expect(workerClient.SatisfyingCallCount()).To(BeZero())
+				Expect(workerClient.ChooseWorkerCallCount()).To(BeZero())
 				Expect(workerClient.CreateContainerCallCount()).To(BeZero())
 			})
 
@@ -33,6 +33,8 @@ const (
 	UnpauseResource = "UnpauseResource"
 	CheckResource   = "CheckResource"
 
+	ListResourceChecks = "ListResourceChecks"
+
 	ListResourceVersions          = "ListResourceVersions"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
@@ -81,6 +83,25 @@ const (
 	ListTeams   = "ListTeams"
 	SetTeam     = "SetTeam"
 	DestroyTeam = "DestroyTeam"
+
+	ListTeamLocks   = "ListTeamLocks"
+	SetTeamLock     = "SetTeamLock"
+	ReleaseTeamLock = "ReleaseTeamLock"
+
+	ListAPITokens  = "ListAPITokens"
+	CreateAPIToken = "CreateAPIToken"
+	RevokeAPIToken = "RevokeAPIToken"
+
+	ListSessions      = "ListSessions"
+	RevokeSession     = "RevokeSession"
+	RevokeAllSessions = "RevokeAllSessions"
+
+	ListLocalUsers       = "ListLocalUsers"
+	CreateLocalUser      = "CreateLocalUser"
+	SetLocalUserPassword = "SetLocalUserPassword"
+	EnableLocalUser      = "EnableLocalUser"
+	DisableLocalUser     = "DisableLocalUser"
+	DeleteLocalUser      = "DeleteLocalUser"
 )
 
 var Routes = rata.Routes([]rata.Route{
@@ -124,6 +145,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", Method: "GET", Name: ListResourceChecks},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
@@ -162,4 +184,23 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
+
+	{Path: "/api/v1/teams/:team_name/locks", Method: "GET", Name: ListTeamLocks},
+	{Path: "/api/v1/teams/:team_name/locks/:lock_name", Method: "PUT", Name: SetTeamLock},
+	{Path: "/api/v1/teams/:team_name/locks/:lock_name/holders/:build_id", Method: "DELETE", Name: ReleaseTeamLock},
+
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "GET", Name: ListAPITokens},
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "POST", Name: CreateAPIToken},
+	{Path: "/api/v1/teams/:team_name/tokens/:token_name", Method: "DELETE", Name: RevokeAPIToken},
+
+	{Path: "/api/v1/teams/:team_name/sessions", Method: "GET", Name: ListSessions},
+	{Path: "/api/v1/teams/:team_name/sessions", Method: "DELETE", Name: RevokeAllSessions},
+	{Path: "/api/v1/teams/:team_name/sessions/:session_id", Method: "DELETE", Name: RevokeSession},
+
+	{Path: "/api/v1/teams/:team_name/users", Method: "GET", Name: ListLocalUsers},
+	{Path: "/api/v1/teams/:team_name/users", Method: "POST", Name: CreateLocalUser},
+	{Path: "/api/v1/teams/:team_name/users/:username/password", Method: "PUT", Name: SetLocalUserPassword},
+	{Path: "/api/v1/teams/:team_name/users/:username/enable", Method: "PUT", Name: EnableLocalUser},
+	{Path: "/api/v1/teams/:team_name/users/:username/disable", Method: "PUT", Name: DisableLocalUser},
+	{Path: "/api/v1/teams/:team_name/users/:username", Method: "DELETE", Name: DeleteLocalUser},
 })
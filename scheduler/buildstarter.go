@@ -31,6 +31,7 @@ type BuildStarterDB interface {
 	GetJob(job string) (db.SavedJob, bool, error)
 	UpdateBuildToScheduled(int) (bool, error)
 	UseInputsForBuild(buildID int, inputs []db.BuildInput) error
+	AcquireTeamLocks(logger lager.Logger, buildID int, locks []string) (bool, error)
 	LoadVersionsDB() (*algorithm.VersionsDB, error)
 }
 
@@ -171,6 +172,16 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
+	acquired, err := s.db.AcquireTeamLocks(logger, nextPendingBuild.ID(), jobConfig.Locks)
+	if err != nil {
+		logger.Error("failed-to-acquire-team-locks", err)
+		return false, err
+	}
+	if !acquired {
+		logger.Debug("waiting-for-team-locks")
+		return false, nil
+	}
+
 	updated, err := s.db.UpdateBuildToScheduled(nextPendingBuild.ID())
 	if err != nil {
 		logger.Error("failed-to-update-build-to-scheduled", err)
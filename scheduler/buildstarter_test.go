@@ -274,6 +274,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 					fakeDB.GetNextBuildInputsReturns([]db.BuildInput{{Name: "some-input"}}, true, nil)
 					fakeDB.IsPausedReturns(false, nil)
 					fakeDB.GetJobReturns(db.SavedJob{Paused: false}, true, nil)
+					fakeDB.AcquireTeamLocksReturns(true, nil)
 				})
 
 				Context("when there are several pending builds", func() {
@@ -551,6 +552,30 @@ var _ = Describe("I'm a BuildStarter", func() {
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
+
+					Context("when acquiring the team locks fails", func() {
+						BeforeEach(func() {
+							fakeDB.AcquireTeamLocksReturns(false, disaster)
+						})
+
+						itReturnsTheError()
+						itUpdatedMaxInFlightForTheFirstBuild()
+					})
+
+					Context("when the team locks are held by other builds", func() {
+						BeforeEach(func() {
+							fakeDB.AcquireTeamLocksReturns(false, nil)
+						})
+
+						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itUpdatedMaxInFlightForTheFirstBuild()
+
+						It("tried to acquire the team locks for the first build", func() {
+							Expect(fakeDB.AcquireTeamLocksCallCount()).To(Equal(1))
+							_, actualBuildID, _ := fakeDB.AcquireTeamLocksArgsForCall(0)
+							Expect(actualBuildID).To(Equal(99))
+						})
+					})
 				})
 			})
 		})
@@ -229,6 +229,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Name:              planConfig.Task,
 			PipelineID:        factory.PipelineID,
 			Privileged:        planConfig.Privileged,
+			RetryOnWorkerLoss: planConfig.RetryOnWorkerLoss,
 			Config:            planConfig.TaskConfig,
 			ConfigPath:        planConfig.TaskConfigPath,
 			Tags:              planConfig.Tags,
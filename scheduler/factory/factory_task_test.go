@@ -74,6 +74,32 @@ var _ = Describe("Factory Task", func() {
 			})
 		})
 
+		Context("when the task should be retried on worker loss", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:              "some-task",
+							RetryOnWorkerLoss: true,
+						},
+					},
+				}
+			})
+
+			It("marks the task plan", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:              "some-task",
+					PipelineID:        42,
+					ResourceTypes:     resourceTypes,
+					RetryOnWorkerLoss: true,
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+
 		Context("when input mapping is specified", func() {
 			BeforeEach(func() {
 				input = atc.JobConfig{
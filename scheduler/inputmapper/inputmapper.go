@@ -24,6 +24,7 @@ type InputMapperDB interface {
 	SaveIndependentInputMapping(inputVersions algorithm.InputMapping, jobName string) error
 	SaveNextInputMapping(inputVersions algorithm.InputMapping, jobName string) error
 	DeleteNextInputMapping(jobName string) error
+	SaveInputsExplanation(explanation algorithm.Explanation, jobName string) error
 }
 
 func NewInputMapper(db InputMapperDB, transformer inputconfig.Transformer) InputMapper {
@@ -69,6 +70,12 @@ func (i *inputMapper) SaveNextInputMapping(
 		err := i.db.DeleteNextInputMapping(job.Name)
 		if err != nil {
 			logger.Error("failed-to-delete-next-input-mapping-after-missing-pending", err)
+			return nil, err
+		}
+
+		err = i.db.SaveInputsExplanation(algorithmInputConfigs.Explain(versions), job.Name)
+		if err != nil {
+			logger.Error("failed-to-save-inputs-explanation-after-missing-pending", err)
 		}
 
 		return nil, err
@@ -79,6 +86,12 @@ func (i *inputMapper) SaveNextInputMapping(
 		err := i.db.DeleteNextInputMapping(job.Name)
 		if err != nil {
 			logger.Error("failed-to-delete-next-input-mapping-after-failed-resolve", err)
+			return nil, err
+		}
+
+		err = i.db.SaveInputsExplanation(algorithmInputConfigs.Explain(versions), job.Name)
+		if err != nil {
+			logger.Error("failed-to-save-inputs-explanation-after-failed-resolve", err)
 		}
 
 		return nil, err
@@ -254,10 +254,28 @@ var _ = Describe("Inputmapper", func() {
 					Expect(fakeDB.SaveNextInputMappingCallCount()).To(BeZero())
 				})
 
+				It("saved an explanation naming the job without a common build", func() {
+					Expect(fakeDB.SaveInputsExplanationCallCount()).To(Equal(1))
+					actualExplanation, actualJobName := fakeDB.SaveInputsExplanationArgsForCall(0)
+					Expect(actualExplanation["a"].JobsWithoutCommonBuild).To(Equal([]string{"upstream"}))
+					Expect(actualExplanation["b"].JobsWithoutCommonBuild).To(Equal([]string{"upstream"}))
+					Expect(actualJobName).To(Equal("some-job"))
+				})
+
 				It("returns an empty mapping and no error", func() {
 					Expect(mappingErr).NotTo(HaveOccurred())
 					Expect(inputMapping).To(BeEmpty())
 				})
+
+				Context("when saving the explanation fails", func() {
+					BeforeEach(func() {
+						fakeDB.SaveInputsExplanationReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(mappingErr).To(Equal(disaster))
+					})
+				})
 			})
 		})
 
@@ -303,6 +321,17 @@ var _ = Describe("Inputmapper", func() {
 				Expect(fakeDB.SaveNextInputMappingCallCount()).To(BeZero())
 			})
 
+			It("saved an explanation with no candidates for the missing input", func() {
+				Expect(fakeDB.SaveInputsExplanationCallCount()).To(Equal(1))
+				actualExplanation, _ := fakeDB.SaveInputsExplanationArgsForCall(0)
+				Expect(actualExplanation["no-versions"]).To(Equal(algorithm.InputExplanation{
+					Constraints: []algorithm.ConstraintExplanation{
+						{Constraint: algorithm.ConstraintLatest, Candidates: 0},
+					},
+				}))
+				Expect(actualExplanation["a"].Satisfied).To(BeTrue())
+			})
+
 			It("returns an empty mapping and no error", func() {
 				Expect(mappingErr).NotTo(HaveOccurred())
 				Expect(inputMapping).To(BeEmpty())
@@ -35,6 +35,15 @@ type FakeInputMapperDB struct {
 	deleteNextInputMappingReturns struct {
 		result1 error
 	}
+	SaveInputsExplanationStub        func(explanation algorithm.Explanation, jobName string) error
+	saveInputsExplanationMutex       sync.RWMutex
+	saveInputsExplanationArgsForCall []struct {
+		explanation algorithm.Explanation
+		jobName     string
+	}
+	saveInputsExplanationReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -140,6 +149,40 @@ func (fake *FakeInputMapperDB) DeleteNextInputMappingReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeInputMapperDB) SaveInputsExplanation(explanation algorithm.Explanation, jobName string) error {
+	fake.saveInputsExplanationMutex.Lock()
+	fake.saveInputsExplanationArgsForCall = append(fake.saveInputsExplanationArgsForCall, struct {
+		explanation algorithm.Explanation
+		jobName     string
+	}{explanation, jobName})
+	fake.recordInvocation("SaveInputsExplanation", []interface{}{explanation, jobName})
+	fake.saveInputsExplanationMutex.Unlock()
+	if fake.SaveInputsExplanationStub != nil {
+		return fake.SaveInputsExplanationStub(explanation, jobName)
+	} else {
+		return fake.saveInputsExplanationReturns.result1
+	}
+}
+
+func (fake *FakeInputMapperDB) SaveInputsExplanationCallCount() int {
+	fake.saveInputsExplanationMutex.RLock()
+	defer fake.saveInputsExplanationMutex.RUnlock()
+	return len(fake.saveInputsExplanationArgsForCall)
+}
+
+func (fake *FakeInputMapperDB) SaveInputsExplanationArgsForCall(i int) (algorithm.Explanation, string) {
+	fake.saveInputsExplanationMutex.RLock()
+	defer fake.saveInputsExplanationMutex.RUnlock()
+	return fake.saveInputsExplanationArgsForCall[i].explanation, fake.saveInputsExplanationArgsForCall[i].jobName
+}
+
+func (fake *FakeInputMapperDB) SaveInputsExplanationReturns(result1 error) {
+	fake.SaveInputsExplanationStub = nil
+	fake.saveInputsExplanationReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeInputMapperDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -149,6 +192,8 @@ func (fake *FakeInputMapperDB) Invocations() map[string][][]interface{} {
 	defer fake.saveNextInputMappingMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
+	fake.saveInputsExplanationMutex.RLock()
+	defer fake.saveInputsExplanationMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -43,6 +43,11 @@ type Runner struct {
 	Noop bool
 
 	Interval time.Duration
+
+	// Debounce is how long to wait after being notified of a change before
+	// scheduling, so that bursts of changes are scheduled together. It is
+	// also the least time between two schedulings caused by changes.
+	Debounce time.Duration
 }
 
 func (runner *Runner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
@@ -58,15 +63,44 @@ func (runner *Runner) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	defer runner.Logger.Info("done")
 
+	// changes to the pipeline trigger scheduling right away; the interval is
+	// only a fallback in case notifications are missed
+	var notified <-chan struct{}
+
+	notifier, err := runner.DB.SchedulingNotifier()
+	if err != nil {
+		runner.Logger.Error("failed-to-listen-for-changes", err)
+	} else {
+		defer notifier.Close()
+		notified = notifier.Notify()
+	}
+
+	interval := runner.Interval
+
 dance:
 	for {
-		err := runner.tick(runner.Logger.Session("tick"))
+		err := runner.tick(runner.Logger.Session("tick"), interval)
 		if err != nil {
 			return err
 		}
 
 		select {
 		case <-time.After(runner.Interval):
+			interval = runner.Interval
+		case <-notified:
+			select {
+			case <-time.After(runner.Debounce):
+			case <-signals:
+				break dance
+			}
+
+			// anything notified while debouncing is covered by this tick
+			select {
+			case <-notified:
+			default:
+			}
+
+			interval = runner.Debounce
 		case <-signals:
 			break dance
 		}
@@ -75,12 +109,12 @@ dance:
 	return nil
 }
 
-func (runner *Runner) tick(logger lager.Logger) error {
+func (runner *Runner) tick(logger lager.Logger, interval time.Duration) error {
 	if runner.Noop {
 		return nil
 	}
 
-	schedulingLease, acquired, err := runner.DB.AcquireSchedulingLock(logger, runner.Interval)
+	schedulingLease, acquired, err := runner.DB.AcquireSchedulingLock(logger, interval)
 	if err != nil {
 		logger.Error("failed-to-acquire-scheduling-lock", err)
 		return nil
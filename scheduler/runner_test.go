@@ -25,6 +25,11 @@ var _ = Describe("Runner", func() {
 
 		lock *dbfakes.FakeLease
 
+		notifier *dbfakes.FakeNotifier
+		notify   chan struct{}
+		interval time.Duration
+		debounce time.Duration
+
 		initialConfig atc.Config
 
 		someVersions *algorithm.VersionsDB
@@ -89,6 +94,14 @@ var _ = Describe("Runner", func() {
 
 		lock = new(dbfakes.FakeLease)
 		pipelineDB.AcquireSchedulingLockReturns(lock, true, nil)
+
+		notify = make(chan struct{}, 1)
+		notifier = new(dbfakes.FakeNotifier)
+		notifier.NotifyReturns(notify)
+		pipelineDB.SchedulingNotifierReturns(notifier, nil)
+
+		interval = 100 * time.Millisecond
+		debounce = 10 * time.Millisecond
 	})
 
 	JustBeforeEach(func() {
@@ -97,7 +110,8 @@ var _ = Describe("Runner", func() {
 			DB:        pipelineDB,
 			Scheduler: scheduler,
 			Noop:      noop,
-			Interval:  100 * time.Millisecond,
+			Interval:  interval,
+			Debounce:  debounce,
 		})
 	})
 
@@ -112,6 +126,59 @@ var _ = Describe("Runner", func() {
 		Expect(duration).To(Equal(100 * time.Millisecond))
 	})
 
+	Context("when notified of a change to the pipeline", func() {
+		BeforeEach(func() {
+			interval = time.Hour
+		})
+
+		It("schedules once the debounce has passed since it last scheduled", func() {
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(1))
+
+			notify <- struct{}{}
+
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(2))
+
+			_, duration := pipelineDB.AcquireSchedulingLockArgsForCall(1)
+			Expect(duration).To(Equal(10 * time.Millisecond))
+		})
+
+		Context("when notified repeatedly while debouncing", func() {
+			BeforeEach(func() {
+				debounce = 500 * time.Millisecond
+			})
+
+			It("schedules only once for all of them", func() {
+				Eventually(scheduler.ScheduleCallCount).Should(Equal(1))
+
+				notify <- struct{}{}
+				Eventually(notify).Should(BeEmpty())
+
+				notify <- struct{}{}
+
+				Eventually(scheduler.ScheduleCallCount, 2*time.Second).Should(Equal(2))
+				Consistently(scheduler.ScheduleCallCount, time.Second).Should(Equal(2))
+			})
+		})
+
+		It("stops listening when it exits", func() {
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(1))
+
+			ginkgomon.Interrupt(process)
+
+			Expect(notifier.CloseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when listening for changes fails", func() {
+		BeforeEach(func() {
+			pipelineDB.SchedulingNotifierReturns(nil, errors.New("nope"))
+		})
+
+		It("falls back to scheduling on an interval", func() {
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(2))
+		})
+	})
+
 	Context("when it can't get the lock", func() {
 		BeforeEach(func() {
 			pipelineDB.AcquireSchedulingLockReturns(nil, false, nil)
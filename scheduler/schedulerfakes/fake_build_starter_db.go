@@ -4,6 +4,7 @@ package schedulerfakes
 import (
 	"sync"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler"
@@ -55,6 +56,17 @@ type FakeBuildStarterDB struct {
 	useInputsForBuildReturns struct {
 		result1 error
 	}
+	AcquireTeamLocksStub        func(logger lager.Logger, buildID int, locks []string) (bool, error)
+	acquireTeamLocksMutex       sync.RWMutex
+	acquireTeamLocksArgsForCall []struct {
+		logger  lager.Logger
+		buildID int
+		locks   []string
+	}
+	acquireTeamLocksReturns struct {
+		result1 bool
+		result2 error
+	}
 	LoadVersionsDBStub        func() (*algorithm.VersionsDB, error)
 	loadVersionsDBMutex       sync.RWMutex
 	loadVersionsDBArgsForCall []struct{}
@@ -235,6 +247,47 @@ func (fake *FakeBuildStarterDB) UseInputsForBuildReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuildStarterDB) AcquireTeamLocks(logger lager.Logger, buildID int, locks []string) (bool, error) {
+	var locksCopy []string
+	if locks != nil {
+		locksCopy = make([]string, len(locks))
+		copy(locksCopy, locks)
+	}
+	fake.acquireTeamLocksMutex.Lock()
+	fake.acquireTeamLocksArgsForCall = append(fake.acquireTeamLocksArgsForCall, struct {
+		logger  lager.Logger
+		buildID int
+		locks   []string
+	}{logger, buildID, locksCopy})
+	fake.recordInvocation("AcquireTeamLocks", []interface{}{logger, buildID, locksCopy})
+	fake.acquireTeamLocksMutex.Unlock()
+	if fake.AcquireTeamLocksStub != nil {
+		return fake.AcquireTeamLocksStub(logger, buildID, locks)
+	} else {
+		return fake.acquireTeamLocksReturns.result1, fake.acquireTeamLocksReturns.result2
+	}
+}
+
+func (fake *FakeBuildStarterDB) AcquireTeamLocksCallCount() int {
+	fake.acquireTeamLocksMutex.RLock()
+	defer fake.acquireTeamLocksMutex.RUnlock()
+	return len(fake.acquireTeamLocksArgsForCall)
+}
+
+func (fake *FakeBuildStarterDB) AcquireTeamLocksArgsForCall(i int) (lager.Logger, int, []string) {
+	fake.acquireTeamLocksMutex.RLock()
+	defer fake.acquireTeamLocksMutex.RUnlock()
+	return fake.acquireTeamLocksArgsForCall[i].logger, fake.acquireTeamLocksArgsForCall[i].buildID, fake.acquireTeamLocksArgsForCall[i].locks
+}
+
+func (fake *FakeBuildStarterDB) AcquireTeamLocksReturns(result1 bool, result2 error) {
+	fake.AcquireTeamLocksStub = nil
+	fake.acquireTeamLocksReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildStarterDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	fake.loadVersionsDBMutex.Lock()
 	fake.loadVersionsDBArgsForCall = append(fake.loadVersionsDBArgsForCall, struct{}{})
@@ -274,6 +327,8 @@ func (fake *FakeBuildStarterDB) Invocations() map[string][][]interface{} {
 	defer fake.updateBuildToScheduledMutex.RUnlock()
 	fake.useInputsForBuildMutex.RLock()
 	defer fake.useInputsForBuildMutex.RUnlock()
+	fake.acquireTeamLocksMutex.RLock()
+	defer fake.acquireTeamLocksMutex.RUnlock()
 	fake.loadVersionsDBMutex.RLock()
 	defer fake.loadVersionsDBMutex.RUnlock()
 	return fake.invocations
@@ -0,0 +1,8 @@
+package atc
+
+type Session struct {
+	ID        string `json:"id"`
+	Username  string `json:"username,omitempty"`
+	IssuedAt  int64  `json:"issued_at"`
+	ExpiresAt int64  `json:"expires_at"`
+}
@@ -12,6 +12,10 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth,omitempty"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth,omitempty"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth,omitempty"`
+	OIDCAuth     *OIDCAuth     `json:"oidc_auth,omitempty"`
+	LDAPAuth     *LDAPAuth     `json:"ldap_auth,omitempty"`
+	GitLabAuth   *GitLabAuth   `json:"gitlab_auth,omitempty"`
+	CertAuth     *CertAuth     `json:"cert_auth,omitempty"`
 }
 
 type BasicAuth struct {
@@ -35,6 +39,16 @@ type GitHubTeam struct {
 	TeamName         string `json:"team_name,omitempty"`
 }
 
+type GitLabAuth struct {
+	ClientID     string   `json:"client_id,omitempty"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	Users        []string `json:"users,omitempty"`
+	AuthURL      string   `json:"auth_url,omitempty"`
+	TokenURL     string   `json:"token_url,omitempty"`
+	APIURL       string   `json:"api_url,omitempty"`
+}
+
 type UAAAuth struct {
 	ClientID     string   `json:"client_id,omitempty"`
 	ClientSecret string   `json:"client_secret,omitempty"`
@@ -54,3 +68,39 @@ type GenericOAuth struct {
 	AuthURLParams map[string]string `json:"auth_url_params,omitempty"`
 	Scope         string            `json:"scope,omitempty"`
 }
+
+type OIDCAuth struct {
+	DisplayName  string   `json:"display_name,omitempty"`
+	Issuer       string   `json:"issuer,omitempty"`
+	ClientID     string   `json:"client_id,omitempty"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	Scopes       []string `json:"scopes,omitempty"`
+	Users        []string `json:"users,omitempty"`
+	EmailDomains []string `json:"email_domains,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	GroupsClaim  string   `json:"groups_claim,omitempty"`
+}
+
+type LDAPAuth struct {
+	DisplayName          string   `json:"display_name,omitempty"`
+	Host                 string   `json:"host,omitempty"`
+	TLS                  bool     `json:"tls,omitempty"`
+	StartTLS             bool     `json:"start_tls,omitempty"`
+	InsecureSkipVerify   bool     `json:"insecure_skip_verify,omitempty"`
+	CACert               string   `json:"ca_cert,omitempty"`
+	BindDN               string   `json:"bind_dn,omitempty"`
+	BindPassword         string   `json:"bind_password,omitempty"`
+	UserSearchBaseDN     string   `json:"user_search_base_dn,omitempty"`
+	UserSearchFilter     string   `json:"user_search_filter,omitempty"`
+	UsernameAttribute    string   `json:"username_attribute,omitempty"`
+	GroupSearchBaseDN    string   `json:"group_search_base_dn,omitempty"`
+	GroupSearchFilter    string   `json:"group_search_filter,omitempty"`
+	GroupMemberAttribute string   `json:"group_member_attribute,omitempty"`
+	GroupNameAttribute   string   `json:"group_name_attribute,omitempty"`
+	Groups               []string `json:"groups,omitempty"`
+}
+
+type CertAuth struct {
+	CommonNames []string `json:"common_names,omitempty"`
+	SANs        []string `json:"sans,omitempty"`
+}
@@ -0,0 +1,19 @@
+package atc
+
+type TeamLock struct {
+	Name     string           `json:"name"`
+	Capacity int              `json:"capacity"`
+	Holders  []TeamLockHolder `json:"holders"`
+}
+
+type TeamLockConfig struct {
+	Capacity int `json:"capacity"`
+}
+
+type TeamLockHolder struct {
+	BuildID      int    `json:"build_id"`
+	BuildName    string `json:"build_name"`
+	JobName      string `json:"job_name"`
+	PipelineName string `json:"pipeline_name"`
+	AcquiredAt   int64  `json:"acquired_at"`
+}
@@ -32,6 +32,14 @@ func newDeprecationWarning(message string) Warning {
 }
 
 func (c Config) Validate() ([]Warning, []string) {
+	return c.ValidateInTeam(nil)
+}
+
+// ValidateInTeam validates the config along with any passed constraints that
+// refer to jobs in other pipelines, given the configs of the team's pipelines
+// by name. Without them, such constraints cannot be checked and are accepted
+// as-is.
+func (c Config) ValidateInTeam(pipelines map[string]Config) ([]Warning, []string) {
 	warnings := []Warning{}
 	errorMessages := []string{}
 
@@ -50,7 +58,7 @@ func (c Config) Validate() ([]Warning, []string) {
 		errorMessages = append(errorMessages, formatErr("resource types", resourceTypesErr))
 	}
 
-	jobWarnings, jobsErr := validateJobs(c)
+	jobWarnings, jobsErr := validateJobs(c, pipelines)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
 	}
@@ -182,7 +190,7 @@ func usedResources(c Config) map[string]bool {
 	return usedResources
 }
 
-func validateJobs(c Config) ([]Warning, error) {
+func validateJobs(c Config, pipelines map[string]Config) ([]Warning, error) {
 	errorMessages := []string{}
 	warnings := []Warning{}
 
@@ -216,7 +224,21 @@ func validateJobs(c Config) ([]Warning, error) {
 			)
 		}
 
-		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
+		lockNames := map[string]bool{}
+		for j, lock := range job.Locks {
+			if lock == "" {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.locks[%d] has no name", identifier, j))
+			} else if lockNames[lock] {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s has the same lock more than once: %s", identifier, lock),
+				)
+			}
+
+			lockNames[lock] = true
+		}
+
+		planWarnings, planErrMessages := validatePlan(c, pipelines, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 
@@ -265,7 +287,7 @@ func (ft foundTypes) IsValid() (bool, string) {
 	return true, ""
 }
 
-func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []string) {
+func validatePlan(c Config, pipelines map[string]Config, identifier string, plan PlanConfig) ([]Warning, []string) {
 	foundTypes := foundTypes{
 		identifier: identifier,
 		found:      make(map[string]bool),
@@ -306,7 +328,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 	case plan.Do != nil:
 		for i, plan := range *plan.Do {
 			subIdentifier := fmt.Sprintf("%s[%d]", identifier, i)
-			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			planWarnings, planErrMessages := validatePlan(c, pipelines, subIdentifier, plan)
 			warnings = append(warnings, planWarnings...)
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
@@ -314,7 +336,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 	case plan.Aggregate != nil:
 		for i, plan := range *plan.Aggregate {
 			subIdentifier := fmt.Sprintf("%s.aggregate[%d]", identifier, i)
-			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			planWarnings, planErrMessages := validatePlan(c, pipelines, subIdentifier, plan)
 			warnings = append(warnings, planWarnings...)
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
@@ -323,7 +345,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "retry_on_worker_loss", "config", "file"},
 			plan, identifier)...,
 		)
 
@@ -353,6 +375,11 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 
 		for _, job := range plan.Passed {
+			if pipelineName, jobName := c.PassedJob(job); pipelineName != "" {
+				errorMessages = append(errorMessages, validatePassedInPipeline(c, pipelines, identifier, plan, job, pipelineName, jobName)...)
+				continue
+			}
+
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
 				errorMessages = append(
@@ -398,7 +425,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "privileged", "retry_on_worker_loss", "config", "file"},
 			plan, identifier)...,
 		)
 
@@ -452,28 +479,28 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
+		planWarnings, planErrMessages := validatePlan(c, pipelines, subIdentifier, *plan.Try)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
 	if plan.Ensure != nil {
 		subIdentifier := fmt.Sprintf("%s.ensure", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Ensure)
+		planWarnings, planErrMessages := validatePlan(c, pipelines, subIdentifier, *plan.Ensure)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
 	if plan.Success != nil {
 		subIdentifier := fmt.Sprintf("%s.success", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Success)
+		planWarnings, planErrMessages := validatePlan(c, pipelines, subIdentifier, *plan.Success)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
 	if plan.Failure != nil {
 		subIdentifier := fmt.Sprintf("%s.failure", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Failure)
+		planWarnings, planErrMessages := validatePlan(c, pipelines, subIdentifier, *plan.Failure)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
@@ -494,6 +521,57 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 	return warnings, errorMessages
 }
 
+func validatePassedInPipeline(c Config, pipelines map[string]Config, identifier string, plan PlanConfig, passed string, pipelineName string, jobName string) []string {
+	if pipelines == nil {
+		return nil
+	}
+
+	pipelineConfig, found := pipelines[pipelineName]
+	if !found {
+		return []string{
+			fmt.Sprintf(
+				"%s.passed references an unknown pipeline ('%s')",
+				identifier,
+				pipelineName,
+			),
+		}
+	}
+
+	jobConfig, found := pipelineConfig.Jobs.Lookup(jobName)
+	if !found {
+		return []string{
+			fmt.Sprintf(
+				"%s.passed references an unknown job ('%s')",
+				identifier,
+				passed,
+			),
+		}
+	}
+
+	resource, found := c.Resources.Lookup(plan.ResourceName())
+	if !found {
+		// already reported as an unknown resource
+		return nil
+	}
+
+	plans := append(jobConfig.Inputs(), jobConfig.Outputs()...)
+	for _, jobPlan := range plans {
+		jobResource, found := pipelineConfig.Resources.Lookup(jobPlan.ResourceName())
+		if found && jobResource.SameIdentityAs(resource) {
+			return nil
+		}
+	}
+
+	return []string{
+		fmt.Sprintf(
+			"%s.passed references a job ('%s') which doesn't interact with the resource ('%s')",
+			identifier,
+			passed,
+			plan.Get,
+		),
+	}
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -516,6 +594,10 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.Privileged {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "retry_on_worker_loss":
+			if plan.RetryOnWorkerLoss {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		case "config":
 			if plan.TaskConfig != nil {
 				foundInapplicableFields = append(foundInapplicableFields, field)
@@ -425,6 +425,32 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a lock with no name", func() {
+			BeforeEach(func() {
+				job.Locks = []string{""}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.locks[0] has no name"))
+			})
+		})
+
+		Context("when a job has the same lock more than once", func() {
+			BeforeEach(func() {
+				job.Locks = []string{"some-lock", "some-lock"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has the same lock more than once: some-lock"))
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.Plan = append(job.Plan, PlanConfig{
@@ -660,6 +686,38 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a put plan is marked to retry on worker loss", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:               "lol",
+						RetryOnWorkerLoss: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.lol has invalid fields specified (retry_on_worker_loss)"))
+				})
+			})
+
+			Context("when a task plan is marked to retry on worker loss", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:              "lol",
+						TaskConfigPath:    "task.yml",
+						RetryOnWorkerLoss: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
 			Context("when a put plan has refers to a resource that does exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1090,6 +1148,142 @@ var _ = Describe("ValidateConfig", func() {
 					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('some-empty-job') which doesn't interact with the resource ('some-resource')"))
 				})
 			})
+
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				var pipelines map[string]Config
+
+				BeforeEach(func() {
+					pipelines = map[string]Config{
+						"other-pipeline": {
+							Resources: ResourceConfigs{
+								{
+									Name: "other-name-for-some-resource",
+									Type: "some-type",
+									Source: Source{
+										"source-config": "some-value",
+									},
+								},
+								{
+									Name: "some-unrelated-resource",
+									Type: "some-type",
+									Source: Source{
+										"source-config": "some-other-value",
+									},
+								},
+							},
+							Jobs: JobConfigs{
+								{
+									Name: "unit",
+									Plan: PlanSequence{
+										{Get: "other-name-for-some-resource"},
+									},
+								},
+								{
+									Name: "unrelated",
+									Plan: PlanSequence{
+										{Get: "some-unrelated-resource"},
+									},
+								},
+								{
+									Name: "unit/slow",
+									Plan: PlanSequence{
+										{Get: "other-name-for-some-resource"},
+									},
+								},
+							},
+						},
+					}
+
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/unit"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				JustBeforeEach(func() {
+					configWarnings, errorMessages = config.ValidateInTeam(pipelines)
+				})
+
+				Context("when the job interacts with the same resource", func() {
+					It("does not return an error", func() {
+						Expect(errorMessages).To(HaveLen(0))
+					})
+				})
+
+				Context("when the job does not interact with the same resource", func() {
+					BeforeEach(func() {
+						job.Plan[0].Passed = []string{"other-pipeline/unrelated"}
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('other-pipeline/unrelated') which doesn't interact with the resource ('some-resource')"))
+					})
+				})
+
+				Context("when the job does not exist", func() {
+					BeforeEach(func() {
+						job.Plan[0].Passed = []string{"other-pipeline/bogus-job"}
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references an unknown job ('other-pipeline/bogus-job')"))
+					})
+				})
+
+				Context("when the pipeline does not exist", func() {
+					BeforeEach(func() {
+						job.Plan[0].Passed = []string{"bogus-pipeline/unit"}
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references an unknown pipeline ('bogus-pipeline')"))
+					})
+				})
+
+				Context("when the team's pipelines are not known", func() {
+					BeforeEach(func() {
+						pipelines = nil
+						job.Plan[0].Passed = []string{"bogus-pipeline/unit"}
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("does not return an error", func() {
+						Expect(errorMessages).To(HaveLen(0))
+					})
+				})
+
+				Context("when the job's name contains a slash", func() {
+					BeforeEach(func() {
+						job.Plan[0].Passed = []string{"other-pipeline/unit/slow"}
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("does not return an error", func() {
+						Expect(errorMessages).To(HaveLen(0))
+					})
+				})
+
+				Context("when a job in the same pipeline has the same name", func() {
+					BeforeEach(func() {
+						config.Jobs = append(config.Jobs, JobConfig{
+							Name: "other-pipeline/unit",
+						})
+					})
+
+					It("refers to the job in the same pipeline", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('other-pipeline/unit') which doesn't interact with the resource ('some-resource')"))
+					})
+				})
+			})
 		})
 
 		Context("when two jobs have the same name", func() {
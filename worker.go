@@ -11,6 +11,9 @@ type Worker struct {
 
 	ActiveContainers int `json:"active_containers"`
 
+	DiskCapacityInBytes int64 `json:"disk_capacity_in_bytes,omitempty"`
+	DiskUsageInBytes    int64 `json:"disk_usage_in_bytes,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
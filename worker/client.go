@@ -35,6 +35,7 @@ type Client interface {
 
 	Satisfying(WorkerSpec, atc.ResourceTypes) (Worker, error)
 	AllSatisfying(WorkerSpec, atc.ResourceTypes) ([]Worker, error)
+	ChooseWorker(lager.Logger, <-chan os.Signal, ImageFetchingDelegate, WorkerSpec, atc.ResourceTypes, PlacementSpec) (Worker, error)
 	RunningWorkers() ([]Worker, error)
 	GetWorker(workerName string) (Worker, error)
 }
@@ -35,6 +35,7 @@ type WorkerDB interface {
 	ReapVolume(handle string) error
 	SetVolumeTTLAndSizeInBytes(string, time.Duration, int64) error
 	SetVolumeTTL(string, time.Duration) error
+	MarkVolumeAsUsed(handle string) error
 	AcquireVolumeCreatingLock(lager.Logger, int) (db.Lock, bool, error)
 }
 
@@ -168,6 +169,8 @@ func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker *dbn
 		provider,
 		tikTok,
 		savedWorker.ActiveContainers,
+		savedWorker.DiskCapacityInBytes,
+		savedWorker.DiskUsageInBytes,
 		savedWorker.ResourceTypes,
 		savedWorker.Platform,
 		savedWorker.Tags,
@@ -38,6 +38,10 @@ type Image interface {
 type ImageFetchingDelegate interface {
 	Stderr() io.Writer
 	ImageVersionDetermined(VolumeIdentifier) error
+
+	// WaitingForWorker is called when no worker satisfying the spec is
+	// currently available and the container's creation is being delayed.
+	WaitingForWorker(WorkerSpec)
 }
 
 type ImageMetadata struct {
@@ -49,3 +53,4 @@ type NoopImageFetchingDelegate struct{}
 
 func (NoopImageFetchingDelegate) Stderr() io.Writer                             { return ioutil.Discard }
 func (NoopImageFetchingDelegate) ImageVersionDetermined(VolumeIdentifier) error { return nil }
+func (NoopImageFetchingDelegate) WaitingForWorker(WorkerSpec)                   {}
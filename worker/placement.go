@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// ErrWorkersAtCapacity is returned by a ContainerPlacementStrategy when all
+// of the compatible workers are too busy to take on the container. Callers
+// should wait and try again rather than fail.
+var ErrWorkersAtCapacity = errors.New("all compatible workers are at capacity")
+
+// VolumeLocality returns how many of the volumes needed by a container are
+// already present on the given worker.
+type VolumeLocality func(Worker) (int, error)
+
+type PlacementSpec struct {
+	// Task is set when the container is for running a task.
+	Task bool
+
+	// VolumeLocality, if set, is used by strategies that prefer to place
+	// containers near their volumes.
+	VolumeLocality VolumeLocality
+}
+
+//go:generate counterfeiter . ContainerPlacementStrategy
+
+type ContainerPlacementStrategy interface {
+	Choose([]Worker, PlacementSpec) (Worker, error)
+}
+
+//go:generate counterfeiter . ActiveTasksDB
+
+type ActiveTasksDB interface {
+	ActiveTaskCounts() (map[string]int, error)
+}
+
+type randomPlacementStrategy struct{}
+
+func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+	return randomPlacementStrategy{}
+}
+
+func (randomPlacementStrategy) Choose(workers []Worker, spec PlacementSpec) (Worker, error) {
+	return workers[rand.Intn(len(workers))], nil
+}
+
+type fewestBuildContainersPlacementStrategy struct{}
+
+func NewFewestBuildContainersPlacementStrategy() ContainerPlacementStrategy {
+	return fewestBuildContainersPlacementStrategy{}
+}
+
+func (fewestBuildContainersPlacementStrategy) Choose(workers []Worker, spec PlacementSpec) (Worker, error) {
+	return chooseLowest(workers, func(w Worker) (int, error) {
+		return w.ActiveContainers(), nil
+	})
+}
+
+type volumeLocalityPlacementStrategy struct{}
+
+func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
+	return volumeLocalityPlacementStrategy{}
+}
+
+func (volumeLocalityPlacementStrategy) Choose(workers []Worker, spec PlacementSpec) (Worker, error) {
+	if spec.VolumeLocality == nil {
+		return workers[rand.Intn(len(workers))], nil
+	}
+
+	return chooseLowest(workers, func(w Worker) (int, error) {
+		volumes, err := spec.VolumeLocality(w)
+		return -volumes, err
+	})
+}
+
+type limitActiveTasksPlacementStrategy struct {
+	db             ActiveTasksDB
+	maxActiveTasks int
+}
+
+// NewLimitActiveTasksPlacementStrategy places task containers on the worker
+// running the fewest tasks, refusing to place them on workers that are
+// already running maxActiveTasks. Other containers are placed randomly.
+func NewLimitActiveTasksPlacementStrategy(db ActiveTasksDB, maxActiveTasks int) ContainerPlacementStrategy {
+	return &limitActiveTasksPlacementStrategy{
+		db:             db,
+		maxActiveTasks: maxActiveTasks,
+	}
+}
+
+func (strategy *limitActiveTasksPlacementStrategy) Choose(workers []Worker, spec PlacementSpec) (Worker, error) {
+	if !spec.Task {
+		return workers[rand.Intn(len(workers))], nil
+	}
+
+	activeTasks, err := strategy.db.ActiveTaskCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	available := []Worker{}
+	for _, w := range workers {
+		if strategy.maxActiveTasks == 0 || activeTasks[w.Name()] < strategy.maxActiveTasks {
+			available = append(available, w)
+		}
+	}
+
+	if len(available) == 0 {
+		return nil, ErrWorkersAtCapacity
+	}
+
+	return chooseLowest(available, func(w Worker) (int, error) {
+		return activeTasks[w.Name()], nil
+	})
+}
+
+// AboveDiskWatermark returns true if the worker has reported its disk
+// capacity and is using more than highWatermark, a fraction between 0 and 1,
+// of it.
+func AboveDiskWatermark(w Worker, highWatermark float64) bool {
+	capacity := w.DiskCapacityInBytes()
+	if capacity <= 0 {
+		return false
+	}
+
+	return float64(w.DiskUsageInBytes()) > float64(capacity)*highWatermark
+}
+
+type diskPressurePlacementStrategy struct {
+	strategy      ContainerPlacementStrategy
+	highWatermark float64
+}
+
+// NewDiskPressurePlacementStrategy wraps another strategy, keeping it from
+// choosing workers whose disk usage is above highWatermark. If every worker
+// is above it, they are all considered, as the volume collector will be
+// freeing up space on them.
+func NewDiskPressurePlacementStrategy(strategy ContainerPlacementStrategy, highWatermark float64) ContainerPlacementStrategy {
+	return &diskPressurePlacementStrategy{
+		strategy:      strategy,
+		highWatermark: highWatermark,
+	}
+}
+
+func (strategy *diskPressurePlacementStrategy) Choose(workers []Worker, spec PlacementSpec) (Worker, error) {
+	available := []Worker{}
+	for _, w := range workers {
+		if !AboveDiskWatermark(w, strategy.highWatermark) {
+			available = append(available, w)
+		}
+	}
+
+	if len(available) == 0 {
+		available = workers
+	}
+
+	return strategy.strategy.Choose(available, spec)
+}
+
+// chooseLowest returns the worker with the lowest score, picking randomly
+// between workers with the same score.
+func chooseLowest(workers []Worker, score func(Worker) (int, error)) (Worker, error) {
+	var lowest []Worker
+	var lowestScore int
+
+	for _, w := range workers {
+		s, err := score(w)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(lowest) == 0 || s < lowestScore {
+			lowest = []Worker{w}
+			lowestScore = s
+		} else if s == lowestScore {
+			lowest = append(lowest, w)
+		}
+	}
+
+	return lowest[rand.Intn(len(lowest))], nil
+}
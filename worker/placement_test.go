@@ -0,0 +1,241 @@
+package worker_test
+
+import (
+	"errors"
+
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerPlacementStrategy", func() {
+	var (
+		strategy  ContainerPlacementStrategy
+		placement PlacementSpec
+
+		workerA *workerfakes.FakeWorker
+		workerB *workerfakes.FakeWorker
+		workerC *workerfakes.FakeWorker
+		workers []Worker
+
+		chosenWorker Worker
+		chooseErr    error
+	)
+
+	BeforeEach(func() {
+		placement = PlacementSpec{}
+
+		workerA = new(workerfakes.FakeWorker)
+		workerA.NameReturns("worker-a")
+
+		workerB = new(workerfakes.FakeWorker)
+		workerB.NameReturns("worker-b")
+
+		workerC = new(workerfakes.FakeWorker)
+		workerC.NameReturns("worker-c")
+
+		workers = []Worker{workerA, workerB, workerC}
+	})
+
+	JustBeforeEach(func() {
+		chosenWorker, chooseErr = strategy.Choose(workers, placement)
+	})
+
+	Describe("random", func() {
+		BeforeEach(func() {
+			strategy = NewRandomPlacementStrategy()
+		})
+
+		It("chooses one of the workers", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(workers).To(ContainElement(chosenWorker))
+		})
+
+		It("chooses different workers over time", func() {
+			chosen := map[Worker]bool{}
+			for i := 0; i < 100; i++ {
+				w, err := strategy.Choose(workers, placement)
+				Expect(err).NotTo(HaveOccurred())
+				chosen[w] = true
+			}
+
+			Expect(chosen).To(HaveLen(3))
+		})
+	})
+
+	Describe("fewest-build-containers", func() {
+		BeforeEach(func() {
+			strategy = NewFewestBuildContainersPlacementStrategy()
+
+			workerA.ActiveContainersReturns(5)
+			workerB.ActiveContainersReturns(2)
+			workerC.ActiveContainersReturns(7)
+		})
+
+		It("chooses the worker with the fewest active containers", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerB))
+		})
+	})
+
+	Describe("volume-locality", func() {
+		BeforeEach(func() {
+			strategy = NewVolumeLocalityPlacementStrategy()
+		})
+
+		Context("when the placement has volume locality", func() {
+			BeforeEach(func() {
+				placement.VolumeLocality = func(w Worker) (int, error) {
+					switch w {
+					case workerA:
+						return 1, nil
+					case workerB:
+						return 0, nil
+					default:
+						return 3, nil
+					}
+				}
+			})
+
+			It("chooses the worker with the most volumes", func() {
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(chosenWorker).To(Equal(workerC))
+			})
+
+			Context("when determining the locality fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					placement.VolumeLocality = func(Worker) (int, error) {
+						return 0, disaster
+					}
+				})
+
+				It("returns the error", func() {
+					Expect(chooseErr).To(Equal(disaster))
+				})
+			})
+		})
+
+		Context("when the placement has no volume locality", func() {
+			It("chooses one of the workers", func() {
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(workers).To(ContainElement(chosenWorker))
+			})
+		})
+	})
+
+	Describe("limit-active-tasks", func() {
+		var fakeDB *workerfakes.FakeActiveTasksDB
+
+		BeforeEach(func() {
+			fakeDB = new(workerfakes.FakeActiveTasksDB)
+			fakeDB.ActiveTaskCountsReturns(map[string]int{
+				"worker-a": 2,
+				"worker-b": 1,
+				"worker-c": 3,
+			}, nil)
+
+			strategy = NewLimitActiveTasksPlacementStrategy(fakeDB, 3)
+		})
+
+		Context("when placing a task", func() {
+			BeforeEach(func() {
+				placement.Task = true
+			})
+
+			It("chooses the worker with the fewest active tasks", func() {
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(chosenWorker).To(Equal(workerB))
+			})
+
+			Context("when every worker is at the limit", func() {
+				BeforeEach(func() {
+					fakeDB.ActiveTaskCountsReturns(map[string]int{
+						"worker-a": 3,
+						"worker-b": 4,
+						"worker-c": 3,
+					}, nil)
+				})
+
+				It("returns ErrWorkersAtCapacity", func() {
+					Expect(chooseErr).To(Equal(ErrWorkersAtCapacity))
+				})
+
+				Context("when there is no limit", func() {
+					BeforeEach(func() {
+						strategy = NewLimitActiveTasksPlacementStrategy(fakeDB, 0)
+					})
+
+					It("still chooses the worker with the fewest active tasks", func() {
+						Expect(chooseErr).NotTo(HaveOccurred())
+						Expect([]Worker{workerA, workerC}).To(ContainElement(chosenWorker))
+					})
+				})
+			})
+
+			Context("when counting the active tasks fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeDB.ActiveTaskCountsReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(chooseErr).To(Equal(disaster))
+				})
+			})
+		})
+
+		Context("when placing something other than a task", func() {
+			It("does not count the active tasks", func() {
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(workers).To(ContainElement(chosenWorker))
+				Expect(fakeDB.ActiveTaskCountsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("disk-pressure", func() {
+		var fakeStrategy *workerfakes.FakeContainerPlacementStrategy
+
+		BeforeEach(func() {
+			fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+			fakeStrategy.ChooseReturns(workerB, nil)
+
+			strategy = NewDiskPressurePlacementStrategy(fakeStrategy, 0.9)
+
+			workerA.DiskCapacityInBytesReturns(1000)
+			workerA.DiskUsageInBytesReturns(950)
+			workerB.DiskCapacityInBytesReturns(1000)
+			workerB.DiskUsageInBytesReturns(500)
+		})
+
+		It("returns the worker chosen by the wrapped strategy", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerB))
+		})
+
+		It("does not offer workers above the watermark to the wrapped strategy", func() {
+			Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+			offered, _ := fakeStrategy.ChooseArgsForCall(0)
+			Expect(offered).To(Equal([]Worker{workerB, workerC}))
+		})
+
+		Context("when every worker is above the watermark", func() {
+			BeforeEach(func() {
+				workerB.DiskUsageInBytesReturns(950)
+				workerC.DiskCapacityInBytesReturns(1000)
+				workerC.DiskUsageInBytesReturns(1000)
+			})
+
+			It("offers all of the workers to the wrapped strategy", func() {
+				Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+				offered, _ := fakeStrategy.ChooseArgsForCall(0)
+				Expect(offered).To(Equal(workers))
+			})
+		})
+	})
+})
@@ -7,9 +7,11 @@ import (
 	"os"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
 )
 
 //go:generate counterfeiter . WorkerProvider
@@ -25,8 +27,13 @@ type WorkerProvider interface {
 var (
 	ErrNoWorkers     = errors.New("no workers")
 	ErrMissingWorker = errors.New("worker for container is missing")
+	ErrInterrupted   = errors.New("interrupted while waiting for a worker")
 )
 
+// WorkerPollingInterval is how often a pool re-evaluates its workers while
+// waiting for a compatible worker to become available or to have capacity.
+const WorkerPollingInterval = 5 * time.Second
+
 type NoCompatibleWorkersError struct {
 	Spec    WorkerSpec
 	Workers []Worker
@@ -46,15 +53,21 @@ func (err NoCompatibleWorkersError) Error() string {
 }
 
 type pool struct {
-	provider WorkerProvider
-
-	rand *rand.Rand
+	provider    WorkerProvider
+	strategy    ContainerPlacementStrategy
+	waitTimeout time.Duration
+	clock       clock.Clock
 }
 
-func NewPool(provider WorkerProvider) Client {
+// NewPool constructs a Client which places containers on the workers
+// returned by the provider. When no compatible worker is running, callers
+// wait up to waitTimeout for one to appear before giving up.
+func NewPool(provider WorkerProvider, strategy ContainerPlacementStrategy, waitTimeout time.Duration, clock clock.Clock) Client {
 	return &pool{
-		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		provider:    provider,
+		strategy:    strategy,
+		waitTimeout: waitTimeout,
+		clock:       clock,
 	}
 }
 
@@ -126,12 +139,73 @@ func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (
 	if err != nil {
 		return nil, err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
-	return randomWorker, nil
+
+	return pool.strategy.Choose(compatibleWorkers, PlacementSpec{})
+}
+
+func (pool *pool) ChooseWorker(logger lager.Logger, signals <-chan os.Signal, delegate ImageFetchingDelegate, spec WorkerSpec, resourceTypes atc.ResourceTypes, placement PlacementSpec) (Worker, error) {
+	logger = logger.Session("choose-worker")
+
+	var waiting bool
+	var unavailableSince time.Time
+
+	for {
+		compatibleWorkers, err := pool.AllSatisfying(spec, resourceTypes)
+		if err == nil {
+			unavailableSince = time.Time{}
+
+			var chosenWorker Worker
+			chosenWorker, err = pool.strategy.Choose(compatibleWorkers, placement)
+			if err != ErrWorkersAtCapacity {
+				return chosenWorker, err
+			}
+		} else {
+			if !isUnavailableErr(err) {
+				return nil, err
+			}
+
+			if unavailableSince.IsZero() {
+				unavailableSince = pool.clock.Now()
+			}
+
+			if pool.clock.Since(unavailableSince) >= pool.waitTimeout {
+				return nil, err
+			}
+		}
+
+		if !waiting {
+			waiting = true
+
+			logger.Info("waiting-for-worker", lager.Data{"reason": err.Error()})
+
+			metric.StepsWaitingForWorker.Inc()
+			defer metric.StepsWaitingForWorker.Dec()
+
+			delegate.WaitingForWorker(spec)
+		}
+
+		timer := pool.clock.NewTimer(WorkerPollingInterval)
+
+		select {
+		case <-timer.C():
+		case <-signals:
+			timer.Stop()
+			return nil, ErrInterrupted
+		}
+	}
+}
+
+func isUnavailableErr(err error) bool {
+	if err == ErrNoWorkers {
+		return true
+	}
+
+	_, ok := err.(NoCompatibleWorkersError)
+	return ok
 }
 
 func (pool *pool) CreateContainer(logger lager.Logger, signals <-chan os.Signal, delegate ImageFetchingDelegate, id Identifier, metadata Metadata, spec ContainerSpec, resourceTypes atc.ResourceTypes) (Container, error) {
-	worker, err := pool.Satisfying(spec.WorkerSpec(), resourceTypes)
+	worker, err := pool.ChooseWorker(logger, signals, delegate, spec.WorkerSpec(), resourceTypes, PlacementSpec{})
 	if err != nil {
 		return nil, err
 	}
@@ -155,17 +229,29 @@ func (pool *pool) FindContainerForIdentifier(logger lager.Logger, id Identifier)
 	}
 
 	worker, found, err := pool.provider.GetWorker(containerInfo.WorkerName)
-	if err != nil {
+	if err != nil && err != ErrDesiredWorkerNotRunning {
 		return nil, found, err
 	}
 
 	if !found {
-		logger.Info("found-container-for-missing-worker", lager.Data{
+		// the worker is gone or stalled, so the container is lost along with it.
+		// the caller still errors, but the container is forgotten so that a
+		// step retried on worker loss starts over on another worker.
+		logger.Info("reaping-container-for-lost-worker", lager.Data{
 			"container-handle": containerInfo.Handle,
 			"worker-name":      containerInfo.WorkerName,
 		})
 
-		return nil, false, ErrMissingWorker
+		reapErr := pool.provider.ReapContainer(containerInfo.Handle)
+		if reapErr != nil {
+			return nil, false, reapErr
+		}
+
+		if err == nil {
+			err = ErrMissingWorker
+		}
+
+		return nil, false, err
 	}
 
 	valid, err := worker.ValidateResourceCheckVersion(containerInfo)
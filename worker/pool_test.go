@@ -2,7 +2,10 @@ package worker_test
 
 import (
 	"errors"
+	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -17,6 +20,8 @@ var _ = Describe("Pool", func() {
 	var (
 		logger       *lagertest.TestLogger
 		fakeProvider *workerfakes.FakeWorkerProvider
+		fakeStrategy *workerfakes.FakeContainerPlacementStrategy
+		fakeClock    *fakeclock.FakeClock
 
 		pool Client
 	)
@@ -24,8 +29,12 @@ var _ = Describe("Pool", func() {
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 
-		pool = NewPool(fakeProvider)
+		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+		fakeStrategy.ChooseStub = NewRandomPlacementStrategy().Choose
+
+		pool = NewPool(fakeProvider, fakeStrategy, 0, fakeClock)
 	})
 
 	Describe("GetWorker", func() {
@@ -529,6 +538,224 @@ var _ = Describe("Pool", func() {
 		})
 	})
 
+	Describe("ChooseWorker", func() {
+		var (
+			signals   chan os.Signal
+			delegate  *workerfakes.FakeImageFetchingDelegate
+			spec      WorkerSpec
+			placement PlacementSpec
+
+			workerA *workerfakes.FakeWorker
+			workerB *workerfakes.FakeWorker
+
+			chosenWorker Worker
+			chooseErr    error
+			done         chan struct{}
+		)
+
+		BeforeEach(func() {
+			signals = make(chan os.Signal, 1)
+			delegate = new(workerfakes.FakeImageFetchingDelegate)
+			spec = WorkerSpec{Platform: "some-platform"}
+			placement = PlacementSpec{Task: true}
+
+			workerA = new(workerfakes.FakeWorker)
+			workerA.SatisfyingReturns(workerA, nil)
+
+			workerB = new(workerfakes.FakeWorker)
+			workerB.SatisfyingReturns(nil, errors.New("nope"))
+
+			fakeProvider.RunningWorkersReturns([]Worker{workerA, workerB}, nil)
+
+			fakeStrategy.ChooseStub = nil
+			fakeStrategy.ChooseReturns(workerA, nil)
+
+			pool = NewPool(fakeProvider, fakeStrategy, time.Minute, fakeClock)
+		})
+
+		JustBeforeEach(func() {
+			done = make(chan struct{})
+
+			go func() {
+				defer close(done)
+				chosenWorker, chooseErr = pool.ChooseWorker(logger, signals, delegate, spec, atc.ResourceTypes{}, placement)
+			}()
+		})
+
+		It("returns the worker chosen by the placement strategy", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerA))
+		})
+
+		It("does not wait", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(delegate.WaitingForWorkerCallCount()).To(BeZero())
+		})
+
+		It("gives the strategy the compatible workers and the placement", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+
+			workers, actualPlacement := fakeStrategy.ChooseArgsForCall(0)
+			Expect(workers).To(Equal([]Worker{workerA}))
+			Expect(actualPlacement.Task).To(BeTrue())
+		})
+
+		Context("when the strategy fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStrategy.ChooseReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Eventually(done).Should(BeClosed())
+				Expect(chooseErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when all of the workers are at capacity", func() {
+			BeforeEach(func() {
+				fakeStrategy.ChooseStub = func([]Worker, PlacementSpec) (Worker, error) {
+					if fakeStrategy.ChooseCallCount() == 1 {
+						return nil, ErrWorkersAtCapacity
+					}
+
+					return workerA, nil
+				}
+			})
+
+			It("waits and tries again", func() {
+				Eventually(fakeClock.WatcherCount).Should(Equal(1))
+				Consistently(done).ShouldNot(BeClosed())
+
+				fakeClock.Increment(WorkerPollingInterval)
+
+				Eventually(done).Should(BeClosed())
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(chosenWorker).To(Equal(workerA))
+				Expect(fakeStrategy.ChooseCallCount()).To(Equal(2))
+			})
+
+			It("tells the delegate that it is waiting", func() {
+				Eventually(delegate.WaitingForWorkerCallCount).Should(Equal(1))
+				Expect(delegate.WaitingForWorkerArgsForCall(0)).To(Equal(spec))
+
+				fakeClock.Increment(WorkerPollingInterval)
+
+				Eventually(done).Should(BeClosed())
+				Expect(delegate.WaitingForWorkerCallCount()).To(Equal(1))
+			})
+
+			Context("when the workers remain at capacity", func() {
+				BeforeEach(func() {
+					fakeStrategy.ChooseStub = nil
+					fakeStrategy.ChooseReturns(nil, ErrWorkersAtCapacity)
+				})
+
+				It("waits beyond the timeout", func() {
+					for i := 0; i < 20; i++ {
+						Eventually(fakeClock.WatcherCount).Should(Equal(1))
+						fakeClock.Increment(WorkerPollingInterval)
+					}
+
+					Consistently(done).ShouldNot(BeClosed())
+
+					signals <- os.Interrupt
+					Eventually(done).Should(BeClosed())
+					Expect(chooseErr).To(Equal(ErrInterrupted))
+				})
+			})
+
+			Context("when interrupted while waiting", func() {
+				It("returns ErrInterrupted", func() {
+					Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+					signals <- os.Interrupt
+
+					Eventually(done).Should(BeClosed())
+					Expect(chooseErr).To(Equal(ErrInterrupted))
+				})
+			})
+		})
+
+		Context("when no workers satisfy the spec", func() {
+			BeforeEach(func() {
+				workerA.SatisfyingReturns(nil, errors.New("nope"))
+			})
+
+			It("waits for a compatible worker, telling the delegate", func() {
+				Eventually(fakeClock.WatcherCount).Should(Equal(1))
+				Consistently(done).ShouldNot(BeClosed())
+
+				Expect(delegate.WaitingForWorkerCallCount()).To(Equal(1))
+				Expect(delegate.WaitingForWorkerArgsForCall(0)).To(Equal(spec))
+
+				workerA.SatisfyingReturns(workerA, nil)
+				fakeClock.Increment(WorkerPollingInterval)
+
+				Eventually(done).Should(BeClosed())
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect(chosenWorker).To(Equal(workerA))
+			})
+
+			It("gives up with a NoCompatibleWorkersError after the timeout", func() {
+				for i := time.Duration(0); i < time.Minute; i += WorkerPollingInterval {
+					Eventually(fakeClock.WatcherCount).Should(Equal(1))
+					fakeClock.Increment(WorkerPollingInterval)
+				}
+
+				Eventually(done).Should(BeClosed())
+				Expect(chooseErr).To(BeAssignableToTypeOf(NoCompatibleWorkersError{}))
+				Expect(fakeStrategy.ChooseCallCount()).To(BeZero())
+			})
+
+			Context("when the pool does not wait for workers", func() {
+				BeforeEach(func() {
+					pool = NewPool(fakeProvider, fakeStrategy, 0, fakeClock)
+				})
+
+				It("returns a NoCompatibleWorkersError immediately", func() {
+					Eventually(done).Should(BeClosed())
+					Expect(chooseErr).To(BeAssignableToTypeOf(NoCompatibleWorkersError{}))
+					Expect(delegate.WaitingForWorkerCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when no workers are running", func() {
+			BeforeEach(func() {
+				fakeProvider.RunningWorkersReturns(nil, nil)
+			})
+
+			It("waits for a worker", func() {
+				Eventually(fakeClock.WatcherCount).Should(Equal(1))
+				Consistently(done).ShouldNot(BeClosed())
+
+				fakeProvider.RunningWorkersReturns([]Worker{workerA}, nil)
+				fakeClock.Increment(WorkerPollingInterval)
+
+				Eventually(done).Should(BeClosed())
+				Expect(chosenWorker).To(Equal(workerA))
+			})
+		})
+
+		Context("when getting the workers fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeProvider.RunningWorkersReturns(nil, disaster)
+			})
+
+			It("returns the error without waiting", func() {
+				Eventually(done).Should(BeClosed())
+				Expect(chooseErr).To(Equal(disaster))
+				Expect(delegate.WaitingForWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("LookupContainer", func() {
 		Context("when looking up the container info contains an error", func() {
 			BeforeEach(func() {
@@ -757,11 +984,44 @@ var _ = Describe("Pool", func() {
 					fakeProvider.GetWorkerReturns(nil, false, nil)
 				})
 
-				It("returns ErrMissingWorker", func() {
+				It("reaps the container and returns ErrMissingWorker", func() {
 					container, found, err := pool.FindContainerForIdentifier(logger, identifier)
 					Expect(err).To(Equal(ErrMissingWorker))
 					Expect(container).To(BeNil())
 					Expect(found).To(BeFalse())
+
+					Expect(fakeProvider.ReapContainerCallCount()).To(Equal(1))
+					Expect(fakeProvider.ReapContainerArgsForCall(0)).To(Equal("some-container-handle"))
+				})
+
+				Context("when reaping the container fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeProvider.ReapContainerReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						_, found, err := pool.FindContainerForIdentifier(logger, identifier)
+						Expect(err).To(Equal(disaster))
+						Expect(found).To(BeFalse())
+					})
+				})
+			})
+
+			Context("when the worker from the container info is not running", func() {
+				BeforeEach(func() {
+					fakeProvider.GetWorkerReturns(nil, false, ErrDesiredWorkerNotRunning)
+				})
+
+				It("reaps the container and returns the error", func() {
+					container, found, err := pool.FindContainerForIdentifier(logger, identifier)
+					Expect(err).To(Equal(ErrDesiredWorkerNotRunning))
+					Expect(container).To(BeNil())
+					Expect(found).To(BeFalse())
+
+					Expect(fakeProvider.ReapContainerCallCount()).To(Equal(1))
+					Expect(fakeProvider.ReapContainerArgsForCall(0)).To(Equal("some-container-handle"))
 				})
 			})
 
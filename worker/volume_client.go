@@ -68,7 +68,19 @@ func (c *volumeClient) FindVolume(
 		}
 	}
 
-	return c.LookupVolume(logger, savedVolume.Handle)
+	volume, found, err := c.LookupVolume(logger, savedVolume.Handle)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	// only used to decide which caches to evict first under disk pressure, so
+	// failing to record it should not fail the step
+	err = c.db.MarkVolumeAsUsed(savedVolume.Handle)
+	if err != nil {
+		logger.Error("failed-to-mark-volume-as-used", err)
+	}
+
+	return volume, true, nil
 }
 
 func (c *volumeClient) CreateVolume(
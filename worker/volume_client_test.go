@@ -268,6 +268,23 @@ var _ = Describe("VolumeClient", func() {
 						Expect(found).To(BeTrue())
 						Expect(foundVolume).To(Equal(builtVolume))
 					})
+
+					It("marks the volume as used", func() {
+						Expect(fakeGardenWorkerDB.MarkVolumeAsUsedCallCount()).To(Equal(1))
+						Expect(fakeGardenWorkerDB.MarkVolumeAsUsedArgsForCall(0)).To(Equal("db-vol-handle"))
+					})
+
+					Context("when marking the volume as used fails", func() {
+						BeforeEach(func() {
+							fakeGardenWorkerDB.MarkVolumeAsUsedReturns(errors.New("nope"))
+						})
+
+						It("still returns the worker volume", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(foundVolume).To(Equal(builtVolume))
+						})
+					})
 				})
 
 				Context("when building the worker volume fails", func() {
@@ -51,6 +51,11 @@ type Worker interface {
 
 	ActiveContainers() int
 
+	// DiskCapacityInBytes and DiskUsageInBytes are as last reported by the
+	// worker; a capacity of 0 means the worker did not report it.
+	DiskCapacityInBytes() int64
+	DiskUsageInBytes() int64
+
 	Description() string
 	Name() string
 	Uptime() time.Duration
@@ -69,6 +74,7 @@ type GardenWorkerDB interface {
 	SetVolumeTTLAndSizeInBytes(string, time.Duration, int64) error
 	GetVolumeTTL(string) (time.Duration, bool, error)
 	GetVolumesByIdentifier(db.VolumeIdentifier) ([]db.SavedVolume, error)
+	MarkVolumeAsUsed(handle string) error
 	AcquireVolumeCreatingLock(lager.Logger, int) (db.Lock, bool, error)
 }
 
@@ -85,16 +91,18 @@ type gardenWorker struct {
 
 	clock clock.Clock
 
-	activeContainers int
-	resourceTypes    []atc.WorkerResourceType
-	platform         string
-	tags             atc.Tags
-	teamID           int
-	name             string
-	startTime        int64
-	httpProxyURL     string
-	httpsProxyURL    string
-	noProxy          string
+	activeContainers    int
+	diskCapacityInBytes int64
+	diskUsageInBytes    int64
+	resourceTypes       []atc.WorkerResourceType
+	platform            string
+	tags                atc.Tags
+	teamID              int
+	name                string
+	startTime           int64
+	httpProxyURL        string
+	httpsProxyURL       string
+	noProxy             string
 }
 
 func NewGardenWorker(
@@ -108,6 +116,8 @@ func NewGardenWorker(
 	provider WorkerProvider,
 	clock clock.Clock,
 	activeContainers int,
+	diskCapacityInBytes int64,
+	diskUsageInBytes int64,
 	resourceTypes []atc.WorkerResourceType,
 	platform string,
 	tags atc.Tags,
@@ -119,25 +129,27 @@ func NewGardenWorker(
 	noProxy string,
 ) Worker {
 	return &gardenWorker{
-		gardenClient:       gardenClient,
-		baggageclaimClient: baggageclaimClient,
-		volumeClient:       volumeClient,
-		volumeFactory:      volumeFactory,
-		imageFactory:       imageFactory,
-		db:                 db,
-		provider:           provider,
-		clock:              clock,
-		pipelineDBFactory:  pipelineDBFactory,
-		activeContainers:   activeContainers,
-		resourceTypes:      resourceTypes,
-		platform:           platform,
-		tags:               tags,
-		teamID:             teamID,
-		name:               name,
-		startTime:          startTime,
-		httpProxyURL:       httpProxyURL,
-		httpsProxyURL:      httpsProxyURL,
-		noProxy:            noProxy,
+		gardenClient:        gardenClient,
+		baggageclaimClient:  baggageclaimClient,
+		volumeClient:        volumeClient,
+		volumeFactory:       volumeFactory,
+		imageFactory:        imageFactory,
+		db:                  db,
+		provider:            provider,
+		clock:               clock,
+		pipelineDBFactory:   pipelineDBFactory,
+		activeContainers:    activeContainers,
+		diskCapacityInBytes: diskCapacityInBytes,
+		diskUsageInBytes:    diskUsageInBytes,
+		resourceTypes:       resourceTypes,
+		platform:            platform,
+		tags:                tags,
+		teamID:              teamID,
+		name:                name,
+		startTime:           startTime,
+		httpProxyURL:        httpProxyURL,
+		httpsProxyURL:       httpsProxyURL,
+		noProxy:             noProxy,
 	}
 }
 
@@ -598,6 +610,14 @@ func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) DiskCapacityInBytes() int64 {
+	return worker.diskCapacityInBytes
+}
+
+func (worker *gardenWorker) DiskUsageInBytes() int64 {
+	return worker.diskUsageInBytes
+}
+
 func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
 	if spec.TeamID != worker.teamID && worker.teamID != 0 {
 		return nil, ErrTeamMismatch
@@ -651,6 +671,10 @@ func (worker *gardenWorker) AllSatisfying(spec WorkerSpec, resourceTypes atc.Res
 	return nil, errors.New("Not implemented")
 }
 
+func (worker *gardenWorker) ChooseWorker(lager.Logger, <-chan os.Signal, ImageFetchingDelegate, WorkerSpec, atc.ResourceTypes, PlacementSpec) (Worker, error) {
+	return nil, errors.New("Not implemented")
+}
+
 func (worker *gardenWorker) RunningWorkers() ([]Worker, error) {
 	return nil, errors.New("Not implemented")
 }
@@ -39,6 +39,8 @@ var _ = Describe("Worker", func() {
 		fakeClock              *fakeclock.FakeClock
 		fakePipelineDBFactory  *dbfakes.FakePipelineDBFactory
 		activeContainers       int
+		diskCapacityInBytes    int64
+		diskUsageInBytes       int64
 		resourceTypes          []atc.WorkerResourceType
 		platform               string
 		tags                   atc.Tags
@@ -68,6 +70,8 @@ var _ = Describe("Worker", func() {
 		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 		activeContainers = 42
+		diskCapacityInBytes = 1000
+		diskUsageInBytes = 900
 		resourceTypes = []atc.WorkerResourceType{
 			{
 				Type:    "some-resource",
@@ -95,6 +99,8 @@ var _ = Describe("Worker", func() {
 			fakeWorkerProvider,
 			fakeClock,
 			activeContainers,
+			diskCapacityInBytes,
+			diskUsageInBytes,
 			resourceTypes,
 			platform,
 			tags,
@@ -1196,6 +1202,13 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("DiskCapacityInBytes and DiskUsageInBytes", func() {
+		It("returns the disk capacity and usage reported by the worker", func() {
+			Expect(gardenWorker.DiskCapacityInBytes()).To(Equal(int64(1000)))
+			Expect(gardenWorker.DiskUsageInBytes()).To(Equal(int64(900)))
+		})
+	})
+
 	Describe("LookupContainer", func() {
 		var handle string
 
@@ -1332,6 +1345,8 @@ var _ = Describe("Worker", func() {
 								fakeWorkerProvider,
 								fakeClock,
 								activeContainers,
+								diskCapacityInBytes,
+								diskUsageInBytes,
 								resourceTypes,
 								platform,
 								tags,
@@ -1396,6 +1411,8 @@ var _ = Describe("Worker", func() {
 								fakeWorkerProvider,
 								fakeClock,
 								activeContainers,
+								diskCapacityInBytes,
+								diskUsageInBytes,
 								resourceTypes,
 								platform,
 								tags,
@@ -0,0 +1,68 @@
+// This file was generated by counterfeiter
+package workerfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/worker"
+)
+
+type FakeActiveTasksDB struct {
+	ActiveTaskCountsStub        func() (map[string]int, error)
+	activeTaskCountsMutex       sync.RWMutex
+	activeTaskCountsArgsForCall []struct{}
+	activeTaskCountsReturns     struct {
+		result1 map[string]int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeActiveTasksDB) ActiveTaskCounts() (map[string]int, error) {
+	fake.activeTaskCountsMutex.Lock()
+	fake.activeTaskCountsArgsForCall = append(fake.activeTaskCountsArgsForCall, struct{}{})
+	fake.recordInvocation("ActiveTaskCounts", []interface{}{})
+	fake.activeTaskCountsMutex.Unlock()
+	if fake.ActiveTaskCountsStub != nil {
+		return fake.ActiveTaskCountsStub()
+	} else {
+		return fake.activeTaskCountsReturns.result1, fake.activeTaskCountsReturns.result2
+	}
+}
+
+func (fake *FakeActiveTasksDB) ActiveTaskCountsCallCount() int {
+	fake.activeTaskCountsMutex.RLock()
+	defer fake.activeTaskCountsMutex.RUnlock()
+	return len(fake.activeTaskCountsArgsForCall)
+}
+
+func (fake *FakeActiveTasksDB) ActiveTaskCountsReturns(result1 map[string]int, result2 error) {
+	fake.ActiveTaskCountsStub = nil
+	fake.activeTaskCountsReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActiveTasksDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.activeTaskCountsMutex.RLock()
+	defer fake.activeTaskCountsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeActiveTasksDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.ActiveTasksDB = new(FakeActiveTasksDB)
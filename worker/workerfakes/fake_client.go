@@ -130,6 +130,20 @@ type FakeClient struct {
 		result1 []worker.Worker
 		result2 error
 	}
+	ChooseWorkerStub        func(lager.Logger, <-chan os.Signal, worker.ImageFetchingDelegate, worker.WorkerSpec, atc.ResourceTypes, worker.PlacementSpec) (worker.Worker, error)
+	chooseWorkerMutex       sync.RWMutex
+	chooseWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 <-chan os.Signal
+		arg3 worker.ImageFetchingDelegate
+		arg4 worker.WorkerSpec
+		arg5 atc.ResourceTypes
+		arg6 worker.PlacementSpec
+	}
+	chooseWorkerReturns struct {
+		result1 worker.Worker
+		result2 error
+	}
 	RunningWorkersStub        func() ([]worker.Worker, error)
 	runningWorkersMutex       sync.RWMutex
 	runningWorkersArgsForCall []struct{}
@@ -543,6 +557,45 @@ func (fake *FakeClient) AllSatisfyingReturns(result1 []worker.Worker, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ChooseWorker(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 worker.ImageFetchingDelegate, arg4 worker.WorkerSpec, arg5 atc.ResourceTypes, arg6 worker.PlacementSpec) (worker.Worker, error) {
+	fake.chooseWorkerMutex.Lock()
+	fake.chooseWorkerArgsForCall = append(fake.chooseWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 <-chan os.Signal
+		arg3 worker.ImageFetchingDelegate
+		arg4 worker.WorkerSpec
+		arg5 atc.ResourceTypes
+		arg6 worker.PlacementSpec
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("ChooseWorker", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.chooseWorkerMutex.Unlock()
+	if fake.ChooseWorkerStub != nil {
+		return fake.ChooseWorkerStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	} else {
+		return fake.chooseWorkerReturns.result1, fake.chooseWorkerReturns.result2
+	}
+}
+
+func (fake *FakeClient) ChooseWorkerCallCount() int {
+	fake.chooseWorkerMutex.RLock()
+	defer fake.chooseWorkerMutex.RUnlock()
+	return len(fake.chooseWorkerArgsForCall)
+}
+
+func (fake *FakeClient) ChooseWorkerArgsForCall(i int) (lager.Logger, <-chan os.Signal, worker.ImageFetchingDelegate, worker.WorkerSpec, atc.ResourceTypes, worker.PlacementSpec) {
+	fake.chooseWorkerMutex.RLock()
+	defer fake.chooseWorkerMutex.RUnlock()
+	return fake.chooseWorkerArgsForCall[i].arg1, fake.chooseWorkerArgsForCall[i].arg2, fake.chooseWorkerArgsForCall[i].arg3, fake.chooseWorkerArgsForCall[i].arg4, fake.chooseWorkerArgsForCall[i].arg5, fake.chooseWorkerArgsForCall[i].arg6
+}
+
+func (fake *FakeClient) ChooseWorkerReturns(result1 worker.Worker, result2 error) {
+	fake.ChooseWorkerStub = nil
+	fake.chooseWorkerReturns = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) RunningWorkers() ([]worker.Worker, error) {
 	fake.runningWorkersMutex.Lock()
 	fake.runningWorkersArgsForCall = append(fake.runningWorkersArgsForCall, struct{}{})
@@ -628,6 +681,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.satisfyingMutex.RUnlock()
 	fake.allSatisfyingMutex.RLock()
 	defer fake.allSatisfyingMutex.RUnlock()
+	fake.chooseWorkerMutex.RLock()
+	defer fake.chooseWorkerMutex.RUnlock()
 	fake.runningWorkersMutex.RLock()
 	defer fake.runningWorkersMutex.RUnlock()
 	fake.getWorkerMutex.RLock()
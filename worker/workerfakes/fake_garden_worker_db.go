@@ -96,6 +96,14 @@ type FakeGardenWorkerDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	MarkVolumeAsUsedStub        func(handle string) error
+	markVolumeAsUsedMutex       sync.RWMutex
+	markVolumeAsUsedArgsForCall []struct {
+		handle string
+	}
+	markVolumeAsUsedReturns struct {
+		result1 error
+	}
 	AcquireVolumeCreatingLockStub        func(lager.Logger, int) (db.Lock, bool, error)
 	acquireVolumeCreatingLockMutex       sync.RWMutex
 	acquireVolumeCreatingLockArgsForCall []struct {
@@ -426,6 +434,39 @@ func (fake *FakeGardenWorkerDB) GetVolumesByIdentifierReturns(result1 []db.Saved
 	}{result1, result2}
 }
 
+func (fake *FakeGardenWorkerDB) MarkVolumeAsUsed(handle string) error {
+	fake.markVolumeAsUsedMutex.Lock()
+	fake.markVolumeAsUsedArgsForCall = append(fake.markVolumeAsUsedArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("MarkVolumeAsUsed", []interface{}{handle})
+	fake.markVolumeAsUsedMutex.Unlock()
+	if fake.MarkVolumeAsUsedStub != nil {
+		return fake.MarkVolumeAsUsedStub(handle)
+	} else {
+		return fake.markVolumeAsUsedReturns.result1
+	}
+}
+
+func (fake *FakeGardenWorkerDB) MarkVolumeAsUsedCallCount() int {
+	fake.markVolumeAsUsedMutex.RLock()
+	defer fake.markVolumeAsUsedMutex.RUnlock()
+	return len(fake.markVolumeAsUsedArgsForCall)
+}
+
+func (fake *FakeGardenWorkerDB) MarkVolumeAsUsedArgsForCall(i int) string {
+	fake.markVolumeAsUsedMutex.RLock()
+	defer fake.markVolumeAsUsedMutex.RUnlock()
+	return fake.markVolumeAsUsedArgsForCall[i].handle
+}
+
+func (fake *FakeGardenWorkerDB) MarkVolumeAsUsedReturns(result1 error) {
+	fake.MarkVolumeAsUsedStub = nil
+	fake.markVolumeAsUsedReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeGardenWorkerDB) AcquireVolumeCreatingLock(arg1 lager.Logger, arg2 int) (db.Lock, bool, error) {
 	fake.acquireVolumeCreatingLockMutex.Lock()
 	fake.acquireVolumeCreatingLockArgsForCall = append(fake.acquireVolumeCreatingLockArgsForCall, struct {
@@ -483,6 +524,8 @@ func (fake *FakeGardenWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.getVolumeTTLMutex.RUnlock()
 	fake.getVolumesByIdentifierMutex.RLock()
 	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	fake.markVolumeAsUsedMutex.RLock()
+	defer fake.markVolumeAsUsedMutex.RUnlock()
 	fake.acquireVolumeCreatingLockMutex.RLock()
 	defer fake.acquireVolumeCreatingLockMutex.RUnlock()
 	return fake.invocations
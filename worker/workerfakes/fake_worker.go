@@ -131,6 +131,20 @@ type FakeWorker struct {
 		result1 []worker.Worker
 		result2 error
 	}
+	ChooseWorkerStub        func(lager.Logger, <-chan os.Signal, worker.ImageFetchingDelegate, worker.WorkerSpec, atc.ResourceTypes, worker.PlacementSpec) (worker.Worker, error)
+	chooseWorkerMutex       sync.RWMutex
+	chooseWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 <-chan os.Signal
+		arg3 worker.ImageFetchingDelegate
+		arg4 worker.WorkerSpec
+		arg5 atc.ResourceTypes
+		arg6 worker.PlacementSpec
+	}
+	chooseWorkerReturns struct {
+		result1 worker.Worker
+		result2 error
+	}
 	RunningWorkersStub        func() ([]worker.Worker, error)
 	runningWorkersMutex       sync.RWMutex
 	runningWorkersArgsForCall []struct{}
@@ -153,6 +167,18 @@ type FakeWorker struct {
 	activeContainersReturns     struct {
 		result1 int
 	}
+	DiskCapacityInBytesStub        func() int64
+	diskCapacityInBytesMutex       sync.RWMutex
+	diskCapacityInBytesArgsForCall []struct{}
+	diskCapacityInBytesReturns     struct {
+		result1 int64
+	}
+	DiskUsageInBytesStub        func() int64
+	diskUsageInBytesMutex       sync.RWMutex
+	diskUsageInBytesArgsForCall []struct{}
+	diskUsageInBytesReturns     struct {
+		result1 int64
+	}
 	DescriptionStub        func() string
 	descriptionMutex       sync.RWMutex
 	descriptionArgsForCall []struct{}
@@ -574,6 +600,45 @@ func (fake *FakeWorker) AllSatisfyingReturns(result1 []worker.Worker, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) ChooseWorker(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 worker.ImageFetchingDelegate, arg4 worker.WorkerSpec, arg5 atc.ResourceTypes, arg6 worker.PlacementSpec) (worker.Worker, error) {
+	fake.chooseWorkerMutex.Lock()
+	fake.chooseWorkerArgsForCall = append(fake.chooseWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 <-chan os.Signal
+		arg3 worker.ImageFetchingDelegate
+		arg4 worker.WorkerSpec
+		arg5 atc.ResourceTypes
+		arg6 worker.PlacementSpec
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("ChooseWorker", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.chooseWorkerMutex.Unlock()
+	if fake.ChooseWorkerStub != nil {
+		return fake.ChooseWorkerStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	} else {
+		return fake.chooseWorkerReturns.result1, fake.chooseWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorker) ChooseWorkerCallCount() int {
+	fake.chooseWorkerMutex.RLock()
+	defer fake.chooseWorkerMutex.RUnlock()
+	return len(fake.chooseWorkerArgsForCall)
+}
+
+func (fake *FakeWorker) ChooseWorkerArgsForCall(i int) (lager.Logger, <-chan os.Signal, worker.ImageFetchingDelegate, worker.WorkerSpec, atc.ResourceTypes, worker.PlacementSpec) {
+	fake.chooseWorkerMutex.RLock()
+	defer fake.chooseWorkerMutex.RUnlock()
+	return fake.chooseWorkerArgsForCall[i].arg1, fake.chooseWorkerArgsForCall[i].arg2, fake.chooseWorkerArgsForCall[i].arg3, fake.chooseWorkerArgsForCall[i].arg4, fake.chooseWorkerArgsForCall[i].arg5, fake.chooseWorkerArgsForCall[i].arg6
+}
+
+func (fake *FakeWorker) ChooseWorkerReturns(result1 worker.Worker, result2 error) {
+	fake.ChooseWorkerStub = nil
+	fake.chooseWorkerReturns = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) RunningWorkers() ([]worker.Worker, error) {
 	fake.runningWorkersMutex.Lock()
 	fake.runningWorkersArgsForCall = append(fake.runningWorkersArgsForCall, struct{}{})
@@ -659,6 +724,56 @@ func (fake *FakeWorker) ActiveContainersReturns(result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) DiskCapacityInBytes() int64 {
+	fake.diskCapacityInBytesMutex.Lock()
+	fake.diskCapacityInBytesArgsForCall = append(fake.diskCapacityInBytesArgsForCall, struct{}{})
+	fake.recordInvocation("DiskCapacityInBytes", []interface{}{})
+	fake.diskCapacityInBytesMutex.Unlock()
+	if fake.DiskCapacityInBytesStub != nil {
+		return fake.DiskCapacityInBytesStub()
+	} else {
+		return fake.diskCapacityInBytesReturns.result1
+	}
+}
+
+func (fake *FakeWorker) DiskCapacityInBytesCallCount() int {
+	fake.diskCapacityInBytesMutex.RLock()
+	defer fake.diskCapacityInBytesMutex.RUnlock()
+	return len(fake.diskCapacityInBytesArgsForCall)
+}
+
+func (fake *FakeWorker) DiskCapacityInBytesReturns(result1 int64) {
+	fake.DiskCapacityInBytesStub = nil
+	fake.diskCapacityInBytesReturns = struct {
+		result1 int64
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskUsageInBytes() int64 {
+	fake.diskUsageInBytesMutex.Lock()
+	fake.diskUsageInBytesArgsForCall = append(fake.diskUsageInBytesArgsForCall, struct{}{})
+	fake.recordInvocation("DiskUsageInBytes", []interface{}{})
+	fake.diskUsageInBytesMutex.Unlock()
+	if fake.DiskUsageInBytesStub != nil {
+		return fake.DiskUsageInBytesStub()
+	} else {
+		return fake.diskUsageInBytesReturns.result1
+	}
+}
+
+func (fake *FakeWorker) DiskUsageInBytesCallCount() int {
+	fake.diskUsageInBytesMutex.RLock()
+	defer fake.diskUsageInBytesMutex.RUnlock()
+	return len(fake.diskUsageInBytesArgsForCall)
+}
+
+func (fake *FakeWorker) DiskUsageInBytesReturns(result1 int64) {
+	fake.DiskUsageInBytesStub = nil
+	fake.diskUsageInBytesReturns = struct {
+		result1 int64
+	}{result1}
+}
+
 func (fake *FakeWorker) Description() string {
 	fake.descriptionMutex.Lock()
 	fake.descriptionArgsForCall = append(fake.descriptionArgsForCall, struct{}{})
@@ -784,12 +899,18 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.satisfyingMutex.RUnlock()
 	fake.allSatisfyingMutex.RLock()
 	defer fake.allSatisfyingMutex.RUnlock()
+	fake.chooseWorkerMutex.RLock()
+	defer fake.chooseWorkerMutex.RUnlock()
 	fake.runningWorkersMutex.RLock()
 	defer fake.runningWorkersMutex.RUnlock()
 	fake.getWorkerMutex.RLock()
 	defer fake.getWorkerMutex.RUnlock()
 	fake.activeContainersMutex.RLock()
 	defer fake.activeContainersMutex.RUnlock()
+	fake.diskCapacityInBytesMutex.RLock()
+	defer fake.diskCapacityInBytesMutex.RUnlock()
+	fake.diskUsageInBytesMutex.RLock()
+	defer fake.diskUsageInBytesMutex.RUnlock()
 	fake.descriptionMutex.RLock()
 	defer fake.descriptionMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -140,6 +140,14 @@ type FakeWorkerDB struct {
 	setVolumeTTLReturns struct {
 		result1 error
 	}
+	MarkVolumeAsUsedStub        func(handle string) error
+	markVolumeAsUsedMutex       sync.RWMutex
+	markVolumeAsUsedArgsForCall []struct {
+		handle string
+	}
+	markVolumeAsUsedReturns struct {
+		result1 error
+	}
 	AcquireVolumeCreatingLockStub        func(lager.Logger, int) (db.Lock, bool, error)
 	acquireVolumeCreatingLockMutex       sync.RWMutex
 	acquireVolumeCreatingLockArgsForCall []struct {
@@ -633,6 +641,39 @@ func (fake *FakeWorkerDB) SetVolumeTTLReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorkerDB) MarkVolumeAsUsed(handle string) error {
+	fake.markVolumeAsUsedMutex.Lock()
+	fake.markVolumeAsUsedArgsForCall = append(fake.markVolumeAsUsedArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("MarkVolumeAsUsed", []interface{}{handle})
+	fake.markVolumeAsUsedMutex.Unlock()
+	if fake.MarkVolumeAsUsedStub != nil {
+		return fake.MarkVolumeAsUsedStub(handle)
+	} else {
+		return fake.markVolumeAsUsedReturns.result1
+	}
+}
+
+func (fake *FakeWorkerDB) MarkVolumeAsUsedCallCount() int {
+	fake.markVolumeAsUsedMutex.RLock()
+	defer fake.markVolumeAsUsedMutex.RUnlock()
+	return len(fake.markVolumeAsUsedArgsForCall)
+}
+
+func (fake *FakeWorkerDB) MarkVolumeAsUsedArgsForCall(i int) string {
+	fake.markVolumeAsUsedMutex.RLock()
+	defer fake.markVolumeAsUsedMutex.RUnlock()
+	return fake.markVolumeAsUsedArgsForCall[i].handle
+}
+
+func (fake *FakeWorkerDB) MarkVolumeAsUsedReturns(result1 error) {
+	fake.MarkVolumeAsUsedStub = nil
+	fake.markVolumeAsUsedReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorkerDB) AcquireVolumeCreatingLock(arg1 lager.Logger, arg2 int) (db.Lock, bool, error) {
 	fake.acquireVolumeCreatingLockMutex.Lock()
 	fake.acquireVolumeCreatingLockArgsForCall = append(fake.acquireVolumeCreatingLockArgsForCall, struct {
@@ -700,6 +741,8 @@ func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.setVolumeTTLAndSizeInBytesMutex.RUnlock()
 	fake.setVolumeTTLMutex.RLock()
 	defer fake.setVolumeTTLMutex.RUnlock()
+	fake.markVolumeAsUsedMutex.RLock()
+	defer fake.markVolumeAsUsedMutex.RUnlock()
 	fake.acquireVolumeCreatingLockMutex.RLock()
 	defer fake.acquireVolumeCreatingLockMutex.RUnlock()
 	return fake.invocations
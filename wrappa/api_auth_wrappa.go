@@ -87,6 +87,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
 			atc.ListResources,
+			atc.ListResourceChecks,
 			atc.ListResourceVersions:
 			newHandler = wrappa.checkPipelineAccessHandlerFactory.HandlerFor(handler, rejector)
 
@@ -106,11 +107,17 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DestroyTeam,
 			atc.WritePipe,
 			atc.ListVolumes,
-			atc.GetUser:
+			atc.GetUser,
+			atc.ListSessions,
+			atc.RevokeSession,
+			atc.RevokeAllSessions:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.ListTeamLocks,
+			atc.SetTeamLock,
+			atc.ReleaseTeamLock:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
@@ -132,7 +139,16 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpauseResource,
 			atc.ExposePipeline,
 			atc.HidePipeline,
-			atc.SaveConfig:
+			atc.SaveConfig,
+			atc.ListAPITokens,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken,
+			atc.ListLocalUsers,
+			atc.CreateLocalUser,
+			atc.SetLocalUserPassword,
+			atc.EnableLocalUser,
+			atc.DisableLocalUser,
+			atc.DeleteLocalUser:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
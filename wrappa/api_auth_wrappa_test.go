@@ -205,6 +205,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
 				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsOutput]),
 				atc.ListResources:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResources]),
+				atc.ListResourceChecks:            openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceChecks]),
 				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersions]),
 
 				// authenticated
@@ -230,6 +231,10 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
 				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
 
+				atc.ListTeamLocks:   authenticatedAndAdmin(inputHandlers[atc.ListTeamLocks]),
+				atc.SetTeamLock:     authenticatedAndAdmin(inputHandlers[atc.SetTeamLock]),
+				atc.ReleaseTeamLock: authenticatedAndAdmin(inputHandlers[atc.ReleaseTeamLock]),
+
 				// authorized (requested team matches resource team)
 				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
 				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
@@ -245,6 +250,18 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
 				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
 				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
+				atc.ListAPITokens:          authorized(inputHandlers[atc.ListAPITokens]),
+				atc.CreateAPIToken:         authorized(inputHandlers[atc.CreateAPIToken]),
+				atc.RevokeAPIToken:         authorized(inputHandlers[atc.RevokeAPIToken]),
+				atc.ListSessions:           authenticated(inputHandlers[atc.ListSessions]),
+				atc.RevokeSession:          authenticated(inputHandlers[atc.RevokeSession]),
+				atc.RevokeAllSessions:      authenticated(inputHandlers[atc.RevokeAllSessions]),
+				atc.ListLocalUsers:         authorized(inputHandlers[atc.ListLocalUsers]),
+				atc.CreateLocalUser:        authorized(inputHandlers[atc.CreateLocalUser]),
+				atc.SetLocalUserPassword:   authorized(inputHandlers[atc.SetLocalUserPassword]),
+				atc.EnableLocalUser:        authorized(inputHandlers[atc.EnableLocalUser]),
+				atc.DisableLocalUser:       authorized(inputHandlers[atc.DisableLocalUser]),
+				atc.DeleteLocalUser:        authorized(inputHandlers[atc.DeleteLocalUser]),
 				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
 				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
 				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
@@ -0,0 +1,82 @@
+package wrappa
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/tedsuo/rata"
+)
+
+// APITokenScopeWrappa records the API token scope each route needs. It must
+// wrap the APIAuthWrappa and the RateLimitWrappa so that the scope is known by
+// the time either of them reads the request's token.
+type APITokenScopeWrappa struct{}
+
+func NewAPITokenScopeWrappa() Wrappa {
+	return APITokenScopeWrappa{}
+}
+
+func (wrappa APITokenScopeWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		wrapped[name] = auth.APITokenScopeHandler(handler, APITokenScope(name))
+	}
+
+	return wrapped
+}
+
+// APITokenScope returns the scope an API token needs to be used for the
+// named route. Only routes listed as reads can be used with the read scope,
+// so that a route added without thought requires the write scope.
+func APITokenScope(name string) string {
+	if readScopeRoutes[name] {
+		return atc.APITokenScopeRead
+	}
+
+	return atc.APITokenScopeWrite
+}
+
+// readScopeRoutes only read state. Routes are listed by what they do rather
+// than by their method: e.g. reading from a pipe consumes its contents,
+// hijacking runs processes and getting an auth token hands out a token for
+// the team, despite all being GETs.
+var readScopeRoutes = map[string]bool{
+	atc.GetConfig:                     true,
+	atc.ListBuilds:                    true,
+	atc.GetBuild:                      true,
+	atc.GetBuildPlan:                  true,
+	atc.BuildResources:                true,
+	atc.GetBuildPreparation:           true,
+	atc.BuildEvents:                   true,
+	atc.ListJobs:                      true,
+	atc.GetJob:                        true,
+	atc.ListJobBuilds:                 true,
+	atc.ListJobInputs:                 true,
+	atc.GetJobBuild:                   true,
+	atc.JobBadge:                      true,
+	atc.MainJobBadge:                  true,
+	atc.ListAllPipelines:              true,
+	atc.ListPipelines:                 true,
+	atc.GetPipeline:                   true,
+	atc.GetVersionsDB:                 true,
+	atc.ListResources:                 true,
+	atc.GetResource:                   true,
+	atc.ListResourceChecks:            true,
+	atc.ListResourceVersions:          true,
+	atc.ListBuildsWithVersionAsInput:  true,
+	atc.ListBuildsWithVersionAsOutput: true,
+	atc.ListWorkers:                   true,
+	atc.GetLogLevel:                   true,
+	atc.DownloadCLI:                   true,
+	atc.GetInfo:                       true,
+	atc.ListContainers:                true,
+	atc.GetContainer:                  true,
+	atc.ListVolumes:                   true,
+	atc.ListAuthMethods:               true,
+	atc.GetUser:                       true,
+	atc.ListTeams:                     true,
+	atc.ListTeamLocks:                 true,
+	atc.ListAPITokens:                 true,
+	atc.ListSessions:                  true,
+	atc.ListLocalUsers:                true,
+}
@@ -0,0 +1,51 @@
+package wrappa_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APITokenScopeWrappa", func() {
+	Describe("Wrap", func() {
+		var (
+			inputHandlers rata.Handlers
+
+			wrappedHandlers rata.Handlers
+		)
+
+		BeforeEach(func() {
+			inputHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				inputHandlers[route.Name] = &stupidHandler{}
+			}
+		})
+
+		JustBeforeEach(func() {
+			wrappedHandlers = wrappa.NewAPITokenScopeWrappa().Wrap(inputHandlers)
+		})
+
+		It("requires the read scope for reads and event streams", func() {
+			Expect(wrappedHandlers[atc.ListBuilds]).To(Equal(auth.APITokenScopeHandler(inputHandlers[atc.ListBuilds], "read")))
+			Expect(wrappedHandlers[atc.BuildEvents]).To(Equal(auth.APITokenScopeHandler(inputHandlers[atc.BuildEvents], "read")))
+		})
+
+		It("requires the write scope for everything else", func() {
+			Expect(wrappedHandlers[atc.SetTeamLock]).To(Equal(auth.APITokenScopeHandler(inputHandlers[atc.SetTeamLock], "write")))
+			Expect(wrappedHandlers[atc.ReadPipe]).To(Equal(auth.APITokenScopeHandler(inputHandlers[atc.ReadPipe], "write")))
+			Expect(wrappedHandlers[atc.HijackContainer]).To(Equal(auth.APITokenScopeHandler(inputHandlers[atc.HijackContainer], "write")))
+			Expect(wrappedHandlers[atc.GetAuthToken]).To(Equal(auth.APITokenScopeHandler(inputHandlers[atc.GetAuthToken], "write")))
+		})
+	})
+})
+
+var _ = Describe("APITokenScope", func() {
+	It("requires the write scope for routes not listed as reads", func() {
+		Expect(wrappa.APITokenScope("some-new-route")).To(Equal("write"))
+	})
+})
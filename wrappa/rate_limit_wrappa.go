@@ -0,0 +1,357 @@
+package wrappa
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/metric"
+	"github.com/tedsuo/rata"
+)
+
+// RateLimit is a token bucket refilled at Rate requests per second, holding
+// at most Burst requests. A zero Rate disables limiting.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type RateLimits map[RouteClass]RateLimit
+
+// Limiter names what requests are counted against: the client IP they come
+// from, the team they are authenticated as, or the credential they present.
+type Limiter string
+
+const (
+	IPLimiter    Limiter = "ip"
+	TeamLimiter  Limiter = "team"
+	TokenLimiter Limiter = "token"
+)
+
+// limiters lists the limiters in the order requests are checked against them.
+var limiters = []Limiter{IPLimiter, TeamLimiter, TokenLimiter}
+
+const bucketSweepInterval = time.Minute
+
+type RateLimitWrappa struct {
+	logger lager.Logger
+	clock  clock.Clock
+	limits map[Limiter]RateLimits
+
+	trustedProxies    []*net.IPNet
+	userContextReader auth.UserContextReader
+
+	classes map[string]RouteClass
+
+	bucketsL  *sync.Mutex
+	buckets   map[bucketKey]*tokenBucket
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	class  RouteClass
+	client string
+}
+
+type limitedClient struct {
+	limiter Limiter
+	id      string
+}
+
+// NewRateLimitWrappa returns a wrappa limiting requests per client IP, per
+// team and per credential, each with its own limits. Requests from the
+// trusted proxies are counted against the client IP they forward for instead
+// of their own.
+func NewRateLimitWrappa(
+	logger lager.Logger,
+	clock clock.Clock,
+	limits map[Limiter]RateLimits,
+	trustedProxies []*net.IPNet,
+	userContextReader auth.UserContextReader,
+) Wrappa {
+	classes := map[string]RouteClass{}
+	for _, route := range atc.Routes {
+		classes[route.Name] = ClassifyRoute(route.Name)
+	}
+
+	return &RateLimitWrappa{
+		logger: logger,
+		clock:  clock,
+		limits: limits,
+
+		trustedProxies:    trustedProxies,
+		userContextReader: userContextReader,
+
+		classes: classes,
+
+		bucketsL:  &sync.Mutex{},
+		buckets:   map[bucketKey]*tokenBucket{},
+		lastSweep: clock.Now(),
+	}
+}
+
+func (wrappa *RateLimitWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		class, found := wrappa.classes[name]
+		if !found {
+			wrapped[name] = handler
+			continue
+		}
+
+		if class == SystemRoutes || !wrappa.limited(class) {
+			wrapped[name] = handler
+			continue
+		}
+
+		wrapped[name] = rateLimitedHandler{
+			wrappa:  wrappa,
+			route:   name,
+			class:   class,
+			handler: handler,
+		}
+	}
+
+	return wrapped
+}
+
+func (wrappa *RateLimitWrappa) limited(class RouteClass) bool {
+	for _, limiter := range limiters {
+		if wrappa.limits[limiter][class].Rate > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// take consumes a token from every bucket the request is counted against:
+// one for its client IP and, if it is authenticated, one for its team and one
+// for the credential it presents. A token is only consumed if every bucket
+// has one, so that requests rejected by one limiter do not count against the
+// others. It returns the limiter that rejected the request along with how
+// long the client should wait before retrying.
+func (wrappa *RateLimitWrappa) take(class RouteClass, r *http.Request) (Limiter, time.Duration, bool) {
+	clients := []limitedClient{
+		{limiter: IPLimiter, id: "ip:" + wrappa.clientIP(r)},
+	}
+
+	// every token and user of a team shares its limit, so that minting more
+	// credentials does not raise it
+	if teamName, _, found := wrappa.userContextReader.GetTeam(r); found {
+		clients = append(clients, limitedClient{
+			limiter: TeamLimiter,
+			id:      "team:" + teamName,
+		})
+
+		if credential, found := wrappa.credential(r); found {
+			clients = append(clients, limitedClient{
+				limiter: TokenLimiter,
+				id:      "token:" + credential,
+			})
+		}
+	}
+
+	wrappa.bucketsL.Lock()
+	defer wrappa.bucketsL.Unlock()
+
+	now := wrappa.clock.Now()
+
+	if now.Sub(wrappa.lastSweep) >= bucketSweepInterval {
+		wrappa.sweep(now)
+	}
+
+	buckets := []*tokenBucket{}
+	for _, c := range clients {
+		limit := wrappa.limits[c.limiter][class]
+		if limit.Rate <= 0 {
+			continue
+		}
+
+		key := bucketKey{class: class, client: c.id}
+
+		bucket, found := wrappa.buckets[key]
+		if !found {
+			bucket = newTokenBucket(limit, now)
+			wrappa.buckets[key] = bucket
+		}
+
+		retryAfter, ok := bucket.check(now)
+		if !ok {
+			return c.limiter, retryAfter, false
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	for _, bucket := range buckets {
+		bucket.tokens--
+	}
+
+	return "", 0, true
+}
+
+// sweep forgets buckets that have refilled completely, as they are no
+// different from a fresh one. Callers must hold bucketsL.
+func (wrappa *RateLimitWrappa) sweep(now time.Time) {
+	for key, bucket := range wrappa.buckets {
+		bucket.refill(now)
+
+		if bucket.tokens >= bucket.burst {
+			delete(wrappa.buckets, key)
+		}
+	}
+
+	wrappa.lastSweep = now
+}
+
+// credential identifies the credential the request authenticated with: the
+// session of a token, so that renewing it does not reset its limit, or
+// otherwise a digest of its Authorization header or client certificate.
+func (wrappa *RateLimitWrappa) credential(r *http.Request) (string, bool) {
+	if sessionID, _, found := wrappa.userContextReader.GetSession(r); found {
+		return "session:" + sessionID, true
+	}
+
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		digest := sha256.Sum256([]byte(authorization))
+		return "authorization:" + hex.EncodeToString(digest[:]), true
+	}
+
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		digest := sha256.Sum256(r.TLS.VerifiedChains[0][0].Raw)
+		return "cert:" + hex.EncodeToString(digest[:]), true
+	}
+
+	return "", false
+}
+
+// clientIP returns the IP the request came from. X-Forwarded-For can be set
+// by any client, so it is only honoured for requests from trusted proxies,
+// and only as far back as the last hop that was not itself a trusted proxy.
+func (wrappa *RateLimitWrappa) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if !wrappa.isTrustedProxy(host) {
+		return host
+	}
+
+	forwardedFor := strings.Split(strings.Join(r.Header["X-Forwarded-For"], ","), ",")
+
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwardedFor[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+
+		host = hop
+
+		if !wrappa.isTrustedProxy(hop) {
+			break
+		}
+	}
+
+	return host
+}
+
+func (wrappa *RateLimitWrappa) isTrustedProxy(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range wrappa.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type rateLimitedHandler struct {
+	wrappa  *RateLimitWrappa
+	route   string
+	class   RouteClass
+	handler http.Handler
+}
+
+func (handler rateLimitedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	limiter, retryAfter, ok := handler.wrappa.take(handler.class, r)
+	if !ok {
+		metric.RequestThrottled{
+			Route:   handler.route,
+			Class:   string(handler.class),
+			Limiter: string(limiter),
+		}.Emit(handler.wrappa.logger)
+
+		seconds := int(math.Ceil(retryAfter.Seconds()))
+		if seconds < 1 {
+			seconds = 1
+		}
+
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+
+	handler.handler.ServeHTTP(w, r)
+}
+
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = math.Max(1, math.Ceil(limit.Rate))
+	}
+
+	return &tokenBucket{
+		rate:  limit.Rate,
+		burst: burst,
+
+		tokens: burst,
+		last:   now,
+	}
+}
+
+func (bucket *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(bucket.last).Seconds()
+	if elapsed > 0 {
+		bucket.tokens = math.Min(bucket.burst, bucket.tokens+elapsed*bucket.rate)
+		bucket.last = now
+	}
+}
+
+// check reports whether the bucket has a token to take, or otherwise how long
+// it will be until it does.
+func (bucket *tokenBucket) check(now time.Time) (time.Duration, bool) {
+	bucket.refill(now)
+
+	if bucket.tokens < 1 {
+		missing := 1 - bucket.tokens
+		return time.Duration(missing / bucket.rate * float64(time.Second)), false
+	}
+
+	return 0, true
+}
@@ -0,0 +1,326 @@
+package wrappa_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimitWrappa", func() {
+	var (
+		fakeClock             *fakeclock.FakeClock
+		limits                map[wrappa.Limiter]wrappa.RateLimits
+		trustedProxies        []*net.IPNet
+		fakeUserContextReader *authfakes.FakeUserContextReader
+
+		inputHandlers   rata.Handlers
+		wrappedHandlers rata.Handlers
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		limits = map[wrappa.Limiter]wrappa.RateLimits{
+			wrappa.IPLimiter: {
+				wrappa.ReadRoutes: {Rate: 1, Burst: 2},
+			},
+			wrappa.TeamLimiter: {
+				wrappa.ReadRoutes: {Rate: 1, Burst: 2},
+			},
+		}
+
+		trustedProxies = nil
+
+		// the team is named by the Authorization header, standing in for
+		// whatever credentials it carries, up to the first colon
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+		fakeUserContextReader.GetTeamStub = func(r *http.Request) (string, bool, bool) {
+			teamName := strings.Split(r.Header.Get("Authorization"), ":")[0]
+			return teamName, false, teamName != ""
+		}
+
+		inputHandlers = rata.Handlers{}
+
+		for _, route := range atc.Routes {
+			inputHandlers[route.Name] = &stupidHandler{}
+		}
+	})
+
+	JustBeforeEach(func() {
+		wrappedHandlers = wrappa.NewRateLimitWrappa(
+			lagertest.NewTestLogger("test"),
+			fakeClock,
+			limits,
+			trustedProxies,
+			fakeUserContextReader,
+		).Wrap(inputHandlers)
+	})
+
+	requestForwarded := func(route string, remoteAddr string, team string, forwardedFor string) *httptest.ResponseRecorder {
+		r, err := http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		r.RemoteAddr = remoteAddr
+		if team != "" {
+			r.Header.Set("Authorization", team)
+		}
+
+		if forwardedFor != "" {
+			r.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+
+		recorder := httptest.NewRecorder()
+		wrappedHandlers[route].ServeHTTP(recorder, r)
+
+		return recorder
+	}
+
+	request := func(route string, remoteAddr string, team string) *httptest.ResponseRecorder {
+		return requestForwarded(route, remoteAddr, team, "")
+	}
+
+	It("leaves routes in classes without a limit untouched", func() {
+		Expect(wrappedHandlers[atc.CreateBuild]).To(BeIdenticalTo(inputHandlers[atc.CreateBuild]))
+		Expect(wrappedHandlers[atc.BuildEvents]).To(BeIdenticalTo(inputHandlers[atc.BuildEvents]))
+		Expect(wrappedHandlers[atc.HijackContainer]).To(BeIdenticalTo(inputHandlers[atc.HijackContainer]))
+	})
+
+	It("wraps routes in limited classes", func() {
+		Expect(wrappedHandlers[atc.ListBuilds]).NotTo(BeIdenticalTo(inputHandlers[atc.ListBuilds]))
+	})
+
+	It("allows a burst of requests from a client and then throttles it", func() {
+		Expect(request(atc.ListBuilds, "1.2.3.4:1234", "").Code).To(Equal(http.StatusOK))
+		Expect(request(atc.ListBuilds, "1.2.3.4:1234", "").Code).To(Equal(http.StatusOK))
+
+		throttled := request(atc.ListBuilds, "1.2.3.4:1234", "")
+		Expect(throttled.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(throttled.Header().Get("Retry-After")).To(Equal("1"))
+	})
+
+	It("counts every route in a class against the same bucket", func() {
+		Expect(request(atc.ListBuilds, "1.2.3.4:1234", "").Code).To(Equal(http.StatusOK))
+		Expect(request(atc.GetInfo, "1.2.3.4:1234", "").Code).To(Equal(http.StatusOK))
+		Expect(request(atc.ListTeams, "1.2.3.4:1234", "").Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("refills the bucket over time", func() {
+		request(atc.ListBuilds, "1.2.3.4:1234", "")
+		request(atc.ListBuilds, "1.2.3.4:1234", "")
+		Expect(request(atc.ListBuilds, "1.2.3.4:1234", "").Code).To(Equal(http.StatusTooManyRequests))
+
+		fakeClock.Increment(time.Second)
+
+		Expect(request(atc.ListBuilds, "1.2.3.4:1234", "").Code).To(Equal(http.StatusOK))
+		Expect(request(atc.ListBuilds, "1.2.3.4:1234", "").Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("limits each IP separately", func() {
+		request(atc.ListBuilds, "1.2.3.4:1234", "")
+		request(atc.ListBuilds, "1.2.3.4:1234", "")
+		Expect(request(atc.ListBuilds, "1.2.3.4:5678", "").Code).To(Equal(http.StatusTooManyRequests))
+
+		Expect(request(atc.ListBuilds, "5.6.7.8:1234", "").Code).To(Equal(http.StatusOK))
+	})
+
+	It("limits a team across IPs and credentials", func() {
+		request(atc.ListBuilds, "1.2.3.4:1234", "some-team")
+		request(atc.ListBuilds, "5.6.7.8:1234", "some-team")
+		Expect(request(atc.ListBuilds, "9.10.11.12:1234", "some-team").Code).To(Equal(http.StatusTooManyRequests))
+
+		Expect(request(atc.ListBuilds, "9.10.11.12:1234", "other-team").Code).To(Equal(http.StatusOK))
+	})
+
+	It("does not count requests rejected for their team against their IP", func() {
+		request(atc.ListBuilds, "1.2.3.4:1234", "some-team")
+		request(atc.ListBuilds, "1.2.3.4:1234", "some-team")
+
+		for i := 0; i < 3; i++ {
+			Expect(request(atc.ListBuilds, "5.6.7.8:1234", "some-team").Code).To(Equal(http.StatusTooManyRequests))
+		}
+
+		Expect(request(atc.ListBuilds, "5.6.7.8:1234", "other-team").Code).To(Equal(http.StatusOK))
+		Expect(request(atc.ListBuilds, "5.6.7.8:1234", "other-team").Code).To(Equal(http.StatusOK))
+	})
+
+	It("ignores X-Forwarded-For from untrusted clients", func() {
+		requestForwarded(atc.ListBuilds, "1.2.3.4:1234", "", "5.6.7.8")
+		requestForwarded(atc.ListBuilds, "1.2.3.4:1234", "", "9.10.11.12")
+		Expect(requestForwarded(atc.ListBuilds, "1.2.3.4:1234", "", "13.14.15.16").Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	Context("when requests come through trusted proxies", func() {
+		BeforeEach(func() {
+			_, network, err := net.ParseCIDR("10.0.0.0/8")
+			Expect(err).NotTo(HaveOccurred())
+
+			trustedProxies = []*net.IPNet{network}
+		})
+
+		It("limits each forwarded client separately", func() {
+			requestForwarded(atc.ListBuilds, "10.0.0.1:1234", "", "1.2.3.4")
+			requestForwarded(atc.ListBuilds, "10.0.0.2:1234", "", "1.2.3.4")
+			Expect(requestForwarded(atc.ListBuilds, "10.0.0.1:1234", "", "1.2.3.4").Code).To(Equal(http.StatusTooManyRequests))
+
+			Expect(requestForwarded(atc.ListBuilds, "10.0.0.1:1234", "", "5.6.7.8").Code).To(Equal(http.StatusOK))
+		})
+
+		It("skips over trusted proxies in the chain, but no further", func() {
+			requestForwarded(atc.ListBuilds, "10.0.0.1:1234", "", "66.66.66.66, 1.2.3.4, 10.0.0.2")
+			requestForwarded(atc.ListBuilds, "10.0.0.1:1234", "", "77.77.77.77, 1.2.3.4, 10.0.0.2")
+			Expect(requestForwarded(atc.ListBuilds, "10.0.0.1:1234", "", "88.88.88.88, 1.2.3.4").Code).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("limits the proxy itself if it does not forward a client", func() {
+			request(atc.ListBuilds, "10.0.0.1:1234", "")
+			request(atc.ListBuilds, "10.0.0.1:1234", "")
+			Expect(request(atc.ListBuilds, "10.0.0.1:1234", "").Code).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+
+	Context("when teams have a limit of their own", func() {
+		BeforeEach(func() {
+			limits[wrappa.TeamLimiter] = wrappa.RateLimits{
+				wrappa.ReadRoutes: {Rate: 1, Burst: 4},
+			}
+		})
+
+		It("limits teams separately from IPs", func() {
+			request(atc.ListBuilds, "1.2.3.4:1234", "some-team")
+			request(atc.ListBuilds, "1.2.3.4:1234", "some-team")
+			Expect(request(atc.ListBuilds, "1.2.3.4:1234", "some-team").Code).To(Equal(http.StatusTooManyRequests))
+
+			Expect(request(atc.ListBuilds, "5.6.7.8:1234", "some-team").Code).To(Equal(http.StatusOK))
+			Expect(request(atc.ListBuilds, "9.10.11.12:1234", "some-team").Code).To(Equal(http.StatusOK))
+			Expect(request(atc.ListBuilds, "13.14.15.16:1234", "some-team").Code).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+
+	Context("when only teams are limited", func() {
+		BeforeEach(func() {
+			delete(limits, wrappa.IPLimiter)
+		})
+
+		It("does not limit unauthenticated requests", func() {
+			for i := 0; i < 3; i++ {
+				Expect(request(atc.ListBuilds, "1.2.3.4:1234", "").Code).To(Equal(http.StatusOK))
+			}
+
+			request(atc.ListBuilds, "1.2.3.4:1234", "some-team")
+			request(atc.ListBuilds, "1.2.3.4:1234", "some-team")
+			Expect(request(atc.ListBuilds, "1.2.3.4:1234", "some-team").Code).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+
+	Context("when tokens are limited", func() {
+		BeforeEach(func() {
+			limits[wrappa.TeamLimiter] = wrappa.RateLimits{
+				wrappa.ReadRoutes: {Rate: 1, Burst: 4},
+			}
+
+			limits[wrappa.TokenLimiter] = wrappa.RateLimits{
+				wrappa.ReadRoutes: {Rate: 1, Burst: 1},
+			}
+		})
+
+		It("limits each of a team's tokens separately", func() {
+			Expect(request(atc.ListBuilds, "1.2.3.4:1234", "some-team:some-token").Code).To(Equal(http.StatusOK))
+			Expect(request(atc.ListBuilds, "5.6.7.8:1234", "some-team:some-token").Code).To(Equal(http.StatusTooManyRequests))
+
+			Expect(request(atc.ListBuilds, "5.6.7.8:1234", "some-team:other-token").Code).To(Equal(http.StatusOK))
+		})
+
+		It("still limits the team across its tokens", func() {
+			request(atc.ListBuilds, "1.2.3.4:1234", "some-team:token-1")
+			request(atc.ListBuilds, "1.2.3.4:1234", "some-team:token-2")
+			request(atc.ListBuilds, "5.6.7.8:1234", "some-team:token-3")
+			request(atc.ListBuilds, "5.6.7.8:1234", "some-team:token-4")
+			Expect(request(atc.ListBuilds, "9.10.11.12:1234", "some-team:token-5").Code).To(Equal(http.StatusTooManyRequests))
+		})
+
+		Context("when the tokens belong to a session", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetSessionStub = func(r *http.Request) (string, time.Time, bool) {
+					return "some-session", time.Time{}, r.Header.Get("Authorization") != ""
+				}
+			})
+
+			It("limits the session across renewed tokens", func() {
+				Expect(request(atc.ListBuilds, "1.2.3.4:1234", "some-team:some-token").Code).To(Equal(http.StatusOK))
+				Expect(request(atc.ListBuilds, "1.2.3.4:1234", "some-team:renewed-token").Code).To(Equal(http.StatusTooManyRequests))
+			})
+		})
+
+		It("does not limit unauthenticated requests per token", func() {
+			Expect(request(atc.ListBuilds, "1.2.3.4:1234", "").Code).To(Equal(http.StatusOK))
+			Expect(request(atc.ListBuilds, "5.6.7.8:1234", "").Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("when every class is limited", func() {
+		BeforeEach(func() {
+			limits = map[wrappa.Limiter]wrappa.RateLimits{
+				wrappa.IPLimiter: {
+					wrappa.ReadRoutes:        {Rate: 1},
+					wrappa.WriteRoutes:       {Rate: 1},
+					wrappa.EventStreamRoutes: {Rate: 1},
+					wrappa.HijackRoutes:      {Rate: 1},
+					wrappa.SystemRoutes:      {Rate: 1},
+				},
+			}
+		})
+
+		It("still leaves the routes workers use untouched", func() {
+			Expect(wrappedHandlers[atc.RegisterWorker]).To(BeIdenticalTo(inputHandlers[atc.RegisterWorker]))
+			Expect(wrappedHandlers[atc.HeartbeatWorker]).To(BeIdenticalTo(inputHandlers[atc.HeartbeatWorker]))
+			Expect(wrappedHandlers[atc.LandWorker]).To(BeIdenticalTo(inputHandlers[atc.LandWorker]))
+			Expect(wrappedHandlers[atc.RetireWorker]).To(BeIdenticalTo(inputHandlers[atc.RetireWorker]))
+			Expect(wrappedHandlers[atc.DeleteWorker]).To(BeIdenticalTo(inputHandlers[atc.DeleteWorker]))
+		})
+	})
+
+	Context("when the rate is below one request per second", func() {
+		BeforeEach(func() {
+			limits = map[wrappa.Limiter]wrappa.RateLimits{
+				wrappa.IPLimiter: {
+					wrappa.WriteRoutes: {Rate: 0.1, Burst: 1},
+				},
+			}
+		})
+
+		It("asks the client to retry once a token is available", func() {
+			Expect(request(atc.CreateBuild, "1.2.3.4:1234", "").Code).To(Equal(http.StatusOK))
+
+			throttled := request(atc.CreateBuild, "1.2.3.4:1234", "")
+			Expect(throttled.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(throttled.Header().Get("Retry-After")).To(Equal("10"))
+		})
+	})
+
+	Context("when no burst is configured", func() {
+		BeforeEach(func() {
+			limits = map[wrappa.Limiter]wrappa.RateLimits{
+				wrappa.IPLimiter: {
+					wrappa.HijackRoutes: {Rate: 1},
+				},
+			}
+		})
+
+		It("allows a single request at a time", func() {
+			Expect(request(atc.HijackContainer, "1.2.3.4:1234", "").Code).To(Equal(http.StatusOK))
+			Expect(request(atc.HijackContainer, "1.2.3.4:1234", "").Code).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+})
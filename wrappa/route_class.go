@@ -0,0 +1,121 @@
+package wrappa
+
+import "github.com/concourse/atc"
+
+type RouteClass string
+
+const (
+	ReadRoutes        RouteClass = "read"
+	WriteRoutes       RouteClass = "write"
+	EventStreamRoutes RouteClass = "event-stream"
+	HijackRoutes      RouteClass = "hijack"
+
+	// routes used by workers to register and maintain themselves, which must
+	// never be throttled
+	SystemRoutes RouteClass = "system"
+)
+
+// ClassifyRoute returns the class of the named route. Routes are classified
+// by what they do rather than by their method: e.g. reading from a pipe
+// consumes its contents and hijacking runs processes, despite both being
+// GETs.
+func ClassifyRoute(name string) RouteClass {
+	switch name {
+	case atc.BuildEvents:
+		return EventStreamRoutes
+
+	case atc.HijackContainer:
+		return HijackRoutes
+
+	case atc.RegisterWorker,
+		atc.LandWorker,
+		atc.RetireWorker,
+		atc.HeartbeatWorker,
+		atc.DeleteWorker:
+		return SystemRoutes
+
+	case atc.GetConfig,
+		atc.ListBuilds,
+		atc.GetBuild,
+		atc.GetBuildPlan,
+		atc.BuildResources,
+		atc.GetBuildPreparation,
+		atc.ListJobs,
+		atc.GetJob,
+		atc.ListJobBuilds,
+		atc.ListJobInputs,
+		atc.GetJobBuild,
+		atc.JobBadge,
+		atc.MainJobBadge,
+		atc.ListAllPipelines,
+		atc.ListPipelines,
+		atc.GetPipeline,
+		atc.GetVersionsDB,
+		atc.ListResources,
+		atc.GetResource,
+		atc.ListResourceChecks,
+		atc.ListResourceVersions,
+		atc.ListBuildsWithVersionAsInput,
+		atc.ListBuildsWithVersionAsOutput,
+		atc.ListWorkers,
+		atc.GetLogLevel,
+		atc.DownloadCLI,
+		atc.GetInfo,
+		atc.ListContainers,
+		atc.GetContainer,
+		atc.ListVolumes,
+		atc.ListAuthMethods,
+		atc.GetUser,
+		atc.ListTeams,
+		atc.ListTeamLocks,
+		atc.ListAPITokens,
+		atc.ListSessions,
+		atc.ListLocalUsers:
+		return ReadRoutes
+
+	// reading a pipe consumes what was written to it, and getting an auth
+	// token hands out a token for the team
+	case atc.ReadPipe,
+		atc.GetAuthToken,
+		atc.SaveConfig,
+		atc.CreateBuild,
+		atc.AbortBuild,
+		atc.CreateJobBuild,
+		atc.PauseJob,
+		atc.UnpauseJob,
+		atc.DeletePipeline,
+		atc.OrderPipelines,
+		atc.PausePipeline,
+		atc.UnpausePipeline,
+		atc.ExposePipeline,
+		atc.HidePipeline,
+		atc.RenamePipeline,
+		atc.PauseResource,
+		atc.UnpauseResource,
+		atc.CheckResource,
+		atc.EnableResourceVersion,
+		atc.DisableResourceVersion,
+		atc.CreatePipe,
+		atc.WritePipe,
+		atc.PruneWorker,
+		atc.SetLogLevel,
+		atc.SetTeam,
+		atc.DestroyTeam,
+		atc.SetTeamLock,
+		atc.ReleaseTeamLock,
+		atc.CreateAPIToken,
+		atc.RevokeAPIToken,
+		atc.RevokeAllSessions,
+		atc.RevokeSession,
+		atc.CreateLocalUser,
+		atc.SetLocalUserPassword,
+		atc.EnableLocalUser,
+		atc.DisableLocalUser,
+		atc.DeleteLocalUser:
+		return WriteRoutes
+
+	// think about it!
+	default:
+		panic("you missed a spot")
+	}
+}
@@ -0,0 +1,27 @@
+package wrappa_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/wrappa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClassifyRoute", func() {
+	It("classifies every route", func() {
+		for _, route := range atc.Routes {
+			Expect(func() { wrappa.ClassifyRoute(route.Name) }).NotTo(Panic(), route.Name)
+		}
+	})
+
+	It("classifies routes by what they do rather than by their method", func() {
+		Expect(wrappa.ClassifyRoute(atc.ListBuilds)).To(Equal(wrappa.ReadRoutes))
+		Expect(wrappa.ClassifyRoute(atc.ReadPipe)).To(Equal(wrappa.WriteRoutes))
+		Expect(wrappa.ClassifyRoute(atc.GetAuthToken)).To(Equal(wrappa.WriteRoutes))
+		Expect(wrappa.ClassifyRoute(atc.SetTeamLock)).To(Equal(wrappa.WriteRoutes))
+		Expect(wrappa.ClassifyRoute(atc.ListSessions)).To(Equal(wrappa.ReadRoutes))
+		Expect(wrappa.ClassifyRoute(atc.HijackContainer)).To(Equal(wrappa.HijackRoutes))
+		Expect(wrappa.ClassifyRoute(atc.HeartbeatWorker)).To(Equal(wrappa.SystemRoutes))
+	})
+})